@@ -103,6 +103,9 @@ func authenticateHandler() func(http.Handler) http.Handler {
 			}
 
 			ctx = session.SetCurrentUserID(ctx, userID)
+			if session.GetAPIKey(r) != "" {
+				ctx = session.SetUsingAPIKey(ctx)
+			}
 
 			r = r.WithContext(ctx)
 
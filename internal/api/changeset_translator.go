@@ -495,3 +495,46 @@ func (t changesetTranslator) updateGroupIDDescriptionsBulk(value *BulkUpdateGrou
 		Mode:   value.Mode,
 	}, nil
 }
+
+func performerRelationshipsFromInput(input []models.PerformerRelationshipInput) ([]models.PerformerRelationship, error) {
+	ret := make([]models.PerformerRelationship, len(input))
+
+	for i, v := range input {
+		pID, err := strconv.Atoi(v.PerformerID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid performer ID: %s", v.PerformerID)
+		}
+
+		ret[i] = models.PerformerRelationship{
+			PerformerID: pID,
+			Type:        v.Type,
+		}
+	}
+
+	return ret, nil
+}
+
+func (t changesetTranslator) relatedPerformerRelationships(value []models.PerformerRelationshipInput) (models.RelatedPerformerRelationships, error) {
+	relationships, err := performerRelationshipsFromInput(value)
+	if err != nil {
+		return models.RelatedPerformerRelationships{}, err
+	}
+
+	return models.NewRelatedPerformerRelationships(relationships), nil
+}
+
+func (t changesetTranslator) updatePerformerRelationships(value []models.PerformerRelationshipInput, field string) (*models.UpdatePerformerRelationships, error) {
+	if !t.hasField(field) {
+		return nil, nil
+	}
+
+	relationships, err := performerRelationshipsFromInput(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.UpdatePerformerRelationships{
+		Relationships: relationships,
+		Mode:          models.RelationshipUpdateModeSet,
+	}, nil
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 
 	"github.com/99designs/gqlgen/graphql"
+	"github.com/stashapp/stash/pkg/filterquery"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/vektah/gqlparser/v2/gqlerror"
 )
@@ -38,5 +39,17 @@ func gqlErrorHandler(ctx context.Context, e error) *gqlerror.Error {
 
 	// we may also want to transform the error message for the response
 	// for now just return the original error
-	return graphql.DefaultErrorPresenter(ctx, e)
+	ret := graphql.DefaultErrorPresenter(ctx, e)
+
+	// include the position of errors in filter queries
+	var queryErr *filterquery.Error
+	if errors.As(e, &queryErr) {
+		if ret.Extensions == nil {
+			ret.Extensions = make(map[string]interface{})
+		}
+		ret.Extensions["code"] = "FILTER_QUERY_ERROR"
+		ret.Extensions["position"] = queryErr.Pos
+	}
+
+	return ret
 }
@@ -0,0 +1,53 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/99designs/gqlgen/graphql"
+)
+
+const (
+	defaultFacetLimit = 10
+	maxFacetLimit     = 100
+)
+
+// facetLimit returns the limit argument of the facets field of a find
+// result, and false if the field was not requested.
+func facetLimit(ctx context.Context) (int, bool, error) {
+	opCtx := graphql.GetOperationContext(ctx)
+
+	for _, f := range graphql.CollectFieldsCtx(ctx, nil) {
+		if f.Name != "facets" {
+			continue
+		}
+
+		limit, err := parseFacetLimit(f.ArgumentMap(opCtx.Variables)["limit"])
+		if err != nil {
+			return 0, false, err
+		}
+
+		return limit, true, nil
+	}
+
+	return 0, false, nil
+}
+
+// parseFacetLimit returns the facet limit for the provided argument value.
+// Limits above maxFacetLimit are capped.
+func parseFacetLimit(v interface{}) (int, error) {
+	if v == nil {
+		return defaultFacetLimit, nil
+	}
+
+	limit, err := graphql.UnmarshalInt(v)
+	if err != nil {
+		return 0, fmt.Errorf("facets limit: %w", err)
+	}
+
+	if limit < 1 {
+		return 0, fmt.Errorf("facets limit must be greater than zero: %d", limit)
+	}
+
+	return min(limit, maxFacetLimit), nil
+}
@@ -0,0 +1,33 @@
+package api
+
+import "testing"
+
+func TestParseFacetLimit(t *testing.T) {
+	tests := []struct {
+		name    string
+		v       interface{}
+		want    int
+		wantErr bool
+	}{
+		{"default", nil, defaultFacetLimit, false},
+		{"within range", 5, 5, false},
+		{"maximum", maxFacetLimit, maxFacetLimit, false},
+		{"capped", maxFacetLimit + 1, maxFacetLimit, false},
+		{"zero", 0, 0, true},
+		{"negative", -1, 0, true},
+		{"invalid", "x", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseFacetLimit(tt.v)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseFacetLimit() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("parseFacetLimit() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/pkg/filterquery"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// applyFilterQuery compiles the query argument of a find query and combines
+// it with the filter argument. Must be called within a transaction.
+func applyFilterQuery[T any](ctx context.Context, repo models.Repository, filter *T, query *string) (*T, error) {
+	if query == nil || *query == "" {
+		return filter, nil
+	}
+
+	compiled, err := filterquery.Compile[T](ctx, *query, filterquery.NewRepositoryResolver(repo))
+	if err != nil {
+		return nil, err
+	}
+
+	return filterquery.And(compiled, filter)
+}
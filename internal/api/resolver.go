@@ -6,9 +6,11 @@ import (
 	"fmt"
 	"sort"
 	"strconv"
+	"time"
 
 	"github.com/stashapp/stash/internal/build"
 	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/pkg/history"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/plugin/hook"
@@ -105,6 +107,12 @@ func (r *Resolver) Plugin() PluginResolver {
 func (r *Resolver) ConfigResult() ConfigResultResolver {
 	return &configResultResolver{r}
 }
+func (r *Resolver) StashID() StashIDResolver {
+	return &stashIDResolver{r}
+}
+func (r *Resolver) PerformerRelationship() PerformerRelationshipResolver {
+	return &performerRelationshipResolver{r}
+}
 
 type mutationResolver struct{ *Resolver }
 type queryResolver struct{ *Resolver }
@@ -113,6 +121,7 @@ type subscriptionResolver struct{ *Resolver }
 type galleryResolver struct{ *Resolver }
 type galleryChapterResolver struct{ *Resolver }
 type performerResolver struct{ *Resolver }
+type performerRelationshipResolver struct{ *Resolver }
 type sceneResolver struct{ *Resolver }
 type sceneMarkerResolver struct{ *Resolver }
 type imageResolver struct{ *Resolver }
@@ -129,8 +138,11 @@ type imageFileResolver struct{ *Resolver }
 type savedFilterResolver struct{ *Resolver }
 type pluginResolver struct{ *Resolver }
 type configResultResolver struct{ *Resolver }
+type stashIDResolver struct{ *Resolver }
 
 func (r *Resolver) withTxn(ctx context.Context, fn func(ctx context.Context) error) error {
+	// changes made by mutations are recorded in the edit history
+	ctx = history.WithSource(ctx, history.RequestSource(ctx))
 	return r.repository.WithTxn(ctx, fn)
 }
 
@@ -289,6 +301,45 @@ func (r *queryResolver) Stats(ctx context.Context) (*StatsResultType, error) {
 	return &ret, nil
 }
 
+const defaultStatsTimelineLimit = 10
+
+func (r *queryResolver) StatsTimeline(ctx context.Context, metric models.StatsMetric, interval models.StatsInterval, from *time.Time, to *time.Time, breakdown *models.StatsBreakdown, limit *int) (*StatsTimelineResultType, error) {
+	options := models.StatsTimelineOptions{
+		Metric:    metric,
+		Interval:  interval,
+		From:      from,
+		To:        to,
+		Breakdown: breakdown,
+		Limit:     defaultStatsTimelineLimit,
+	}
+
+	if limit != nil && *limit > 0 {
+		options.Limit = *limit
+	}
+
+	if from != nil && to != nil && !from.Before(*to) {
+		return nil, fmt.Errorf("%w: from must be before to", ErrInput)
+	}
+
+	ret := &StatsTimelineResultType{
+		Metric:   metric,
+		Interval: interval,
+	}
+
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		var err error
+		ret.Series, err = r.repository.Scene.StatsTimeline(ctx, options)
+		return err
+	}); err != nil {
+		if errors.Is(err, models.ErrStatsTimelineTooLong) {
+			return nil, fmt.Errorf("%w: %v", ErrInput, err)
+		}
+		return nil, err
+	}
+
+	return ret, nil
+}
+
 func (r *queryResolver) Version(ctx context.Context) (*Version, error) {
 	version, hash, buildtime := build.Version()
 
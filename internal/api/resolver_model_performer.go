@@ -146,6 +146,28 @@ func (r *performerResolver) Tags(ctx context.Context, obj *models.Performer) (re
 	return ret, firstError(errs)
 }
 
+func (r *performerResolver) RelatedPerformers(ctx context.Context, obj *models.Performer) ([]*models.PerformerRelationship, error) {
+	if !obj.RelatedPerformers.Loaded() {
+		if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+			return obj.LoadRelatedPerformers(ctx, r.repository.Performer)
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	list := obj.RelatedPerformers.List()
+	ret := make([]*models.PerformerRelationship, len(list))
+	for i := range list {
+		ret[i] = &list[i]
+	}
+
+	return ret, nil
+}
+
+func (r *performerRelationshipResolver) Performer(ctx context.Context, obj *models.PerformerRelationship) (*models.Performer, error) {
+	return loaders.From(ctx).PerformerByID.Load(obj.PerformerID)
+}
+
 func (r *performerResolver) SceneCount(ctx context.Context, obj *models.Performer) (ret int, err error) {
 	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
 		ret, err = r.repository.Scene.CountByPerformerID(ctx, obj.ID)
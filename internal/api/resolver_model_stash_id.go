@@ -0,0 +1,17 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func (r *stashIDResolver) UpdatedAt(ctx context.Context, obj *models.StashID) (*time.Time, error) {
+	// a zero time means the entity has never been synchronised
+	if obj.UpdatedAt.IsZero() {
+		return nil, nil
+	}
+
+	return &obj.UpdatedAt, nil
+}
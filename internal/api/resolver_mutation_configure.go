@@ -84,25 +84,34 @@ func (r *mutationResolver) setConfigFloat(key string, value *float64) {
 func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGeneralInput) (*ConfigGeneralResult, error) {
 	c := config.GetInstance()
 
+	refreshLibraryWatcher := false
 	existingPaths := c.GetStashPaths()
 	if input.Stashes != nil {
-		for _, s := range input.Stashes {
+		stashes := config.ToStashConfigs(input.Stashes, existingPaths)
+		for _, s := range stashes {
 			// Only validate existence of new paths
 			isNew := true
+			remoteChanged := s.Remote != nil
 			for _, path := range existingPaths {
 				if path.Path == s.Path {
 					isNew = false
+					remoteChanged = s.Remote != nil && (path.Remote == nil || *path.Remote != *s.Remote)
 					break
 				}
 			}
-			if isNew {
+			if remoteChanged {
+				if err := manager.ValidateRemote(s); err != nil {
+					return makeConfigGeneralResult(), fmt.Errorf("validating remote library %s: %w", s.Path, err)
+				}
+			} else if isNew && s.Remote == nil {
 				exists, err := fsutil.DirExists(s.Path)
 				if !exists {
 					return makeConfigGeneralResult(), err
 				}
 			}
 		}
-		c.SetInterface(config.Stash, input.Stashes)
+		c.SetInterface(config.Stash, stashes)
+		refreshLibraryWatcher = true
 	}
 
 	checkConfigOverride := func(key string) error {
@@ -149,6 +158,27 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGen
 		c.SetString(config.BackupDirectoryPath, *input.BackupDirectoryPath)
 	}
 
+	if input.AutoBackupKeepDaily != nil || input.AutoBackupKeepWeekly != nil {
+		keepDaily := c.GetAutoBackupKeepDaily()
+		if input.AutoBackupKeepDaily != nil {
+			keepDaily = *input.AutoBackupKeepDaily
+		}
+		keepWeekly := c.GetAutoBackupKeepWeekly()
+		if input.AutoBackupKeepWeekly != nil {
+			keepWeekly = *input.AutoBackupKeepWeekly
+		}
+
+		if keepDaily < 0 || keepWeekly < 0 || keepDaily+keepWeekly == 0 {
+			return makeConfigGeneralResult(), errors.New("autoBackupKeepDaily and autoBackupKeepWeekly must not be negative, and at least one must be greater than 0")
+		}
+	}
+
+	r.setConfigBool(config.AutoBackup, input.AutoBackup)
+	r.setConfigInt(config.AutoBackupKeepDaily, input.AutoBackupKeepDaily)
+	r.setConfigInt(config.AutoBackupKeepWeekly, input.AutoBackupKeepWeekly)
+	r.setConfigBool(config.AutoBackupIncludeBlobs, input.AutoBackupIncludeBlobs)
+	r.setConfigBool(config.AutoBackupIncludeConfig, input.AutoBackupIncludeConfig)
+
 	existingGeneratedPath := c.GetGeneratedPath()
 	if input.GeneratedPath != nil && existingGeneratedPath != *input.GeneratedPath {
 		if err := validateDir(config.Generated, *input.GeneratedPath, false); err != nil {
@@ -268,6 +298,7 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGen
 	}
 
 	r.setConfigBool(config.CalculateMD5, input.CalculateMd5)
+	r.setConfigBool(config.UseFingerprintXattrs, input.UseFingerprintXattrs)
 	r.setConfigInt(config.ParallelTasks, input.ParallelTasks)
 	r.setConfigBool(config.PreviewAudio, input.PreviewAudio)
 	r.setConfigInt(config.PreviewSegments, input.PreviewSegments)
@@ -357,6 +388,10 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGen
 		c.SetInterface(config.VideoExtensions, input.VideoExtensions)
 	}
 
+	if input.AudioExtensions != nil {
+		c.SetInterface(config.AudioExtensions, input.AudioExtensions)
+	}
+
 	if input.ImageExtensions != nil {
 		c.SetInterface(config.ImageExtensions, input.ImageExtensions)
 	}
@@ -367,6 +402,15 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGen
 
 	r.setConfigBool(config.CreateGalleriesFromFolders, input.CreateGalleriesFromFolders)
 
+	if input.WatchLibrary != nil && *input.WatchLibrary != c.GetWatchLibrary() {
+		c.SetBool(config.WatchLibrary, *input.WatchLibrary)
+		refreshLibraryWatcher = true
+	}
+
+	r.setConfigBool(config.ReadNFOFiles, input.ReadNfoFiles)
+	r.setConfigBool(config.UseTrash, input.UseTrash)
+	r.setConfigInt(config.TrashRetentionDays, input.TrashRetentionDays)
+
 	if input.CustomPerformerImageLocation != nil {
 		c.SetString(config.CustomPerformerImageLocation, *input.CustomPerformerImageLocation)
 		initCustomPerformerImages(*input.CustomPerformerImageLocation)
@@ -437,6 +481,9 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGen
 	if refreshPluginSource {
 		manager.GetInstance().RefreshPluginSourceManager()
 	}
+	if refreshLibraryWatcher {
+		manager.GetInstance().RefreshLibraryWatcher()
+	}
 
 	return makeConfigGeneralResult(), nil
 }
@@ -0,0 +1,175 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/stashapp/stash/pkg/group"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/performer"
+	"github.com/stashapp/stash/pkg/studio"
+	"github.com/stashapp/stash/pkg/tag"
+)
+
+func (r *mutationResolver) RevertChange(ctx context.Context, id string) (bool, error) {
+	entryID, err := strconv.Atoi(id)
+	if err != nil {
+		return false, fmt.Errorf("converting id: %w", err)
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		entry, err := r.repository.EditHistory.Find(ctx, entryID)
+		if err != nil {
+			return err
+		}
+
+		if entry == nil {
+			return fmt.Errorf("edit history entry with id %d not found", entryID)
+		}
+
+		return r.revertChange(ctx, entry)
+	}); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// revertChange sets the field changed by entry to its old value, using the
+// update of the object type. The old value is in the format of the update
+// input, so the revert is applied as an update setting only that field.
+func (r *mutationResolver) revertChange(ctx context.Context, entry *models.EditHistoryEntry) error {
+	if entry.Action != models.HistoryActionUpdate && entry.Action != models.HistoryActionMerge {
+		return fmt.Errorf("%w: cannot revert %s of %s %d", ErrInput, entry.Action, entry.EntityType, entry.EntityID)
+	}
+
+	if entry.OldValue == nil {
+		return fmt.Errorf("%w: no previous value of %s to revert to", ErrInput, entry.Field)
+	}
+
+	var oldValue interface{}
+	if err := json.Unmarshal([]byte(*entry.OldValue), &oldValue); err != nil {
+		return fmt.Errorf("decoding previous value of %s: %w", entry.Field, err)
+	}
+
+	inputMap := map[string]interface{}{
+		"id":        strconv.Itoa(entry.EntityID),
+		entry.Field: oldValue,
+	}
+
+	translator := changesetTranslator{
+		inputMap: inputMap,
+	}
+
+	switch entry.EntityType {
+	case models.HistoryEntityTypeScene:
+		var input models.SceneUpdateInput
+		if err := decodeUpdateInput(inputMap, &input); err != nil {
+			return err
+		}
+
+		_, err := r.sceneUpdate(ctx, input, translator)
+		return err
+	case models.HistoryEntityTypeImage:
+		var input ImageUpdateInput
+		if err := decodeUpdateInput(inputMap, &input); err != nil {
+			return err
+		}
+
+		_, err := r.imageUpdate(ctx, input, translator)
+		return err
+	case models.HistoryEntityTypeGallery:
+		var input models.GalleryUpdateInput
+		if err := decodeUpdateInput(inputMap, &input); err != nil {
+			return err
+		}
+
+		_, err := r.galleryUpdate(ctx, input, translator)
+		return err
+	case models.HistoryEntityTypePerformer:
+		var input models.PerformerUpdateInput
+		if err := decodeUpdateInput(inputMap, &input); err != nil {
+			return err
+		}
+
+		partial, err := performerPartialFromInput(input, translator)
+		if err != nil {
+			return err
+		}
+
+		qb := r.repository.Performer
+		if err := performer.ValidateUpdate(ctx, entry.EntityID, partial, qb); err != nil {
+			return err
+		}
+
+		_, err = qb.UpdatePartial(ctx, entry.EntityID, partial)
+		return err
+	case models.HistoryEntityTypeStudio:
+		var input models.StudioUpdateInput
+		if err := decodeUpdateInput(inputMap, &input); err != nil {
+			return err
+		}
+
+		partial, err := studioPartialFromInput(entry.EntityID, input, translator)
+		if err != nil {
+			return err
+		}
+
+		qb := r.repository.Studio
+		if err := studio.ValidateModify(ctx, partial, qb); err != nil {
+			return err
+		}
+
+		_, err = qb.UpdatePartial(ctx, partial)
+		return err
+	case models.HistoryEntityTypeTag:
+		var input TagUpdateInput
+		if err := decodeUpdateInput(inputMap, &input); err != nil {
+			return err
+		}
+
+		partial, err := tagPartialFromInput(input, translator)
+		if err != nil {
+			return err
+		}
+
+		qb := r.repository.Tag
+		if err := tag.ValidateUpdate(ctx, entry.EntityID, partial, qb); err != nil {
+			return err
+		}
+
+		_, err = qb.UpdatePartial(ctx, entry.EntityID, partial)
+		return err
+	case models.HistoryEntityTypeGroup:
+		var input GroupUpdateInput
+		if err := decodeUpdateInput(inputMap, &input); err != nil {
+			return err
+		}
+
+		partial, err := groupPartialFromGroupUpdateInput(translator, input)
+		if err != nil {
+			return err
+		}
+
+		_, err = r.groupService.UpdatePartial(ctx, entry.EntityID, partial, group.ImageInput{}, group.ImageInput{})
+		return err
+	default:
+		return fmt.Errorf("%w: unsupported entity type %s", ErrInput, entry.EntityType)
+	}
+}
+
+// decodeUpdateInput decodes inputMap into the update input of an object.
+func decodeUpdateInput(inputMap map[string]interface{}, input interface{}) error {
+	data, err := json.Marshal(inputMap)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(data, input); err != nil {
+		return fmt.Errorf("decoding update input: %w", err)
+	}
+
+	return nil
+}
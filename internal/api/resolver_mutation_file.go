@@ -124,6 +124,10 @@ func (r *mutationResolver) validateFileExtension(oldBasename, newBasename string
 		return err
 	}
 
+	if err := r.validateFileExtensionList(c.GetAudioExtensions(), oldBasename, newBasename); err != nil {
+		return err
+	}
+
 	if err := r.validateFileExtensionList(c.GetImageExtensions(), oldBasename, newBasename); err != nil {
 		return err
 	}
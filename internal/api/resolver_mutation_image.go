@@ -6,7 +6,6 @@ import (
 	"strconv"
 
 	"github.com/stashapp/stash/internal/manager"
-	"github.com/stashapp/stash/pkg/file"
 	"github.com/stashapp/stash/pkg/image"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/plugin"
@@ -309,10 +308,12 @@ func (r *mutationResolver) ImageDestroy(ctx context.Context, input models.ImageD
 	}
 
 	var i *models.Image
+	deleter, trashData := manager.GetInstance().NewFileDeleter()
 	fileDeleter := &image.FileDeleter{
-		Deleter: file.NewDeleter(),
+		Deleter: deleter,
 		Paths:   manager.GetInstance().Paths,
 	}
+	deleteFile := utils.IsTrue(input.DeleteFile)
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		i, err = r.repository.Image.Find(ctx, imageID)
 		if err != nil {
@@ -323,7 +324,13 @@ func (r *mutationResolver) ImageDestroy(ctx context.Context, input models.ImageD
 			return fmt.Errorf("image with id %d not found", imageID)
 		}
 
-		return r.imageService.Destroy(ctx, i, fileDeleter, utils.IsTrue(input.DeleteGenerated), utils.IsTrue(input.DeleteFile))
+		if deleteFile && trashData != nil {
+			if err := trashData.AddImage(ctx, r.repository, i); err != nil {
+				return err
+			}
+		}
+
+		return r.imageService.Destroy(ctx, i, fileDeleter, utils.IsTrue(input.DeleteGenerated), deleteFile)
 	}); err != nil {
 		fileDeleter.Rollback()
 		return false, err
@@ -349,10 +356,12 @@ func (r *mutationResolver) ImagesDestroy(ctx context.Context, input models.Image
 	}
 
 	var images []*models.Image
+	deleter, trashData := manager.GetInstance().NewFileDeleter()
 	fileDeleter := &image.FileDeleter{
-		Deleter: file.NewDeleter(),
+		Deleter: deleter,
 		Paths:   manager.GetInstance().Paths,
 	}
+	deleteFile := utils.IsTrue(input.DeleteFile)
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		qb := r.repository.Image
 
@@ -368,7 +377,13 @@ func (r *mutationResolver) ImagesDestroy(ctx context.Context, input models.Image
 
 			images = append(images, i)
 
-			if err := r.imageService.Destroy(ctx, i, fileDeleter, utils.IsTrue(input.DeleteGenerated), utils.IsTrue(input.DeleteFile)); err != nil {
+			if deleteFile && trashData != nil {
+				if err := trashData.AddImage(ctx, r.repository, i); err != nil {
+					return err
+				}
+			}
+
+			if err := r.imageService.Destroy(ctx, i, fileDeleter, utils.IsTrue(input.DeleteGenerated), deleteFile); err != nil {
 				return err
 			}
 		}
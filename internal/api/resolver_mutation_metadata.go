@@ -53,6 +53,11 @@ func (r *mutationResolver) MetadataExport(ctx context.Context) (string, error) {
 	return strconv.Itoa(jobID), nil
 }
 
+func (r *mutationResolver) MetadataExportNfo(ctx context.Context, input manager.ExportNfoInput) (string, error) {
+	jobID := manager.GetInstance().ExportNfo(ctx, input)
+	return strconv.Itoa(jobID), nil
+}
+
 func (r *mutationResolver) ExportObjects(ctx context.Context, input manager.ExportObjectsInput) (*string, error) {
 	t := manager.CreateExportTask(config.GetInstance().GetVideoFileNamingAlgorithm(), input)
 
@@ -99,6 +104,24 @@ func (r *mutationResolver) MetadataClean(ctx context.Context, input manager.Clea
 	return strconv.Itoa(jobID), nil
 }
 
+func (r *mutationResolver) MetadataOrganise(ctx context.Context, input manager.OrganiseMetadataInput) (string, error) {
+	jobID, err := manager.GetInstance().Organise(ctx, input)
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.Itoa(jobID), nil
+}
+
+func (r *mutationResolver) MetadataHealthCheck(ctx context.Context, input manager.HealthCheckInput) (string, error) {
+	jobID, err := manager.GetInstance().HealthCheck(ctx, input)
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.Itoa(jobID), nil
+}
+
 func (r *mutationResolver) MetadataCleanGenerated(ctx context.Context, input task.CleanGeneratedOptions) (string, error) {
 	mgr := manager.GetInstance()
 	t := &task.CleanGeneratedJob{
@@ -148,6 +171,17 @@ func (r *mutationResolver) BackupDatabase(ctx context.Context, input BackupDatab
 	return nil, nil
 }
 
+func (r *mutationResolver) RestoreBackup(ctx context.Context, input RestoreBackupInput) (bool, error) {
+	restoreBlobs := input.RestoreBlobs != nil && *input.RestoreBlobs
+
+	if err := manager.GetInstance().RestoreBackup(input.Name, restoreBlobs); err != nil {
+		logger.Errorf("Error restoring backup: %v", err)
+		return false, err
+	}
+
+	return true, nil
+}
+
 func (r *mutationResolver) AnonymiseDatabase(ctx context.Context, input AnonymiseDatabaseInput) (*string, error) {
 	// if download is true, then save to temporary file and return a link
 	download := input.Download != nil && *input.Download
@@ -181,3 +215,23 @@ func (r *mutationResolver) OptimiseDatabase(ctx context.Context) (string, error)
 	jobID := manager.GetInstance().OptimiseDatabase(ctx)
 	return strconv.Itoa(jobID), nil
 }
+
+func (r *mutationResolver) RestoreDeleted(ctx context.Context, id string) (bool, error) {
+	if err := manager.GetInstance().RestoreDeleted(ctx, id); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *mutationResolver) PurgeTrash(ctx context.Context, retentionDays *int) (string, error) {
+	mgr := manager.GetInstance()
+
+	days := mgr.Config.GetTrashRetentionDays()
+	if retentionDays != nil {
+		days = *retentionDays
+	}
+
+	jobID := mgr.PurgeTrash(ctx, days)
+	return strconv.Itoa(jobID), nil
+}
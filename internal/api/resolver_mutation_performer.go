@@ -58,6 +58,7 @@ func (r *mutationResolver) PerformerCreate(ctx context.Context, input models.Per
 	newPerformer.Height = input.HeightCm
 	newPerformer.Weight = input.Weight
 	newPerformer.IgnoreAutoTag = translator.bool(input.IgnoreAutoTag)
+	newPerformer.IsGroup = translator.bool(input.IsGroup)
 	newPerformer.StashIDs = models.NewRelatedStashIDs(input.StashIds)
 
 	newPerformer.URLs = models.NewRelatedStrings([]string{})
@@ -91,6 +92,11 @@ func (r *mutationResolver) PerformerCreate(ctx context.Context, input models.Per
 		return nil, fmt.Errorf("converting tag ids: %w", err)
 	}
 
+	newPerformer.RelatedPerformers, err = translator.relatedPerformerRelationships(input.RelatedPerformers)
+	if err != nil {
+		return nil, fmt.Errorf("converting related performers: %w", err)
+	}
+
 	// Process the base 64 encoded image string
 	var imageData []byte
 	if input.Image != nil {
@@ -219,15 +225,8 @@ func (r *mutationResolver) handleLegacyURLs(ctx context.Context, performerID int
 	return nil
 }
 
-func (r *mutationResolver) PerformerUpdate(ctx context.Context, input models.PerformerUpdateInput) (*models.Performer, error) {
-	performerID, err := strconv.Atoi(input.ID)
-	if err != nil {
-		return nil, fmt.Errorf("converting id: %w", err)
-	}
-
-	translator := changesetTranslator{
-		inputMap: getUpdateInputMap(ctx),
-	}
+func performerPartialFromInput(input models.PerformerUpdateInput, translator changesetTranslator) (models.PerformerPartial, error) {
+	var err error
 
 	// Populate performer from the input
 	updatedPerformer := models.NewPerformerPartial()
@@ -251,28 +250,20 @@ func (r *mutationResolver) PerformerUpdate(ctx context.Context, input models.Per
 	updatedPerformer.HairColor = translator.optionalString(input.HairColor, "hair_color")
 	updatedPerformer.Weight = translator.optionalInt(input.Weight, "weight")
 	updatedPerformer.IgnoreAutoTag = translator.optionalBool(input.IgnoreAutoTag, "ignore_auto_tag")
+	updatedPerformer.IsGroup = translator.optionalBool(input.IsGroup, "is_group")
 	updatedPerformer.StashIDs = translator.updateStashIDs(input.StashIds, "stash_ids")
 
 	if translator.hasField("urls") {
-		// ensure url/twitter/instagram are not included in the input
-		if err := r.validateNoLegacyURLs(translator); err != nil {
-			return nil, err
-		}
-
 		updatedPerformer.URLs = translator.updateStrings(input.Urls, "urls")
 	}
 
-	legacyURL := translator.optionalString(input.URL, "url")
-	legacyTwitter := translator.optionalString(input.Twitter, "twitter")
-	legacyInstagram := translator.optionalString(input.Instagram, "instagram")
-
 	updatedPerformer.Birthdate, err = translator.optionalDate(input.Birthdate, "birthdate")
 	if err != nil {
-		return nil, fmt.Errorf("converting birthdate: %w", err)
+		return updatedPerformer, fmt.Errorf("converting birthdate: %w", err)
 	}
 	updatedPerformer.DeathDate, err = translator.optionalDate(input.DeathDate, "death_date")
 	if err != nil {
-		return nil, fmt.Errorf("converting death date: %w", err)
+		return updatedPerformer, fmt.Errorf("converting death date: %w", err)
 	}
 
 	// prefer height_cm over height
@@ -287,9 +278,43 @@ func (r *mutationResolver) PerformerUpdate(ctx context.Context, input models.Per
 
 	updatedPerformer.TagIDs, err = translator.updateIds(input.TagIds, "tag_ids")
 	if err != nil {
-		return nil, fmt.Errorf("converting tag ids: %w", err)
+		return updatedPerformer, fmt.Errorf("converting tag ids: %w", err)
+	}
+
+	updatedPerformer.RelatedPerformers, err = translator.updatePerformerRelationships(input.RelatedPerformers, "related_performers")
+	if err != nil {
+		return updatedPerformer, fmt.Errorf("converting related performers: %w", err)
 	}
 
+	return updatedPerformer, nil
+}
+
+func (r *mutationResolver) PerformerUpdate(ctx context.Context, input models.PerformerUpdateInput) (*models.Performer, error) {
+	performerID, err := strconv.Atoi(input.ID)
+	if err != nil {
+		return nil, fmt.Errorf("converting id: %w", err)
+	}
+
+	translator := changesetTranslator{
+		inputMap: getUpdateInputMap(ctx),
+	}
+
+	if translator.hasField("urls") {
+		// ensure url/twitter/instagram are not included in the input
+		if err := r.validateNoLegacyURLs(translator); err != nil {
+			return nil, err
+		}
+	}
+
+	updatedPerformer, err := performerPartialFromInput(input, translator)
+	if err != nil {
+		return nil, err
+	}
+
+	legacyURL := translator.optionalString(input.URL, "url")
+	legacyTwitter := translator.optionalString(input.Twitter, "twitter")
+	legacyInstagram := translator.optionalString(input.Instagram, "instagram")
+
 	var imageData []byte
 	imageIncluded := translator.hasField("image")
 	if input.Image != nil {
@@ -9,6 +9,7 @@ import (
 
 	"github.com/stashapp/stash/internal/manager"
 	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/history"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/plugin"
@@ -430,8 +431,9 @@ func (r *mutationResolver) SceneDestroy(ctx context.Context, input models.SceneD
 	fileNamingAlgo := manager.GetInstance().Config.GetVideoFileNamingAlgorithm()
 
 	var s *models.Scene
+	deleter, trashData := manager.GetInstance().NewFileDeleter()
 	fileDeleter := &scene.FileDeleter{
-		Deleter:        file.NewDeleter(),
+		Deleter:        deleter,
 		FileNamingAlgo: fileNamingAlgo,
 		Paths:          manager.GetInstance().Paths,
 	}
@@ -454,6 +456,12 @@ func (r *mutationResolver) SceneDestroy(ctx context.Context, input models.SceneD
 		// kill any running encoders
 		manager.KillRunningStreams(s, fileNamingAlgo)
 
+		if deleteFile && trashData != nil {
+			if err := trashData.AddScene(ctx, r.repository, s); err != nil {
+				return err
+			}
+		}
+
 		return r.sceneService.Destroy(ctx, s, fileDeleter, deleteGenerated, deleteFile)
 	}); err != nil {
 		fileDeleter.Rollback()
@@ -483,8 +491,9 @@ func (r *mutationResolver) ScenesDestroy(ctx context.Context, input models.Scene
 	var scenes []*models.Scene
 	fileNamingAlgo := manager.GetInstance().Config.GetVideoFileNamingAlgorithm()
 
+	deleter, trashData := manager.GetInstance().NewFileDeleter()
 	fileDeleter := &scene.FileDeleter{
-		Deleter:        file.NewDeleter(),
+		Deleter:        deleter,
 		FileNamingAlgo: fileNamingAlgo,
 		Paths:          manager.GetInstance().Paths,
 	}
@@ -509,6 +518,12 @@ func (r *mutationResolver) ScenesDestroy(ctx context.Context, input models.Scene
 			// kill any running encoders
 			manager.KillRunningStreams(scene, fileNamingAlgo)
 
+			if deleteFile && trashData != nil {
+				if err := trashData.AddScene(ctx, r.repository, scene); err != nil {
+					return err
+				}
+			}
+
 			if err := r.sceneService.Destroy(ctx, scene, fileDeleter, deleteGenerated, deleteFile); err != nil {
 				return err
 			}
@@ -599,6 +614,9 @@ func (r *mutationResolver) SceneMerge(ctx context.Context, input SceneMergeInput
 		Paths:          mgr.Paths,
 	}
 
+	// changes made by the merge are recorded as merges in the edit history
+	ctx = history.WithAction(ctx, models.HistoryActionMerge)
+
 	var ret *models.Scene
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		if err := r.Resolver.sceneService.Merge(ctx, srcIDs, destID, fileDeleter, scene.MergeOptions{
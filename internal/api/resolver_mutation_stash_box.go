@@ -39,6 +39,26 @@ func (r *mutationResolver) StashBoxBatchStudioTag(ctx context.Context, input man
 	return strconv.Itoa(jobID), nil
 }
 
+func (r *mutationResolver) StashBoxBatchTagTag(ctx context.Context, input manager.StashBoxBatchTagInput) (string, error) {
+	b, err := resolveStashBoxBatchTagInput(input.Endpoint, input.StashBoxEndpoint)
+	if err != nil {
+		return "", err
+	}
+
+	jobID := manager.GetInstance().StashBoxBatchTagTag(ctx, b, input)
+	return strconv.Itoa(jobID), nil
+}
+
+func (r *mutationResolver) StashBoxCheckUpdates(ctx context.Context, input manager.StashBoxCheckUpdatesInput) (string, error) {
+	b, err := resolveStashBox(nil, &input.StashBoxEndpoint)
+	if err != nil {
+		return "", err
+	}
+
+	jobID := manager.GetInstance().StashBoxCheckUpdates(ctx, b, input)
+	return strconv.Itoa(jobID), nil
+}
+
 func (r *mutationResolver) SubmitStashBoxSceneDraft(ctx context.Context, input StashBoxDraftSubmissionInput) (*string, error) {
 	b, err := resolveStashBox(input.StashBoxIndex, input.StashBoxEndpoint)
 	if err != nil {
@@ -111,3 +131,67 @@ func (r *mutationResolver) SubmitStashBoxPerformerDraft(ctx context.Context, inp
 
 	return res, err
 }
+
+func (r *mutationResolver) SubmitStashBoxStudioEdit(ctx context.Context, input StashBoxDraftSubmissionInput) (*string, error) {
+	b, err := resolveStashBox(input.StashBoxIndex, input.StashBoxEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	client := r.newStashBoxClient(*b)
+
+	id, err := strconv.Atoi(input.ID)
+	if err != nil {
+		return nil, fmt.Errorf("converting id: %w", err)
+	}
+
+	var res *string
+	err = r.withReadTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.Studio
+		studio, err := qb.Find(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if studio == nil {
+			return fmt.Errorf("studio with id %d not found", id)
+		}
+
+		res, err = client.SubmitStudioEdit(ctx, studio)
+		return err
+	})
+
+	return res, err
+}
+
+func (r *mutationResolver) SubmitStashBoxTagEdit(ctx context.Context, input StashBoxDraftSubmissionInput) (*string, error) {
+	b, err := resolveStashBox(input.StashBoxIndex, input.StashBoxEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	client := r.newStashBoxClient(*b)
+
+	id, err := strconv.Atoi(input.ID)
+	if err != nil {
+		return nil, fmt.Errorf("converting id: %w", err)
+	}
+
+	var res *string
+	err = r.withReadTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.Tag
+		tag, err := qb.Find(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if tag == nil {
+			return fmt.Errorf("tag with id %d not found", id)
+		}
+
+		res, err = client.SubmitTagEdit(ctx, tag)
+		return err
+	})
+
+	return res, err
+}
@@ -91,15 +91,8 @@ func (r *mutationResolver) StudioCreate(ctx context.Context, input models.Studio
 	return r.getStudio(ctx, newStudio.ID)
 }
 
-func (r *mutationResolver) StudioUpdate(ctx context.Context, input models.StudioUpdateInput) (*models.Studio, error) {
-	studioID, err := strconv.Atoi(input.ID)
-	if err != nil {
-		return nil, fmt.Errorf("converting id: %w", err)
-	}
-
-	translator := changesetTranslator{
-		inputMap: getUpdateInputMap(ctx),
-	}
+func studioPartialFromInput(studioID int, input models.StudioUpdateInput, translator changesetTranslator) (models.StudioPartial, error) {
+	var err error
 
 	// Populate studio from the input
 	updatedStudio := models.NewStudioPartial()
@@ -116,12 +109,30 @@ func (r *mutationResolver) StudioUpdate(ctx context.Context, input models.Studio
 
 	updatedStudio.ParentID, err = translator.optionalIntFromString(input.ParentID, "parent_id")
 	if err != nil {
-		return nil, fmt.Errorf("converting parent id: %w", err)
+		return updatedStudio, fmt.Errorf("converting parent id: %w", err)
 	}
 
 	updatedStudio.TagIDs, err = translator.updateIds(input.TagIds, "tag_ids")
 	if err != nil {
-		return nil, fmt.Errorf("converting tag ids: %w", err)
+		return updatedStudio, fmt.Errorf("converting tag ids: %w", err)
+	}
+
+	return updatedStudio, nil
+}
+
+func (r *mutationResolver) StudioUpdate(ctx context.Context, input models.StudioUpdateInput) (*models.Studio, error) {
+	studioID, err := strconv.Atoi(input.ID)
+	if err != nil {
+		return nil, fmt.Errorf("converting id: %w", err)
+	}
+
+	translator := changesetTranslator{
+		inputMap: getUpdateInputMap(ctx),
+	}
+
+	updatedStudio, err := studioPartialFromInput(studioID, input, translator)
+	if err != nil {
+		return nil, err
 	}
 
 	// Process the base 64 encoded image string
@@ -5,9 +5,11 @@ import (
 	"fmt"
 	"strconv"
 
+	"github.com/stashapp/stash/pkg/history"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/plugin/hook"
+	"github.com/stashapp/stash/pkg/sliceutil"
 	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
 	"github.com/stashapp/stash/pkg/tag"
 	"github.com/stashapp/stash/pkg/utils"
@@ -37,6 +39,7 @@ func (r *mutationResolver) TagCreate(ctx context.Context, input TagCreateInput)
 	newTag.Favorite = translator.bool(input.Favorite)
 	newTag.Description = translator.string(input.Description)
 	newTag.IgnoreAutoTag = translator.bool(input.IgnoreAutoTag)
+	newTag.StashIDs = models.NewRelatedStashIDs(sliceutil.PtrsToValues(input.StashIds))
 
 	var err error
 
@@ -88,15 +91,8 @@ func (r *mutationResolver) TagCreate(ctx context.Context, input TagCreateInput)
 	return r.getTag(ctx, newTag.ID)
 }
 
-func (r *mutationResolver) TagUpdate(ctx context.Context, input TagUpdateInput) (*models.Tag, error) {
-	tagID, err := strconv.Atoi(input.ID)
-	if err != nil {
-		return nil, fmt.Errorf("converting id: %w", err)
-	}
-
-	translator := changesetTranslator{
-		inputMap: getUpdateInputMap(ctx),
-	}
+func tagPartialFromInput(input TagUpdateInput, translator changesetTranslator) (models.TagPartial, error) {
+	var err error
 
 	// Populate tag from the input
 	updatedTag := models.NewTagPartial()
@@ -107,15 +103,34 @@ func (r *mutationResolver) TagUpdate(ctx context.Context, input TagUpdateInput)
 	updatedTag.Description = translator.optionalString(input.Description, "description")
 
 	updatedTag.Aliases = translator.updateStrings(input.Aliases, "aliases")
+	updatedTag.StashIDs = translator.updateStashIDs(sliceutil.PtrsToValues(input.StashIds), "stash_ids")
 
 	updatedTag.ParentIDs, err = translator.updateIds(input.ParentIds, "parent_ids")
 	if err != nil {
-		return nil, fmt.Errorf("converting parent tag ids: %w", err)
+		return updatedTag, fmt.Errorf("converting parent tag ids: %w", err)
 	}
 
 	updatedTag.ChildIDs, err = translator.updateIds(input.ChildIds, "child_ids")
 	if err != nil {
-		return nil, fmt.Errorf("converting child tag ids: %w", err)
+		return updatedTag, fmt.Errorf("converting child tag ids: %w", err)
+	}
+
+	return updatedTag, nil
+}
+
+func (r *mutationResolver) TagUpdate(ctx context.Context, input TagUpdateInput) (*models.Tag, error) {
+	tagID, err := strconv.Atoi(input.ID)
+	if err != nil {
+		return nil, fmt.Errorf("converting id: %w", err)
+	}
+
+	translator := changesetTranslator{
+		inputMap: getUpdateInputMap(ctx),
+	}
+
+	updatedTag, err := tagPartialFromInput(input, translator)
+	if err != nil {
+		return nil, err
 	}
 
 	var imageData []byte
@@ -284,6 +299,9 @@ func (r *mutationResolver) TagsMerge(ctx context.Context, input TagsMergeInput)
 		return nil, nil
 	}
 
+	// changes made by the merge are recorded as merges in the edit history
+	ctx = history.WithAction(ctx, models.HistoryActionMerge)
+
 	var t *models.Tag
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		qb := r.repository.Tag
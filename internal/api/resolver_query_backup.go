@@ -0,0 +1,30 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/internal/manager"
+)
+
+func (r *queryResolver) ListBackups(ctx context.Context) ([]*DatabaseBackup, error) {
+	backups, err := manager.GetInstance().ListBackups()
+	if err != nil {
+		return nil, err
+	}
+
+	ret := []*DatabaseBackup{}
+	for _, b := range backups {
+		ret = append(ret, &DatabaseBackup{
+			Name:           b.Name,
+			Path:           b.Path,
+			SchemaVersion:  int(b.SchemaVersion),
+			CreatedAt:      b.CreatedAt,
+			Size:           b.Size,
+			Automatic:      b.Automatic,
+			IncludesBlobs:  b.IncludesBlobs,
+			IncludesConfig: b.IncludesConfig,
+		})
+	}
+
+	return ret, nil
+}
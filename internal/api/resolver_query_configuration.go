@@ -83,6 +83,11 @@ func makeConfigGeneralResult() *ConfigGeneralResult {
 		Stashes:                       config.GetStashPaths(),
 		DatabasePath:                  config.GetDatabasePath(),
 		BackupDirectoryPath:           config.GetBackupDirectoryPath(),
+		AutoBackup:                    config.GetAutoBackup(),
+		AutoBackupKeepDaily:           config.GetAutoBackupKeepDaily(),
+		AutoBackupKeepWeekly:          config.GetAutoBackupKeepWeekly(),
+		AutoBackupIncludeBlobs:        config.GetAutoBackupIncludeBlobs(),
+		AutoBackupIncludeConfig:       config.GetAutoBackupIncludeConfig(),
 		GeneratedPath:                 config.GetGeneratedPath(),
 		MetadataPath:                  config.GetMetadataPath(),
 		ConfigFilePath:                config.GetConfigFile(),
@@ -94,6 +99,7 @@ func makeConfigGeneralResult() *ConfigGeneralResult {
 		FfmpegPath:                    config.GetFFMpegPath(),
 		FfprobePath:                   config.GetFFProbePath(),
 		CalculateMd5:                  config.IsCalculateMD5(),
+		UseFingerprintXattrs:          config.GetUseFingerprintXattrs(),
 		VideoFileNamingAlgorithm:      config.GetVideoFileNamingAlgorithm(),
 		ParallelTasks:                 config.GetParallelTasks(),
 		PreviewAudio:                  config.GetPreviewAudio(),
@@ -117,9 +123,14 @@ func makeConfigGeneralResult() *ConfigGeneralResult {
 		LogLevel:                      config.GetLogLevel(),
 		LogAccess:                     config.GetLogAccess(),
 		VideoExtensions:               config.GetVideoExtensions(),
+		AudioExtensions:               config.GetAudioExtensions(),
 		ImageExtensions:               config.GetImageExtensions(),
 		GalleryExtensions:             config.GetGalleryExtensions(),
 		CreateGalleriesFromFolders:    config.GetCreateGalleriesFromFolders(),
+		WatchLibrary:                  config.GetWatchLibrary(),
+		ReadNfoFiles:                  config.GetReadNFOFiles(),
+		UseTrash:                      config.GetUseTrash(),
+		TrashRetentionDays:            config.GetTrashRetentionDays(),
 		Excludes:                      config.GetExcludes(),
 		ImageExcludes:                 config.GetImageExcludes(),
 		CustomPerformerImageLocation:  &customPerformerImageLocation,
@@ -0,0 +1,25 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func (r *queryResolver) History(ctx context.Context, entity models.HistoryEntityType, id string) (ret []*models.EditHistoryEntry, err error) {
+	entityID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, fmt.Errorf("converting id: %w", err)
+	}
+
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.repository.EditHistory.FindByEntity(ctx, entity, entityID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
@@ -24,13 +24,17 @@ func (r *queryResolver) FindGallery(ctx context.Context, id string) (ret *models
 	return ret, nil
 }
 
-func (r *queryResolver) FindGalleries(ctx context.Context, galleryFilter *models.GalleryFilterType, filter *models.FindFilterType, ids []string) (ret *FindGalleriesResultType, err error) {
+func (r *queryResolver) FindGalleries(ctx context.Context, galleryFilter *models.GalleryFilterType, query *string, filter *models.FindFilterType, ids []string) (ret *FindGalleriesResultType, err error) {
 	idInts, err := stringslice.StringSliceToIntSlice(ids)
 	if err != nil {
 		return nil, err
 	}
 
 	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		if galleryFilter, err = applyFilterQuery(ctx, r.repository, galleryFilter, query); err != nil {
+			return err
+		}
+
 		var galleries []*models.Gallery
 		var err error
 		var total int
@@ -50,6 +54,19 @@ func (r *queryResolver) FindGalleries(ctx context.Context, galleryFilter *models
 			Count:     total,
 			Galleries: galleries,
 		}
+
+		limit, ok, err := facetLimit(ctx)
+		if err != nil {
+			return err
+		}
+
+		if ok && len(idInts) == 0 {
+			ret.Facets, err = r.repository.Gallery.QueryFacets(ctx, galleryFilter, filter, limit)
+			if err != nil {
+				return err
+			}
+		}
+
 		return nil
 	}); err != nil {
 		return nil, err
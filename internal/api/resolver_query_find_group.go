@@ -24,13 +24,17 @@ func (r *queryResolver) FindGroup(ctx context.Context, id string) (ret *models.G
 	return ret, nil
 }
 
-func (r *queryResolver) FindGroups(ctx context.Context, groupFilter *models.GroupFilterType, filter *models.FindFilterType, ids []string) (ret *FindGroupsResultType, err error) {
+func (r *queryResolver) FindGroups(ctx context.Context, groupFilter *models.GroupFilterType, query *string, filter *models.FindFilterType, ids []string) (ret *FindGroupsResultType, err error) {
 	idInts, err := stringslice.StringSliceToIntSlice(ids)
 	if err != nil {
 		return nil, err
 	}
 
 	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		if groupFilter, err = applyFilterQuery(ctx, r.repository, groupFilter, query); err != nil {
+			return err
+		}
+
 		var groups []*models.Group
 		var err error
 		var total int
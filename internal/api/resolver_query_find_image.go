@@ -50,6 +50,7 @@ func (r *queryResolver) FindImage(ctx context.Context, id *string, checksum *str
 func (r *queryResolver) FindImages(
 	ctx context.Context,
 	imageFilter *models.ImageFilterType,
+	query *string,
 	imageIds []int,
 	ids []string,
 	filter *models.FindFilterType,
@@ -62,6 +63,10 @@ func (r *queryResolver) FindImages(
 	}
 
 	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		if imageFilter, err = applyFilterQuery(ctx, r.repository, imageFilter, query); err != nil {
+			return err
+		}
+
 		qb := r.repository.Image
 
 		var images []*models.Image
@@ -117,6 +122,18 @@ func (r *queryResolver) FindImages(
 			Filesize:   result.TotalSize,
 		}
 
+		limit, ok, err := facetLimit(ctx)
+		if err != nil {
+			return err
+		}
+
+		if ok && len(imageIds) == 0 {
+			ret.Facets, err = qb.QueryFacets(ctx, imageFilter, filter, limit)
+			if err != nil {
+				return err
+			}
+		}
+
 		return nil
 	}); err != nil {
 		return nil, err
@@ -125,6 +142,21 @@ func (r *queryResolver) FindImages(
 	return ret, nil
 }
 
+func (r *queryResolver) FindDuplicateImages(ctx context.Context, distance *int) (ret [][]*models.Image, err error) {
+	dist := 0
+	if distance != nil {
+		dist = *distance
+	}
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.repository.Image.FindDuplicates(ctx, dist)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
 func (r *queryResolver) AllImages(ctx context.Context) (ret []*models.Image, err error) {
 	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
 		ret, err = r.repository.Image.All(ctx)
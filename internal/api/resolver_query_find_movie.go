@@ -24,13 +24,17 @@ func (r *queryResolver) FindMovie(ctx context.Context, id string) (ret *models.G
 	return ret, nil
 }
 
-func (r *queryResolver) FindMovies(ctx context.Context, movieFilter *models.GroupFilterType, filter *models.FindFilterType, ids []string) (ret *FindMoviesResultType, err error) {
+func (r *queryResolver) FindMovies(ctx context.Context, movieFilter *models.GroupFilterType, query *string, filter *models.FindFilterType, ids []string) (ret *FindMoviesResultType, err error) {
 	idInts, err := stringslice.StringSliceToIntSlice(ids)
 	if err != nil {
 		return nil, err
 	}
 
 	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		if movieFilter, err = applyFilterQuery(ctx, r.repository, movieFilter, query); err != nil {
+			return err
+		}
+
 		var groups []*models.Group
 		var err error
 		var total int
@@ -24,7 +24,7 @@ func (r *queryResolver) FindPerformer(ctx context.Context, id string) (ret *mode
 	return ret, nil
 }
 
-func (r *queryResolver) FindPerformers(ctx context.Context, performerFilter *models.PerformerFilterType, filter *models.FindFilterType, performerIDs []int, ids []string) (ret *FindPerformersResultType, err error) {
+func (r *queryResolver) FindPerformers(ctx context.Context, performerFilter *models.PerformerFilterType, query *string, filter *models.FindFilterType, performerIDs []int, ids []string) (ret *FindPerformersResultType, err error) {
 	if len(ids) > 0 {
 		performerIDs, err = stringslice.StringSliceToIntSlice(ids)
 		if err != nil {
@@ -33,6 +33,10 @@ func (r *queryResolver) FindPerformers(ctx context.Context, performerFilter *mod
 	}
 
 	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		if performerFilter, err = applyFilterQuery(ctx, r.repository, performerFilter, query); err != nil {
+			return err
+		}
+
 		var performers []*models.Performer
 		var err error
 		var total int
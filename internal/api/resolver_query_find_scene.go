@@ -78,6 +78,7 @@ func (r *queryResolver) FindSceneByHash(ctx context.Context, input SceneHashInpu
 func (r *queryResolver) FindScenes(
 	ctx context.Context,
 	sceneFilter *models.SceneFilterType,
+	query *string,
 	sceneIDs []int,
 	ids []string,
 	filter *models.FindFilterType,
@@ -90,6 +91,10 @@ func (r *queryResolver) FindScenes(
 	}
 
 	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		if sceneFilter, err = applyFilterQuery(ctx, r.repository, sceneFilter, query); err != nil {
+			return err
+		}
+
 		var scenes []*models.Scene
 		var err error
 
@@ -141,6 +146,18 @@ func (r *queryResolver) FindScenes(
 			Filesize: result.TotalSize,
 		}
 
+		limit, ok, err := facetLimit(ctx)
+		if err != nil {
+			return err
+		}
+
+		if ok && len(sceneIDs) == 0 {
+			ret.Facets, err = r.repository.Scene.QueryFacets(ctx, sceneFilter, filter, limit)
+			if err != nil {
+				return err
+			}
+		}
+
 		return nil
 	}); err != nil {
 		return nil, err
@@ -6,8 +6,12 @@ import (
 	"github.com/stashapp/stash/pkg/models"
 )
 
-func (r *queryResolver) FindSceneMarkers(ctx context.Context, sceneMarkerFilter *models.SceneMarkerFilterType, filter *models.FindFilterType) (ret *FindSceneMarkersResultType, err error) {
+func (r *queryResolver) FindSceneMarkers(ctx context.Context, sceneMarkerFilter *models.SceneMarkerFilterType, query *string, filter *models.FindFilterType) (ret *FindSceneMarkersResultType, err error) {
 	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		if sceneMarkerFilter, err = applyFilterQuery(ctx, r.repository, sceneMarkerFilter, query); err != nil {
+			return err
+		}
+
 		sceneMarkers, total, err := r.repository.SceneMarker.Query(ctx, sceneMarkerFilter, filter)
 		if err != nil {
 			return err
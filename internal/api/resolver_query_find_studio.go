@@ -25,13 +25,17 @@ func (r *queryResolver) FindStudio(ctx context.Context, id string) (ret *models.
 	return ret, nil
 }
 
-func (r *queryResolver) FindStudios(ctx context.Context, studioFilter *models.StudioFilterType, filter *models.FindFilterType, ids []string) (ret *FindStudiosResultType, err error) {
+func (r *queryResolver) FindStudios(ctx context.Context, studioFilter *models.StudioFilterType, query *string, filter *models.FindFilterType, ids []string) (ret *FindStudiosResultType, err error) {
 	idInts, err := stringslice.StringSliceToIntSlice(ids)
 	if err != nil {
 		return nil, err
 	}
 
 	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		if studioFilter, err = applyFilterQuery(ctx, r.repository, studioFilter, query); err != nil {
+			return err
+		}
+
 		var studios []*models.Studio
 		var err error
 		var total int
@@ -24,13 +24,17 @@ func (r *queryResolver) FindTag(ctx context.Context, id string) (ret *models.Tag
 	return ret, nil
 }
 
-func (r *queryResolver) FindTags(ctx context.Context, tagFilter *models.TagFilterType, filter *models.FindFilterType, ids []string) (ret *FindTagsResultType, err error) {
+func (r *queryResolver) FindTags(ctx context.Context, tagFilter *models.TagFilterType, query *string, filter *models.FindFilterType, ids []string) (ret *FindTagsResultType, err error) {
 	idInts, err := stringslice.StringSliceToIntSlice(ids)
 	if err != nil {
 		return nil, err
 	}
 
 	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		if tagFilter, err = applyFilterQuery(ctx, r.repository, tagFilter, query); err != nil {
+			return err
+		}
+
 		var tags []*models.Tag
 		var err error
 		var total int
@@ -0,0 +1,11 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/internal/manager"
+)
+
+func (r *queryResolver) OrganisePreview(ctx context.Context, input manager.OrganiseMetadataInput) ([]*manager.OrganiseMove, error) {
+	return manager.GetInstance().OrganisePreview(ctx, input)
+}
@@ -320,7 +320,20 @@ func (r *queryResolver) ScrapeSingleScene(ctx context.Context, source scraper.So
 
 func (r *queryResolver) ScrapeMultiScenes(ctx context.Context, source scraper.Source, input ScrapeMultiScenesInput) ([][]*scraper.ScrapedScene, error) {
 	if source.ScraperID != nil {
-		return nil, ErrNotImplemented
+		sceneIDs, err := stringslice.StringSliceToIntSlice(input.SceneIds)
+		if err != nil {
+			return nil, err
+		}
+
+		ret := make([][]*scraper.ScrapedScene, len(sceneIDs))
+		for i, sceneID := range sceneIDs {
+			ret[i], err = r.scraperCache().ScrapeFingerprint(ctx, *source.ScraperID, sceneID)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return ret, nil
 	} else if source.StashBoxIndex != nil || source.StashBoxEndpoint != nil {
 		b, err := resolveStashBox(source.StashBoxIndex, source.StashBoxEndpoint)
 		if err != nil {
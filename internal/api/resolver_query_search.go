@@ -0,0 +1,199 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sliceutil"
+)
+
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
+)
+
+func (r *queryResolver) Search(ctx context.Context, q string, types []SearchResultType, limit *int) ([]*SearchResult, error) {
+	ret := []*SearchResult{}
+
+	q = strings.TrimSpace(q)
+	if q == "" {
+		return ret, nil
+	}
+
+	perPage, err := searchLimit(limit)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(types) == 0 {
+		types = AllSearchResultType
+	}
+	types = sliceutil.Unique(types)
+
+	terms := searchTerms(q)
+
+	// results of each type are ordered by relevance when no sort is set
+	findFilter := &models.FindFilterType{
+		Q:       &q,
+		PerPage: &perPage,
+	}
+
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		for _, t := range types {
+			candidates, err := r.searchType(ctx, t, findFilter)
+			if err != nil {
+				return fmt.Errorf("searching %s: %w", strings.ToLower(t.String()), err)
+			}
+
+			for i, c := range candidates {
+				ret = append(ret, &SearchResult{
+					Type:    t,
+					Item:    c.item,
+					Score:   searchScore(terms, c.name, i, len(candidates)),
+					Snippet: searchSnippet(terms, c.fields),
+				})
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(ret, func(i, j int) bool {
+		return ret[i].Score > ret[j].Score
+	})
+
+	if len(ret) > perPage {
+		ret = ret[:perPage]
+	}
+
+	return ret, nil
+}
+
+// searchLimit returns the maximum number of search results for the provided
+// limit argument. Limits above maxSearchLimit are capped.
+func searchLimit(limit *int) (int, error) {
+	if limit == nil {
+		return defaultSearchLimit, nil
+	}
+
+	if *limit < 1 {
+		return 0, fmt.Errorf("limit must be greater than zero: %d", *limit)
+	}
+
+	return min(*limit, maxSearchLimit), nil
+}
+
+func (r *queryResolver) searchType(ctx context.Context, t SearchResultType, findFilter *models.FindFilterType) ([]searchCandidate, error) {
+	var ret []searchCandidate
+
+	switch t {
+	case SearchResultTypeScene:
+		result, err := r.repository.Scene.Query(ctx, models.SceneQueryOptions{
+			QueryOptions: models.QueryOptions{
+				FindFilter: findFilter,
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		scenes, err := result.Resolve(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, s := range scenes {
+			ret = append(ret, searchCandidate{
+				item:   s,
+				name:   s.GetTitle(),
+				fields: []string{s.GetTitle(), s.Code, s.Details, s.Path},
+			})
+		}
+	case SearchResultTypePerformer:
+		performers, _, err := r.repository.Performer.Query(ctx, nil, findFilter)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, p := range performers {
+			if err := p.LoadAliases(ctx, r.repository.Performer); err != nil {
+				return nil, err
+			}
+
+			ret = append(ret, searchCandidate{
+				item:   p,
+				name:   p.Name,
+				fields: append([]string{p.Name}, p.Aliases.List()...),
+			})
+		}
+	case SearchResultTypeStudio:
+		studios, _, err := r.repository.Studio.Query(ctx, nil, findFilter)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, s := range studios {
+			if err := s.LoadAliases(ctx, r.repository.Studio); err != nil {
+				return nil, err
+			}
+
+			ret = append(ret, searchCandidate{
+				item:   s,
+				name:   s.Name,
+				fields: append([]string{s.Name}, s.Aliases.List()...),
+			})
+		}
+	case SearchResultTypeTag:
+		tags, _, err := r.repository.Tag.Query(ctx, nil, findFilter)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, tag := range tags {
+			if err := tag.LoadAliases(ctx, r.repository.Tag); err != nil {
+				return nil, err
+			}
+
+			ret = append(ret, searchCandidate{
+				item:   tag,
+				name:   tag.Name,
+				fields: append([]string{tag.Name}, tag.Aliases.List()...),
+			})
+		}
+	case SearchResultTypeGallery:
+		galleries, _, err := r.repository.Gallery.Query(ctx, nil, findFilter)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, g := range galleries {
+			ret = append(ret, searchCandidate{
+				item:   g,
+				name:   g.GetTitle(),
+				fields: []string{g.GetTitle(), g.Path},
+			})
+		}
+	case SearchResultTypeGroup:
+		groups, _, err := r.repository.Group.Query(ctx, nil, findFilter)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, g := range groups {
+			ret = append(ret, searchCandidate{
+				item:   g,
+				name:   g.Name,
+				fields: []string{g.Name, g.Aliases},
+			})
+		}
+	default:
+		return nil, fmt.Errorf("unsupported search type: %s", t)
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,43 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+func (r *queryResolver) TrashEntries(ctx context.Context) ([]*TrashEntry, error) {
+	manifests, err := manager.GetInstance().GetTrash().List()
+	if err != nil {
+		return nil, err
+	}
+
+	ret := []*TrashEntry{}
+	for _, m := range manifests {
+		entry := &TrashEntry{
+			ID:        m.ID,
+			DeletedAt: m.DeletedAt,
+			Files:     []string{},
+		}
+
+		for _, f := range m.Files {
+			entry.Files = append(entry.Files, f.OriginalPath)
+		}
+
+		if len(m.Data) > 0 {
+			var data manager.TrashData
+			if err := json.Unmarshal(m.Data, &data); err != nil {
+				logger.Warnf("Error decoding data of trash entry %s: %v", m.ID, err)
+			}
+
+			entry.SceneCount = len(data.Scenes)
+			entry.ImageCount = len(data.Images)
+		}
+
+		ret = append(ret, entry)
+	}
+
+	return ret, nil
+}
@@ -62,6 +62,7 @@ func (rs sceneRoutes) Routes() chi.Router {
 		r.Get("/stream.mp4", rs.StreamMp4)
 		r.Get("/stream.webm", rs.StreamWebM)
 		r.Get("/stream.mkv", rs.StreamMKV)
+		r.Get("/stream.aac", rs.StreamAAC)
 		r.Get("/stream.m3u8", rs.StreamHLS)
 		r.Get("/stream.m3u8/{segment}.ts", rs.StreamHLSSegment)
 		r.Get("/stream.mpd", rs.StreamDASH)
@@ -106,6 +107,10 @@ func (rs sceneRoutes) StreamWebM(w http.ResponseWriter, r *http.Request) {
 	rs.streamTranscode(w, r, ffmpeg.StreamTypeWEBM)
 }
 
+func (rs sceneRoutes) StreamAAC(w http.ResponseWriter, r *http.Request) {
+	rs.streamTranscode(w, r, ffmpeg.StreamTypeAAC)
+}
+
 func (rs sceneRoutes) StreamMKV(w http.ResponseWriter, r *http.Request) {
 	// only allow mkv streaming if the scene container is an mkv already
 	scene := r.Context().Value(sceneKey).(*models.Scene)
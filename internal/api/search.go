@@ -0,0 +1,138 @@
+package api
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// SearchResultItem is an object matched by a search.
+type SearchResultItem interface{}
+
+// snippetContext is the number of characters either side of the match
+// included in a search snippet.
+const snippetContext = 30
+
+// searchCandidate is an object returned by the query of its type, before
+// it is scored against the other results.
+type searchCandidate struct {
+	item SearchResultItem
+	// name is the title or name of the object.
+	name string
+	// fields are the searched text fields of the object, in order of
+	// importance. Used to create the snippet.
+	fields []string
+}
+
+// searchTerms returns the terms of the search query that results may match.
+func searchTerms(q string) []string {
+	specs := models.ParseSearchString(q)
+
+	ret := append([]string{}, specs.MustHave...)
+	for _, set := range specs.AnySets {
+		ret = append(ret, set...)
+	}
+
+	return ret
+}
+
+// searchScore returns the relevance of an object to the search terms.
+// Objects are scored on how closely their name matches the terms, then on
+// their position in the results of their type, which are ordered by
+// relevance. pos is the index of the object in count results.
+func searchScore(terms []string, name string, pos int, count int) float64 {
+	var score float64
+
+	name = strings.ToLower(name)
+	phrase := strings.ToLower(strings.Join(terms, " "))
+
+	switch {
+	case name == phrase:
+		score += 1
+	case strings.HasPrefix(name, phrase):
+		score += 0.5
+	}
+
+	if len(terms) > 0 {
+		matched := 0
+		for _, t := range terms {
+			if strings.Contains(name, strings.ToLower(t)) {
+				matched++
+			}
+		}
+		score += 0.5 * float64(matched) / float64(len(terms))
+	}
+
+	if count > 0 {
+		score += 0.25 * float64(count-pos) / float64(count)
+	}
+
+	return score
+}
+
+// searchSnippet returns an excerpt of the first field containing one of the
+// search terms. Returns nil if no field contains a term.
+func searchSnippet(terms []string, fields []string) *string {
+	for _, f := range fields {
+		field := []rune(f)
+		lower := toLowerRunes(field)
+		for _, t := range terms {
+			term := toLowerRunes([]rune(t))
+			i := indexRunes(lower, term)
+			if i == -1 {
+				continue
+			}
+
+			ret := excerpt(field, i, len(term))
+			return &ret
+		}
+	}
+
+	return nil
+}
+
+// toLowerRunes lower-cases each rune of s. Unlike strings.ToLower, the result
+// always has the same number of runes as s, so that indexes in the result are
+// indexes in s.
+func toLowerRunes(s []rune) []rune {
+	ret := make([]rune, len(s))
+	for i, r := range s {
+		ret[i] = unicode.ToLower(r)
+	}
+	return ret
+}
+
+// indexRunes returns the index of the first instance of substr in s, or -1 if
+// substr is not present in s.
+func indexRunes(s []rune, substr []rune) int {
+	if len(substr) == 0 {
+		return 0
+	}
+
+	for i := 0; i+len(substr) <= len(s); i++ {
+		if slices.Equal(s[i:i+len(substr)], substr) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// excerpt returns the text of field around the match at start with length
+// runes. Whitespace is collapsed, and elided text is marked with ellipses.
+func excerpt(field []rune, start int, length int) string {
+	from := max(start-snippetContext, 0)
+	to := min(start+length+snippetContext, len(field))
+
+	ret := strings.Join(strings.Fields(string(field[from:to])), " ")
+	if from > 0 {
+		ret = "…" + ret
+	}
+	if to < len(field) {
+		ret += "…"
+	}
+
+	return ret
+}
@@ -0,0 +1,98 @@
+package api
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSearchSnippet(t *testing.T) {
+	long := "The quick brown fox jumps over the lazy dog. Pack my box with five dozen liquor jugs."
+
+	tests := []struct {
+		name   string
+		terms  []string
+		fields []string
+		want   *string
+	}{
+		{"no match", []string{"cat"}, []string{"dog", "fox"}, nil},
+		{"whole field", []string{"FOX"}, []string{"dog", "brown fox"}, strPtr("brown fox")},
+		{"first field", []string{"dog"}, []string{"hot dog", "dog"}, strPtr("hot dog")},
+		{"elided", []string{"lazy"}, []string{long}, strPtr("…uick brown fox jumps over the lazy dog. Pack my box with five do…")},
+		{"collapse whitespace", []string{"b"}, []string{"a\n\nb  c"}, strPtr("a b c")},
+		{"multibyte", []string{"ÉTÉ"}, []string{"Un été à Paris"}, strPtr("Un été à Paris")},
+		// lower-casing İ with strings.ToLower produces two runes
+		{"lower case changes length", []string{"match"}, []string{strings.Repeat("İ", 40) + " match"}, strPtr("…" + strings.Repeat("İ", 29) + " match")},
+		{"lower case term changes length", []string{"İSTANBUL"}, []string{"Visit İstanbul"}, strPtr("Visit İstanbul")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := searchSnippet(tt.terms, tt.fields)
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Errorf("searchSnippet() = %v, want %v", ptrString(got), ptrString(tt.want))
+			}
+		})
+	}
+}
+
+func TestSearchScore(t *testing.T) {
+	terms := searchTerms("foo bar")
+
+	// scores in descending order
+	scores := []float64{
+		searchScore(terms, "Foo Bar", 1, 2),
+		searchScore(terms, "foo bar baz", 0, 2),
+		searchScore(terms, "bar foo", 0, 2),
+		searchScore(terms, "foo", 0, 2),
+		searchScore(terms, "foo", 1, 2),
+		searchScore(terms, "other", 0, 2),
+	}
+
+	for i := 1; i < len(scores); i++ {
+		if scores[i-1] <= scores[i] {
+			t.Errorf("score %d (%v) should be greater than score %d (%v)", i-1, scores[i-1], i, scores[i])
+		}
+	}
+}
+
+func TestSearchLimit(t *testing.T) {
+	intPtr := func(i int) *int { return &i }
+
+	tests := []struct {
+		name    string
+		limit   *int
+		want    int
+		wantErr bool
+	}{
+		{"default", nil, defaultSearchLimit, false},
+		{"within range", intPtr(5), 5, false},
+		{"maximum", intPtr(maxSearchLimit), maxSearchLimit, false},
+		{"capped", intPtr(maxSearchLimit + 1), maxSearchLimit, false},
+		{"zero", intPtr(0), 0, true},
+		{"negative", intPtr(-1), 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := searchLimit(tt.limit)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("searchLimit() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("searchLimit() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func ptrString(s *string) string {
+	if s == nil {
+		return "<nil>"
+	}
+	return *s
+}
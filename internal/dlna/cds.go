@@ -37,6 +37,7 @@ import (
 	"github.com/anacrolix/dms/dlna"
 	"github.com/anacrolix/dms/upnp"
 	"github.com/anacrolix/dms/upnpav"
+	"github.com/stashapp/stash/pkg/ffmpeg"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/scene"
@@ -88,13 +89,38 @@ func sceneToContainer(scene *models.Scene, parent string, host string) interface
 		}.Encode(),
 	}).String()
 
+	class := "object.item.videoItem"
+	mimeType := "video/mp4"
+	var (
+		size     int
+		bitrate  uint
+		duration int64
+	)
+
+	f := scene.Files.Primary()
+	if f != nil {
+		size = int(f.Size)
+		bitrate = uint(f.BitRate)
+		duration = int64(f.Duration)
+
+		// audio files are served directly, so advertise the mime type of
+		// the file
+		if f.IsAudio() {
+			class = "object.item.audioItem"
+			mimeType = ffmpeg.AudioMimeType(ffmpeg.Container(f.Format))
+			if mimeType == "" {
+				mimeType = "application/octet-stream"
+			}
+		}
+	}
+
 	// Object goes first
 	obj := upnpav.Object{
 		ID:          strconv.Itoa(scene.ID),
 		Restricted:  1,
 		ParentID:    parent,
 		Title:       scene.GetTitle(),
-		Class:       "object.item.videoItem",
+		Class:       class,
 		Icon:        iconURI,
 		AlbumArtURI: iconURI,
 	}
@@ -105,20 +131,6 @@ func sceneToContainer(scene *models.Scene, parent string, host string) interface
 		Res:    make([]upnpav.Resource, 0, 1),
 	}
 
-	mimeType := "video/mp4"
-	var (
-		size     int
-		bitrate  uint
-		duration int64
-	)
-
-	f := scene.Files.Primary()
-	if f != nil {
-		size = int(f.Size)
-		bitrate = uint(f.BitRate)
-		duration = int64(f.Duration)
-	}
-
 	item.Res = append(item.Res, upnpav.Resource{
 		URL: (&url.URL{
 			Scheme: "http",
@@ -31,7 +31,10 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/anacrolix/dms/upnpav"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/stashapp/stash/pkg/models"
 )
 
 func TestEscapeObjectID(t *testing.T) {
@@ -78,3 +81,36 @@ func TestBrowseMetadataTags(t *testing.T) {
 
 	assert.Nil(t, err)
 }
+
+func TestSceneToContainer(t *testing.T) {
+	tests := []struct {
+		name      string
+		file      *models.VideoFile
+		wantClass string
+		wantMime  string
+	}{
+		{"video", &models.VideoFile{BaseFile: &models.BaseFile{}, Format: "mp4", VideoCodec: "h264", AudioCodec: "aac"}, "object.item.videoItem", "video/mp4"},
+		{"mp3", &models.VideoFile{BaseFile: &models.BaseFile{}, Format: "mp3", AudioCodec: "mp3"}, "object.item.audioItem", "audio/mpeg"},
+		{"flac", &models.VideoFile{BaseFile: &models.BaseFile{}, Format: "flac", AudioCodec: "flac"}, "object.item.audioItem", "audio/flac"},
+		{"unknown audio container", &models.VideoFile{BaseFile: &models.BaseFile{}, Format: "wma", AudioCodec: "wmav2"}, "object.item.audioItem", "application/octet-stream"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scene := &models.Scene{
+				ID:    1,
+				Files: models.NewRelatedVideoFiles([]*models.VideoFile{tt.file}),
+			}
+
+			item, ok := sceneToContainer(scene, "parent", "localhost:9999").(upnpav.Item)
+			if !ok {
+				t.Fatalf("sceneToContainer() did not return an item")
+			}
+
+			assert.Equal(t, tt.wantClass, item.Class)
+			if assert.NotEmpty(t, item.Res) {
+				assert.True(t, strings.HasPrefix(item.Res[0].ProtocolInfo, "http-get:*:"+tt.wantMime+":"), "ProtocolInfo = %s", item.Res[0].ProtocolInfo)
+			}
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
@@ -20,6 +21,9 @@ import (
 
 var (
 	ErrSkipSingleNamePerformer = errors.New("a performer was skipped because they only had a single name and no disambiguation")
+
+	// errDryRun is used to roll back the transaction when performing a dry run
+	errDryRun = errors.New("dry run")
 )
 
 type MultipleMatchesFoundError struct {
@@ -55,6 +59,9 @@ type SceneIdentifier struct {
 	DefaultOptions              *MetadataOptions
 	Sources                     []ScraperSource
 	SceneUpdatePostHookExecutor SceneUpdatePostHookExecutor
+
+	// DryRun logs the fields that would be changed without modifying the scene.
+	DryRun bool
 }
 
 func (t *SceneIdentifier) Identify(ctx context.Context, scene *models.Scene) error {
@@ -295,6 +302,14 @@ func (t *SceneIdentifier) modifyScene(ctx context.Context, s *models.Scene, resu
 			return nil
 		}
 
+		if t.DryRun {
+			fields := utils.NotNilFields(updater.UpdateInput(), "json")
+			logger.Infof("Would update %s using %s: %s", s.Path, result.source.Name, strings.Join(fields, ", "))
+
+			// roll back any objects created while resolving relationships
+			return errDryRun
+		}
+
 		if _, err := updater.Update(ctx, t.SceneReaderUpdater); err != nil {
 			return fmt.Errorf("error updating scene: %w", err)
 		}
@@ -308,6 +323,9 @@ func (t *SceneIdentifier) modifyScene(ctx context.Context, s *models.Scene, resu
 
 		return nil
 	}); err != nil {
+		if errors.Is(err, errDryRun) {
+			return nil
+		}
 		return err
 	}
 
@@ -268,6 +268,49 @@ func TestSceneIdentifier_modifyScene(t *testing.T) {
 	}
 }
 
+func TestSceneIdentifier_modifyScene_dryRun(t *testing.T) {
+	db := mocks.NewDatabase()
+
+	boolFalse := false
+	defaultOptions := &MetadataOptions{
+		SetOrganized:             &boolFalse,
+		SetCoverImage:            &boolFalse,
+		IncludeMalePerformers:    &boolFalse,
+		SkipSingleNamePerformers: &boolFalse,
+	}
+	tr := &SceneIdentifier{
+		TxnManager:         db,
+		SceneReaderUpdater: db.Scene,
+		StudioReaderWriter: db.Studio,
+		PerformerCreator:   db.Performer,
+		TagFinderCreator:   db.Tag,
+		DefaultOptions:     defaultOptions,
+		DryRun:             true,
+	}
+
+	title := "title"
+	scene := &models.Scene{
+		URLs:         models.NewRelatedStrings([]string{}),
+		PerformerIDs: models.NewRelatedIDs([]int{}),
+		TagIDs:       models.NewRelatedIDs([]int{}),
+		StashIDs:     models.NewRelatedStashIDs([]models.StashID{}),
+	}
+	result := &scrapeResult{
+		result: &scraper.ScrapedScene{
+			Title: &title,
+		},
+		source: ScraperSource{
+			Options: defaultOptions,
+		},
+	}
+
+	if err := tr.modifyScene(testCtx, scene, result); err != nil {
+		t.Errorf("SceneIdentifier.modifyScene() error = %v", err)
+	}
+
+	db.Scene.AssertNotCalled(t, "UpdatePartial", mock.Anything, mock.Anything, mock.Anything)
+}
+
 func Test_getFieldOptions(t *testing.T) {
 	const (
 		inFirst  = "inFirst"
@@ -345,6 +345,9 @@ func Test_sceneRelationships_tags(t *testing.T) {
 	existingIDStr := strconv.Itoa(existingID)
 	validName := "validName"
 	invalidName := "invalidName"
+	remoteSiteName := "remoteSiteName"
+	remoteSiteID := "remoteSiteID"
+	endpoint := "endpoint"
 
 	defaultOptions := &FieldOptions{
 		Strategy: FieldStrategyMerge,
@@ -377,6 +380,13 @@ func Test_sceneRelationships_tags(t *testing.T) {
 	db.Tag.On("Create", testCtx, mock.MatchedBy(func(p *models.Tag) bool {
 		return p.Name == invalidName
 	})).Return(errors.New("error creating tag"))
+	db.Tag.On("Create", testCtx, mock.MatchedBy(func(p *models.Tag) bool {
+		stashIDs := p.StashIDs.List()
+		return p.Name == remoteSiteName && len(stashIDs) == 1 && stashIDs[0].StashID == remoteSiteID && stashIDs[0].Endpoint == endpoint
+	})).Run(func(args mock.Arguments) {
+		t := args.Get(1).(*models.Tag)
+		t.ID = validStoredIDInt
+	}).Return(nil)
 
 	tr := sceneRelationships{
 		sceneReader:  db.Scene,
@@ -485,6 +495,22 @@ func Test_sceneRelationships_tags(t *testing.T) {
 			[]int{validStoredIDInt},
 			false,
 		},
+		{
+			"create missing with stash id",
+			emptyScene,
+			&FieldOptions{
+				Strategy:      FieldStrategyOverwrite,
+				CreateMissing: &createMissing,
+			},
+			[]*models.ScrapedTag{
+				{
+					Name:         remoteSiteName,
+					RemoteSiteID: &remoteSiteID,
+				},
+			},
+			[]int{validStoredIDInt},
+			false,
+		},
 		{
 			"error creating",
 			emptyScene,
@@ -509,6 +535,9 @@ func Test_sceneRelationships_tags(t *testing.T) {
 				result: &scraper.ScrapedScene{
 					Tags: tt.scraped,
 				},
+				source: ScraperSource{
+					RemoteSite: endpoint,
+				},
 			}
 
 			got, err := tr.tags(testCtx)
@@ -0,0 +1,430 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/sqlite"
+)
+
+const (
+	// autoBackupSuffix is appended to the name of automatic backups. These
+	// are directories containing the database file, and optionally the
+	// blobs and configuration file.
+	autoBackupSuffix = ".auto"
+
+	backupBlobsDir       = "blobs"
+	backupConfigFile     = "config.yml"
+	backupTimeFormat     = "20060102_150405"
+	autoBackupEvery      = 24 * time.Hour
+	autoBackupCheckEvery = time.Hour
+)
+
+// Backup is a backup of the database in the backup directory.
+type Backup struct {
+	// Name is the file or directory name of the backup.
+	Name string
+	Path string
+	// DatabasePath is the path of the backed up database file.
+	DatabasePath  string
+	SchemaVersion uint
+	CreatedAt     time.Time
+	// Size is the size of the backed up database file.
+	Size           int64
+	Automatic      bool
+	IncludesBlobs  bool
+	IncludesConfig bool
+}
+
+// parseBackupName returns the schema version and creation time of a backup
+// of the database named dbName. Backups are named
+// <dbName>.<schema version>.<timestamp>, with automatic backups suffixed with
+// autoBackupSuffix. Returns false if name is not the name of a backup.
+func parseBackupName(dbName string, name string) (schemaVersion uint, createdAt time.Time, automatic bool, ok bool) {
+	rest, found := strings.CutPrefix(name, dbName+".")
+	if !found {
+		return
+	}
+
+	rest, automatic = strings.CutSuffix(rest, autoBackupSuffix)
+
+	versionStr, timestamp, found := strings.Cut(rest, ".")
+	if !found {
+		return
+	}
+
+	version, err := strconv.ParseUint(versionStr, 10, 32)
+	if err != nil {
+		return
+	}
+
+	createdAt, err = time.ParseInLocation(backupTimeFormat, timestamp, time.Local)
+	if err != nil {
+		return
+	}
+
+	return uint(version), createdAt, automatic, true
+}
+
+// ListBackups returns the database backups in the backup directory, newest
+// first.
+func (s *Manager) ListBackups() ([]Backup, error) {
+	dir := s.Config.GetBackupDirectoryPathOrDefault()
+	dbName := filepath.Base(s.Config.GetDatabasePath())
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading backup directory %s: %w", dir, err)
+	}
+
+	var ret []Backup
+	for _, e := range entries {
+		schemaVersion, createdAt, automatic, ok := parseBackupName(dbName, e.Name())
+		if !ok || automatic != e.IsDir() {
+			continue
+		}
+
+		b := Backup{
+			Name:          e.Name(),
+			Path:          filepath.Join(dir, e.Name()),
+			SchemaVersion: schemaVersion,
+			CreatedAt:     createdAt,
+			Automatic:     automatic,
+		}
+
+		b.DatabasePath = b.Path
+		if automatic {
+			b.DatabasePath = filepath.Join(b.Path, dbName)
+			b.IncludesBlobs, _ = fsutil.DirExists(filepath.Join(b.Path, backupBlobsDir))
+			b.IncludesConfig, _ = fsutil.FileExists(filepath.Join(b.Path, backupConfigFile))
+		}
+
+		info, err := os.Stat(b.DatabasePath)
+		if err != nil {
+			logger.Warnf("Skipping backup %s: %v", b.Name, err)
+			continue
+		}
+		b.Size = info.Size()
+
+		ret = append(ret, b)
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].CreatedAt.After(ret[j].CreatedAt)
+	})
+
+	return ret, nil
+}
+
+// AutoBackup adds a job creating an automatic backup, and removing the
+// automatic backups that are no longer kept. Returns the job ID.
+func (s *Manager) AutoBackup(ctx context.Context) int {
+	j := job.MakeJobExec(func(ctx context.Context, progress *job.Progress) error {
+		backupPath, err := s.createAutoBackup()
+		if err != nil {
+			return fmt.Errorf("creating backup: %w", err)
+		}
+
+		logger.Infof("Backed up database to %s", backupPath)
+
+		if err := s.pruneAutoBackups(); err != nil {
+			return fmt.Errorf("removing old backups: %w", err)
+		}
+
+		return nil
+	})
+
+	return s.JobManager.Add(ctx, "Backing up database...", j)
+}
+
+func (s *Manager) createAutoBackup() (string, error) {
+	cfg := s.Config
+
+	dir := cfg.GetBackupDirectoryPathOrDefault()
+	if err := fsutil.EnsureDirAll(dir); err != nil {
+		return "", fmt.Errorf("could not create backup directory %v: %w", dir, err)
+	}
+
+	backupPath := s.Database.DatabaseBackupPath(dir) + autoBackupSuffix
+	if err := fsutil.EnsureDir(backupPath); err != nil {
+		return "", err
+	}
+
+	err := s.writeAutoBackup(backupPath)
+	if err != nil {
+		// don't leave incomplete backups
+		if removeErr := fsutil.RemoveDir(backupPath); removeErr != nil {
+			logger.Warnf("error removing incomplete backup %s: %v", backupPath, removeErr)
+		}
+		return "", err
+	}
+
+	return backupPath, nil
+}
+
+func (s *Manager) writeAutoBackup(backupPath string) error {
+	cfg := s.Config
+
+	dbPath := filepath.Join(backupPath, filepath.Base(cfg.GetDatabasePath()))
+	if err := s.Database.Backup(dbPath); err != nil {
+		return err
+	}
+
+	if cfg.GetAutoBackupIncludeBlobs() && cfg.GetBlobsStorage() == config.BlobStorageTypeFilesystem {
+		if blobsPath := cfg.GetBlobsPath(); blobsPath != "" {
+			// blobs are never modified once written, so are linked where
+			// possible rather than copied
+			if err := fsutil.LinkOrCopyDir(blobsPath, filepath.Join(backupPath, backupBlobsDir)); err != nil {
+				return fmt.Errorf("backing up blobs: %w", err)
+			}
+		}
+	}
+
+	if cfg.GetAutoBackupIncludeConfig() {
+		if err := fsutil.CopyFile(cfg.GetConfigFile(), filepath.Join(backupPath, backupConfigFile)); err != nil {
+			return fmt.Errorf("backing up configuration: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// backupsToRemove returns the automatic backups that are not kept. The newest
+// backup of each of the keepDaily most recent days with backups is kept, as
+// is the newest backup of each of the keepWeekly most recent weeks with
+// backups. The newest automatic backup is always kept. backups must be
+// ordered newest first.
+func backupsToRemove(backups []Backup, keepDaily int, keepWeekly int) []Backup {
+	days := make(map[string]bool)
+	weeks := make(map[string]bool)
+
+	var ret []Backup
+	newest := true
+	for _, b := range backups {
+		if !b.Automatic {
+			continue
+		}
+
+		keep := newest
+		newest = false
+
+		day := b.CreatedAt.Format("2006-01-02")
+		if !days[day] && len(days) < keepDaily {
+			days[day] = true
+			keep = true
+		}
+
+		year, w := b.CreatedAt.ISOWeek()
+		week := fmt.Sprintf("%d-%d", year, w)
+		if !weeks[week] && len(weeks) < keepWeekly {
+			weeks[week] = true
+			keep = true
+		}
+
+		if !keep {
+			ret = append(ret, b)
+		}
+	}
+
+	return ret
+}
+
+func (s *Manager) pruneAutoBackups() error {
+	backups, err := s.ListBackups()
+	if err != nil {
+		return err
+	}
+
+	for _, b := range backupsToRemove(backups, s.Config.GetAutoBackupKeepDaily(), s.Config.GetAutoBackupKeepWeekly()) {
+		logger.Infof("Removing old backup %s", b.Path)
+		if err := fsutil.RemoveDir(b.Path); err != nil {
+			return fmt.Errorf("removing %s: %w", b.Path, err)
+		}
+	}
+
+	return nil
+}
+
+// autoBackupDue returns true if automatic backups are enabled, and the last
+// automatic backup is at least a day old.
+func (s *Manager) autoBackupDue() bool {
+	if !s.Config.GetAutoBackup() || s.Database.Ready() != nil {
+		return false
+	}
+
+	backups, err := s.ListBackups()
+	if err != nil {
+		logger.Warnf("error listing backups: %v", err)
+		return false
+	}
+
+	for _, b := range backups {
+		if b.Automatic {
+			return time.Since(b.CreatedAt) >= autoBackupEvery
+		}
+	}
+
+	return true
+}
+
+// startAutoBackup periodically checks if an automatic backup is due, until
+// the manager is shut down.
+func (s *Manager) startAutoBackup() {
+	s.stopAutoBackup()
+
+	s.autoBackupStop = make(chan struct{})
+	stop := s.autoBackupStop
+
+	go func() {
+		ticker := time.NewTicker(autoBackupCheckEvery)
+		defer ticker.Stop()
+
+		// the ID of the last automatic backup job. Job IDs start from 1.
+		jobID := 0
+
+		for {
+			// the backup of a job that is still queued is not listed yet, so
+			// don't add another
+			if !s.jobPending(jobID) && s.autoBackupDue() {
+				jobID = s.AutoBackup(context.Background())
+			}
+
+			select {
+			case <-ticker.C:
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// jobPending returns true if the job with the provided ID is queued or
+// running.
+func (s *Manager) jobPending(id int) bool {
+	j := s.JobManager.GetJob(id)
+	if j == nil {
+		return false
+	}
+
+	switch j.Status {
+	case job.StatusReady, job.StatusRunning, job.StatusStopping:
+		return true
+	}
+
+	return false
+}
+
+func (s *Manager) stopAutoBackup() {
+	if s.autoBackupStop != nil {
+		close(s.autoBackupStop)
+		s.autoBackupStop = nil
+	}
+}
+
+// RestoreBackup replaces the database with the database of the backup named
+// name. The current database is backed up first. If restoreBlobs is true and
+// the backup includes the blobs, the filesystem blob store is replaced too.
+// Fails if any jobs are queued or running.
+func (s *Manager) RestoreBackup(name string, restoreBlobs bool) error {
+	backups, err := s.ListBackups()
+	if err != nil {
+		return err
+	}
+
+	var backup *Backup
+	for i := range backups {
+		if backups[i].Name == name {
+			backup = &backups[i]
+			break
+		}
+	}
+
+	if backup == nil {
+		return fmt.Errorf("backup %s not found", name)
+	}
+
+	if backup.SchemaVersion > s.Database.AppSchemaVersion() {
+		return fmt.Errorf("backup %s has schema version %d, which is newer than the supported version %d", name, backup.SchemaVersion, s.Database.AppSchemaVersion())
+	}
+
+	if restoreBlobs && backup.IncludesBlobs && s.Config.GetBlobsStorage() != config.BlobStorageTypeFilesystem {
+		return errors.New("blobs can only be restored to filesystem blob storage")
+	}
+
+	if len(s.JobManager.GetQueue()) > 0 {
+		return errors.New("cannot restore a backup while jobs are queued or running")
+	}
+
+	// keep the current database in case the restore was a mistake
+	currentBackup, _, err := s.BackupDatabase(false)
+	if err != nil {
+		return fmt.Errorf("backing up current database: %w", err)
+	}
+	logger.Infof("Backed up current database to %s", currentBackup)
+
+	if err := s.Database.Restore(backup.DatabasePath); err != nil {
+		var migrationNeededErr *sqlite.MigrationNeededError
+		if !errors.As(err, &migrationNeededErr) {
+			return fmt.Errorf("restoring database: %w", err)
+		}
+
+		logger.Warn(err)
+	}
+
+	if restoreBlobs && backup.IncludesBlobs {
+		if err := s.restoreBlobs(filepath.Join(backup.Path, backupBlobsDir)); err != nil {
+			return fmt.Errorf("restoring blobs: %w", err)
+		}
+	}
+
+	logger.Infof("Restored backup %s", name)
+	return nil
+}
+
+// restoreBlobs replaces the filesystem blob store with the blobs at srcpath.
+// The existing blobs are only removed once the restored blobs are in place.
+func (s *Manager) restoreBlobs(srcpath string) error {
+	blobsPath := s.Config.GetBlobsPath()
+	if blobsPath == "" {
+		return errors.New("blobs path is not set")
+	}
+
+	restorePath := blobsPath + ".restore"
+	if err := fsutil.RemoveDir(restorePath); err != nil {
+		return err
+	}
+
+	if err := fsutil.LinkOrCopyDir(srcpath, restorePath); err != nil {
+		return err
+	}
+
+	oldPath := blobsPath + ".old"
+	if err := fsutil.RemoveDir(oldPath); err != nil {
+		return err
+	}
+
+	if err := os.Rename(blobsPath, oldPath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	if err := os.Rename(restorePath, blobsPath); err != nil {
+		return err
+	}
+
+	return fsutil.RemoveDir(oldPath)
+}
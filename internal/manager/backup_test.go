@@ -0,0 +1,135 @@
+package manager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stashapp/stash/pkg/job"
+)
+
+func TestParseBackupName(t *testing.T) {
+	const dbName = "stash-go.sqlite"
+	createdAt := time.Date(2024, 3, 5, 13, 4, 5, 0, time.Local)
+
+	tests := []struct {
+		name          string
+		wantVersion   uint
+		wantAutomatic bool
+		wantOk        bool
+	}{
+		{"stash-go.sqlite.72.20240305_130405", 72, false, true},
+		{"stash-go.sqlite.72.20240305_130405.auto", 72, true, true},
+		{"stash-go.sqlite", 0, false, false},
+		{"stash-go.sqlite.20240305_130405", 0, false, false},
+		{"stash-go.sqlite.x.20240305_130405", 0, false, false},
+		{"stash-go.sqlite.72.2024", 0, false, false},
+		{"other.sqlite.72.20240305_130405", 0, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version, gotCreatedAt, automatic, ok := parseBackupName(dbName, tt.name)
+			assert.Equal(t, tt.wantOk, ok)
+			if !tt.wantOk {
+				return
+			}
+
+			assert.Equal(t, tt.wantVersion, version)
+			assert.Equal(t, tt.wantAutomatic, automatic)
+			assert.True(t, createdAt.Equal(gotCreatedAt))
+		})
+	}
+}
+
+func TestBackupsToRemove(t *testing.T) {
+	// Wednesday, so that "3 days" is in the previous week
+	now := time.Date(2024, 3, 6, 12, 0, 0, 0, time.Local)
+
+	backup := func(name string, age time.Duration, automatic bool) Backup {
+		return Backup{
+			Name:      name,
+			CreatedAt: now.Add(-age),
+			Automatic: automatic,
+		}
+	}
+
+	const day = 24 * time.Hour
+
+	// newest first
+	backups := []Backup{
+		backup("today", 0, true),
+		backup("today earlier", time.Hour, true),
+		backup("manual", 2*time.Hour, false),
+		backup("yesterday", day, true),
+		backup("2 days", 2*day, true),
+		backup("3 days", 3*day, true),
+		backup("last week", 7*day, true),
+		backup("2 weeks", 14*day, true),
+		backup("3 weeks", 21*day, true),
+	}
+
+	names := func(v []Backup) []string {
+		var ret []string
+		for _, b := range v {
+			ret = append(ret, b.Name)
+		}
+		return ret
+	}
+
+	tests := []struct {
+		name       string
+		keepDaily  int
+		keepWeekly int
+		want       []string
+	}{
+		{
+			"daily only",
+			2, 0,
+			[]string{"today earlier", "2 days", "3 days", "last week", "2 weeks", "3 weeks"},
+		},
+		{
+			"weekly only",
+			0, 2,
+			[]string{"today earlier", "yesterday", "2 days", "last week", "2 weeks", "3 weeks"},
+		},
+		{
+			"daily and weekly",
+			3, 3,
+			[]string{"today earlier", "last week", "3 weeks"},
+		},
+		{
+			"none",
+			0, 0,
+			[]string{"today earlier", "yesterday", "2 days", "3 days", "last week", "2 weeks", "3 weeks"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := backupsToRemove(backups, tt.keepDaily, tt.keepWeekly)
+			assert.Equal(t, tt.want, names(got))
+		})
+	}
+}
+
+func TestJobPending(t *testing.T) {
+	s := &Manager{JobManager: job.NewManager()}
+	defer s.JobManager.Stop()
+
+	assert.False(t, s.jobPending(0))
+
+	release := make(chan struct{})
+	id := s.JobManager.Add(context.Background(), "test", job.MakeJobExec(func(ctx context.Context, progress *job.Progress) error {
+		<-release
+		return nil
+	}))
+	assert.True(t, s.jobPending(id))
+
+	close(release)
+	assert.Eventually(t, func() bool {
+		return !s.jobPending(id)
+	}, time.Second, 10*time.Millisecond)
+}
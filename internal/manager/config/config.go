@@ -10,6 +10,7 @@ import (
 	"runtime"
 	"strconv"
 	"strings"
+	"time"
 
 	"sync"
 	// "github.com/sasha-s/go-deadlock" // if you have deadlock issues
@@ -56,14 +57,59 @@ const (
 	ImageExclude = "image_exclude"
 
 	VideoExtensions            = "video_extensions"
+	AudioExtensions            = "audio_extensions"
 	ImageExtensions            = "image_extensions"
 	GalleryExtensions          = "gallery_extensions"
 	CreateGalleriesFromFolders = "create_galleries_from_folders"
 
+	// WatchLibrary is the config key used to determine if the library paths
+	// should be watched for changes.
+	WatchLibrary = "watch_library"
+
+	// ReadNFOFiles is the config key used to determine if NFO files next to
+	// scanned video files should be used to populate unorganised scenes.
+	ReadNFOFiles = "read_nfo_files"
+
+	// UseTrash is the config key used to determine if deleted files should
+	// be moved to the trash directory of their library path.
+	UseTrash = "use_trash"
+
+	// TrashRetentionDays is the number of days that files are kept in the
+	// trash before being purged.
+	TrashRetentionDays        = "trash_retention_days"
+	trashRetentionDaysDefault = 30
+
+	// AutoBackup is the config key used to determine if the database should
+	// be backed up automatically every day.
+	AutoBackup = "auto_backup"
+
+	// AutoBackupKeepDaily and AutoBackupKeepWeekly are the number of daily
+	// and weekly automatic backups that are kept.
+	AutoBackupKeepDaily         = "auto_backup_keep_daily"
+	autoBackupKeepDailyDefault  = 7
+	AutoBackupKeepWeekly        = "auto_backup_keep_weekly"
+	autoBackupKeepWeeklyDefault = 4
+
+	// AutoBackupIncludeBlobs is the config key used to determine if the
+	// filesystem blob store is included in automatic backups.
+	AutoBackupIncludeBlobs = "auto_backup_include_blobs"
+
+	// AutoBackupIncludeConfig is the config key used to determine if the
+	// configuration file is included in automatic backups.
+	AutoBackupIncludeConfig = "auto_backup_include_config"
+
+	// LastIncrementalExport is the time of the last incremental export. It is
+	// set by the export task, and not by the user.
+	LastIncrementalExport = "last_incremental_export"
+
 	// CalculateMD5 is the config key used to determine if MD5 should be calculated
 	// for video files.
 	CalculateMD5 = "calculate_md5"
 
+	// UseFingerprintXattrs is the config key used to determine if file
+	// fingerprints should be cached in the extended attributes of files.
+	UseFingerprintXattrs = "use_fingerprint_xattrs"
+
 	// VideoFileNamingAlgorithm is the config key used to determine what hash
 	// should be used when generating and using generated files for scenes.
 	VideoFileNamingAlgorithm = "video_file_naming_algorithm"
@@ -285,8 +331,9 @@ const (
 // slice default values
 var (
 	defaultVideoExtensions   = []string{"m4v", "mp4", "mov", "wmv", "avi", "mpg", "mpeg", "rmvb", "rm", "flv", "asf", "mkv", "webm"}
+	defaultAudioExtensions   = []string{"mp3", "flac", "m4a", "opus"}
 	defaultImageExtensions   = []string{"png", "jpg", "jpeg", "gif", "webp"}
-	defaultGalleryExtensions = []string{"zip", "cbz"}
+	defaultGalleryExtensions = []string{"zip", "cbz", "7z", "cb7", "rar", "cbr", "tar", "cbt"}
 	defaultMenuItems         = []string{"scenes", "images", "movies", "markers", "galleries", "performers", "studios", "tags"}
 )
 
@@ -709,6 +756,54 @@ func (i *Config) GetBackupDirectoryPathOrDefault() string {
 	return ret
 }
 
+// GetAutoBackup returns true if the database should be backed up
+// automatically every day.
+func (i *Config) GetAutoBackup() bool {
+	return i.getBool(AutoBackup)
+}
+
+// GetAutoBackupKeepDaily returns the number of days for which the newest
+// automatic backup is kept.
+func (i *Config) GetAutoBackupKeepDaily() int {
+	return i.getInt(AutoBackupKeepDaily)
+}
+
+// GetAutoBackupKeepWeekly returns the number of weeks for which the newest
+// automatic backup is kept.
+func (i *Config) GetAutoBackupKeepWeekly() int {
+	return i.getInt(AutoBackupKeepWeekly)
+}
+
+// GetAutoBackupIncludeBlobs returns true if the filesystem blob store should
+// be included in automatic backups.
+func (i *Config) GetAutoBackupIncludeBlobs() bool {
+	return i.getBool(AutoBackupIncludeBlobs)
+}
+
+// GetAutoBackupIncludeConfig returns true if the configuration file should
+// be included in automatic backups.
+func (i *Config) GetAutoBackupIncludeConfig() bool {
+	return i.getBool(AutoBackupIncludeConfig)
+}
+
+// GetLastIncrementalExport returns the time of the last incremental export,
+// or the zero time if there has not been one.
+func (i *Config) GetLastIncrementalExport() time.Time {
+	ret, err := time.Parse(time.RFC3339, i.getString(LastIncrementalExport))
+	if err != nil {
+		return time.Time{}
+	}
+
+	return ret
+}
+
+// SetLastIncrementalExport sets the time of the last incremental export and
+// writes the configuration.
+func (i *Config) SetLastIncrementalExport(t time.Time) error {
+	i.SetString(LastIncrementalExport, t.Format(time.RFC3339))
+	return i.Write()
+}
+
 // GetFFMpegPath returns the path to the FFMpeg executable.
 // If empty, stash will attempt to resolve it from the path.
 func (i *Config) GetFFMpegPath() string {
@@ -752,6 +847,16 @@ func (i *Config) GetVideoExtensions() []string {
 	return ret
 }
 
+// GetAudioExtensions returns the extensions of audio-only files, which are
+// added as scenes.
+func (i *Config) GetAudioExtensions() []string {
+	ret := i.getStringSlice(AudioExtensions)
+	if len(ret) == 0 {
+		ret = defaultAudioExtensions
+	}
+	return ret
+}
+
 func (i *Config) GetImageExtensions() []string {
 	ret := i.getStringSlice(ImageExtensions)
 	if len(ret) == 0 {
@@ -772,6 +877,30 @@ func (i *Config) GetCreateGalleriesFromFolders() bool {
 	return i.getBool(CreateGalleriesFromFolders)
 }
 
+// GetWatchLibrary returns true if the library paths should be watched
+// for changes, and changed paths scanned automatically.
+func (i *Config) GetWatchLibrary() bool {
+	return i.getBool(WatchLibrary)
+}
+
+// GetReadNFOFiles returns true if NFO files next to scanned video files
+// should be used to populate unorganised scenes.
+func (i *Config) GetReadNFOFiles() bool {
+	return i.getBool(ReadNFOFiles)
+}
+
+// GetUseTrash returns true if deleted files should be moved to the trash
+// instead of being deleted permanently.
+func (i *Config) GetUseTrash() bool {
+	return i.getBool(UseTrash)
+}
+
+// GetTrashRetentionDays returns the number of days that files are kept in
+// the trash. Zero or less means that the trash is not purged automatically.
+func (i *Config) GetTrashRetentionDays() int {
+	return i.getInt(TrashRetentionDays)
+}
+
 func (i *Config) GetLanguage() string {
 	ret := i.getString(Language)
 
@@ -789,6 +918,12 @@ func (i *Config) IsCalculateMD5() bool {
 	return i.getBool(CalculateMD5)
 }
 
+// GetUseFingerprintXattrs returns true if fingerprints should be stored in
+// the extended attributes of files, and reused when scanning unchanged files.
+func (i *Config) GetUseFingerprintXattrs() bool {
+	return i.getBool(UseFingerprintXattrs)
+}
+
 // GetVideoFileNamingAlgorithm returns what hash algorithm should be used for
 // naming generated scene video files.
 func (i *Config) GetVideoFileNamingAlgorithm() models.HashAlgorithm {
@@ -1767,6 +1902,9 @@ func (i *Config) setDefaultValues() {
 	i.setDefault(Port, portDefault)
 
 	i.setDefault(ParallelTasks, parallelTasksDefault)
+	i.setDefault(TrashRetentionDays, trashRetentionDaysDefault)
+	i.setDefault(AutoBackupKeepDaily, autoBackupKeepDailyDefault)
+	i.setDefault(AutoBackupKeepWeekly, autoBackupKeepWeeklyDefault)
 	i.setDefault(SequentialScanning, SequentialScanningDefault)
 	i.setDefault(PreviewSegmentDuration, previewSegmentDurationDefault)
 	i.setDefault(PreviewSegments, previewSegmentsDefault)
@@ -11,12 +11,85 @@ type StashConfigInput struct {
 	Path         string `json:"path"`
 	ExcludeVideo bool   `json:"excludeVideo"`
 	ExcludeImage bool   `json:"excludeImage"`
+	// Remote is set if the files are served by a remote server
+	Remote *StashRemoteInput `json:"remote"`
 }
 
 type StashConfig struct {
 	Path         string `json:"path"`
 	ExcludeVideo bool   `json:"excludeVideo"`
 	ExcludeImage bool   `json:"excludeImage"`
+	// Remote is set if the files are served by a remote server
+	Remote *StashRemote `json:"remote"`
+}
+
+// StashRemote is the location of a stash path on a remote server. The stash
+// path is used as the local path of the remote files.
+type StashRemote struct {
+	// URL is the remote directory. Supported schemes are sftp, http and
+	// https (WebDAV).
+	URL            string `json:"url"`
+	Username       string `json:"username"`
+	Password       string `json:"password"`
+	PrivateKeyPath string `json:"privateKeyPath"`
+	HostKey        string `json:"hostKey"`
+}
+
+// PasswordSet returns true if a password is configured. The password itself
+// is not returned to clients.
+func (r StashRemote) PasswordSet() bool {
+	return r.Password != ""
+}
+
+// StashRemoteInput is the remote configuration of a stash path sent by a
+// client.
+type StashRemoteInput struct {
+	URL      string `json:"url"`
+	Username string `json:"username"`
+	// Password is nil to keep the existing password
+	Password       *string `json:"password"`
+	PrivateKeyPath string  `json:"privateKeyPath"`
+	HostKey        string  `json:"hostKey"`
+}
+
+// ToStashConfigs returns the stash configurations of the input. Remote
+// passwords that are not set in the input are kept from the existing
+// configuration of the same path.
+func ToStashConfigs(input []*StashConfigInput, existing StashConfigs) StashConfigs {
+	ret := make(StashConfigs, len(input))
+	for i, s := range input {
+		ret[i] = &StashConfig{
+			Path:         s.Path,
+			ExcludeVideo: s.ExcludeVideo,
+			ExcludeImage: s.ExcludeImage,
+		}
+
+		if s.Remote == nil {
+			continue
+		}
+
+		r := &StashRemote{
+			URL:            s.Remote.URL,
+			Username:       s.Remote.Username,
+			PrivateKeyPath: s.Remote.PrivateKeyPath,
+			HostKey:        s.Remote.HostKey,
+		}
+
+		if s.Remote.Password != nil {
+			r.Password = *s.Remote.Password
+		} else {
+			for _, e := range existing {
+				if e.Path == s.Path && e.Remote != nil {
+					r.Password = e.Remote.Password
+					break
+				}
+			}
+		}
+
+		ret[i].Remote = r
+	}
+
+	return ret
 }
 
 type StashConfigs []*StashConfig
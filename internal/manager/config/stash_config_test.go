@@ -0,0 +1,42 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToStashConfigs(t *testing.T) {
+	existing := StashConfigs{
+		{Path: "/a", Remote: &StashRemote{URL: "sftp://host/a", Password: "secret"}},
+		{Path: "/b"},
+	}
+
+	newPassword := "new"
+	empty := ""
+
+	tests := []struct {
+		name     string
+		input    *StashRemoteInput
+		path     string
+		expected string
+	}{
+		{"keep existing", &StashRemoteInput{URL: "sftp://host/a"}, "/a", "secret"},
+		{"set new", &StashRemoteInput{URL: "sftp://host/a", Password: &newPassword}, "/a", "new"},
+		{"clear", &StashRemoteInput{URL: "sftp://host/a", Password: &empty}, "/a", ""},
+		{"new remote", &StashRemoteInput{URL: "sftp://host/b"}, "/b", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ToStashConfigs([]*StashConfigInput{{Path: tt.path, Remote: tt.input}}, existing)
+			assert.Len(t, got, 1)
+			assert.Equal(t, tt.input.URL, got[0].Remote.URL)
+			assert.Equal(t, tt.expected, got[0].Remote.Password)
+			assert.Equal(t, tt.expected != "", got[0].Remote.PasswordSet())
+		})
+	}
+
+	got := ToStashConfigs([]*StashConfigInput{{Path: "/c", ExcludeVideo: true}}, existing)
+	assert.Equal(t, StashConfigs{{Path: "/c", ExcludeVideo: true}}, got)
+}
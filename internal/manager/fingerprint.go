@@ -115,3 +115,30 @@ func (c *fingerprintCalculator) CalculateFingerprints(f *models.BaseFile, o file
 
 	return ret, nil
 }
+
+func (s *Manager) useFingerprintXattrs(f *models.BaseFile) bool {
+	return s.Config.GetUseFingerprintXattrs() && !s.IsRemotePath(f.Path)
+}
+
+// getCachedPhash returns the phash cached in the extended attributes of the
+// file, or nil if there is none.
+func (s *Manager) getCachedPhash(f *models.BaseFile) interface{} {
+	if !s.useFingerprintXattrs(f) {
+		return nil
+	}
+
+	return file.ReadCachedFingerprints(f).Get(models.FingerprintTypePhash)
+}
+
+// cachePhash stores the phash in the extended attributes of the file.
+func (s *Manager) cachePhash(f *models.BaseFile, phash int64) {
+	if !s.useFingerprintXattrs(f) {
+		return
+	}
+
+	if err := file.WriteCachedFingerprints(f, models.Fingerprints{
+		{Type: models.FingerprintTypePhash, Fingerprint: phash},
+	}); err != nil {
+		logger.Debugf("Unable to cache phash for %s: %v", f.Path, err)
+	}
+}
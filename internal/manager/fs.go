@@ -0,0 +1,108 @@
+package manager
+
+import (
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/file/remote"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func remoteConfig(s *config.StashConfig) remote.Config {
+	r := s.Remote
+	return remote.Config{
+		Path:           s.Path,
+		URL:            r.URL,
+		Username:       r.Username,
+		Password:       r.Password,
+		PrivateKeyPath: r.PrivateKeyPath,
+		HostKey:        r.HostKey,
+	}
+}
+
+// ValidateRemote returns an error if the remote configuration of the stash
+// path is invalid.
+func ValidateRemote(s *config.StashConfig) error {
+	f, err := remote.New(remoteConfig(s))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = f.Stat(s.Path)
+	return err
+}
+
+// FS returns the file system containing the library files. Files in stash
+// paths with a remote configured are served by the remote server.
+func (s *Manager) FS() models.FS {
+	s.remoteFSMutex.Lock()
+	defer s.remoteFSMutex.Unlock()
+
+	ret := &file.MountFS{}
+	current := make(map[remote.Config]remote.FS)
+
+	for _, p := range s.Config.GetStashPaths() {
+		if p.Remote == nil {
+			continue
+		}
+
+		c := remoteConfig(p)
+		f := s.remoteFS[c]
+		if f == nil {
+			var err error
+			f, err = remote.New(c)
+			if err != nil {
+				logger.Errorf("Error configuring remote library %s: %v", p.Path, err)
+				continue
+			}
+		}
+
+		current[c] = f
+		ret.Mounts = append(ret.Mounts, file.Mount{
+			Path: p.Path,
+			FS:   f,
+		})
+	}
+
+	// close file systems of removed stash paths
+	for c, f := range s.remoteFS {
+		if _, found := current[c]; !found {
+			f.Close()
+		}
+	}
+	s.remoteFS = current
+
+	return ret
+}
+
+// IsRemotePath returns true if the path is in a stash path served by a remote
+// server.
+func (s *Manager) IsRemotePath(path string) bool {
+	stash := s.Config.GetStashPaths().GetStashFromDirPath(path)
+	return stash != nil && stash.Remote != nil
+}
+
+// ffmpegInput returns the ffmpeg input for the path of a library file.
+func (s *Manager) ffmpegInput(path string) string {
+	if !s.IsRemotePath(path) {
+		return path
+	}
+
+	ret, err := file.FFMpegInput(s.FS(), path)
+	if err != nil {
+		logger.Warnf("Unable to get ffmpeg input for %s: %v", path, err)
+		return path
+	}
+	return ret
+}
+
+func (s *Manager) closeRemoteFS() {
+	s.remoteFSMutex.Lock()
+	defer s.remoteFSMutex.Unlock()
+
+	for _, f := range s.remoteFS {
+		f.Close()
+	}
+	s.remoteFS = nil
+}
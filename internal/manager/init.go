@@ -18,6 +18,7 @@ import (
 	"github.com/stashapp/stash/pkg/fsutil"
 	"github.com/stashapp/stash/pkg/gallery"
 	"github.com/stashapp/stash/pkg/group"
+	"github.com/stashapp/stash/pkg/history"
 	"github.com/stashapp/stash/pkg/image"
 	"github.com/stashapp/stash/pkg/job"
 	"github.com/stashapp/stash/pkg/logger"
@@ -36,7 +37,8 @@ func Initialize(cfg *config.Config, l *log.Logger) (*Manager, error) {
 	ctx := context.TODO()
 
 	db := sqlite.NewDatabase()
-	repo := db.Repository()
+	// changes made through the repository are recorded in the edit history
+	repo := history.WrapRepository(db.Repository())
 
 	// start with empty paths
 	mgrPaths := &paths.Paths{}
@@ -48,7 +50,7 @@ func Initialize(cfg *config.Config, l *log.Logger) (*Manager, error) {
 
 	sceneService := &scene.Service{
 		File:             db.File,
-		Repository:       db.Scene,
+		Repository:       repo.Scene,
 		MarkerRepository: db.SceneMarker,
 		PluginCache:      pluginCache,
 		Paths:            mgrPaths,
@@ -57,11 +59,11 @@ func Initialize(cfg *config.Config, l *log.Logger) (*Manager, error) {
 
 	imageService := &image.Service{
 		File:       db.File,
-		Repository: db.Image,
+		Repository: repo.Image,
 	}
 
 	galleryService := &gallery.Service{
-		Repository:   db.Gallery,
+		Repository:   repo.Gallery,
 		ImageFinder:  db.Image,
 		ImageService: imageService,
 		File:         db.File,
@@ -69,7 +71,7 @@ func Initialize(cfg *config.Config, l *log.Logger) (*Manager, error) {
 	}
 
 	groupService := &group.Service{
-		Repository: db.Group,
+		Repository: history.WrapGroupServiceRepository(db.Group, db.EditHistory),
 	}
 
 	sceneServer := &SceneServer{
@@ -200,6 +202,10 @@ func (s *Manager) postInit(ctx context.Context) error {
 
 	s.RefreshDLNA()
 
+	s.RefreshLibraryWatcher()
+
+	s.startTrashPurge()
+
 	s.SetBlobStoreOptions()
 
 	s.writeStashIcon()
@@ -247,6 +253,8 @@ func (s *Manager) postInit(ctx context.Context) error {
 	s.RefreshFFMpeg(ctx)
 	s.RefreshStreamManager()
 
+	s.startAutoBackup()
+
 	return nil
 }
 
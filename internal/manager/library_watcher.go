@@ -0,0 +1,203 @@
+package manager
+
+import (
+	"context"
+	"path/filepath"
+	"time"
+
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/file/video"
+	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+// libraryWatcherDebounce is the time to wait after the last filesystem event
+// before scanning. This allows for files that are still being copied.
+const libraryWatcherDebounce = 10 * time.Second
+
+// RefreshLibraryWatcher starts or stops watching the library paths as needed.
+// Call this when the library paths or the watch library setting changes.
+func (s *Manager) RefreshLibraryWatcher() {
+	// watching large libraries requires walking the directory tree,
+	// so don't block the caller
+	go func() {
+		s.libraryWatcherMutex.Lock()
+		defer s.libraryWatcherMutex.Unlock()
+
+		if s.libraryWatcher != nil {
+			if err := s.libraryWatcher.Close(); err != nil {
+				logger.Warnf("error stopping library watcher: %v", err)
+			}
+			s.libraryWatcher = nil
+		}
+
+		if !s.Config.GetWatchLibrary() {
+			return
+		}
+
+		stashPaths := s.Config.GetStashPaths()
+		if len(stashPaths) == 0 {
+			return
+		}
+
+		var paths []string
+		for _, p := range stashPaths {
+			// remote file systems cannot be watched
+			if p.Remote == nil {
+				paths = append(paths, p.Path)
+			}
+		}
+
+		if len(paths) == 0 {
+			return
+		}
+
+		zipExt := s.Config.GetGalleryExtensions()
+
+		w := &file.Watcher{
+			Debounce: libraryWatcherDebounce,
+			Filter: func(path string) bool {
+				return useAsVideo(path) || useAsImage(path) || fsutil.MatchExtension(path, zipExt) || fsutil.MatchExtension(path, video.CaptionExts)
+			},
+			Exclude: libraryWatchExclude(s.Config.GetGeneratedPath(), paths),
+			Handler: s.onLibraryChanged,
+		}
+
+		logger.Infof("Watching %d library paths for changes", len(paths))
+		if err := w.Start(context.Background(), paths); err != nil {
+			logger.Errorf("error starting library watcher: %v", err)
+			return
+		}
+
+		s.libraryWatcher = w
+	}()
+}
+
+// libraryWatchExclude returns a function returning true for the directories
+// that are not watched: the generated directory and the trash directories of
+// the library paths.
+func libraryWatchExclude(generatedPath string, paths []string) func(path string) bool {
+	trash := &file.Trash{Roots: paths}
+
+	return func(path string) bool {
+		return (generatedPath != "" && fsutil.IsPathInDir(generatedPath, path)) || trash.IsTrashPath(path)
+	}
+}
+
+func (s *Manager) stopLibraryWatcher() {
+	s.libraryWatcherMutex.Lock()
+	defer s.libraryWatcherMutex.Unlock()
+
+	if s.libraryWatcher != nil {
+		if err := s.libraryWatcher.Close(); err != nil {
+			logger.Warnf("error stopping library watcher: %v", err)
+		}
+		s.libraryWatcher = nil
+	}
+}
+
+// libraryTasks starts the tasks run when the library changes.
+type libraryTasks interface {
+	Scan(ctx context.Context, input ScanMetadataInput) (int, error)
+	Clean(ctx context.Context, input CleanMetadataInput) int
+}
+
+// onLibraryChanged queues a scan of the changed paths, followed by a clean
+// of the removed paths.
+func (s *Manager) onLibraryChanged(ctx context.Context, changed []string, removed []string) {
+	var scanPaths []string
+	if len(changed) > 0 {
+		var err error
+		scanPaths, err = s.getWatchScanPaths(ctx, changed)
+		if err != nil {
+			logger.Errorf("error resolving paths to scan: %v", err)
+			return
+		}
+	}
+
+	startLibraryChangeTasks(ctx, s, scanPaths, removed, s.Config.GetDefaultScanSettings())
+}
+
+// startLibraryChangeTasks queues a scan of scanPaths using the scan
+// options, followed by a clean of removed. Moved files are detected by the
+// scan using their fingerprints, so the clean does not remove them.
+func startLibraryChangeTasks(ctx context.Context, tasks libraryTasks, scanPaths []string, removed []string, options *config.ScanMetadataOptions) {
+	if len(scanPaths) > 0 {
+		input := ScanMetadataInput{
+			Paths: scanPaths,
+		}
+		if options != nil {
+			input.ScanMetadataOptions = *options
+		}
+
+		logger.Infof("Library changes detected. Scanning %d paths", len(scanPaths))
+		if _, err := tasks.Scan(ctx, input); err != nil {
+			logger.Errorf("error starting scan of changed paths: %v", err)
+		}
+	}
+
+	if len(removed) > 0 {
+		logger.Infof("Library removals detected. Cleaning %d paths", len(removed))
+		tasks.Clean(ctx, CleanMetadataInput{
+			Paths: removed,
+		})
+	}
+}
+
+// getWatchScanPaths returns the paths to scan for the changed paths.
+// The scanner requires the parent folder of a path to exist in the database,
+// so paths in unknown folders are replaced with the outermost unknown folder.
+func (s *Manager) getWatchScanPaths(ctx context.Context, changed []string) ([]string, error) {
+	stashPaths := s.Config.GetStashPaths()
+
+	var ret []string
+	r := s.Repository
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		for _, p := range changed {
+			stash := stashPaths.GetStashFromDirPath(p)
+			if stash == nil {
+				continue
+			}
+
+			for p != stash.Path {
+				parent := filepath.Dir(p)
+				folder, err := r.Folder.FindByPath(ctx, parent)
+				if err != nil {
+					return err
+				}
+
+				if folder != nil {
+					break
+				}
+
+				p = parent
+			}
+
+			ret = appendPathUnique(ret, p)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// appendPathUnique appends p to paths, unless it is contained in one of
+// the paths. Paths contained in p are removed.
+func appendPathUnique(paths []string, p string) []string {
+	var ret []string
+	for _, existing := range paths {
+		if fsutil.IsPathInDir(existing, p) {
+			return paths
+		}
+
+		if !fsutil.IsPathInDir(p, existing) {
+			ret = append(ret, existing)
+		}
+	}
+
+	return append(ret, p)
+}
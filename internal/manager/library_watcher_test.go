@@ -0,0 +1,211 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+)
+
+func TestLibraryWatchExclude(t *testing.T) {
+	library := filepath.FromSlash("/library")
+	generated := filepath.FromSlash("/generated")
+
+	tests := []struct {
+		name          string
+		generatedPath string
+		path          string
+		want          bool
+	}{
+		{"library path", generated, library, false},
+		{"library subdirectory", generated, filepath.Join(library, "sub"), false},
+		{"trash", generated, filepath.Join(library, file.TrashDirName), true},
+		{"trash entry", generated, filepath.Join(library, file.TrashDirName, "entry"), true},
+		{"nested trash name", generated, filepath.Join(library, "sub", file.TrashDirName), false},
+		{"generated", generated, generated, true},
+		{"generated subdirectory", generated, filepath.Join(generated, "screenshots"), true},
+		{"generated in library", filepath.Join(library, "generated"), filepath.Join(library, "generated", "vtt"), true},
+		{"generated not set", "", filepath.Join(library, "generated"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exclude := libraryWatchExclude(tt.generatedPath, []string{library})
+			assert.Equal(t, tt.want, exclude(tt.path))
+		})
+	}
+}
+
+func TestGetWatchScanPaths(t *testing.T) {
+	library := filepath.FromSlash("/library")
+	known := filepath.Join(library, "known")
+	unknown := filepath.Join(library, "new")
+
+	tests := []struct {
+		name    string
+		changed []string
+		want    []string
+	}{
+		{
+			"file in known folder",
+			[]string{filepath.Join(known, "a.mp4")},
+			[]string{filepath.Join(known, "a.mp4")},
+		},
+		{
+			"file in library root",
+			[]string{filepath.Join(library, "a.mp4")},
+			[]string{filepath.Join(library, "a.mp4")},
+		},
+		{
+			"file in unknown folder",
+			[]string{filepath.Join(unknown, "sub", "b.mp4")},
+			[]string{unknown},
+		},
+		{
+			"files in the same unknown folder",
+			[]string{filepath.Join(unknown, "sub", "b.mp4"), filepath.Join(unknown, "c.mp4")},
+			[]string{unknown},
+		},
+		{
+			"library root",
+			[]string{library},
+			[]string{library},
+		},
+		{
+			"outside of library",
+			[]string{filepath.FromSlash("/other/d.mp4")},
+			nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := mocks.NewDatabase()
+			db.Folder.On("FindByPath", mock.Anything, library).Return(&models.Folder{Path: library}, nil)
+			db.Folder.On("FindByPath", mock.Anything, known).Return(&models.Folder{Path: known}, nil)
+			db.Folder.On("FindByPath", mock.Anything, mock.Anything).Return(nil, nil)
+
+			cfg := config.InitializeEmpty()
+			cfg.SetInterface(config.Stash, config.StashConfigs{{Path: library}})
+
+			s := &Manager{
+				Config:     cfg,
+				Repository: db.Repository(),
+			}
+
+			got, err := s.getWatchScanPaths(context.Background(), tt.changed)
+			if err != nil {
+				t.Fatalf("getWatchScanPaths() error = %v", err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGetWatchScanPaths_Error(t *testing.T) {
+	library := filepath.FromSlash("/library")
+
+	db := mocks.NewDatabase()
+	db.Folder.On("FindByPath", mock.Anything, mock.Anything).Return(nil, errors.New("find error"))
+
+	cfg := config.InitializeEmpty()
+	cfg.SetInterface(config.Stash, config.StashConfigs{{Path: library}})
+
+	s := &Manager{
+		Config:     cfg,
+		Repository: db.Repository(),
+	}
+
+	_, err := s.getWatchScanPaths(context.Background(), []string{filepath.Join(library, "a", "b.mp4")})
+	assert.NotNil(t, err)
+}
+
+func TestAppendPathUnique(t *testing.T) {
+	a := filepath.FromSlash("/library/a")
+	ab := filepath.Join(a, "b")
+	c := filepath.FromSlash("/library/c")
+
+	tests := []struct {
+		name  string
+		paths []string
+		p     string
+		want  []string
+	}{
+		{"empty", nil, a, []string{a}},
+		{"unrelated", []string{a}, c, []string{a, c}},
+		{"duplicate", []string{a}, a, []string{a}},
+		{"contained", []string{a}, ab, []string{a}},
+		{"containing", []string{ab, c}, a, []string{c, a}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, appendPathUnique(tt.paths, tt.p))
+		})
+	}
+}
+
+type recordedTasks struct {
+	calls   []string
+	scan    []ScanMetadataInput
+	clean   []CleanMetadataInput
+	scanErr error
+}
+
+func (r *recordedTasks) Scan(ctx context.Context, input ScanMetadataInput) (int, error) {
+	r.calls = append(r.calls, "scan")
+	r.scan = append(r.scan, input)
+	return len(r.calls), r.scanErr
+}
+
+func (r *recordedTasks) Clean(ctx context.Context, input CleanMetadataInput) int {
+	r.calls = append(r.calls, "clean")
+	r.clean = append(r.clean, input)
+	return len(r.calls)
+}
+
+func TestStartLibraryChangeTasks(t *testing.T) {
+	changed := []string{filepath.FromSlash("/library/a.mp4")}
+	removed := []string{filepath.FromSlash("/library/b.mp4")}
+	options := &config.ScanMetadataOptions{ScanGenerateCovers: true}
+
+	tests := []struct {
+		name      string
+		scanPaths []string
+		removed   []string
+		scanErr   error
+		want      []string
+	}{
+		{"changed and removed", changed, removed, nil, []string{"scan", "clean"}},
+		{"changed only", changed, nil, nil, []string{"scan"}},
+		{"removed only", nil, removed, nil, []string{"clean"}},
+		{"nothing", nil, nil, nil, nil},
+		{"scan error", changed, removed, errors.New("scan error"), []string{"scan", "clean"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tasks := &recordedTasks{scanErr: tt.scanErr}
+			startLibraryChangeTasks(context.Background(), tasks, tt.scanPaths, tt.removed, options)
+
+			assert.Equal(t, tt.want, tasks.calls)
+
+			if len(tasks.scan) > 0 {
+				assert.Equal(t, tt.scanPaths, tasks.scan[0].Paths)
+				assert.True(t, tasks.scan[0].ScanGenerateCovers)
+			}
+			if len(tasks.clean) > 0 {
+				assert.Equal(t, tt.removed, tasks.clean[0].Paths)
+				assert.False(t, tasks.clean[0].DryRun)
+			}
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"sync"
 	"time"
 
 	"github.com/remeh/sizedwaitgroup"
@@ -17,6 +18,8 @@ import (
 	"github.com/stashapp/stash/internal/log"
 	"github.com/stashapp/stash/internal/manager/config"
 	"github.com/stashapp/stash/pkg/ffmpeg"
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/file/remote"
 	"github.com/stashapp/stash/pkg/fsutil"
 	"github.com/stashapp/stash/pkg/job"
 	"github.com/stashapp/stash/pkg/logger"
@@ -60,6 +63,15 @@ type Manager struct {
 
 	DLNAService *dlna.Service
 
+	libraryWatcher      *file.Watcher
+	libraryWatcherMutex sync.Mutex
+
+	remoteFS      map[remote.Config]remote.FS
+	remoteFSMutex sync.Mutex
+
+	autoBackupStop chan struct{}
+	trashPurgeStop chan struct{}
+
 	Database   *sqlite.Database
 	Repository models.Repository
 
@@ -144,6 +156,7 @@ func (s *Manager) RefreshStreamManager() {
 	cfg := s.Config
 	cacheDir := cfg.GetCachePath()
 	s.StreamManager = ffmpeg.NewStreamManager(cacheDir, s.FFMpeg, s.FFProbe, cfg, s.ReadLockManager)
+	s.StreamManager.InputPath = s.ffmpegInput
 }
 
 // RefreshDLNA starts/stops the DLNA service as needed.
@@ -283,7 +296,7 @@ func (s *Manager) Setup(ctx context.Context, input SetupInput) error {
 		cfg.SetString(config.Database, input.DatabaseFile)
 	}
 
-	cfg.SetInterface(config.Stash, input.Stashes)
+	cfg.SetInterface(config.Stash, config.ToStashConfigs(input.Stashes, nil))
 
 	if err := cfg.Write(); err != nil {
 		return fmt.Errorf("error writing configuration file: %v", err)
@@ -427,6 +440,11 @@ func (s *Manager) Shutdown() {
 		s.StreamManager = nil
 	}
 
+	s.stopLibraryWatcher()
+	s.stopAutoBackup()
+	s.stopTrashPurge()
+	s.closeRemoteFS()
+
 	err := s.Database.Close()
 	if err != nil {
 		logger.Errorf("Error closing database: %s", err)
@@ -24,7 +24,7 @@ func useAsVideo(pathname string) bool {
 	if instance.Config.IsCreateImageClipsFromVideos() && stash != nil && stash.ExcludeVideo {
 		return false
 	}
-	return isVideo(pathname)
+	return isVideo(pathname) || isAudio(pathname)
 }
 
 func useAsImage(pathname string) bool {
@@ -45,6 +45,11 @@ func isVideo(pathname string) bool {
 	return fsutil.MatchExtension(pathname, vidExt)
 }
 
+func isAudio(pathname string) bool {
+	audioExt := config.GetInstance().GetAudioExtensions()
+	return fsutil.MatchExtension(pathname, audioExt)
+}
+
 func isImage(pathname string) bool {
 	imgExt := config.GetInstance().GetImageExtensions()
 	return fsutil.MatchExtension(pathname, imgExt)
@@ -117,7 +122,7 @@ func (s *Manager) Scan(ctx context.Context, input ScanMetadataInput) (int, error
 			},
 		},
 		FingerprintCalculator: &fingerprintCalculator{s.Config},
-		FS:                    &file.OsFS{},
+		FS:                    s.FS(),
 	}
 
 	scanJob := ScanJob{
@@ -289,7 +294,7 @@ type CleanMetadataInput struct {
 
 func (s *Manager) Clean(ctx context.Context, input CleanMetadataInput) int {
 	cleaner := &file.Cleaner{
-		FS:         &file.OsFS{},
+		FS:         s.FS(),
 		Repository: file.NewRepository(s.Repository),
 		Handlers: []file.CleanHandler{
 			&cleanHandler{},
@@ -374,6 +379,7 @@ type StashBoxBatchTagInput struct {
 	// Refresh items already tagged by StashBox if true. Only tag items with no StashBox tagging if false
 	Refresh bool `json:"refresh"`
 	// If batch adding studios, should their parent studios also be created?
+	// If batch adding tags, should tags for their stash-box categories also be created?
 	CreateParent bool `json:"createParent"`
 	// If set, only tag these ids
 	Ids []string `json:"ids"`
@@ -641,3 +647,124 @@ func (s *Manager) StashBoxBatchStudioTag(ctx context.Context, box *models.StashB
 
 	return s.JobManager.Add(ctx, "Batch stash-box studio tag...", j)
 }
+
+func (s *Manager) StashBoxBatchTagTag(ctx context.Context, box *models.StashBox, input StashBoxBatchTagInput) int {
+	j := job.MakeJobExec(func(ctx context.Context, progress *job.Progress) error {
+		logger.Infof("Initiating stash-box batch tag tag")
+
+		var tasks []StashBoxBatchTagTask
+
+		// The gocritic linter wants to turn this ifElseChain into a switch.
+		// however, such a switch would contain quite large blocks for each section
+		// and would arguably be hard to read.
+		//
+		// This is why we mark this section nolint. In principle, we should look to
+		// rewrite the section at some point, to avoid the linter warning.
+		if len(input.Ids) > 0 { //nolint:gocritic
+			// The user has chosen only to tag the items on the current page
+			if err := s.Repository.WithTxn(ctx, func(ctx context.Context) error {
+				tagQuery := s.Repository.Tag
+
+				for _, tagID := range input.Ids {
+					if id, err := strconv.Atoi(tagID); err == nil {
+						tag, err := tagQuery.Find(ctx, id)
+						if err == nil {
+							if err := tag.LoadStashIDs(ctx, tagQuery); err != nil {
+								return fmt.Errorf("loading tag stash ids: %w", err)
+							}
+
+							// Check if the user wants to refresh existing or new items
+							hasStashID := tag.StashIDs.ForEndpoint(box.Endpoint) != nil
+							if (input.Refresh && hasStashID) || (!input.Refresh && !hasStashID) {
+								tasks = append(tasks, StashBoxBatchTagTask{
+									tag:            tag,
+									refresh:        input.Refresh,
+									createParent:   input.CreateParent,
+									box:            box,
+									excludedFields: input.ExcludeFields,
+									taskType:       Tag,
+								})
+							}
+						} else {
+							return err
+						}
+					}
+				}
+				return nil
+			}); err != nil {
+				logger.Error(err.Error())
+			}
+		} else if len(input.Names) > 0 {
+			// The user is batch adding tags
+			for i := range input.Names {
+				name := input.Names[i]
+				if len(name) > 0 {
+					tasks = append(tasks, StashBoxBatchTagTask{
+						name:           &name,
+						refresh:        false,
+						createParent:   input.CreateParent,
+						box:            box,
+						excludedFields: input.ExcludeFields,
+						taskType:       Tag,
+					})
+				}
+			}
+		} else { //nolint:gocritic
+			// The gocritic linter wants to fold this if-block into the else on the line above.
+			// However, this doesn't really help with readability of the current section. Mark it
+			// as nolint for now. In the future we'd like to rewrite this code by factoring some of
+			// this into separate functions.
+
+			// The user has chosen to tag every item in their database
+			if err := s.Repository.WithTxn(ctx, func(ctx context.Context) error {
+				tagQuery := s.Repository.Tag
+				var tags []*models.Tag
+				var err error
+
+				if input.Refresh {
+					tags, err = tagQuery.FindByStashIDStatus(ctx, true, box.Endpoint)
+				} else {
+					tags, err = tagQuery.FindByStashIDStatus(ctx, false, box.Endpoint)
+				}
+
+				if err != nil {
+					return fmt.Errorf("error querying tags: %v", err)
+				}
+
+				for _, tag := range tags {
+					tasks = append(tasks, StashBoxBatchTagTask{
+						tag:            tag,
+						refresh:        input.Refresh,
+						createParent:   input.CreateParent,
+						box:            box,
+						excludedFields: input.ExcludeFields,
+						taskType:       Tag,
+					})
+				}
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+
+		if len(tasks) == 0 {
+			return nil
+		}
+
+		progress.SetTotal(len(tasks))
+
+		logger.Infof("Starting stash-box batch operation for %d tags", len(tasks))
+
+		for _, task := range tasks {
+			progress.ExecuteTask(task.Description(), func() {
+				task.Start(ctx)
+			})
+
+			progress.Increment()
+		}
+
+		return nil
+	})
+
+	return s.JobManager.Add(ctx, "Batch stash-box tag tag...", j)
+}
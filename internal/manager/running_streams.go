@@ -47,6 +47,16 @@ func (s *SceneServer) StreamSceneDirect(scene *models.Scene, w http.ResponseWrit
 	sceneHash := scene.GetHash(config.GetInstance().GetVideoFileNamingAlgorithm())
 
 	filepath := GetInstance().Paths.Scene.GetStreamPath(scene.Path, sceneHash)
+
+	// files in remote libraries are read from the remote file system
+	if filepath == scene.Path && GetInstance().IsRemotePath(filepath) {
+		if err := scene.Files.Primary().Serve(GetInstance().FS(), w, r); err != nil {
+			logger.Errorf("error serving %s: %v", filepath, err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
 	streamRequestCtx := ffmpeg.NewStreamRequestContext(w, r)
 
 	// #2579 - hijacking and closing the connection here causes video playback to fail in Safari
@@ -54,6 +54,11 @@ var (
 		mimeType:  ffmpeg.MimeDASH,
 		extension: ".mpd",
 	}
+	aacEndpointType = endpointType{
+		label:     "AAC",
+		mimeType:  ffmpeg.MimeAACAudio,
+		extension: ".aac",
+	}
 )
 
 func GetVideoFileContainer(file *models.VideoFile) (ffmpeg.Container, error) {
@@ -155,6 +160,19 @@ func GetSceneStreamPaths(scene *models.Scene, directStreamURL *url.URL, maxStrea
 	// don't care if we can't get the container
 	container, _ := GetVideoFileContainer(pf)
 
+	// audio files are streamed directly or transcoded to AAC
+	if pf.IsAudio() {
+		mimeType := ffmpeg.AudioMimeType(container)
+		if mimeType != "" && ffmpeg.IsValidAudioForContainer(audioCodec, container) {
+			directAudio := directEndpointType
+			directAudio.mimeType = mimeType
+			endpoints = append(endpoints, makeStreamEndpoint(directAudio, ""))
+		}
+
+		endpoints = append(endpoints, makeStreamEndpoint(aacEndpointType, ""))
+		return endpoints, nil
+	}
+
 	if HasTranscode(scene, config.GetInstance().GetVideoFileNamingAlgorithm()) || ffmpeg.IsValidAudioForContainer(audioCodec, container) {
 		endpoints = append(endpoints, makeStreamEndpoint(directEndpointType, ""))
 	}
@@ -219,6 +237,18 @@ func GetSceneStreamPaths(scene *models.Scene, directStreamURL *url.URL, maxStrea
 	return endpoints, nil
 }
 
+// isAudioScene returns true if the primary file of the scene is an audio-only
+// file. Sprites, previews, markers, transcodes and phashes cannot be generated
+// for audio files.
+func isAudioScene(scene *models.Scene) bool {
+	if !scene.Files.PrimaryLoaded() {
+		return false
+	}
+
+	f := scene.Files.Primary()
+	return f != nil && f.IsAudio()
+}
+
 // HasTranscode returns true if a transcoded video exists for the provided
 // scene. It will check using the OSHash of the scene first, then fall back
 // to the checksum.
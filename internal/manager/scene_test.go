@@ -0,0 +1,60 @@
+package manager
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stashapp/stash/pkg/ffmpeg"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func TestGetSceneStreamPaths_Audio(t *testing.T) {
+	const streamURL = "http://localhost/scene/1/stream"
+
+	type endpoint struct {
+		url      string
+		mimeType string
+		label    string
+	}
+
+	aac := endpoint{streamURL + ".aac", ffmpeg.MimeAACAudio, "AAC"}
+
+	tests := []struct {
+		name       string
+		format     string
+		audioCodec string
+		want       []endpoint
+	}{
+		{"mp3", "mp3", "mp3", []endpoint{{streamURL, ffmpeg.MimeMpegAudio, "Direct stream"}, aac}},
+		{"flac", "flac", "flac", []endpoint{{streamURL, ffmpeg.MimeFlacAudio, "Direct stream"}, aac}},
+		{"ogg", "ogg", "vorbis", []endpoint{{streamURL, ffmpeg.MimeOggAudio, "Direct stream"}, aac}},
+		{"unsupported codec", "mp3", "aac", []endpoint{aac}},
+		{"unknown container", "wma", "wmav2", []endpoint{aac}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scene := &models.Scene{
+				ID: 1,
+				Files: models.NewRelatedVideoFiles([]*models.VideoFile{{
+					Format:     tt.format,
+					AudioCodec: tt.audioCodec,
+				}}),
+			}
+
+			u, _ := url.Parse(streamURL)
+			got, err := GetSceneStreamPaths(scene, u, models.StreamingResolutionEnumOriginal)
+			if err != nil {
+				t.Fatalf("GetSceneStreamPaths() error = %v", err)
+			}
+
+			var gotEndpoints []endpoint
+			for _, e := range got {
+				gotEndpoints = append(gotEndpoints, endpoint{e.URL, *e.MimeType, *e.Label})
+			}
+			assert.Equal(t, tt.want, gotEndpoints)
+		})
+	}
+}
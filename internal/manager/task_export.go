@@ -49,6 +49,13 @@ type ExportTask struct {
 
 	includeDependencies bool
 
+	// since is the time objects must have been updated at or after to be
+	// exported. All objects are exported if nil.
+	since *time.Time
+	// incremental is true if the time of the export should be stored as the
+	// checkpoint of the next incremental export.
+	incremental bool
+
 	DownloadHash string
 }
 
@@ -67,6 +74,8 @@ type ExportObjectsInput struct {
 	Movies              *ExportObjectTypeInput `json:"movies"` // deprecated
 	Galleries           *ExportObjectTypeInput `json:"galleries"`
 	IncludeDependencies *bool                  `json:"includeDependencies"`
+	Since               *time.Time             `json:"since"`
+	Incremental         *bool                  `json:"incremental"`
 }
 
 type exportSpec struct {
@@ -104,6 +113,15 @@ func CreateExportTask(a models.HashAlgorithm, input ExportObjectsInput) *ExportT
 		groupSpec = input.Movies
 	}
 
+	incremental := input.Incremental != nil && *input.Incremental
+
+	since := input.Since
+	if since == nil && incremental {
+		// the zero time exports everything if there is no checkpoint
+		lastExport := config.GetInstance().GetLastIncrementalExport()
+		since = &lastExport
+	}
+
 	return &ExportTask{
 		repository:          GetInstance().Repository,
 		fileNamingAlgorithm: a,
@@ -115,9 +133,23 @@ func CreateExportTask(a models.HashAlgorithm, input ExportObjectsInput) *ExportT
 		studios:             newExportSpec(input.Studios),
 		galleries:           newExportSpec(input.Galleries),
 		includeDependencies: includeDeps,
+		since:               since,
+		incremental:         incremental,
 	}
 }
 
+// filterChanged returns the objects that were updated at or after the since
+// time of the export.
+func filterChanged[T any](t *ExportTask, objs []T, updatedAt func(T) time.Time) []T {
+	if t.since == nil {
+		return objs
+	}
+
+	return sliceutil.Filter(objs, func(o T) bool {
+		return !updatedAt(o).Before(*t.since)
+	})
+}
+
 func (t *ExportTask) Start(ctx context.Context, wg *sync.WaitGroup) {
 	defer wg.Done()
 	// @manager.total = Scene.count + Gallery.count + Performer.count + Studio.count + Group.count
@@ -177,6 +209,10 @@ func (t *ExportTask) Start(ctx context.Context, wg *sync.WaitGroup) {
 		t.ExportStudios(ctx, workerCount)
 		t.ExportTags(ctx, workerCount)
 
+		if t.since != nil {
+			t.ExportDeleted(ctx)
+		}
+
 		return nil
 	})
 	if txnErr != nil {
@@ -190,6 +226,14 @@ func (t *ExportTask) Start(ctx context.Context, wg *sync.WaitGroup) {
 			return
 		}
 	}
+
+	if t.incremental && txnErr == nil {
+		// objects changed during the export are exported again next time
+		if err := config.GetInstance().SetLastIncrementalExport(startTime); err != nil {
+			logger.Errorf("error storing time of incremental export: %v", err)
+		}
+	}
+
 	logger.Infof("Export complete in %s.", time.Since(startTime))
 }
 
@@ -233,6 +277,12 @@ func (t *ExportTask) zipFiles(w io.Writer) error {
 	walkWarn(t.json.json.Scenes, t.zipWalkFunc(u.json.Scenes, z))
 	walkWarn(t.json.json.Images, t.zipWalkFunc(u.json.Images, z))
 
+	if exists, _ := fsutil.FileExists(t.json.json.Deleted); exists {
+		if err := t.zipFile(t.json.json.Deleted, u.json.Metadata, z); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -367,6 +417,8 @@ func (t *ExportTask) ExportScenes(ctx context.Context, workers int) {
 		logger.Errorf("[scenes] failed to fetch scenes: %v", err)
 	}
 
+	scenes = filterChanged(t, scenes, func(o *models.Scene) time.Time { return o.UpdatedAt })
+
 	jobCh := make(chan *models.Scene, workers*2) // make a buffered channel to feed workers
 
 	logger.Info("[scenes] exporting")
@@ -616,6 +668,8 @@ func (t *ExportTask) ExportImages(ctx context.Context, workers int) {
 		logger.Errorf("[images] failed to fetch images: %v", err)
 	}
 
+	images = filterChanged(t, images, func(o *models.Image) time.Time { return o.UpdatedAt })
+
 	jobCh := make(chan *models.Image, workers*2) // make a buffered channel to feed workers
 
 	logger.Info("[images] exporting")
@@ -744,6 +798,8 @@ func (t *ExportTask) ExportGalleries(ctx context.Context, workers int) {
 		logger.Errorf("[galleries] failed to fetch galleries: %v", err)
 	}
 
+	galleries = filterChanged(t, galleries, func(o *models.Gallery) time.Time { return o.UpdatedAt })
+
 	jobCh := make(chan *models.Gallery, workers*2) // make a buffered channel to feed workers
 
 	logger.Info("[galleries] exporting")
@@ -889,6 +945,8 @@ func (t *ExportTask) ExportPerformers(ctx context.Context, workers int) {
 	if err != nil {
 		logger.Errorf("[performers] failed to fetch performers: %v", err)
 	}
+
+	performers = filterChanged(t, performers, func(o *models.Performer) time.Time { return o.UpdatedAt })
 	jobCh := make(chan *models.Performer, workers*2) // make a buffered channel to feed workers
 
 	logger.Info("[performers] exporting")
@@ -938,6 +996,29 @@ func (t *ExportTask) exportPerformer(ctx context.Context, wg *sync.WaitGroup, jo
 			t.tags.IDs = sliceutil.AppendUniques(t.tags.IDs, tag.GetIDs(tags))
 		}
 
+		if err := p.LoadRelatedPerformers(ctx, performerReader); err != nil {
+			logger.Errorf("[performers] <%s> error getting related performers: %v", p.Name, err)
+			continue
+		}
+
+		if err := func() error {
+			for _, rp := range p.RelatedPerformers.List() {
+				related, err := performerReader.Find(ctx, rp.PerformerID)
+				if err != nil {
+					return fmt.Errorf("error getting related performer: %v", err)
+				}
+
+				newPerformerJSON.RelatedPerformers = append(newPerformerJSON.RelatedPerformers, jsonschema.PerformerRelationship{
+					Name:           related.Name,
+					Disambiguation: related.Disambiguation,
+					Type:           rp.Type.String(),
+				})
+			}
+			return nil
+		}(); err != nil {
+			logger.Errorf("[performers] <%s> %v", p.Name, err)
+		}
+
 		fn := newPerformerJSON.Filename()
 
 		if err := t.json.savePerformer(fn, newPerformerJSON); err != nil {
@@ -963,6 +1044,8 @@ func (t *ExportTask) ExportStudios(ctx context.Context, workers int) {
 		logger.Errorf("[studios] failed to fetch studios: %v", err)
 	}
 
+	studios = filterChanged(t, studios, func(o *models.Studio) time.Time { return o.UpdatedAt })
+
 	logger.Info("[studios] exporting")
 	startTime := time.Now()
 
@@ -1037,6 +1120,8 @@ func (t *ExportTask) ExportTags(ctx context.Context, workers int) {
 		logger.Errorf("[tags] failed to fetch tags: %v", err)
 	}
 
+	tags = filterChanged(t, tags, func(o *models.Tag) time.Time { return o.UpdatedAt })
+
 	logger.Info("[tags] exporting")
 	startTime := time.Now()
 
@@ -1098,6 +1183,8 @@ func (t *ExportTask) ExportGroups(ctx context.Context, workers int) {
 		logger.Errorf("[groups] failed to fetch groups: %v", err)
 	}
 
+	groups = filterChanged(t, groups, func(o *models.Group) time.Time { return o.UpdatedAt })
+
 	logger.Info("[groups] exporting")
 	startTime := time.Now()
 
@@ -1186,3 +1273,67 @@ func (t *ExportTask) exportGroup(ctx context.Context, wg *sync.WaitGroup, jobCha
 		}
 	}
 }
+
+// ExportDeleted lists the objects deleted since the since time of the export,
+// for the object types that are exported in full.
+func (t *ExportTask) ExportDeleted(ctx context.Context) {
+	tombstones, err := t.repository.Tombstone.FindSince(ctx, *t.since)
+	if err != nil {
+		logger.Errorf("[deleted] failed to fetch deleted objects: %v", err)
+		return
+	}
+
+	all := func(spec *exportSpec) bool {
+		return t.full || (spec != nil && spec.all)
+	}
+
+	deleted := &jsonschema.Deleted{
+		Since: json.JSONTime{Time: *t.since},
+	}
+
+	for _, ts := range tombstones {
+		switch ts.ObjectType {
+		case models.HistoryEntityTypeScene:
+			if all(t.scenes) {
+				deleted.Scenes = append(deleted.Scenes, jsonschema.DeletedFiles{Files: ts.Paths})
+			}
+		case models.HistoryEntityTypeImage:
+			if all(t.images) {
+				deleted.Images = append(deleted.Images, jsonschema.DeletedFiles{Files: ts.Paths})
+			}
+		case models.HistoryEntityTypeGallery:
+			if all(t.galleries) {
+				deleted.Galleries = append(deleted.Galleries, jsonschema.GalleryRef{
+					ZipFiles:   ts.Paths,
+					FolderPath: ts.FolderPath,
+					Title:      ts.Name,
+				})
+			}
+		case models.HistoryEntityTypePerformer:
+			if all(t.performers) {
+				deleted.Performers = append(deleted.Performers, jsonschema.DeletedPerformer{
+					Name:           ts.Name,
+					Disambiguation: ts.Disambiguation,
+				})
+			}
+		case models.HistoryEntityTypeStudio:
+			if all(t.studios) {
+				deleted.Studios = append(deleted.Studios, ts.Name)
+			}
+		case models.HistoryEntityTypeTag:
+			if all(t.tags) {
+				deleted.Tags = append(deleted.Tags, ts.Name)
+			}
+		case models.HistoryEntityTypeGroup:
+			if all(t.groups) {
+				deleted.Groups = append(deleted.Groups, ts.Name)
+			}
+		}
+	}
+
+	if err := t.json.saveDeleted(deleted); err != nil {
+		logger.Errorf("[deleted] failed to save json: %v", err)
+	}
+
+	logger.Infof("[deleted] %d deleted objects since %s", len(tombstones), t.since.Format(time.RFC3339))
+}
@@ -0,0 +1,210 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/nfo"
+	"github.com/stashapp/stash/pkg/scene"
+	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
+)
+
+type ExportNfoInput struct {
+	// IDs of scenes to export. If nil, all scenes are exported.
+	SceneIds []string `json:"scene_ids"`
+	// Overwrite existing NFO and artwork files
+	Overwrite *bool `json:"overwrite"`
+}
+
+// ExportNfo starts a job that writes NFO files and artwork next to the video
+// files of scenes.
+func (s *Manager) ExportNfo(ctx context.Context, input ExportNfoInput) int {
+	j := &exportNfoJob{
+		repository:      s.Repository,
+		videoExtensions: append(append([]string{}, s.Config.GetVideoExtensions()...), s.Config.GetAudioExtensions()...),
+		input:           input,
+	}
+
+	return s.JobManager.Add(ctx, "Exporting NFO files...", j)
+}
+
+type exportNfoJob struct {
+	repository      models.Repository
+	videoExtensions []string
+	input           ExportNfoInput
+}
+
+func (j *exportNfoJob) Execute(ctx context.Context, progress *job.Progress) error {
+	r := j.repository
+	nfoReader := &scene.NFOReader{
+		Studio:    r.Studio,
+		Performer: r.Performer,
+		Tag:       r.Tag,
+		Group:     r.Group,
+	}
+
+	return r.WithReadTxn(ctx, func(ctx context.Context) error {
+		scenes, err := j.findScenes(ctx)
+		if err != nil {
+			return err
+		}
+
+		progress.SetTotal(len(scenes))
+
+		for _, s := range scenes {
+			if job.IsCancelled(ctx) {
+				logger.Info("Stopping due to user request")
+				return nil
+			}
+
+			progress.ExecuteTask(fmt.Sprintf("Exporting NFO for %s", s.DisplayName()), func() {
+				if err := j.exportScene(ctx, nfoReader, s); err != nil {
+					logger.Errorf("Error exporting NFO for scene %s: %v", s.DisplayName(), err)
+				}
+			})
+
+			progress.Increment()
+		}
+
+		return nil
+	})
+}
+
+func (j *exportNfoJob) findScenes(ctx context.Context) ([]*models.Scene, error) {
+	qb := j.repository.Scene
+	if j.input.SceneIds == nil {
+		return qb.All(ctx)
+	}
+
+	ids, err := stringslice.StringSliceToIntSlice(j.input.SceneIds)
+	if err != nil {
+		return nil, fmt.Errorf("converting scene ids: %w", err)
+	}
+
+	return qb.FindMany(ctx, ids)
+}
+
+func (j *exportNfoJob) exportScene(ctx context.Context, nfoReader *scene.NFOReader, s *models.Scene) error {
+	r := j.repository
+	if err := s.LoadFiles(ctx, r.Scene); err != nil {
+		return fmt.Errorf("loading files: %w", err)
+	}
+
+	if err := s.LoadGroups(ctx, r.Scene); err != nil {
+		return fmt.Errorf("loading groups: %w", err)
+	}
+
+	var poster, fanart []byte
+
+	// the scene cover is used as fanart, and the front image of the first
+	// group is used as the poster if present
+	cover, err := r.Scene.GetCover(ctx, s.ID)
+	if err != nil {
+		return fmt.Errorf("getting cover: %w", err)
+	}
+
+	fanart = cover
+	poster = cover
+
+	if groups := s.Groups.List(); len(groups) > 0 {
+		frontImage, err := r.Group.GetFrontImage(ctx, groups[0].GroupID)
+		if err != nil {
+			return fmt.Errorf("getting group front image: %w", err)
+		}
+
+		if len(frontImage) > 0 {
+			poster = frontImage
+		}
+	}
+
+	overwrite := j.input.Overwrite != nil && *j.input.Overwrite
+
+	for _, f := range s.Files.List() {
+		if f.ZipFileID != nil {
+			continue
+		}
+
+		movie, err := nfoReader.ToNFO(ctx, s, f)
+		if err != nil {
+			return err
+		}
+
+		nfoPath := nfo.SidecarPath(f.Path)
+		if overwrite || !fileExists(nfoPath) {
+			if err := nfo.WriteMovieFile(nfoPath, movie); err != nil {
+				return err
+			}
+		}
+
+		j.writeArtwork(f.Path, "poster", poster, overwrite)
+		j.writeArtwork(f.Path, "fanart", fanart, overwrite)
+	}
+
+	return nil
+}
+
+// writeArtwork writes the image next to the video file. The image is named
+// after the artwork type (poster.jpg) if the video is the only video in its
+// folder, otherwise it is prefixed with the video name (video-poster.jpg).
+// Only JPEG and PNG images are written.
+func (j *exportNfoJob) writeArtwork(videoPath string, artworkType string, data []byte, overwrite bool) {
+	if len(data) == 0 {
+		return
+	}
+
+	var ext string
+	switch contentType := http.DetectContentType(data); contentType {
+	case "image/jpeg":
+		ext = ".jpg"
+	case "image/png":
+		ext = ".png"
+	default:
+		logger.Warnf("Not writing %s for %s: unsupported image type %s", artworkType, videoPath, contentType)
+		return
+	}
+
+	dir := filepath.Dir(videoPath)
+	name := artworkType + ext
+	if !j.isOnlyVideo(videoPath) {
+		videoName := strings.TrimSuffix(filepath.Base(videoPath), filepath.Ext(videoPath))
+		name = videoName + "-" + name
+	}
+
+	fn := filepath.Join(dir, name)
+	if !overwrite && fileExists(fn) {
+		return
+	}
+
+	if err := os.WriteFile(fn, data, 0644); err != nil {
+		logger.Errorf("Error writing %s: %v", fn, err)
+	}
+}
+
+func (j *exportNfoJob) isOnlyVideo(videoPath string) bool {
+	entries, err := os.ReadDir(filepath.Dir(videoPath))
+	if err != nil {
+		return false
+	}
+
+	base := filepath.Base(videoPath)
+	for _, e := range entries {
+		if !e.IsDir() && e.Name() != base && fsutil.MatchExtension(e.Name(), j.videoExtensions) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func fileExists(path string) bool {
+	exists, _ := fsutil.FileExists(path)
+	return exists
+}
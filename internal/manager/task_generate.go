@@ -284,7 +284,7 @@ func (j *GenerateJob) queueImagesTasks(ctx context.Context, g *generate.Generato
 
 	r := j.repository
 
-	for more := j.input.ClipPreviews || j.input.ImageThumbnails; more; {
+	for more := j.input.ClipPreviews || j.input.ImageThumbnails || j.input.Phashes; more; {
 		if job.IsCancelled(ctx) {
 			return
 		}
@@ -521,4 +521,26 @@ func (j *GenerateJob) queueImageJob(g *generate.Generator, image *models.Image,
 			queue <- task
 		}
 	}
+
+	if j.input.Phashes {
+		// generate for all image files, excluding clips
+		for _, f := range image.Files.List() {
+			imageFile, ok := f.(*models.ImageFile)
+			if !ok {
+				continue
+			}
+
+			task := &GenerateImagePhashTask{
+				repository: j.repository,
+				File:       imageFile,
+				Overwrite:  j.overwrite,
+			}
+
+			if task.required() {
+				j.totals.phashes++
+				j.totals.tasks++
+				queue <- task
+			}
+		}
+	}
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/stashapp/stash/pkg/file"
 	"github.com/stashapp/stash/pkg/fsutil"
 	"github.com/stashapp/stash/pkg/image"
 	"github.com/stashapp/stash/pkg/logger"
@@ -33,8 +34,14 @@ func (t *GenerateClipPreviewTask) Start(ctx context.Context) {
 		Preset:     GetInstance().Config.GetPreviewPreset().String(),
 	}
 
+	input, err := file.FFMpegInput(GetInstance().FS(), filePath)
+	if err != nil {
+		logger.Errorf("getting preview for image %s: %v", filePath, err)
+		return
+	}
+
 	encoder := image.NewThumbnailEncoder(GetInstance().FFMpeg, GetInstance().FFProbe, clipPreviewOptions)
-	err := encoder.GetPreview(filePath, prevPath, models.DefaultGthumbWidth)
+	err = encoder.GetPreview(input, prevPath, models.DefaultGthumbWidth)
 	if err != nil {
 		logger.Errorf("getting preview for image %s: %w", filePath, err)
 		return
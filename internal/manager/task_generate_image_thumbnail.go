@@ -41,6 +41,7 @@ func (t *GenerateImageThumbnailTask) Start(ctx context.Context) {
 	}
 
 	encoder := image.NewThumbnailEncoder(mgr.FFMpeg, mgr.FFProbe, clipPreviewOptions)
+	encoder.FS = mgr.FS()
 	data, err := encoder.GetThumbnail(f, models.DefaultGthumbWidth)
 
 	if err != nil {
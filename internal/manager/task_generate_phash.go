@@ -38,6 +38,10 @@ func (t *GeneratePhashTask) Start(ctx context.Context) {
 			logger.Infof("Using existing phash for %s", t.File.Path)
 			hash = existing.(int64)
 			set = true
+		} else if cached := instance.getCachedPhash(t.File.BaseFile); cached != nil {
+			logger.Infof("Using cached phash for %s", t.File.Path)
+			hash = cached.(int64)
+			set = true
 		}
 	}
 
@@ -50,6 +54,7 @@ func (t *GeneratePhashTask) Start(ctx context.Context) {
 		}
 
 		hash = int64(*generated)
+		instance.cachePhash(t.File.BaseFile, hash)
 	}
 
 	r := t.repository
@@ -96,6 +101,10 @@ func (t *GeneratePhashTask) findExistingPhash(ctx context.Context) (interface{},
 }
 
 func (t *GeneratePhashTask) required() bool {
+	if t.File.IsAudio() {
+		return false
+	}
+
 	if t.Overwrite {
 		return true
 	}
@@ -34,7 +34,7 @@ func (t *GeneratePreviewTask) Start(ctx context.Context) {
 
 	if t.videoPreviewRequired() {
 		ffprobe := instance.FFProbe
-		videoFile, err := ffprobe.NewVideoFile(t.Scene.Path)
+		videoFile, err := ffprobe.NewVideoFile(instance.ffmpegInput(t.Scene.Path))
 		if err != nil {
 			logger.Errorf("error reading video file: %v", err)
 			return
@@ -56,7 +56,7 @@ func (t *GeneratePreviewTask) Start(ctx context.Context) {
 }
 
 func (t *GeneratePreviewTask) generateVideo(videoChecksum string, videoDuration float64, videoFrameRate float64) error {
-	videoFilename := t.Scene.Path
+	videoFilename := instance.ffmpegInput(t.Scene.Path)
 	useVsync2 := false
 
 	if videoFrameRate <= 0.01 {
@@ -75,7 +75,7 @@ func (t *GeneratePreviewTask) generateVideo(videoChecksum string, videoDuration
 }
 
 func (t *GeneratePreviewTask) generateWebp(videoChecksum string) error {
-	videoFilename := t.Scene.Path
+	videoFilename := instance.ffmpegInput(t.Scene.Path)
 	return t.generator.PreviewWebp(context.TODO(), videoFilename, videoChecksum)
 }
 
@@ -84,7 +84,7 @@ func (t *GeneratePreviewTask) required() bool {
 }
 
 func (t *GeneratePreviewTask) videoPreviewRequired() bool {
-	if t.Scene.Path == "" {
+	if t.Scene.Path == "" || isAudioScene(&t.Scene) {
 		return false
 	}
 
@@ -110,7 +110,7 @@ func (t *GeneratePreviewTask) imagePreviewRequired() bool {
 		return false
 	}
 
-	if t.Scene.Path == "" {
+	if t.Scene.Path == "" || isAudioScene(&t.Scene) {
 		return false
 	}
 
@@ -43,13 +43,6 @@ func (t *GenerateCoverTask) Start(ctx context.Context) {
 		return
 	}
 
-	var at float64
-	if t.ScreenshotAt == nil {
-		at = float64(videoFile.Duration) * 0.2
-	} else {
-		at = *t.ScreenshotAt
-	}
-
 	// we'll generate the screenshot, grab the generated data and set it
 	// in the database.
 
@@ -63,9 +56,25 @@ func (t *GenerateCoverTask) Start(ctx context.Context) {
 		Overwrite:    true,
 	}
 
-	coverImageData, err := g.Screenshot(context.TODO(), videoFile.Path, videoFile.Width, videoFile.Duration, generate.ScreenshotOptions{
-		At: &at,
-	})
+	input := instance.ffmpegInput(videoFile.Path)
+
+	var coverImageData []byte
+	var err error
+	if videoFile.IsAudio() {
+		// audio files have no frames - use an image of the waveform instead
+		coverImageData, err = g.Waveform(context.TODO(), input)
+	} else {
+		var at float64
+		if t.ScreenshotAt == nil {
+			at = float64(videoFile.Duration) * 0.2
+		} else {
+			at = *t.ScreenshotAt
+		}
+
+		coverImageData, err = g.Screenshot(context.TODO(), input, videoFile.Width, videoFile.Duration, generate.ScreenshotOptions{
+			At: &at,
+		})
+	}
 	if err != nil {
 		logger.Errorf("Error generating screenshot: %v", err)
 		logErrorOutput(err)
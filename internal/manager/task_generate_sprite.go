@@ -25,7 +25,7 @@ func (t *GenerateSpriteTask) Start(ctx context.Context) {
 	}
 
 	ffprobe := instance.FFProbe
-	videoFile, err := ffprobe.NewVideoFile(t.Scene.Path)
+	videoFile, err := ffprobe.NewVideoFile(instance.ffmpegInput(t.Scene.Path))
 	if err != nil {
 		logger.Errorf("error reading video file: %s", err.Error())
 		return
@@ -51,7 +51,7 @@ func (t *GenerateSpriteTask) Start(ctx context.Context) {
 
 // required returns true if the sprite needs to be generated
 func (t GenerateSpriteTask) required() bool {
-	if t.Scene.Path == "" {
+	if t.Scene.Path == "" || isAudioScene(&t.Scene) {
 		return false
 	}
 
@@ -0,0 +1,361 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/ffmpeg"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
+)
+
+// healthCheckQuickDuration is the number of seconds decoded from the start
+// and end of videos in quick mode.
+const healthCheckQuickDuration = 10
+
+type HealthCheckMode string
+
+const (
+	// HealthCheckModeQuick probes the file and decodes the start and end of
+	// videos. Detects most truncated and unreadable files.
+	HealthCheckModeQuick HealthCheckMode = "QUICK"
+	// HealthCheckModeFull decodes the entire file.
+	HealthCheckModeFull HealthCheckMode = "FULL"
+)
+
+var AllHealthCheckMode = []HealthCheckMode{
+	HealthCheckModeQuick,
+	HealthCheckModeFull,
+}
+
+func (e HealthCheckMode) IsValid() bool {
+	switch e {
+	case HealthCheckModeQuick, HealthCheckModeFull:
+		return true
+	}
+	return false
+}
+
+func (e HealthCheckMode) String() string {
+	return string(e)
+}
+
+func (e *HealthCheckMode) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = HealthCheckMode(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid HealthCheckMode", str)
+	}
+	return nil
+}
+
+func (e HealthCheckMode) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type HealthCheckInput struct {
+	// IDs of scenes to check. If both ID lists are nil, then all scenes and
+	// images are checked.
+	SceneIds []string `json:"scene_ids"`
+	ImageIds []string `json:"image_ids"`
+	// Defaults to QUICK
+	Mode *HealthCheckMode `json:"mode"`
+	// If set, this tag is added to scenes and images with corrupt files
+	TagID *string `json:"tag_id"`
+}
+
+func (i HealthCheckInput) all() bool {
+	return i.SceneIds == nil && i.ImageIds == nil
+}
+
+// HealthCheck starts a job that decodes the files of scenes and images and
+// records the result on each file.
+func (s *Manager) HealthCheck(ctx context.Context, input HealthCheckInput) (int, error) {
+	if err := s.validateFFmpeg(); err != nil {
+		return 0, err
+	}
+
+	j := &healthCheckJob{
+		repository:    s.Repository,
+		ffmpeg:        s.FFMpeg,
+		ffprobe:       s.FFProbe,
+		inputPath:     s.ffmpegInput,
+		parallelTasks: config.GetInstance().GetParallelTasksWithAutoDetection(),
+		input:         input,
+		mode:          HealthCheckModeQuick,
+	}
+
+	if input.Mode != nil {
+		j.mode = *input.Mode
+	}
+
+	if input.TagID != nil {
+		tagID, err := strconv.Atoi(*input.TagID)
+		if err != nil {
+			return 0, fmt.Errorf("converting tag id: %w", err)
+		}
+		j.tagID = &tagID
+	}
+
+	return s.JobManager.Add(ctx, "Checking file health...", j), nil
+}
+
+type healthCheckJob struct {
+	repository    models.Repository
+	ffmpeg        *ffmpeg.FFMpeg
+	ffprobe       *ffmpeg.FFProbe
+	inputPath     func(path string) string
+	parallelTasks int
+	input         HealthCheckInput
+	mode          HealthCheckMode
+	tagID         *int
+}
+
+// healthCheckTarget is a file to be checked, along with the scenes and
+// images that it belongs to.
+type healthCheckTarget struct {
+	file     models.File
+	sceneIDs []int
+	imageIDs []int
+}
+
+func (j *healthCheckJob) Execute(ctx context.Context, progress *job.Progress) error {
+	var targets []*healthCheckTarget
+	if err := j.repository.WithReadTxn(ctx, func(ctx context.Context) error {
+		var err error
+		targets, err = j.findTargets(ctx)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	progress.SetTotal(len(targets))
+
+	taskQueue := job.NewTaskQueue(ctx, progress, len(targets), j.parallelTasks)
+
+	for _, t := range targets {
+		if job.IsCancelled(ctx) {
+			break
+		}
+
+		t := t
+		taskQueue.Add(fmt.Sprintf("Checking %s", t.file.Base().Path), func(ctx context.Context) {
+			defer progress.Increment()
+
+			if err := j.checkFile(ctx, t); err != nil {
+				logger.Errorf("Error checking %s: %v", t.file.Base().Path, err)
+			}
+		})
+	}
+
+	taskQueue.Close()
+
+	if job.IsCancelled(ctx) {
+		logger.Info("Stopping due to user request")
+		return nil
+	}
+
+	logger.Info("Finished checking file health")
+	return nil
+}
+
+func (j *healthCheckJob) findTargets(ctx context.Context) ([]*healthCheckTarget, error) {
+	var ret []*healthCheckTarget
+	byID := make(map[models.FileID]*healthCheckTarget)
+
+	getTarget := func(f models.File) *healthCheckTarget {
+		// ffmpeg cannot read files within zip files
+		if f.Base().ZipFileID != nil {
+			return nil
+		}
+
+		id := f.Base().ID
+		if t, ok := byID[id]; ok {
+			return t
+		}
+
+		t := &healthCheckTarget{file: f}
+		byID[id] = t
+		ret = append(ret, t)
+		return t
+	}
+
+	scenes, err := j.findScenes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, s := range scenes {
+		if err := s.LoadFiles(ctx, j.repository.Scene); err != nil {
+			return nil, fmt.Errorf("loading files for scene %d: %w", s.ID, err)
+		}
+
+		for _, f := range s.Files.List() {
+			if t := getTarget(f); t != nil {
+				t.sceneIDs = append(t.sceneIDs, s.ID)
+			}
+		}
+	}
+
+	images, err := j.findImages(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, i := range images {
+		if err := i.LoadFiles(ctx, j.repository.Image); err != nil {
+			return nil, fmt.Errorf("loading files for image %d: %w", i.ID, err)
+		}
+
+		for _, f := range i.Files.List() {
+			if t := getTarget(f); t != nil {
+				t.imageIDs = append(t.imageIDs, i.ID)
+			}
+		}
+	}
+
+	return ret, nil
+}
+
+func (j *healthCheckJob) findScenes(ctx context.Context) ([]*models.Scene, error) {
+	qb := j.repository.Scene
+	if j.input.all() {
+		return qb.All(ctx)
+	}
+
+	if j.input.SceneIds == nil {
+		return nil, nil
+	}
+
+	ids, err := stringslice.StringSliceToIntSlice(j.input.SceneIds)
+	if err != nil {
+		return nil, fmt.Errorf("converting scene ids: %w", err)
+	}
+
+	return qb.FindMany(ctx, ids)
+}
+
+func (j *healthCheckJob) findImages(ctx context.Context) ([]*models.Image, error) {
+	qb := j.repository.Image
+	if j.input.all() {
+		return qb.All(ctx)
+	}
+
+	if j.input.ImageIds == nil {
+		return nil, nil
+	}
+
+	ids, err := stringslice.StringSliceToIntSlice(j.input.ImageIds)
+	if err != nil {
+		return nil, fmt.Errorf("converting image ids: %w", err)
+	}
+
+	return qb.FindMany(ctx, ids)
+}
+
+func (j *healthCheckJob) checkFile(ctx context.Context, t *healthCheckTarget) error {
+	path := t.file.Base().Path
+
+	var (
+		decodeErrors string
+		err          error
+	)
+
+	if vf, ok := t.file.(*models.VideoFile); ok {
+		decodeErrors, err = j.checkVideo(ctx, vf)
+	} else {
+		decodeErrors, err = j.ffmpeg.CheckDecode(ctx, j.inputPath(path), ffmpeg.DecodeCheckOptions{})
+	}
+
+	if err != nil {
+		return err
+	}
+
+	health := &models.FileHealth{
+		Status:    models.FileHealthStatusOk,
+		Error:     decodeErrors,
+		CheckedAt: time.Now(),
+	}
+
+	if decodeErrors != "" {
+		health.Status = models.FileHealthStatusCorrupt
+		logger.Warnf("Errors decoding %s: %s", path, decodeErrors)
+	}
+
+	return j.repository.WithTxn(ctx, func(ctx context.Context) error {
+		if err := j.repository.File.UpdateHealth(ctx, t.file.Base().ID, health); err != nil {
+			return fmt.Errorf("updating file health: %w", err)
+		}
+
+		if health.Status == models.FileHealthStatusCorrupt && j.tagID != nil {
+			return j.tagCorrupt(ctx, t)
+		}
+
+		return nil
+	})
+}
+
+func (j *healthCheckJob) checkVideo(ctx context.Context, f *models.VideoFile) (string, error) {
+	path := j.inputPath(f.Path)
+
+	if j.mode == HealthCheckModeFull {
+		return j.ffmpeg.CheckDecode(ctx, path, ffmpeg.DecodeCheckOptions{})
+	}
+
+	probed, err := j.ffprobe.NewVideoFile(path)
+	if err != nil {
+		return err.Error(), nil
+	}
+
+	ret, err := j.ffmpeg.CheckDecode(ctx, path, ffmpeg.DecodeCheckOptions{
+		Duration: healthCheckQuickDuration,
+	})
+	if err != nil || ret != "" {
+		return ret, err
+	}
+
+	// decode the end of the video to detect truncated files
+	if probed.FileDuration > healthCheckQuickDuration {
+		return j.ffmpeg.CheckDecode(ctx, path, ffmpeg.DecodeCheckOptions{
+			Seek:     probed.FileDuration - healthCheckQuickDuration,
+			Duration: healthCheckQuickDuration,
+		})
+	}
+
+	return "", nil
+}
+
+func (j *healthCheckJob) tagCorrupt(ctx context.Context, t *healthCheckTarget) error {
+	tagIDs := &models.UpdateIDs{
+		IDs:  []int{*j.tagID},
+		Mode: models.RelationshipUpdateModeAdd,
+	}
+
+	for _, id := range t.sceneIDs {
+		partial := models.NewScenePartial()
+		partial.TagIDs = tagIDs
+		if _, err := j.repository.Scene.UpdatePartial(ctx, id, partial); err != nil {
+			return fmt.Errorf("tagging scene %d: %w", id, err)
+		}
+	}
+
+	for _, id := range t.imageIDs {
+		partial := models.NewImagePartial()
+		partial.TagIDs = tagIDs
+		if _, err := j.repository.Image.UpdatePartial(ctx, id, partial); err != nil {
+			return fmt.Errorf("tagging image %d: %w", id, err)
+		}
+	}
+
+	return nil
+}
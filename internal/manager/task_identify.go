@@ -7,12 +7,14 @@ import (
 	"strings"
 
 	"github.com/stashapp/stash/internal/identify"
+	"github.com/stashapp/stash/pkg/history"
 	"github.com/stashapp/stash/pkg/job"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/scene"
 	"github.com/stashapp/stash/pkg/scraper"
 	"github.com/stashapp/stash/pkg/scraper/stashbox"
+	"github.com/stashapp/stash/pkg/sliceutil"
 	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
 )
 
@@ -37,6 +39,12 @@ func CreateIdentifyJob(input identify.Options) *IdentifyJob {
 func (j *IdentifyJob) Execute(ctx context.Context, progress *job.Progress) error {
 	j.progress = progress
 
+	// changes are recorded in the edit history as made by identify
+	source := history.RequestSource(ctx)
+	source.Origin = models.HistoryOriginIdentify
+	source.PluginID = ""
+	ctx = history.WithSource(ctx, source)
+
 	// if no sources provided - just return
 	if len(j.input.Sources) == 0 {
 		return nil
@@ -187,8 +195,10 @@ func (j *IdentifyJob) getSources() ([]identify.ScraperSource, error) {
 			src = identify.ScraperSource{
 				Name: s.Name,
 				Scraper: scraperSource{
-					cache:     instance.ScraperCache,
-					scraperID: scraperID,
+					cache:       instance.ScraperCache,
+					scraperID:   scraperID,
+					fingerprint: s.Scene != nil && sliceutil.Contains(s.Scene.SupportedScrapes, scraper.ScrapeTypeFingerprint),
+					fragment:    s.Scene != nil && sliceutil.Contains(s.Scene.SupportedScrapes, scraper.ScrapeTypeFragment),
 				},
 			}
 		}
@@ -269,9 +279,26 @@ func (s stashboxSource) String() string {
 type scraperSource struct {
 	cache     *scraper.Cache
 	scraperID string
+
+	// fingerprint is true if the scraper supports scraping by fingerprint
+	fingerprint bool
+	// fragment is true if the scraper supports scraping by scene fragment
+	fragment bool
 }
 
 func (s scraperSource) ScrapeScenes(ctx context.Context, sceneID int) ([]*scraper.ScrapedScene, error) {
+	// prefer fingerprint matches, falling back to the scene fragment
+	if s.fingerprint {
+		results, err := s.cache.ScrapeFingerprint(ctx, s.scraperID, sceneID)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(results) > 0 || !s.fragment {
+			return results, nil
+		}
+	}
+
 	content, err := s.cache.ScrapeID(ctx, s.scraperID, sceneID, scraper.ScrapeContentTypeScene)
 	if err != nil {
 		return nil, err
@@ -34,6 +34,10 @@ type ImportTask struct {
 	resetter   Resetter
 	json       jsonUtils
 
+	sceneService   SceneService
+	imageService   ImageService
+	galleryService GalleryService
+
 	BaseDir             string
 	TmpZip              string
 	Reset               bool
@@ -75,6 +79,9 @@ func CreateImportTask(a models.HashAlgorithm, input ImportObjectsInput) (*Import
 	return &ImportTask{
 		repository:          mgr.Repository,
 		resetter:            mgr.Database,
+		sceneService:        mgr.SceneService,
+		imageService:        mgr.ImageService,
+		galleryService:      mgr.GalleryService,
 		BaseDir:             baseDir,
 		TmpZip:              tmpZip,
 		Reset:               false,
@@ -124,6 +131,10 @@ func (t *ImportTask) Start(ctx context.Context) {
 		}
 	}
 
+	// apply deletions first, so that objects deleted and recreated since the
+	// previous export are imported again
+	t.ImportDeleted(ctx)
+
 	t.ImportTags(ctx)
 	t.ImportPerformers(ctx)
 	t.ImportStudios(ctx)
@@ -203,6 +214,9 @@ func (t *ImportTask) ImportPerformers(ctx context.Context) {
 
 	r := t.repository
 
+	// relationships are imported once all performers exist
+	var related []*performer.RelationshipImporter
+
 	for i, fi := range files {
 		index := i + 1
 		performerJSON, err := jsonschema.LoadPerformerFile(filepath.Join(path, fi.Name()))
@@ -213,16 +227,35 @@ func (t *ImportTask) ImportPerformers(ctx context.Context) {
 
 		logger.Progressf("[performers] %d of %d", index, len(files))
 
-		if err := r.WithTxn(ctx, func(ctx context.Context) error {
-			importer := &performer.Importer{
-				ReaderWriter: r.Performer,
-				TagWriter:    r.Tag,
-				Input:        *performerJSON,
-			}
+		importer := &performer.Importer{
+			ReaderWriter: r.Performer,
+			TagWriter:    r.Tag,
+			Input:        *performerJSON,
+		}
 
+		if err := r.WithTxn(ctx, func(ctx context.Context) error {
 			return performImport(ctx, importer, t.DuplicateBehaviour)
 		}); err != nil {
 			logger.Errorf("[performers] <%s> import failed: %v", fi.Name(), err)
+			continue
+		}
+
+		// ID is not set if the performer was skipped
+		if importer.ID != 0 && len(performerJSON.RelatedPerformers) > 0 {
+			related = append(related, &performer.RelationshipImporter{
+				ReaderWriter:        r.Performer,
+				ID:                  importer.ID,
+				Input:               *performerJSON,
+				MissingRefBehaviour: t.MissingRefBehaviour,
+			})
+		}
+	}
+
+	for _, importer := range related {
+		if err := r.WithTxn(ctx, func(ctx context.Context) error {
+			return importer.Import(ctx)
+		}); err != nil {
+			logger.Errorf("[performers] <%s> failed to import related performers: %v", importer.Input.Name, err)
 		}
 	}
 
@@ -779,3 +812,245 @@ func (t *ImportTask) ImportImages(ctx context.Context) {
 
 	logger.Info("[images] import complete")
 }
+
+// ImportDeleted destroys the objects listed in the deleted objects file of an
+// incremental export. Generated files of destroyed objects are deleted, but
+// their files are left untouched.
+func (t *ImportTask) ImportDeleted(ctx context.Context) {
+	deleted, err := jsonschema.LoadDeletedFile(t.json.json.Deleted)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			logger.Errorf("[deleted] failed to read deleted objects: %v", err)
+		}
+
+		return
+	}
+
+	logger.Info("[deleted] removing deleted objects")
+
+	for _, s := range deleted.Scenes {
+		if err := t.destroyDeleted(ctx, func(ctx context.Context, deleter *file.Deleter) error {
+			return t.destroyDeletedScene(ctx, s.Files, deleter)
+		}); err != nil {
+			logger.Errorf("[deleted] <%v> failed to remove scene: %v", s.Files, err)
+		}
+	}
+
+	for _, i := range deleted.Images {
+		if err := t.destroyDeleted(ctx, func(ctx context.Context, deleter *file.Deleter) error {
+			return t.destroyDeletedImage(ctx, i.Files, deleter)
+		}); err != nil {
+			logger.Errorf("[deleted] <%v> failed to remove image: %v", i.Files, err)
+		}
+	}
+
+	for _, g := range deleted.Galleries {
+		if err := t.destroyDeleted(ctx, func(ctx context.Context, deleter *file.Deleter) error {
+			return t.destroyDeletedGallery(ctx, g, deleter)
+		}); err != nil {
+			logger.Errorf("[deleted] <%s> failed to remove gallery: %v", g.String(), err)
+		}
+	}
+
+	r := t.repository
+
+	for _, p := range deleted.Performers {
+		if err := r.WithTxn(ctx, func(ctx context.Context) error {
+			importer := &performer.Importer{
+				ReaderWriter: r.Performer,
+				Input: jsonschema.Performer{
+					Name:           p.Name,
+					Disambiguation: p.Disambiguation,
+				},
+			}
+
+			id, err := importer.FindExistingID(ctx)
+			if err != nil || id == nil {
+				return err
+			}
+
+			return r.Performer.Destroy(ctx, *id)
+		}); err != nil {
+			logger.Errorf("[deleted] <%s> failed to remove performer: %v", p.Name, err)
+		}
+	}
+
+	for _, name := range deleted.Studios {
+		if err := r.WithTxn(ctx, func(ctx context.Context) error {
+			s, err := r.Studio.FindByName(ctx, name, false)
+			if err != nil || s == nil {
+				return err
+			}
+
+			return r.Studio.Destroy(ctx, s.ID)
+		}); err != nil {
+			logger.Errorf("[deleted] <%s> failed to remove studio: %v", name, err)
+		}
+	}
+
+	for _, name := range deleted.Tags {
+		if err := r.WithTxn(ctx, func(ctx context.Context) error {
+			tag, err := r.Tag.FindByName(ctx, name, false)
+			if err != nil || tag == nil {
+				return err
+			}
+
+			return r.Tag.Destroy(ctx, tag.ID)
+		}); err != nil {
+			logger.Errorf("[deleted] <%s> failed to remove tag: %v", name, err)
+		}
+	}
+
+	for _, name := range deleted.Groups {
+		if err := r.WithTxn(ctx, func(ctx context.Context) error {
+			g, err := r.Group.FindByName(ctx, name, false)
+			if err != nil || g == nil {
+				return err
+			}
+
+			return r.Group.Destroy(ctx, g.ID)
+		}); err != nil {
+			logger.Errorf("[deleted] <%s> failed to remove group: %v", name, err)
+		}
+	}
+
+	logger.Info("[deleted] removal complete")
+}
+
+// destroyDeleted runs fn in a transaction, deleting the files marked for
+// deletion by fn once the transaction is committed.
+func (t *ImportTask) destroyDeleted(ctx context.Context, fn func(ctx context.Context, deleter *file.Deleter) error) error {
+	deleter := file.NewDeleter()
+
+	if err := t.repository.WithTxn(ctx, func(ctx context.Context) error {
+		return fn(ctx, deleter)
+	}); err != nil {
+		deleter.Rollback()
+		return err
+	}
+
+	deleter.Commit()
+	return nil
+}
+
+// findFileIDs returns the IDs of the files with the provided paths, ignoring
+// paths that are not in the database.
+func (t *ImportTask) findFileIDs(ctx context.Context, paths []string) ([]models.FileID, error) {
+	var ret []models.FileID
+	for _, p := range paths {
+		f, err := t.repository.File.FindByPath(ctx, p)
+		if err != nil {
+			return nil, err
+		}
+
+		if f != nil {
+			ret = append(ret, f.Base().ID)
+		}
+	}
+
+	return ret, nil
+}
+
+func (t *ImportTask) destroyDeletedScene(ctx context.Context, paths []string, deleter *file.Deleter) error {
+	fileIDs, err := t.findFileIDs(ctx, paths)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range fileIDs {
+		scenes, err := t.repository.Scene.FindByFileID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if len(scenes) > 0 {
+			fileDeleter := &scene.FileDeleter{
+				Deleter:        deleter,
+				FileNamingAlgo: t.fileNamingAlgorithm,
+				Paths:          instance.Paths,
+			}
+
+			return t.sceneService.Destroy(ctx, scenes[0], fileDeleter, true, false)
+		}
+	}
+
+	return nil
+}
+
+func (t *ImportTask) destroyDeletedImage(ctx context.Context, paths []string, deleter *file.Deleter) error {
+	fileIDs, err := t.findFileIDs(ctx, paths)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range fileIDs {
+		images, err := t.repository.Image.FindByFileID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if len(images) > 0 {
+			fileDeleter := &image.FileDeleter{
+				Deleter: deleter,
+				Paths:   instance.Paths,
+			}
+
+			return t.imageService.Destroy(ctx, images[0], fileDeleter, true, false)
+		}
+	}
+
+	return nil
+}
+
+func (t *ImportTask) destroyDeletedGallery(ctx context.Context, ref jsonschema.GalleryRef, deleter *file.Deleter) error {
+	r := t.repository
+
+	var galleries []*models.Gallery
+
+	switch {
+	case len(ref.ZipFiles) > 0:
+		fileIDs, err := t.findFileIDs(ctx, ref.ZipFiles)
+		if err != nil {
+			return err
+		}
+
+		for _, id := range fileIDs {
+			galleries, err = r.Gallery.FindByFileID(ctx, id)
+			if err != nil {
+				return err
+			}
+
+			if len(galleries) > 0 {
+				break
+			}
+		}
+	case ref.FolderPath != "":
+		folder, err := r.Folder.FindByPath(ctx, ref.FolderPath)
+		if err != nil || folder == nil {
+			return err
+		}
+
+		galleries, err = r.Gallery.FindByFolderID(ctx, folder.ID)
+		if err != nil {
+			return err
+		}
+	default:
+		var err error
+		galleries, err = r.Gallery.FindUserGalleryByTitle(ctx, ref.Title)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(galleries) == 0 {
+		return nil
+	}
+
+	fileDeleter := &image.FileDeleter{
+		Deleter: deleter,
+		Paths:   instance.Paths,
+	}
+
+	_, err := t.galleryService.Destroy(ctx, galleries[0], fileDeleter, true, false)
+	return err
+}
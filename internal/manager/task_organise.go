@@ -0,0 +1,344 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/organise"
+	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
+)
+
+type OrganiseMetadataInput struct {
+	// Path template, relative to the library path containing each file
+	Template string `json:"template"`
+	// IDs of scenes to organise. If all ID lists are nil, then all scenes,
+	// images and galleries are organised.
+	SceneIds   []string `json:"scene_ids"`
+	ImageIds   []string `json:"image_ids"`
+	GalleryIds []string `json:"gallery_ids"`
+}
+
+func (i OrganiseMetadataInput) all() bool {
+	return i.SceneIds == nil && i.ImageIds == nil && i.GalleryIds == nil
+}
+
+// OrganiseMove is a planned move of a file.
+type OrganiseMove struct {
+	FileID  models.FileID `json:"file_id"`
+	OldPath string        `json:"old_path"`
+	NewPath string        `json:"new_path"`
+	// Collision is true if a file already exists at the new path, or if
+	// another file is planned to be moved to the new path. Colliding moves
+	// are not performed.
+	Collision bool `json:"collision"`
+}
+
+// OrganisePreview returns the moves that would be performed by Organise.
+func (s *Manager) OrganisePreview(ctx context.Context, input OrganiseMetadataInput) ([]*OrganiseMove, error) {
+	o, err := s.newOrganiser(input)
+	if err != nil {
+		return nil, err
+	}
+
+	return o.plan(ctx)
+}
+
+// Organise starts a job that moves the files of scenes, images and galleries
+// to the paths rendered from the template.
+func (s *Manager) Organise(ctx context.Context, input OrganiseMetadataInput) (int, error) {
+	o, err := s.newOrganiser(input)
+	if err != nil {
+		return 0, err
+	}
+
+	return s.JobManager.Add(ctx, "Organising files...", o), nil
+}
+
+func (s *Manager) newOrganiser(input OrganiseMetadataInput) (*organiser, error) {
+	tmpl, err := organise.ParseTemplate(input.Template)
+	if err != nil {
+		return nil, err
+	}
+
+	return &organiser{
+		repository: s.Repository,
+		stashPaths: s.Config.GetStashPaths(),
+		template:   tmpl,
+		input:      input,
+	}, nil
+}
+
+type organiser struct {
+	repository models.Repository
+	stashPaths config.StashConfigs
+	template   *organise.Template
+	input      OrganiseMetadataInput
+}
+
+func (o *organiser) Execute(ctx context.Context, progress *job.Progress) error {
+	moves, err := o.plan(ctx)
+	if err != nil {
+		return err
+	}
+
+	if job.IsCancelled(ctx) {
+		logger.Info("Stopping due to user request")
+		return nil
+	}
+
+	progress.SetTotal(len(moves))
+
+	moved := 0
+	for _, m := range moves {
+		if job.IsCancelled(ctx) {
+			logger.Info("Stopping due to user request")
+			return nil
+		}
+
+		progress.ExecuteTask(fmt.Sprintf("Moving %s", m.OldPath), func() {
+			if m.Collision {
+				logger.Warnf("Not moving %s: %s already exists", m.OldPath, m.NewPath)
+				return
+			}
+
+			if err := o.move(ctx, m); err != nil {
+				logger.Errorf("Error moving %s to %s: %v", m.OldPath, m.NewPath, err)
+				return
+			}
+
+			moved++
+		})
+
+		progress.Increment()
+	}
+
+	logger.Infof("Organised %d of %d files", moved, len(moves))
+	return nil
+}
+
+func (o *organiser) move(ctx context.Context, m *OrganiseMove) error {
+	r := o.repository
+	return r.WithTxn(ctx, func(ctx context.Context) error {
+		files, err := r.File.Find(ctx, m.FileID)
+		if err != nil {
+			return err
+		}
+
+		if len(files) == 0 || files[0].Base().Path != m.OldPath {
+			return errors.New("file has changed since the move was planned")
+		}
+
+		mover := file.NewMover(r.File, r.Folder)
+		mover.RegisterHooks(ctx)
+
+		dir, basename := filepath.Split(m.NewPath)
+		dir = filepath.Clean(dir)
+
+		folder, err := file.GetOrCreateFolderHierarchy(ctx, r.Folder, dir)
+		if err != nil {
+			return fmt.Errorf("getting or creating folder hierarchy: %w", err)
+		}
+
+		if err := mover.CreateFolderHierarchy(dir); err != nil {
+			return fmt.Errorf("creating folder hierarchy %s in filesystem: %w", dir, err)
+		}
+
+		return mover.Move(ctx, files[0], folder, basename)
+	})
+}
+
+// plan returns the moves for the input objects. Files that are already at
+// their rendered path, are in zip files, or are outside of the library paths
+// are omitted.
+func (o *organiser) plan(ctx context.Context) ([]*OrganiseMove, error) {
+	var ret []*OrganiseMove
+	seen := make(map[models.FileID]bool)
+
+	add := func(f models.File, fields organise.Fields) {
+		base := f.Base()
+		if seen[base.ID] || base.ZipFileID != nil {
+			return
+		}
+		seen[base.ID] = true
+
+		stash := o.stashPaths.GetStashFromPath(base.Path)
+		if stash == nil {
+			logger.Debugf("Not organising %s: not in a library path", base.Path)
+			return
+		}
+
+		newPath := filepath.Join(stash.Path, o.template.Render(fields))
+		if newPath == base.Path {
+			return
+		}
+
+		ret = append(ret, &OrganiseMove{
+			FileID:  base.ID,
+			OldPath: base.Path,
+			NewPath: newPath,
+		})
+	}
+
+	r := o.repository
+	fr := &organise.FieldReader{
+		Studio:    r.Studio,
+		Performer: r.Performer,
+	}
+
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		scenes, err := o.findScenes(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, s := range scenes {
+			if err := s.LoadPrimaryFile(ctx, r.File); err != nil {
+				return fmt.Errorf("loading scene files: %w", err)
+			}
+
+			f := s.Files.Primary()
+			if f == nil {
+				continue
+			}
+
+			fields, err := fr.SceneFields(ctx, s, f)
+			if err != nil {
+				return fmt.Errorf("getting fields for scene %s: %w", s.DisplayName(), err)
+			}
+
+			add(f, fields)
+		}
+
+		images, err := o.findImages(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, i := range images {
+			if err := i.LoadPrimaryFile(ctx, r.File); err != nil {
+				return fmt.Errorf("loading image files: %w", err)
+			}
+
+			f := i.Files.Primary()
+			if f == nil {
+				continue
+			}
+
+			fields, err := fr.ImageFields(ctx, i, f)
+			if err != nil {
+				return fmt.Errorf("getting fields for image %s: %w", i.DisplayName(), err)
+			}
+
+			add(f, fields)
+		}
+
+		galleries, err := o.findGalleries(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, g := range galleries {
+			// folder-based galleries have no files
+			if err := g.LoadPrimaryFile(ctx, r.File); err != nil {
+				return fmt.Errorf("loading gallery files: %w", err)
+			}
+
+			f := g.Files.Primary()
+			if f == nil {
+				continue
+			}
+
+			fields, err := fr.GalleryFields(ctx, g, f)
+			if err != nil {
+				return fmt.Errorf("getting fields for gallery %s: %w", g.DisplayName(), err)
+			}
+
+			add(f, fields)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	markCollisions(ret)
+
+	return ret, nil
+}
+
+func markCollisions(moves []*OrganiseMove) {
+	targets := make(map[string]bool)
+	for _, m := range moves {
+		if targets[m.NewPath] {
+			m.Collision = true
+			continue
+		}
+		targets[m.NewPath] = true
+
+		info, err := os.Stat(m.NewPath)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+
+		// allow changing the case of the filename on case-insensitive
+		// filesystems
+		if err == nil {
+			if oldInfo, err := os.Stat(m.OldPath); err == nil && os.SameFile(info, oldInfo) {
+				continue
+			}
+		}
+
+		m.Collision = true
+	}
+}
+
+func (o *organiser) findScenes(ctx context.Context) ([]*models.Scene, error) {
+	qb := o.repository.Scene
+	if o.input.all() {
+		return qb.All(ctx)
+	}
+
+	ids, err := stringslice.StringSliceToIntSlice(o.input.SceneIds)
+	if err != nil {
+		return nil, fmt.Errorf("converting scene ids: %w", err)
+	}
+
+	return qb.FindMany(ctx, ids)
+}
+
+func (o *organiser) findImages(ctx context.Context) ([]*models.Image, error) {
+	qb := o.repository.Image
+	if o.input.all() {
+		return qb.All(ctx)
+	}
+
+	ids, err := stringslice.StringSliceToIntSlice(o.input.ImageIds)
+	if err != nil {
+		return nil, fmt.Errorf("converting image ids: %w", err)
+	}
+
+	return qb.FindMany(ctx, ids)
+}
+
+func (o *organiser) findGalleries(ctx context.Context) ([]*models.Gallery, error) {
+	qb := o.repository.Gallery
+	if o.input.all() {
+		return qb.All(ctx)
+	}
+
+	ids, err := stringslice.StringSliceToIntSlice(o.input.GalleryIds)
+	if err != nil {
+		return nil, fmt.Errorf("converting gallery ids: %w", err)
+	}
+
+	return qb.FindMany(ctx, ids)
+}
@@ -70,6 +70,7 @@ func (j *ScanJob) Execute(ctx context.Context, progress *job.Progress) error {
 		ParallelTasks:          cfg.GetParallelTasksWithAutoDetection(),
 		HandlerRequiredFilters: []file.Filter{newHandlerRequiredFilter(cfg, repo)},
 		Rescan:                 j.input.Rescan,
+		UseFingerprintXattrs:   cfg.GetUseFingerprintXattrs(),
 	}, progress)
 
 	taskQueue.Close()
@@ -287,6 +288,11 @@ func (f *scanFilter) Accept(ctx context.Context, path string, info fs.FileInfo)
 		return false
 	}
 
+	if fsutil.IsPathInDir(filepath.Join(s.Path, file.TrashDirName), path) {
+		logger.Debugf("Skipping %s as it is in the trash", path)
+		return false
+	}
+
 	isVideoFile := useAsVideo(path)
 	isImageFile := useAsImage(path)
 	isZipFile := fsutil.MatchExtension(path, f.zipExt)
@@ -359,6 +365,16 @@ func getScanHandlers(options ScanMetadataInput, taskQueue *job.TaskQueue, progre
 	r := mgr.Repository
 	pluginCache := mgr.PluginCache
 
+	var sceneDecorator scene.ScanDecorator
+	if c.GetReadNFOFiles() {
+		sceneDecorator = &scene.NFODecorator{
+			ReaderWriter:    r.Scene,
+			StudioWriter:    r.Studio,
+			PerformerWriter: r.Performer,
+			TagWriter:       r.Tag,
+		}
+	}
+
 	return []file.Handler{
 		&file.FilteredHandler{
 			Filter: file.FilterFunc(imageFileFilter),
@@ -396,6 +412,7 @@ func getScanHandlers(options ScanMetadataInput, taskQueue *job.TaskQueue, progre
 				CreatorUpdater: r.Scene,
 				CaptionUpdater: r.File,
 				PluginCache:    pluginCache,
+				ScanDecorator:  sceneDecorator,
 				ScanGenerator: &sceneGenerators{
 					input:               options,
 					taskQueue:           taskQueue,
@@ -442,6 +459,27 @@ func (g *imageGenerators) Generate(ctx context.Context, i *models.Image, f model
 		taskThumbnail.Start(ctx)
 	}
 
+	imageFile, isImage := f.(*models.ImageFile)
+	if isImage && t.ScanGeneratePhashes {
+		progress.AddTotal(1)
+		phashFn := func(ctx context.Context) {
+			taskPhash := GenerateImagePhashTask{
+				repository: GetInstance().Repository,
+				File:       imageFile,
+				Overwrite:  overwrite,
+			}
+
+			taskPhash.Start(ctx)
+			progress.Increment()
+		}
+
+		if g.sequentialScanning {
+			phashFn(ctx)
+		} else {
+			g.taskQueue.Add(fmt.Sprintf("Generating phash for %s", path), phashFn)
+		}
+	}
+
 	// avoid adding a task if the file isn't a video file
 	_, isVideo := f.(*models.VideoFile)
 	if isVideo && t.ScanGenerateClipPreviews {
@@ -485,7 +523,10 @@ func (g *sceneGenerators) Generate(ctx context.Context, s *models.Scene, f *mode
 
 	mgr := GetInstance()
 
-	if t.ScanGenerateSprites {
+	// only covers can be generated for audio files
+	audio := f.IsAudio()
+
+	if t.ScanGenerateSprites && !audio {
 		progress.AddTotal(1)
 		spriteFn := func(ctx context.Context) {
 			taskSprite := GenerateSpriteTask{
@@ -504,7 +545,7 @@ func (g *sceneGenerators) Generate(ctx context.Context, s *models.Scene, f *mode
 		}
 	}
 
-	if t.ScanGeneratePhashes {
+	if t.ScanGeneratePhashes && !audio {
 		progress.AddTotal(1)
 		phashFn := func(ctx context.Context) {
 			taskPhash := GeneratePhashTask{
@@ -524,7 +565,7 @@ func (g *sceneGenerators) Generate(ctx context.Context, s *models.Scene, f *mode
 		}
 	}
 
-	if t.ScanGeneratePreviews {
+	if t.ScanGeneratePreviews && !audio {
 		progress.AddTotal(1)
 		previewsFn := func(ctx context.Context) {
 			options := getGeneratePreviewOptions(GeneratePreviewOptionsInput{})
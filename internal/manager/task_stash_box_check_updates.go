@@ -0,0 +1,572 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stashapp/stash/internal/identify"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/performer"
+	"github.com/stashapp/stash/pkg/scene"
+	"github.com/stashapp/stash/pkg/scraper"
+	"github.com/stashapp/stash/pkg/scraper/stashbox"
+	"github.com/stashapp/stash/pkg/sliceutil"
+	"github.com/stashapp/stash/pkg/studio"
+)
+
+type StashBoxCheckUpdatesInput struct {
+	StashBoxEndpoint string `json:"stash_box_endpoint"`
+	// Check scenes linked to the endpoint
+	Scenes bool `json:"scenes"`
+	// Check performers linked to the endpoint
+	Performers bool `json:"performers"`
+	// Check studios linked to the endpoint
+	Studios bool `json:"studios"`
+	// Only report changes without applying them
+	DryRun bool `json:"dry_run"`
+	// Strategies for each field. Fields not listed default to MERGE.
+	FieldOptions []*identify.FieldOptions `json:"field_options"`
+}
+
+// StashBoxCheckUpdatesJob queries a stash-box endpoint for every scene,
+// performer and studio linked to it, and reports or applies any upstream
+// changes according to the configured field strategies.
+type StashBoxCheckUpdatesJob struct {
+	box              *models.StashBox
+	input            StashBoxCheckUpdatesInput
+	postHookExecutor identify.SceneUpdatePostHookExecutor
+
+	client     *stashbox.Client
+	strategies map[string]identify.FieldStrategy
+}
+
+func (s *Manager) StashBoxCheckUpdates(ctx context.Context, box *models.StashBox, input StashBoxCheckUpdatesInput) int {
+	j := &StashBoxCheckUpdatesJob{
+		box:              box,
+		input:            input,
+		postHookExecutor: s.PluginCache,
+	}
+
+	return s.JobManager.Add(ctx, "Checking stash-box for updates...", j)
+}
+
+func (j *StashBoxCheckUpdatesJob) Execute(ctx context.Context, progress *job.Progress) error {
+	r := instance.Repository
+	j.client = stashbox.NewClient(*j.box, stashbox.NewRepository(r))
+
+	j.strategies = make(map[string]identify.FieldStrategy)
+	for _, f := range j.input.FieldOptions {
+		if f.Strategy.IsValid() {
+			j.strategies[f.Field] = f.Strategy
+		}
+	}
+
+	var (
+		scenes     []*models.Scene
+		performers []*models.Performer
+		studios    []*models.Studio
+	)
+
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		var err error
+		if j.input.Scenes {
+			scenes, err = j.findScenes(ctx)
+			if err != nil {
+				return fmt.Errorf("querying scenes: %w", err)
+			}
+		}
+
+		if j.input.Performers {
+			performers, err = r.Performer.FindByStashIDStatus(ctx, true, j.box.Endpoint)
+			if err != nil {
+				return fmt.Errorf("querying performers: %w", err)
+			}
+
+			for _, p := range performers {
+				if err := p.LoadStashIDs(ctx, r.Performer); err != nil {
+					return fmt.Errorf("loading stash ids for performer %s: %w", p.Name, err)
+				}
+			}
+		}
+
+		if j.input.Studios {
+			studios, err = r.Studio.FindByStashIDStatus(ctx, true, j.box.Endpoint)
+			if err != nil {
+				return fmt.Errorf("querying studios: %w", err)
+			}
+
+			for _, s := range studios {
+				if err := s.LoadStashIDs(ctx, r.Studio); err != nil {
+					return fmt.Errorf("loading stash ids for studio %s: %w", s.Name, err)
+				}
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	progress.SetTotal(len(scenes) + len(performers) + len(studios))
+
+	logger.Infof("Checking stash-box %s for updates to %d scenes, %d performers and %d studios", j.box.Endpoint, len(scenes), len(performers), len(studios))
+
+	if len(scenes) > 0 {
+		j.checkScenes(ctx, progress, scenes)
+	}
+
+	for _, p := range performers {
+		if job.IsCancelled(ctx) {
+			return nil
+		}
+
+		progress.ExecuteTask("Checking performer "+p.Name, func() {
+			if err := j.checkPerformer(ctx, p); err != nil {
+				logger.Errorf("Error checking performer %s for updates: %v", p.Name, err)
+			}
+		})
+
+		progress.Increment()
+	}
+
+	for _, s := range studios {
+		if job.IsCancelled(ctx) {
+			return nil
+		}
+
+		progress.ExecuteTask("Checking studio "+s.Name, func() {
+			if err := j.checkStudio(ctx, s); err != nil {
+				logger.Errorf("Error checking studio %s for updates: %v", s.Name, err)
+			}
+		})
+
+		progress.Increment()
+	}
+
+	return nil
+}
+
+func (j *StashBoxCheckUpdatesJob) findScenes(ctx context.Context) ([]*models.Scene, error) {
+	r := instance.Repository
+	endpoint := j.box.Endpoint
+	sceneFilter := &models.SceneFilterType{
+		StashIDEndpoint: &models.StashIDCriterionInput{
+			Endpoint: &endpoint,
+			Modifier: models.CriterionModifierNotNull,
+		},
+	}
+
+	sort := "path"
+	findFilter := &models.FindFilterType{
+		Sort: &sort,
+	}
+
+	var ret []*models.Scene
+	if err := scene.BatchProcess(ctx, r.Scene, sceneFilter, findFilter, func(s *models.Scene) error {
+		if err := s.LoadStashIDs(ctx, r.Scene); err != nil {
+			return fmt.Errorf("loading stash ids for scene %s: %w", s.Path, err)
+		}
+
+		ret = append(ret, s)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (j *StashBoxCheckUpdatesJob) checkScenes(ctx context.Context, progress *job.Progress, scenes []*models.Scene) {
+	r := instance.Repository
+
+	var fieldOptions []*identify.FieldOptions
+	for field, strategy := range j.strategies {
+		fieldOptions = append(fieldOptions, &identify.FieldOptions{
+			Field:    field,
+			Strategy: strategy,
+		})
+	}
+
+	// scenes are updated using the identify logic, using the linked
+	// stash id in place of fingerprint matching
+	source := stashboxIDSource{
+		Client:   j.client,
+		endpoint: j.box.Endpoint,
+		stashIDs: make(map[int]string),
+		fetched:  make(map[int]bool),
+	}
+	for _, s := range scenes {
+		if stashID := s.StashIDs.ForEndpoint(j.box.Endpoint); stashID != nil {
+			source.stashIDs[s.ID] = stashID.StashID
+		}
+	}
+
+	// don't set cover images or organized flags when checking for updates
+	setCoverImage := false
+	task := identify.SceneIdentifier{
+		TxnManager:         r.TxnManager,
+		SceneReaderUpdater: r.Scene,
+		StudioReaderWriter: r.Studio,
+		PerformerCreator:   r.Performer,
+		TagFinderCreator:   r.Tag,
+
+		DefaultOptions: &identify.MetadataOptions{
+			FieldOptions:  fieldOptions,
+			SetCoverImage: &setCoverImage,
+		},
+		Sources: []identify.ScraperSource{
+			{
+				Name:       "stash-box: " + j.box.Endpoint,
+				Scraper:    source,
+				RemoteSite: j.box.Endpoint,
+			},
+		},
+		SceneUpdatePostHookExecutor: j.postHookExecutor,
+		DryRun:                      j.input.DryRun,
+	}
+
+	for _, s := range scenes {
+		if job.IsCancelled(ctx) {
+			return
+		}
+
+		progress.ExecuteTask("Checking scene "+s.Path, func() {
+			if err := task.Identify(ctx, s); err != nil {
+				logger.Errorf("Error checking scene %s for updates: %v", s.Path, err)
+				return
+			}
+
+			// only record the check if the scene was found on stash-box
+			if !j.input.DryRun && source.fetched[s.ID] {
+				if err := j.touchStashID(ctx, r.Scene, s.ID); err != nil {
+					logger.Errorf("Error updating stash id for scene %s: %v", s.Path, err)
+				}
+			}
+		})
+
+		progress.Increment()
+	}
+}
+
+// touchStashID sets the updated time of the stash id for the endpoint, without
+// changing the updated time of the object itself.
+func (j *StashBoxCheckUpdatesJob) touchStashID(ctx context.Context, qb models.StashIDUpdatedAtSetter, id int) error {
+	r := instance.Repository
+	return r.WithTxn(ctx, func(ctx context.Context) error {
+		return qb.UpdateStashIDUpdatedAt(ctx, id, j.box.Endpoint, time.Now())
+	})
+}
+
+// touchStashIDs returns a stash id update setting the updated time of the
+// stash id for the endpoint. Returns nil if there is no stash id for the endpoint.
+func (j *StashBoxCheckUpdatesJob) touchStashIDs(existing []models.StashID) *models.UpdateStashIDs {
+	ret := &models.UpdateStashIDs{
+		StashIDs: append([]models.StashID(nil), existing...),
+		Mode:     models.RelationshipUpdateModeSet,
+	}
+
+	for i, stashID := range ret.StashIDs {
+		if stashID.Endpoint == j.box.Endpoint {
+			ret.StashIDs[i].UpdatedAt = time.Now()
+			return ret
+		}
+	}
+
+	return nil
+}
+
+func (j *StashBoxCheckUpdatesJob) checkPerformer(ctx context.Context, p *models.Performer) error {
+	stashID := p.StashIDs.ForEndpoint(j.box.Endpoint)
+	if stashID == nil {
+		return nil
+	}
+
+	scraped, err := j.client.FindStashBoxPerformerByID(ctx, stashID.StashID)
+	if err != nil {
+		return err
+	}
+
+	if scraped == nil {
+		logger.Warnf("Performer %s was not found on stash-box", p.Name)
+		return nil
+	}
+
+	r := instance.Repository
+	return r.WithTxn(ctx, func(ctx context.Context) error {
+		qb := r.Performer
+		if err := p.LoadAliases(ctx, qb); err != nil {
+			return err
+		}
+		if err := p.LoadURLs(ctx, qb); err != nil {
+			return err
+		}
+
+		partial := scraped.ToPartial(j.box.Endpoint, j.excludedFields(), p.StashIDs.List())
+
+		u := j.newFieldUpdater()
+		u.string("name", p.Name, &partial.Name)
+		u.string("disambiguation", p.Disambiguation, &partial.Disambiguation)
+		u.strings("aliases", p.Aliases.List(), &partial.Aliases)
+		var gender string
+		if p.Gender != nil {
+			gender = p.Gender.String()
+		}
+		u.string("gender", gender, &partial.Gender)
+		u.date("birthdate", p.Birthdate, &partial.Birthdate)
+		u.date("death_date", p.DeathDate, &partial.DeathDate)
+		u.string("ethnicity", p.Ethnicity, &partial.Ethnicity)
+		u.string("country", p.Country, &partial.Country)
+		u.string("eye_color", p.EyeColor, &partial.EyeColor)
+		u.string("hair_color", p.HairColor, &partial.HairColor)
+		u.int("height", p.Height, &partial.Height)
+		u.int("weight", p.Weight, &partial.Weight)
+		u.string("measurements", p.Measurements, &partial.Measurements)
+		u.string("fake_tits", p.FakeTits, &partial.FakeTits)
+		u.string("career_length", p.CareerLength, &partial.CareerLength)
+		u.string("tattoos", p.Tattoos, &partial.Tattoos)
+		u.string("piercings", p.Piercings, &partial.Piercings)
+		u.string("details", p.Details, &partial.Details)
+		u.strings("urls", p.URLs.List(), &partial.URLs)
+
+		if len(u.changed) == 0 {
+			logger.Debugf("Performer %s is up to date", p.Name)
+		} else {
+			j.logChanges("performer", p.Name, u.changed)
+		}
+
+		if j.input.DryRun {
+			return nil
+		}
+
+		if len(u.changed) == 0 {
+			return qb.UpdateStashIDUpdatedAt(ctx, p.ID, j.box.Endpoint, time.Now())
+		}
+
+		partial.StashIDs = j.touchStashIDs(p.StashIDs.List())
+
+		if err := performer.ValidateUpdate(ctx, p.ID, partial, qb); err != nil {
+			return err
+		}
+
+		_, err := qb.UpdatePartial(ctx, p.ID, partial)
+		return err
+	})
+}
+
+func (j *StashBoxCheckUpdatesJob) checkStudio(ctx context.Context, s *models.Studio) error {
+	stashID := s.StashIDs.ForEndpoint(j.box.Endpoint)
+	if stashID == nil {
+		return nil
+	}
+
+	scraped, err := j.client.FindStashBoxStudio(ctx, stashID.StashID)
+	if err != nil {
+		return err
+	}
+
+	if scraped == nil {
+		logger.Warnf("Studio %s was not found on stash-box", s.Name)
+		return nil
+	}
+
+	r := instance.Repository
+	return r.WithTxn(ctx, func(ctx context.Context) error {
+		qb := r.Studio
+
+		// the parent studio is only set if it exists locally
+		partial := scraped.ToPartial(strconv.Itoa(s.ID), j.box.Endpoint, j.excludedFields(), s.StashIDs.List())
+
+		u := j.newFieldUpdater()
+		u.string("name", s.Name, &partial.Name)
+		u.string("url", s.URL, &partial.URL)
+		u.int("parent", s.ParentID, &partial.ParentID)
+
+		if len(u.changed) == 0 {
+			logger.Debugf("Studio %s is up to date", s.Name)
+		} else {
+			j.logChanges("studio", s.Name, u.changed)
+		}
+
+		if j.input.DryRun {
+			return nil
+		}
+
+		if len(u.changed) == 0 {
+			return qb.UpdateStashIDUpdatedAt(ctx, s.ID, j.box.Endpoint, time.Now())
+		}
+
+		partial.StashIDs = j.touchStashIDs(s.StashIDs.List())
+
+		if err := studio.ValidateModify(ctx, partial, qb); err != nil {
+			return err
+		}
+
+		_, err := qb.UpdatePartial(ctx, partial)
+		return err
+	})
+}
+
+func (j *StashBoxCheckUpdatesJob) logChanges(objectType string, name string, changed []string) {
+	if j.input.DryRun {
+		logger.Infof("Stash-box has updates for %s %s: %s", objectType, name, strings.Join(changed, ", "))
+	} else {
+		logger.Infof("Updated %s %s from stash-box: %s", objectType, name, strings.Join(changed, ", "))
+	}
+}
+
+func (j *StashBoxCheckUpdatesJob) excludedFields() map[string]bool {
+	ret := make(map[string]bool)
+	for field, strategy := range j.strategies {
+		if strategy == identify.FieldStrategyIgnore {
+			ret[field] = true
+		}
+	}
+
+	return ret
+}
+
+func (j *StashBoxCheckUpdatesJob) newFieldUpdater() *stashBoxFieldUpdater {
+	return &stashBoxFieldUpdater{
+		strategies: j.strategies,
+	}
+}
+
+// stashBoxFieldUpdater filters the fields of a partial update according to
+// the field strategies, removing any fields that are unchanged or should
+// not be set. The names of the fields that remain set are recorded.
+type stashBoxFieldUpdater struct {
+	strategies map[string]identify.FieldStrategy
+	changed    []string
+}
+
+func (u *stashBoxFieldUpdater) strategy(field string) identify.FieldStrategy {
+	if s, found := u.strategies[field]; found {
+		return s
+	}
+
+	return identify.FieldStrategyMerge
+}
+
+// shouldSet returns true if a single-value field with the given existing
+// value state should be set.
+func (u *stashBoxFieldUpdater) shouldSet(field string, hasExistingValue bool) bool {
+	switch u.strategy(field) {
+	case identify.FieldStrategyIgnore:
+		return false
+	case identify.FieldStrategyMerge:
+		return !hasExistingValue
+	}
+
+	return true
+}
+
+func (u *stashBoxFieldUpdater) string(field string, existing string, v *models.OptionalString) {
+	if !v.Set {
+		return
+	}
+
+	if v.Value == existing || !u.shouldSet(field, existing != "") {
+		*v = models.OptionalString{}
+		return
+	}
+
+	u.changed = append(u.changed, field)
+}
+
+func (u *stashBoxFieldUpdater) int(field string, existing *int, v *models.OptionalInt) {
+	if !v.Set {
+		return
+	}
+
+	if (existing != nil && v.Value == *existing) || !u.shouldSet(field, existing != nil) {
+		*v = models.OptionalInt{}
+		return
+	}
+
+	u.changed = append(u.changed, field)
+}
+
+func (u *stashBoxFieldUpdater) date(field string, existing *models.Date, v *models.OptionalDate) {
+	if !v.Set {
+		return
+	}
+
+	if (existing != nil && v.Value.String() == existing.String()) || !u.shouldSet(field, existing != nil) {
+		*v = models.OptionalDate{}
+		return
+	}
+
+	u.changed = append(u.changed, field)
+}
+
+// strings handles multi-value fields. Merge adds missing values to the
+// existing values, while overwrite replaces them.
+func (u *stashBoxFieldUpdater) strings(field string, existing []string, v **models.UpdateStrings) {
+	if *v == nil {
+		return
+	}
+
+	values := (*v).Values
+
+	switch u.strategy(field) {
+	case identify.FieldStrategyIgnore:
+		*v = nil
+		return
+	case identify.FieldStrategyMerge:
+		values = sliceutil.AppendUniques(existing, values)
+		if len(values) == len(existing) {
+			*v = nil
+			return
+		}
+	default:
+		if len(values) == len(existing) && len(sliceutil.Exclude(values, existing)) == 0 {
+			*v = nil
+			return
+		}
+	}
+
+	*v = &models.UpdateStrings{
+		Values: values,
+		Mode:   models.RelationshipUpdateModeSet,
+	}
+	u.changed = append(u.changed, field)
+}
+
+// stashboxIDSource is an identify source returning the stash-box scene
+// linked to a scene.
+type stashboxIDSource struct {
+	*stashbox.Client
+	endpoint string
+	stashIDs map[int]string
+	// fetched contains the ids of the scenes found on stash-box
+	fetched map[int]bool
+}
+
+func (s stashboxIDSource) ScrapeScenes(ctx context.Context, sceneID int) ([]*scraper.ScrapedScene, error) {
+	stashID, found := s.stashIDs[sceneID]
+	if !found {
+		return nil, nil
+	}
+
+	result, err := s.FindStashBoxSceneByID(ctx, stashID)
+	if err != nil {
+		return nil, fmt.Errorf("error querying stash-box using stash ID %s: %w", stashID, err)
+	}
+
+	if result == nil {
+		logger.Warnf("Scene with stash ID %s was not found on stash-box", stashID)
+		return nil, nil
+	}
+
+	s.fetched[sceneID] = true
+	return []*scraper.ScrapedScene{result}, nil
+}
+
+func (s stashboxIDSource) String() string {
+	return fmt.Sprintf("stash-box %s", s.endpoint)
+}
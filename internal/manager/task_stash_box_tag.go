@@ -2,14 +2,18 @@ package manager
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/performer"
 	"github.com/stashapp/stash/pkg/scraper/stashbox"
+	"github.com/stashapp/stash/pkg/sliceutil"
 	"github.com/stashapp/stash/pkg/studio"
+	"github.com/stashapp/stash/pkg/tag"
 )
 
 type StashBoxTagTaskType int
@@ -17,6 +21,7 @@ type StashBoxTagTaskType int
 const (
 	Performer StashBoxTagTaskType = iota
 	Studio
+	Tag
 )
 
 type StashBoxBatchTagTask struct {
@@ -24,6 +29,7 @@ type StashBoxBatchTagTask struct {
 	name           *string
 	performer      *models.Performer
 	studio         *models.Studio
+	tag            *models.Tag
 	refresh        bool
 	createParent   bool
 	excludedFields []string
@@ -36,6 +42,8 @@ func (t *StashBoxBatchTagTask) Start(ctx context.Context) {
 		t.stashBoxPerformerTag(ctx)
 	case Studio:
 		t.stashBoxStudioTag(ctx)
+	case Tag:
+		t.stashBoxTagTag(ctx)
 	default:
 		logger.Errorf("Error starting batch task, unknown task_type %d", t.taskType)
 	}
@@ -58,6 +66,14 @@ func (t *StashBoxBatchTagTask) Description() string {
 			name = t.studio.Name
 		}
 		return fmt.Sprintf("Tagging studio %s from stash-box", name)
+	} else if t.taskType == Tag {
+		var name string
+		if t.name != nil {
+			name = *t.name
+		} else {
+			name = t.tag.Name
+		}
+		return fmt.Sprintf("Tagging tag %s from stash-box", name)
 	}
 	return fmt.Sprintf("Unknown tagging task type %d from stash-box", t.taskType)
 }
@@ -156,6 +172,13 @@ func (t *StashBoxBatchTagTask) processMatchedPerformer(ctx context.Context, p *m
 
 			partial := p.ToPartial(t.box.Endpoint, excluded, existingStashIDs)
 
+			// the matched performer may not be the one being refreshed
+			if partial.RelatedPerformers != nil {
+				partial.RelatedPerformers.Relationships = sliceutil.Filter(partial.RelatedPerformers.Relationships, func(r models.PerformerRelationship) bool {
+					return r.PerformerID != t.performer.ID
+				})
+			}
+
 			if err := performer.ValidateUpdate(ctx, t.performer.ID, partial, qb); err != nil {
 				return err
 			}
@@ -461,3 +484,213 @@ func (t *StashBoxBatchTagTask) processParentStudio(ctx context.Context, parent *
 		return err
 	}
 }
+
+func (t *StashBoxBatchTagTask) stashBoxTagTag(ctx context.Context) {
+	tag, err := t.findStashBoxTag(ctx)
+	if err != nil {
+		logger.Errorf("Error fetching tag data from stash-box: %v", err)
+		return
+	}
+
+	excluded := map[string]bool{}
+	for _, field := range t.excludedFields {
+		excluded[field] = true
+	}
+
+	// tag will have a value if pulling from Stash-box by Stash ID, name or alias was successful
+	if tag != nil {
+		t.processMatchedTag(ctx, tag, excluded)
+	} else {
+		var name string
+		if t.name != nil {
+			name = *t.name
+		} else if t.tag != nil {
+			name = t.tag.Name
+		}
+		logger.Infof("No match found for %s", name)
+	}
+}
+
+func (t *StashBoxBatchTagTask) findStashBoxTag(ctx context.Context) (*models.ScrapedTag, error) {
+	var tag *models.ScrapedTag
+	var err error
+
+	r := instance.Repository
+
+	stashboxRepository := stashbox.NewRepository(r)
+	client := stashbox.NewClient(*t.box, stashboxRepository)
+
+	if t.refresh {
+		var remoteID string
+		if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+			if !t.tag.StashIDs.Loaded() {
+				err = t.tag.LoadStashIDs(ctx, r.Tag)
+				if err != nil {
+					return err
+				}
+			}
+			if id := t.tag.StashIDs.ForEndpoint(t.box.Endpoint); id != nil {
+				remoteID = id.StashID
+			}
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+		if remoteID != "" {
+			tag, err = client.FindStashBoxTag(ctx, remoteID)
+		}
+	} else {
+		var name string
+		if t.name != nil {
+			name = *t.name
+		} else {
+			name = t.tag.Name
+		}
+		tag, err = client.FindStashBoxTag(ctx, name)
+	}
+
+	return tag, err
+}
+
+func (t *StashBoxBatchTagTask) processMatchedTag(ctx context.Context, s *models.ScrapedTag, excluded map[string]bool) {
+	if s.Parent != nil && (s.Parent.StoredID != nil || t.createParent) {
+		if err := t.processParentTag(ctx, s.Parent); err != nil {
+			return
+		}
+	}
+
+	r := instance.Repository
+
+	// Refreshing an existing tag
+	if t.tag != nil {
+		err := r.WithTxn(ctx, func(ctx context.Context) error {
+			qb := r.Tag
+
+			existingStashIDs, err := qb.GetStashIDs(ctx, t.tag.ID)
+			if err != nil {
+				return err
+			}
+
+			partial := s.ToPartial(t.box.Endpoint, excluded, existingStashIDs)
+
+			if partial.Aliases != nil {
+				name := t.tag.Name
+				aliases := partial.Aliases.Values
+
+				// keep the existing name as an alias when renaming
+				if partial.Name.Set && !strings.EqualFold(partial.Name.Value, t.tag.Name) {
+					name = partial.Name.Value
+					aliases = append(aliases, t.tag.Name)
+				}
+
+				partial.Aliases.Values, err = t.uniqueTagAliases(ctx, t.tag.ID, name, aliases)
+				if err != nil {
+					return err
+				}
+			}
+
+			if err := tag.ValidateUpdate(ctx, t.tag.ID, partial, qb); err != nil {
+				return err
+			}
+
+			_, err = qb.UpdatePartial(ctx, t.tag.ID, partial)
+			return err
+		})
+		if err != nil {
+			logger.Errorf("Failed to update tag %s: %v", s.Name, err)
+		} else {
+			logger.Infof("Updated tag %s", s.Name)
+		}
+	} else if t.name != nil && s.Name != "" {
+		// Creating a new tag
+		if s.StoredID != nil {
+			logger.Infof("Tag %s already exists", s.Name)
+			return
+		}
+
+		newTag := s.ToTag(t.box.Endpoint, excluded)
+
+		err := r.WithTxn(ctx, func(ctx context.Context) error {
+			qb := r.Tag
+
+			if newTag.Aliases.Loaded() {
+				aliases, err := t.uniqueTagAliases(ctx, 0, newTag.Name, newTag.Aliases.List())
+				if err != nil {
+					return err
+				}
+				newTag.Aliases = models.NewRelatedStrings(aliases)
+			}
+
+			if err := tag.ValidateCreate(ctx, *newTag, qb); err != nil {
+				return err
+			}
+
+			return qb.Create(ctx, newTag)
+		})
+		if err != nil {
+			logger.Errorf("Failed to create tag %s: %v", s.Name, err)
+		} else {
+			logger.Infof("Created tag %s", s.Name)
+		}
+	}
+}
+
+// uniqueTagAliases returns the provided aliases, omitting those that match
+// the tag name or are already in use by another tag.
+func (t *StashBoxBatchTagTask) uniqueTagAliases(ctx context.Context, id int, name string, aliases []string) ([]string, error) {
+	var ret []string
+	for _, a := range aliases {
+		if strings.EqualFold(a, name) {
+			continue
+		}
+
+		err := tag.EnsureTagNameUnique(ctx, id, a, instance.Repository.Tag)
+		var nameExistsErr *tag.NameExistsError
+		var usedByAliasErr *tag.NameUsedByAliasError
+		switch {
+		case errors.As(err, &nameExistsErr), errors.As(err, &usedByAliasErr):
+			logger.Debugf("Skipping alias %s: %v", a, err)
+			continue
+		case err != nil:
+			return nil, err
+		}
+
+		ret = append(ret, a)
+	}
+
+	return ret, nil
+}
+
+func (t *StashBoxBatchTagTask) processParentTag(ctx context.Context, parent *models.ScrapedTag) error {
+	if parent.StoredID != nil {
+		// the category matched an existing tag, which will be added as a parent
+		return nil
+	}
+
+	// The parent needs to be created
+	newParentTag := models.NewTag()
+	newParentTag.Name = parent.Name
+
+	r := instance.Repository
+	err := r.WithTxn(ctx, func(ctx context.Context) error {
+		qb := r.Tag
+
+		if err := tag.ValidateCreate(ctx, newParentTag, qb); err != nil {
+			return err
+		}
+
+		if err := qb.Create(ctx, &newParentTag); err != nil {
+			return err
+		}
+
+		storedID := strconv.Itoa(newParentTag.ID)
+		parent.StoredID = &storedID
+		return nil
+	})
+	if err != nil {
+		logger.Errorf("Failed to create tag %s: %v", parent.Name, err)
+	} else {
+		logger.Infof("Created tag %s", parent.Name)
+	}
+	return err
+}
@@ -61,7 +61,7 @@ func (t *GenerateTranscodeTask) Start(ctx context.Context) {
 
 	// TODO - move transcode generation logic elsewhere
 
-	videoFile, err := ffprobe.NewVideoFile(f.Path)
+	videoFile, err := ffprobe.NewVideoFile(instance.ffmpegInput(f.Path))
 	if err != nil {
 		logger.Errorf("[transcode] error reading video file: %s", err.Error())
 		return
@@ -106,7 +106,7 @@ func (t *GenerateTranscodeTask) Start(ctx context.Context) {
 // if container is missing from DB it is treated as non supported in order not to delay the user
 func (t *GenerateTranscodeTask) required() bool {
 	f := t.Scene.Files.Primary()
-	if f == nil {
+	if f == nil || f.IsAudio() {
 		return false
 	}
 
@@ -0,0 +1,439 @@
+package manager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/gallery"
+	"github.com/stashapp/stash/pkg/image"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/jsonschema"
+	"github.com/stashapp/stash/pkg/performer"
+	"github.com/stashapp/stash/pkg/scene"
+	"github.com/stashapp/stash/pkg/tag"
+)
+
+// trashPurgeEvery is how often expired trash entries are purged.
+const trashPurgeEvery = 24 * time.Hour
+
+// TrashData is stored in the trash manifest. It contains the exported JSON
+// of the files and entities deleted with the trashed files, so that they can
+// be restored.
+type TrashData struct {
+	Files  []json.RawMessage  `json:"files,omitempty"`
+	Scenes []jsonschema.Scene `json:"scenes,omitempty"`
+	Images []jsonschema.Image `json:"images,omitempty"`
+}
+
+// GetTrash returns the trash for the library paths.
+func (s *Manager) GetTrash() *file.Trash {
+	stashPaths := s.Config.GetStashPaths()
+	roots := make([]string, len(stashPaths))
+	for i, p := range stashPaths {
+		roots[i] = p.Path
+	}
+
+	return &file.Trash{
+		Roots: roots,
+	}
+}
+
+// NewFileDeleter returns a new file deleter. If the trash is enabled, then
+// the deleter moves deleted files to the trash, and the returned TrashData
+// should be populated with the entities being deleted. Otherwise, the
+// returned TrashData is nil.
+func (s *Manager) NewFileDeleter() (*file.Deleter, *TrashData) {
+	ret := file.NewDeleter()
+	if !s.Config.GetUseTrash() {
+		return ret, nil
+	}
+
+	data := &TrashData{}
+	ret.Trash = s.GetTrash()
+	ret.TrashData = data
+
+	return ret, data
+}
+
+func (d *TrashData) addFiles(files []models.File) error {
+	for _, f := range files {
+		data, err := json.Marshal(fileToJSON(f))
+		if err != nil {
+			return fmt.Errorf("encoding file %q: %w", f.Base().Path, err)
+		}
+
+		d.Files = append(d.Files, data)
+	}
+
+	return nil
+}
+
+// AddScene records the scene and its files, so that they can be restored
+// from the trash.
+func (d *TrashData) AddScene(ctx context.Context, r models.Repository, s *models.Scene) error {
+	if err := s.LoadRelationships(ctx, r.Scene); err != nil {
+		return fmt.Errorf("loading scene relationships: %w", err)
+	}
+
+	sceneJSON, err := scene.ToBasicJSON(ctx, r.Scene, s)
+	if err != nil {
+		return fmt.Errorf("getting scene JSON: %w", err)
+	}
+
+	sceneJSON.Studio, err = scene.GetStudioName(ctx, r.Studio, s)
+	if err != nil {
+		return fmt.Errorf("getting scene studio name: %w", err)
+	}
+
+	galleries, err := r.Gallery.FindBySceneID(ctx, s.ID)
+	if err != nil {
+		return fmt.Errorf("getting scene galleries: %w", err)
+	}
+
+	for _, g := range galleries {
+		if err := g.LoadFiles(ctx, r.Gallery); err != nil {
+			return fmt.Errorf("getting scene gallery files: %w", err)
+		}
+	}
+
+	sceneJSON.Galleries = gallery.GetRefs(galleries)
+	sceneJSON.ResumeTime = s.ResumeTime
+	sceneJSON.PlayDuration = s.PlayDuration
+
+	performers, err := r.Performer.FindBySceneID(ctx, s.ID)
+	if err != nil {
+		return fmt.Errorf("getting scene performers: %w", err)
+	}
+
+	sceneJSON.Performers = performer.GetNames(performers)
+
+	sceneJSON.Tags, err = scene.GetTagNames(ctx, r.Tag, s)
+	if err != nil {
+		return fmt.Errorf("getting scene tag names: %w", err)
+	}
+
+	sceneJSON.Markers, err = scene.GetSceneMarkersJSON(ctx, r.SceneMarker, r.Tag, s)
+	if err != nil {
+		return fmt.Errorf("getting scene markers: %w", err)
+	}
+
+	sceneJSON.Groups, err = scene.GetSceneGroupsJSON(ctx, r.Group, s)
+	if err != nil {
+		return fmt.Errorf("getting scene groups: %w", err)
+	}
+
+	var files []models.File
+	for _, f := range s.Files.List() {
+		files = append(files, f)
+	}
+
+	if err := d.addFiles(files); err != nil {
+		return err
+	}
+
+	d.Scenes = append(d.Scenes, *sceneJSON)
+	return nil
+}
+
+// AddImage records the image and its files, so that they can be restored
+// from the trash.
+func (d *TrashData) AddImage(ctx context.Context, r models.Repository, i *models.Image) error {
+	if err := i.LoadFiles(ctx, r.Image); err != nil {
+		return fmt.Errorf("loading image files: %w", err)
+	}
+
+	if err := i.LoadURLs(ctx, r.Image); err != nil {
+		return fmt.Errorf("loading image urls: %w", err)
+	}
+
+	imageJSON := image.ToBasicJSON(i)
+
+	var err error
+	imageJSON.Studio, err = image.GetStudioName(ctx, r.Studio, i)
+	if err != nil {
+		return fmt.Errorf("getting image studio name: %w", err)
+	}
+
+	galleries, err := r.Gallery.FindByImageID(ctx, i.ID)
+	if err != nil {
+		return fmt.Errorf("getting image galleries: %w", err)
+	}
+
+	for _, g := range galleries {
+		if err := g.LoadFiles(ctx, r.Gallery); err != nil {
+			return fmt.Errorf("getting image gallery files: %w", err)
+		}
+	}
+
+	imageJSON.Galleries = gallery.GetRefs(galleries)
+
+	performers, err := r.Performer.FindByImageID(ctx, i.ID)
+	if err != nil {
+		return fmt.Errorf("getting image performers: %w", err)
+	}
+
+	imageJSON.Performers = performer.GetNames(performers)
+
+	tags, err := r.Tag.FindByImageID(ctx, i.ID)
+	if err != nil {
+		return fmt.Errorf("getting image tags: %w", err)
+	}
+
+	imageJSON.Tags = tag.GetNames(tags)
+
+	if err := d.addFiles(i.Files.List()); err != nil {
+		return err
+	}
+
+	d.Images = append(d.Images, *imageJSON)
+	return nil
+}
+
+// RestoreDeleted moves the files of the trash entry back to their original
+// paths, and recreates the files, scenes and images that were deleted
+// with them.
+//
+// If some files could not be restored, only the scenes and images with all of
+// their files restored are recreated. The others are kept in the trash entry,
+// so that they are recreated when the entry is restored again.
+func (s *Manager) RestoreDeleted(ctx context.Context, id string) error {
+	trash := s.GetTrash()
+	manifest, restoreErr := trash.Restore(id)
+	if manifest == nil {
+		return restoreErr
+	}
+
+	logger.Infof("Restored %d files from trash entry %s", len(manifest.Files), id)
+
+	if len(manifest.Data) == 0 {
+		return restoreErr
+	}
+
+	var data TrashData
+	if err := json.Unmarshal(manifest.Data, &data); err != nil {
+		return fmt.Errorf("decoding trash data: %w", err)
+	}
+
+	if restoreErr != nil {
+		var remaining TrashData
+		data, remaining = data.splitRestored()
+		if err := trash.SetData(id, remaining); err != nil {
+			logger.Errorf("Error updating trash entry %s: %v", id, err)
+		}
+	}
+
+	if err := s.restoreTrashData(ctx, data); err != nil {
+		return err
+	}
+
+	return restoreErr
+}
+
+// splitRestored splits the data into the files, scenes and images whose files
+// are all on disk, and the rest.
+func (d TrashData) splitRestored() (restored TrashData, remaining TrashData) {
+	trashedFiles := make(map[string]bool)
+	onDisk := make(map[string]bool)
+	for _, fd := range d.Files {
+		f, err := jsonschema.ParseDirEntry(fd)
+		if err != nil {
+			// will fail when restored
+			restored.Files = append(restored.Files, fd)
+			continue
+		}
+
+		path := f.DirEntry().Path
+		trashedFiles[path] = true
+
+		// files in zip files are restored with the zip file
+		checkPath := path
+		if zipFile := f.DirEntry().ZipFile; zipFile != "" {
+			checkPath = zipFile
+		}
+
+		if exists, _ := fsutil.FileExists(checkPath); exists {
+			onDisk[path] = true
+			restored.Files = append(restored.Files, fd)
+		} else {
+			remaining.Files = append(remaining.Files, fd)
+		}
+	}
+
+	// files that are not in the data were not trashed
+	allOnDisk := func(paths []string) bool {
+		for _, p := range paths {
+			if trashedFiles[p] && !onDisk[p] {
+				return false
+			}
+		}
+		return true
+	}
+
+	for _, sceneJSON := range d.Scenes {
+		if allOnDisk(sceneJSON.Files) {
+			restored.Scenes = append(restored.Scenes, sceneJSON)
+		} else {
+			remaining.Scenes = append(remaining.Scenes, sceneJSON)
+		}
+	}
+
+	for _, imageJSON := range d.Images {
+		if allOnDisk(imageJSON.Files) {
+			restored.Images = append(restored.Images, imageJSON)
+		} else {
+			remaining.Images = append(remaining.Images, imageJSON)
+		}
+	}
+
+	return restored, remaining
+}
+
+func (s *Manager) restoreTrashData(ctx context.Context, data TrashData) error {
+	r := s.Repository
+	return r.WithTxn(ctx, func(ctx context.Context) error {
+		for _, fd := range data.Files {
+			fileJSON, err := jsonschema.ParseDirEntry(fd)
+			if err != nil {
+				return fmt.Errorf("decoding file: %w", err)
+			}
+
+			fileImporter := &file.Importer{
+				ReaderWriter: r.File,
+				FolderStore:  r.Folder,
+				Input:        fileJSON,
+			}
+
+			// files that were not deleted will already exist
+			if err := performImport(ctx, fileImporter, ImportDuplicateEnumIgnore); err != nil {
+				return fmt.Errorf("restoring file %q: %w", fileJSON.DirEntry().Path, err)
+			}
+		}
+
+		for _, sceneJSON := range data.Scenes {
+			sceneImporter := &scene.Importer{
+				ReaderWriter: r.Scene,
+				Input:        sceneJSON,
+				FileFinder:   r.File,
+
+				FileNamingAlgorithm: s.Config.GetVideoFileNamingAlgorithm(),
+				MissingRefBehaviour: models.ImportMissingRefEnumIgnore,
+
+				GalleryFinder:   r.Gallery,
+				GroupWriter:     r.Group,
+				PerformerWriter: r.Performer,
+				StudioWriter:    r.Studio,
+				TagWriter:       r.Tag,
+			}
+
+			if err := importDeleted(ctx, sceneImporter); err != nil {
+				return fmt.Errorf("restoring scene %q: %w", sceneImporter.Name(), err)
+			}
+
+			for _, m := range sceneJSON.Markers {
+				markerImporter := &scene.MarkerImporter{
+					SceneID:             sceneImporter.ID,
+					Input:               m,
+					MissingRefBehaviour: models.ImportMissingRefEnumIgnore,
+					ReaderWriter:        r.SceneMarker,
+					TagWriter:           r.Tag,
+				}
+
+				if err := importDeleted(ctx, markerImporter); err != nil {
+					return fmt.Errorf("restoring scene marker %q: %w", markerImporter.Name(), err)
+				}
+			}
+		}
+
+		for _, imageJSON := range data.Images {
+			imageImporter := &image.Importer{
+				ReaderWriter: r.Image,
+				FileFinder:   r.File,
+				Input:        imageJSON,
+
+				MissingRefBehaviour: models.ImportMissingRefEnumIgnore,
+
+				GalleryFinder:   r.Gallery,
+				PerformerWriter: r.Performer,
+				StudioWriter:    r.Studio,
+				TagWriter:       r.Tag,
+			}
+
+			if err := importDeleted(ctx, imageImporter); err != nil {
+				return fmt.Errorf("restoring image %q: %w", imageImporter.Name(), err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// importDeleted always creates a new object, since the deleted object may
+// share files with objects that were not deleted.
+func importDeleted(ctx context.Context, i importer) error {
+	if err := i.PreImport(ctx); err != nil {
+		return err
+	}
+
+	id, err := i.Create(ctx)
+	if err != nil {
+		return err
+	}
+
+	return i.PostImport(ctx, *id)
+}
+
+// PurgeTrash starts a job that permanently deletes the trash entries older
+// than the provided number of days.
+func (s *Manager) PurgeTrash(ctx context.Context, retentionDays int) int {
+	j := job.MakeJobExec(func(ctx context.Context, progress *job.Progress) error {
+		before := time.Now().AddDate(0, 0, -retentionDays)
+		n, err := s.GetTrash().Purge(before)
+		if err != nil {
+			return fmt.Errorf("purging trash: %w", err)
+		}
+
+		logger.Infof("Purged %d entries from the trash", n)
+		return nil
+	})
+
+	return s.JobManager.Add(ctx, "Purging trash...", j)
+}
+
+// startTrashPurge purges the expired trash entries now and then daily, until
+// the manager is shut down.
+func (s *Manager) startTrashPurge() {
+	s.stopTrashPurge()
+
+	s.trashPurgeStop = make(chan struct{})
+	stop := s.trashPurgeStop
+
+	go func() {
+		ticker := time.NewTicker(trashPurgeEvery)
+		defer ticker.Stop()
+
+		for {
+			if days := s.Config.GetTrashRetentionDays(); s.Config.GetUseTrash() && days > 0 {
+				s.PurgeTrash(context.Background(), days)
+			}
+
+			select {
+			case <-ticker.C:
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+func (s *Manager) stopTrashPurge() {
+	if s.trashPurgeStop != nil {
+		close(s.trashPurgeStop)
+		s.trashPurgeStop = nil
+	}
+}
@@ -0,0 +1,76 @@
+package manager
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stashapp/stash/pkg/models/jsonschema"
+)
+
+func TestTrashData_splitRestored(t *testing.T) {
+	dir := t.TempDir()
+	restored := filepath.Join(dir, "restored.mp4")
+	trashed := filepath.Join(dir, "trashed.mp4")
+	notTrashed := filepath.Join(dir, "other.mp4")
+	zip := filepath.Join(dir, "images.zip")
+	inZip := filepath.Join(zip, "image.jpg")
+
+	for _, f := range []string{restored, notTrashed, zip} {
+		if err := os.WriteFile(f, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	fileJSON := func(path string, zipFile string) json.RawMessage {
+		ret, err := json.Marshal(jsonschema.BaseFile{
+			BaseDirEntry: jsonschema.BaseDirEntry{
+				Type:    jsonschema.DirEntryTypeFile,
+				Path:    path,
+				ZipFile: zipFile,
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ret
+	}
+
+	data := TrashData{
+		Files: []json.RawMessage{
+			fileJSON(restored, ""),
+			fileJSON(trashed, ""),
+			fileJSON(inZip, zip),
+		},
+		Scenes: []jsonschema.Scene{
+			{Title: "restored", Files: []string{restored}},
+			{Title: "trashed", Files: []string{trashed}},
+			{Title: "mixed", Files: []string{restored, trashed}},
+			{Title: "not trashed", Files: []string{restored, notTrashed}},
+		},
+		Images: []jsonschema.Image{
+			{Title: "in zip", Files: []string{inZip}},
+		},
+	}
+
+	gotRestored, gotRemaining := data.splitRestored()
+
+	assert.Equal(t, []json.RawMessage{fileJSON(restored, ""), fileJSON(inZip, zip)}, gotRestored.Files)
+	assert.Equal(t, []json.RawMessage{fileJSON(trashed, "")}, gotRemaining.Files)
+
+	sceneTitles := func(scenes []jsonschema.Scene) []string {
+		var ret []string
+		for _, s := range scenes {
+			ret = append(ret, s.Title)
+		}
+		return ret
+	}
+
+	assert.Equal(t, []string{"restored", "not trashed"}, sceneTitles(gotRestored.Scenes))
+	assert.Equal(t, []string{"trashed", "mixed"}, sceneTitles(gotRemaining.Scenes))
+	assert.Len(t, gotRestored.Images, 1)
+	assert.Len(t, gotRemaining.Images, 0)
+}
@@ -21,6 +21,9 @@ var validForHevc = []Container{Mp4}
 var validAudioForMkv = []ProbeAudioCodec{Aac, Mp3, Vorbis, Opus}
 var validAudioForWebm = []ProbeAudioCodec{Vorbis, Opus}
 var validAudioForMp4 = []ProbeAudioCodec{Aac, Mp3, Opus}
+var validAudioForMpegAudio = []ProbeAudioCodec{Mp3}
+var validAudioForFlac = []ProbeAudioCodec{FlacAudio}
+var validAudioForOgg = []ProbeAudioCodec{Vorbis, Opus, FlacAudio}
 
 var (
 	// ErrUnsupportedVideoCodecForBrowser is returned when the video codec is not supported for browser streaming.
@@ -87,10 +90,34 @@ func IsValidAudioForContainer(audio ProbeAudioCodec, format Container) bool {
 		return isValidAudio(audio, validAudioForWebm)
 	case Mp4:
 		return isValidAudio(audio, validAudioForMp4)
+	case MpegAudio:
+		return isValidAudio(audio, validAudioForMpegAudio)
+	case Flac:
+		return isValidAudio(audio, validAudioForFlac)
+	case Ogg:
+		return isValidAudio(audio, validAudioForOgg)
 	}
 	return false
 }
 
+// AudioMimeType returns the mime type of an audio-only file in the given
+// container. Returns an empty string if the container is not known.
+func AudioMimeType(format Container) string {
+	switch format {
+	case Mp4:
+		return MimeMp4Audio
+	case MpegAudio:
+		return MimeMpegAudio
+	case Flac:
+		return MimeFlacAudio
+	case Ogg:
+		return MimeOggAudio
+	case Wav:
+		return MimeWavAudio
+	}
+	return ""
+}
+
 // isValidCombo checks if a codec/container combination is valid.
 // Returns true on validity, false otherwise
 func isValidCombo(codecName string, format Container, supportedVideoCodecs []string) bool {
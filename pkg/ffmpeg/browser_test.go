@@ -0,0 +1,55 @@
+package ffmpeg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsValidAudioForContainer(t *testing.T) {
+	tests := []struct {
+		audio  ProbeAudioCodec
+		format Container
+		want   bool
+	}{
+		{Mp3, MpegAudio, true},
+		{Aac, MpegAudio, false},
+		{FlacAudio, Flac, true},
+		{Mp3, Flac, false},
+		{Vorbis, Ogg, true},
+		{Opus, Ogg, true},
+		{FlacAudio, Ogg, true},
+		{Mp3, Ogg, false},
+		// unknown codecs are reported as valid
+		{MissingUnsupported, Ogg, true},
+		{Aac, Wav, false},
+		{Aac, Mp4, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.audio)+"/"+string(tt.format), func(t *testing.T) {
+			assert.Equal(t, tt.want, IsValidAudioForContainer(tt.audio, tt.format))
+		})
+	}
+}
+
+func TestAudioMimeType(t *testing.T) {
+	tests := []struct {
+		format Container
+		want   string
+	}{
+		{Mp4, MimeMp4Audio},
+		{MpegAudio, MimeMpegAudio},
+		{Flac, MimeFlacAudio},
+		{Ogg, MimeOggAudio},
+		{Wav, MimeWavAudio},
+		{Matroska, ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			assert.Equal(t, tt.want, AudioMimeType(tt.format))
+		})
+	}
+}
@@ -1,5 +1,7 @@
 package ffmpeg
 
+import "io"
+
 type Container string
 type ProbeAudioCodec string
 
@@ -14,10 +16,17 @@ const (
 	Flv      Container = "flv"
 	Mpegts   Container = "mpegts"
 
+	// audio-only containers
+	MpegAudio Container = "mp3"
+	Flac      Container = "flac"
+	Ogg       Container = "ogg"
+	Wav       Container = "wav"
+
 	Aac                ProbeAudioCodec = "aac"
 	Mp3                ProbeAudioCodec = "mp3"
 	Opus               ProbeAudioCodec = "opus"
 	Vorbis             ProbeAudioCodec = "vorbis"
+	FlacAudio          ProbeAudioCodec = "flac"
 	MissingUnsupported ProbeAudioCodec = ""
 
 	Mp4Ffmpeg      string = "mov,mp4,m4a,3gp,3g2,mj2" // browsers support all of them
@@ -57,3 +66,16 @@ func MatchContainer(format string, filePath string) (Container, error) { // matc
 	}
 	return container, nil
 }
+
+// MatchContainerOpener is MatchContainer for files that are not on the OS
+// file system. open is only called if the file needs to be read.
+func MatchContainerOpener(format string, open func() (io.ReadCloser, error)) (Container, error) {
+	container := ffprobeToContainer[format]
+	if container == Matroska {
+		return magicContainerOpener(open)
+	}
+	if container == "" {
+		container = Container(format)
+	}
+	return container, nil
+}
@@ -0,0 +1,71 @@
+package ffmpeg
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// maxDecodeErrorLength is the maximum length of the error output returned
+// by CheckDecode.
+const maxDecodeErrorLength = 4096
+
+// DecodeCheckOptions are the options for CheckDecode.
+type DecodeCheckOptions struct {
+	// Seek is the position in seconds to start decoding from.
+	Seek float64
+	// Duration is the number of seconds to decode. If zero, the input is
+	// decoded to the end.
+	Duration float64
+}
+
+// CheckDecode decodes the input and discards the output. It returns the
+// errors reported by ffmpeg while decoding, or an empty string if the input
+// was decoded without errors. The returned error is non-nil only if ffmpeg
+// could not be run.
+func (f *FFMpeg) CheckDecode(ctx context.Context, input string, options DecodeCheckOptions) (string, error) {
+	var args Args
+	args = append(args, "-hide_banner", "-nostdin")
+	args = args.LogLevel(LogLevelError)
+	if options.Seek > 0 {
+		args = args.Seek(options.Seek)
+	}
+	args = args.Input(input)
+	if options.Duration > 0 {
+		args = args.Duration(options.Duration)
+	}
+	args = args.Format(FormatNull)
+	args = args.NullOutput()
+
+	cmd := f.Command(ctx, args)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	if ctx.Err() != nil {
+		return "", ctx.Err()
+	}
+
+	output := strings.TrimSpace(stderr.String())
+	if len(output) > maxDecodeErrorLength {
+		output = output[:maxDecodeErrorLength]
+	}
+
+	if err != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			return "", fmt.Errorf("error running ffmpeg command <%s>: %w", strings.Join(args, " "), err)
+		}
+
+		// ffmpeg ran but failed to decode the input
+		if output == "" {
+			output = exitErr.Error()
+		}
+	}
+
+	return output, nil
+}
@@ -264,6 +264,11 @@ func (f *FFProbe) GetReadFrameCount(path string) (int64, error) {
 	return fc.FrameCount, err
 }
 
+// IsURL returns true if the ffmpeg input is a URL rather than a file path.
+func IsURL(input string) bool {
+	return strings.Contains(input, "://")
+}
+
 func parse(filePath string, probeJSON *FFProbeJSON) (*VideoFile, error) {
 	if probeJSON == nil {
 		return nil, fmt.Errorf("failed to get ffprobe json for <%s>", filePath)
@@ -285,13 +290,17 @@ func parse(filePath string, probeJSON *FFProbeJSON) (*VideoFile, error) {
 	result.Container = probeJSON.Format.FormatName
 	duration, _ := strconv.ParseFloat(probeJSON.Format.Duration, 64)
 	result.FileDuration = math.Round(duration*100) / 100
-	fileStat, err := os.Stat(filePath)
-	if err != nil {
-		statErr := fmt.Errorf("error statting file <%s>: %w", filePath, err)
-		logger.Errorf("%v", statErr)
-		return nil, statErr
+	if IsURL(filePath) {
+		result.Size, _ = strconv.ParseInt(probeJSON.Format.Size, 10, 64)
+	} else {
+		fileStat, err := os.Stat(filePath)
+		if err != nil {
+			statErr := fmt.Errorf("error statting file <%s>: %w", filePath, err)
+			logger.Errorf("%v", statErr)
+			return nil, statErr
+		}
+		result.Size = fileStat.Size()
 	}
-	result.Size = fileStat.Size()
 	result.StartTime, _ = strconv.ParseFloat(probeJSON.Format.StartTime, 64)
 	result.CreationTime = probeJSON.Format.Tags.CreationTime.Time
 
@@ -336,6 +345,7 @@ func parse(filePath string, probeJSON *FFProbeJSON) (*VideoFile, error) {
 			result.Height = videoStream.Width
 		}
 
+		var err error
 		result.VideoStreamDuration, err = strconv.ParseFloat(videoStream.Duration, 64)
 		if err != nil {
 			// Revert to the historical behaviour, which is still correct in the vast majority of cases.
@@ -93,6 +93,12 @@ func (f VideoFilter) Select(frame int) VideoFilter {
 	return f.Append(fmt.Sprintf("select=eq(n\\,%d)", frame))
 }
 
+// ShowWavesPic returns a VideoFilter drawing the waveform of the audio input
+// as a single image with the given dimensions.
+func (f VideoFilter) ShowWavesPic(w, h int) VideoFilter {
+	return f.Append(fmt.Sprintf("showwavespic=s=%dx%d:split_channels=1", w, h))
+}
+
 // Append returns a VideoFilter appending the given string.
 func (f VideoFilter) Append(s string) VideoFilter {
 	// if filter is empty, then just set
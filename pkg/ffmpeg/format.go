@@ -20,6 +20,8 @@ var (
 	FormatMP4      Format = "mp4"
 	FormatWebm     Format = "webm"
 	FormatMatroska Format = "matroska"
+	FormatADTS     Format = "adts"
+	FormatNull     Format = "null"
 )
 
 // ImageFormat represents the input format for an image for ffmpeg.
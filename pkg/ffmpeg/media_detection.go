@@ -2,6 +2,8 @@ package ffmpeg
 
 import (
 	"bytes"
+	"errors"
+	"io"
 	"os"
 )
 
@@ -41,7 +43,14 @@ func containsMatroskaSignature(buf, subType []byte) bool {
 // webm only, as ffprobe can't distinguish between them and not all
 // browsers support mkv
 func magicContainer(filePath string) (Container, error) {
-	file, err := os.Open(filePath)
+	return magicContainerOpener(func() (io.ReadCloser, error) {
+		return os.Open(filePath)
+	})
+}
+
+// magicContainerOpener is magicContainer for the file opened by open.
+func magicContainerOpener(open func() (io.ReadCloser, error)) (Container, error) {
+	file, err := open()
 	if err != nil {
 		return "", err
 	}
@@ -49,10 +58,11 @@ func magicContainer(filePath string) (Container, error) {
 	defer file.Close()
 
 	buf := make([]byte, 4096)
-	_, err = file.Read(buf)
-	if err != nil {
+	n, err := io.ReadFull(file, buf)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
 		return "", err
 	}
+	buf = buf[:n]
 
 	if webm(buf) {
 		return Webm, nil
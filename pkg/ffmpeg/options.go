@@ -101,6 +101,16 @@ func (a Args) SkipAudio() Args {
 	return append(a, "-an")
 }
 
+// SkipVideo adds the skip video flag (-vn) and returns the result.
+func (a Args) SkipVideo() Args {
+	return append(a, "-vn")
+}
+
+// ComplexFilter adds the filter graph f (using -filter_complex) and returns the result.
+func (a Args) ComplexFilter(f VideoFilter) Args {
+	return append(a, "-filter_complex", string(f))
+}
+
 // VideoCodec adds the given video codec and returns the result.
 func (a Args) VideoCodec(c VideoCodec) Args {
 	return append(a, c.Args()...)
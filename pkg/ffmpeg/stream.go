@@ -18,6 +18,11 @@ const (
 	MimeMkvAudio  string = "audio/x-matroska"
 	MimeMp4Video  string = "video/mp4"
 	MimeMp4Audio  string = "audio/mp4"
+	MimeAACAudio  string = "audio/aac"
+	MimeMpegAudio string = "audio/mpeg"
+	MimeFlacAudio string = "audio/flac"
+	MimeOggAudio  string = "audio/ogg"
+	MimeWavAudio  string = "audio/wav"
 )
 
 type StreamManager struct {
@@ -33,6 +38,17 @@ type StreamManager struct {
 
 	runningStreams map[string]*runningStream
 	streamsMutex   sync.Mutex
+
+	// InputPath returns the ffmpeg input for the path of a video file. The
+	// path is used if nil.
+	InputPath func(path string) string
+}
+
+func (sm *StreamManager) input(path string) string {
+	if sm.InputPath != nil {
+		return sm.InputPath(path)
+	}
+	return path
 }
 
 type StreamManagerConfig interface {
@@ -343,7 +343,7 @@ func (s *runningStream) makeStreamArgs(sm *StreamManager, segment int) Args {
 		args = args.Seek(float64(segment * segmentLength))
 	}
 
-	args = args.Input(s.vf.Path)
+	args = args.Input(sm.input(s.vf.Path))
 
 	videoOnly := ProbeAudioCodec(s.vf.AudioCodec) == MissingUnsupported
 
@@ -419,7 +419,7 @@ func serveHLSManifest(sm *StreamManager, w http.ResponseWriter, r *http.Request,
 		return
 	}
 
-	probeResult, err := sm.ffprobe.NewVideoFile(vf.Path)
+	probeResult, err := sm.ffprobe.NewVideoFile(sm.input(vf.Path))
 	if err != nil {
 		logger.Warnf("[transcode] error generating HLS manifest: %v", err)
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -486,7 +486,7 @@ func serveDASHManifest(sm *StreamManager, w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	probeResult, err := sm.ffprobe.NewVideoFile(vf.Path)
+	probeResult, err := sm.ffprobe.NewVideoFile(sm.input(vf.Path))
 	if err != nil {
 		logger.Warnf("[transcode] error generating DASH manifest: %v", err)
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -139,6 +139,18 @@ var (
 			return
 		},
 	}
+	// StreamTypeAAC transcodes the audio stream only, for audio-only files.
+	StreamTypeAAC = StreamFormat{
+		MimeType: MimeAACAudio,
+		Args: func(codec VideoCodec, videoFilter VideoFilter, videoOnly bool) (args Args) {
+			args = args.SkipVideo()
+			args = args.AudioCodec(AudioCodecAAC)
+			args = args.AudioBitrate("192k")
+			args = append(args, "-ac", "2")
+			args = args.Format(FormatADTS)
+			return
+		},
+	}
 )
 
 type TranscodeOptions struct {
@@ -176,7 +188,7 @@ func (o TranscodeOptions) FileGetCodec(sm *StreamManager, maxTranscodeSize int)
 		if hwcodec := sm.encoder.hwCodecWEBMCompatible(); hwcodec != nil && sm.config.GetTranscodeHardwareAcceleration() {
 			codec = *hwcodec
 		}
-	case MimeMkvVideo:
+	case MimeMkvVideo, MimeAACAudio:
 		codec = VideoCodecCopy
 	}
 
@@ -204,7 +216,7 @@ func (o TranscodeOptions) makeStreamArgs(sm *StreamManager) Args {
 		args = args.Seek(o.StartTime)
 	}
 
-	args = args.Input(o.VideoFile.Path)
+	args = args.Input(sm.input(o.VideoFile.Path))
 
 	videoOnly := ProbeAudioCodec(o.VideoFile.AudioCodec) == MissingUnsupported
 
@@ -0,0 +1,14 @@
+package ffmpeg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreamTypeAAC_Args(t *testing.T) {
+	assert.Equal(t, MimeAACAudio, StreamTypeAAC.MimeType)
+
+	got := StreamTypeAAC.Args(VideoCodecCopy, "", false)
+	assert.Equal(t, Args{"-vn", "-c:a", "aac", "-b:a", "192k", "-ac", "2", "-f", "adts"}, got)
+}
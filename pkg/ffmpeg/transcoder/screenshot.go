@@ -107,3 +107,36 @@ func ScreenshotFrame(input string, frame int, options ScreenshotOptions) ffmpeg.
 
 	return args
 }
+
+// ScreenshotWaveform draws the waveform of an audio file as a single image.
+// options.Width is the width of the image, which defaults to 1280. The image
+// has a 16:9 aspect ratio.
+func ScreenshotWaveform(input string, options ScreenshotOptions) ffmpeg.Args {
+	options.setDefaults()
+
+	width := options.Width
+	if width <= 0 {
+		width = 1280
+	}
+	height := width * 9 / 16
+
+	var args ffmpeg.Args
+	args = args.LogLevel(options.Verbosity)
+	args = args.Overwrite()
+
+	args = args.Input(input)
+
+	var vf ffmpeg.VideoFilter
+	vf = vf.ShowWavesPic(width, height)
+	args = args.ComplexFilter(vf)
+	args = args.VideoFrames(1)
+
+	if options.Quality > 0 {
+		args = args.FixedQualityScaleVideo(options.Quality)
+	}
+
+	args = args.AppendArgs(options.OutputType)
+	args = args.Output(options.OutputPath)
+
+	return args
+}
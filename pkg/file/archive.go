@@ -0,0 +1,306 @@
+package file
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+var errNoParentArchive = errors.New("path is not within an archive")
+
+type archiveFormat int
+
+const (
+	archiveFormatZip archiveFormat = iota
+	archiveFormat7z
+	archiveFormatRar
+	archiveFormatTar
+)
+
+const tarMagicOffset = 257
+
+var (
+	zipMagic      = []byte("PK\x03\x04")
+	zipEmptyMagic = []byte("PK\x05\x06")
+	sevenZipMagic = []byte("7z\xBC\xAF\x27\x1C")
+	rarMagic      = []byte("Rar!\x1A\x07")
+	tarMagic      = []byte("ustar")
+)
+
+// detectArchiveFormat detects the format of the archive from its signature.
+// Files with an unknown signature are treated as zip files.
+func detectArchiveFormat(r io.ReaderAt) archiveFormat {
+	header := make([]byte, tarMagicOffset+len(tarMagic))
+	n, _ := r.ReadAt(header, 0)
+	header = header[:n]
+
+	switch {
+	case bytes.HasPrefix(header, zipMagic) || bytes.HasPrefix(header, zipEmptyMagic):
+		return archiveFormatZip
+	case bytes.HasPrefix(header, sevenZipMagic):
+		return archiveFormat7z
+	case bytes.HasPrefix(header, rarMagic):
+		return archiveFormatRar
+	case len(header) >= tarMagicOffset+len(tarMagic) && bytes.Equal(header[tarMagicOffset:], tarMagic):
+		return archiveFormatTar
+	}
+
+	return archiveFormatZip
+}
+
+type readerAtCloser interface {
+	io.ReaderAt
+	io.Closer
+}
+
+// archiveFS is a read-only file system backed by an archive file. Paths are
+// the path of the archive file joined with the path within the archive.
+// Zip, 7z, rar and tar archives are supported.
+type archiveFS struct {
+	fs.FS
+	archivePath string
+
+	// closers are closed in order when the file system is closed
+	closers []io.Closer
+}
+
+// openArchiveFS opens the archive file at path in f. If the path is within
+// another archive, then the containing archives are opened first.
+func openArchiveFS(f models.FS, path string, size int64) (*archiveFS, error) {
+	reader, err := f.Open(path)
+	if err != nil {
+		parent, parentErr := openParentArchiveFS(f, path)
+		if parentErr != nil {
+			if !errors.Is(parentErr, errNoParentArchive) {
+				logger.Debugf("Error opening parent archive of %s: %v", path, parentErr)
+			}
+			return nil, err
+		}
+
+		ret, err := openArchiveFS(parent, path, size)
+		if err != nil {
+			parent.Close()
+			return nil, err
+		}
+
+		ret.closers = append(ret.closers, parent)
+		return ret, nil
+	}
+
+	if info, err := reader.Stat(); err == nil {
+		size = info.Size()
+	}
+
+	var (
+		r         readerAtCloser
+		localPath = path
+		localFS   = f
+	)
+
+	if asReaderAt, ok := reader.(readerAtCloser); ok {
+		r = asReaderAt
+	} else {
+		// archives within archives are extracted to a temporary file
+		tmp, err := spoolToTempFile(reader)
+		reader.Close()
+		if err != nil {
+			return nil, fmt.Errorf("extracting %s: %w", path, err)
+		}
+
+		r = tmp
+		localPath = tmp.Name()
+		localFS = &OsFS{}
+	}
+
+	ret, err := newArchiveFS(localFS, localPath, r, size, path)
+	if err != nil {
+		r.Close()
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func newArchiveFS(f models.FS, localPath string, r readerAtCloser, size int64, path string) (*archiveFS, error) {
+	var (
+		fsys fs.FS
+		err  error
+	)
+
+	switch detectArchiveFormat(r) {
+	case archiveFormat7z:
+		fsys, err = newSevenZipReader(r, size)
+	case archiveFormatRar:
+		fsys, err = newRarReader(f, localPath)
+	case archiveFormatTar:
+		fsys, err = newTarReader(r, size)
+	default:
+		fsys, err = newZipReader(r, size, path)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &archiveFS{
+		FS:          fsys,
+		archivePath: path,
+		closers:     []io.Closer{r},
+	}, nil
+}
+
+// openParentArchiveFS opens the closest archive file containing path.
+func openParentArchiveFS(f models.FS, path string) (*archiveFS, error) {
+	for dir := filepath.Dir(path); dir != filepath.Dir(dir); dir = filepath.Dir(dir) {
+		info, err := f.Stat(dir)
+		if err != nil {
+			continue
+		}
+
+		if info.IsDir() {
+			return nil, errNoParentArchive
+		}
+
+		return openArchiveFS(f, dir, info.Size())
+	}
+
+	return nil, errNoParentArchive
+}
+
+type tempFile struct {
+	*os.File
+}
+
+func spoolToTempFile(r io.Reader) (*tempFile, error) {
+	f, err := os.CreateTemp("", "stash-archive-*")
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &tempFile{File: f}
+	if _, err := io.Copy(f, r); err != nil {
+		ret.Close()
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// Close closes and removes the temporary file.
+func (f *tempFile) Close() error {
+	err := f.File.Close()
+	if removeErr := os.Remove(f.Name()); removeErr != nil && err == nil {
+		err = removeErr
+	}
+
+	return err
+}
+
+func (f *archiveFS) rel(name string) (string, error) {
+	if f.archivePath == name {
+		return ".", nil
+	}
+
+	relName, err := filepath.Rel(f.archivePath, name)
+	if err != nil {
+		return "", fmt.Errorf("internal error getting relative path: %w", err)
+	}
+
+	// convert relName to use slash, since archives do so regardless of os
+	relName = filepath.ToSlash(relName)
+
+	return relName, nil
+}
+
+func (f *archiveFS) Stat(name string) (fs.FileInfo, error) {
+	reader, err := f.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	return reader.Stat()
+}
+
+func (f *archiveFS) Lstat(name string) (fs.FileInfo, error) {
+	return f.Stat(name)
+}
+
+// OpenZip opens an archive within this archive.
+func (f *archiveFS) OpenZip(name string, size int64) (models.ZipFS, error) {
+	return openArchiveFS(f, name, size)
+}
+
+func (f *archiveFS) IsPathCaseSensitive(path string) (bool, error) {
+	return true, nil
+}
+
+type archiveReadDirFile struct {
+	fs.File
+}
+
+func (f *archiveReadDirFile) ReadDir(n int) ([]fs.DirEntry, error) {
+	asReadDirFile, _ := f.File.(fs.ReadDirFile)
+	if asReadDirFile == nil {
+		return nil, fmt.Errorf("internal error: not a ReadDirFile")
+	}
+
+	return asReadDirFile.ReadDir(n)
+}
+
+func (f *archiveFS) Open(name string) (fs.ReadDirFile, error) {
+	relName, err := f.rel(name)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := f.FS.Open(relName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &archiveReadDirFile{
+		File: r,
+	}, nil
+}
+
+func (f *archiveFS) Close() error {
+	var ret error
+	for _, c := range f.closers {
+		if err := c.Close(); err != nil && ret == nil {
+			ret = err
+		}
+	}
+
+	return ret
+}
+
+// OpenOnly returns a ReadCloser where calling Close will close the archive fs as well.
+func (f *archiveFS) OpenOnly(name string) (io.ReadCloser, error) {
+	r, err := f.Open(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &wrappedReadCloser{
+		ReadCloser: r,
+		outer:      f,
+	}, nil
+}
+
+type wrappedReadCloser struct {
+	io.ReadCloser
+	outer io.Closer
+}
+
+func (f *wrappedReadCloser) Close() error {
+	_ = f.ReadCloser.Close()
+	return f.outer.Close()
+}
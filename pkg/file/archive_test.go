@@ -0,0 +1,148 @@
+package file
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var archiveTestFiles = map[string]string{
+	"a.jpg":     "image a",
+	"sub/b.jpg": "image b",
+}
+
+func writeTestZip(t *testing.T, w io.Writer, files map[string]string) {
+	zw := zip.NewWriter(w)
+	for name, content := range files {
+		fw, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := fw.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func writeTestTar(t *testing.T, w io.Writer, files map[string]string) {
+	tw := tar.NewWriter(w)
+	for name, content := range files {
+		if err := tw.WriteHeader(&tar.Header{
+			Name:     name,
+			Mode:     0644,
+			Size:     int64(len(content)),
+			Typeflag: tar.TypeReg,
+		}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func writeTestFile(t *testing.T, path string, write func(t *testing.T, w io.Writer, files map[string]string), files map[string]string) {
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	write(t, f, files)
+}
+
+func assertArchiveContents(t *testing.T, archivePath string) {
+	t.Helper()
+
+	osFS := &OsFS{}
+	zfs, err := osFS.OpenZip(archivePath, 0)
+	if err != nil {
+		t.Fatalf("OpenZip() error = %v", err)
+	}
+	defer zfs.Close()
+
+	var walked []string
+	if err := symWalk(zfs, archivePath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			walked = append(walked, path)
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("symWalk() error = %v", err)
+	}
+
+	assert.ElementsMatch(t, []string{
+		filepath.Join(archivePath, "a.jpg"),
+		filepath.Join(archivePath, "sub", "b.jpg"),
+	}, walked)
+
+	for name, content := range archiveTestFiles {
+		p := filepath.Join(archivePath, filepath.FromSlash(name))
+
+		info, err := zfs.Stat(p)
+		if err != nil {
+			t.Fatalf("Stat(%q) error = %v", p, err)
+		}
+		assert.Equal(t, int64(len(content)), info.Size())
+
+		r, err := zfs.Open(p)
+		if err != nil {
+			t.Fatalf("Open(%q) error = %v", p, err)
+		}
+		got, err := io.ReadAll(r)
+		r.Close()
+		if err != nil {
+			t.Fatalf("reading %q: %v", p, err)
+		}
+		assert.Equal(t, content, string(got))
+	}
+}
+
+func TestOpenArchiveFS(t *testing.T) {
+	dir := t.TempDir()
+
+	zipPath := filepath.Join(dir, "gallery.zip")
+	writeTestFile(t, zipPath, writeTestZip, archiveTestFiles)
+
+	tarPath := filepath.Join(dir, "gallery.cbt")
+	writeTestFile(t, tarPath, writeTestTar, archiveTestFiles)
+
+	t.Run("zip", func(t *testing.T) {
+		assertArchiveContents(t, zipPath)
+	})
+
+	t.Run("tar", func(t *testing.T) {
+		assertArchiveContents(t, tarPath)
+	})
+}
+
+func TestOpenArchiveFS_nested(t *testing.T) {
+	dir := t.TempDir()
+
+	var inner bytes.Buffer
+	writeTestTar(t, &inner, archiveTestFiles)
+
+	outerPath := filepath.Join(dir, "outer.zip")
+	writeTestFile(t, outerPath, writeTestZip, map[string]string{
+		"inner.tar": inner.String(),
+	})
+
+	// nested archives are opened from the outer archive
+	assertArchiveContents(t, filepath.Join(outerPath, "inner.tar"))
+}
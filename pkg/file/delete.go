@@ -63,10 +63,19 @@ func newRenamerRemoverImpl() renamerRemoverImpl {
 // be restored to their original state with the Abort method. If the
 // transaction is committed, the marked files are then deleted from the
 // filesystem using the Complete method.
+//
+// If Trash is set, then committed files in a library root are moved to the
+// trash instead of being deleted.
 type Deleter struct {
 	RenamerRemover RenamerRemover
-	files          []string
-	dirs           []string
+
+	Trash *Trash
+	// TrashData is stored in the trash manifest. It is used to restore
+	// anything that was deleted along with the files.
+	TrashData interface{}
+
+	files []string
+	dirs  []string
 }
 
 func NewDeleter() *Deleter {
@@ -152,11 +161,17 @@ func (d *Deleter) Rollback() {
 	d.dirs = nil
 }
 
-// Commit deletes all files marked for deletion and clears the marked list.
+// Commit deletes all files marked for deletion, or moves them to the trash if
+// set, and clears the marked list.
 // Any errors encountered are logged. All files will be attempted, regardless
 // of the errors encountered.
 func (d *Deleter) Commit() {
-	for _, f := range d.files {
+	files := d.files
+	if d.Trash != nil && len(files) > 0 {
+		files = d.commitToTrash(files)
+	}
+
+	for _, f := range files {
 		if err := d.RenamerRemover.Remove(f + deleteFileSuffix); err != nil {
 			logger.Warnf("Error deleting file %q: %v", f+deleteFileSuffix, err)
 		}
@@ -172,6 +187,42 @@ func (d *Deleter) Commit() {
 	d.dirs = nil
 }
 
+// commitToTrash moves the marked files to the trash. Returns the files that
+// are not in a library root, which should be deleted.
+func (d *Deleter) commitToTrash(files []string) []string {
+	toTrash := make(map[string]string)
+	for _, f := range files {
+		toTrash[f] = f + deleteFileSuffix
+	}
+
+	notTrashed, failed, err := d.Trash.moveToTrash(toTrash, d.TrashData)
+	if err != nil {
+		// files meant for the trash must not be deleted, so restore any
+		// that were not moved. They will be picked up by the next scan.
+		logger.Errorf("Error moving files to trash: %v", err)
+		for _, f := range files {
+			if _, err := d.RenamerRemover.Stat(f + deleteFileSuffix); err != nil {
+				continue
+			}
+
+			if err := d.renameForRestore(f); err != nil {
+				logger.Warnf("Error restoring %q: %v", f, err)
+			}
+		}
+
+		return nil
+	}
+
+	// restore the files that could not be moved, as above
+	for _, f := range failed {
+		if err := d.renameForRestore(f); err != nil {
+			logger.Warnf("Error restoring %q: %v", f, err)
+		}
+	}
+
+	return notTrashed
+}
+
 func (d *Deleter) renameForDelete(path string) error {
 	return d.RenamerRemover.Rename(path, path+deleteFileSuffix)
 }
@@ -0,0 +1,65 @@
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stashapp/stash/pkg/fsutil"
+)
+
+func TestDeleter_CommitToTrash(t *testing.T) {
+	root := t.TempDir()
+	trashed := filepath.Join(root, "a.mp4")
+	failed := filepath.Join(root, "b.mp4")
+	outside := filepath.Join(t.TempDir(), "c.mp4")
+
+	for _, f := range []string{trashed, failed, outside} {
+		writeFileContent(t, f, f)
+	}
+
+	var renamed [][2]string
+	var removed []string
+	rr := newRenamerRemoverImpl()
+	rr.RenameFn = func(oldpath, newpath string) error {
+		renamed = append(renamed, [2]string{oldpath, newpath})
+		return fsutil.SafeMove(oldpath, newpath)
+	}
+	rr.RemoveFn = func(name string) error {
+		removed = append(removed, name)
+		return os.Remove(name)
+	}
+
+	d := &Deleter{
+		RenamerRemover: rr,
+		Trash:          &Trash{Roots: []string{root}},
+	}
+
+	if err := d.Files([]string{trashed, failed, outside}); err != nil {
+		t.Fatalf("Files() error = %v", err)
+	}
+
+	// remove the renamed file so that moving it to the trash fails
+	if err := os.Remove(failed + deleteFileSuffix); err != nil {
+		t.Fatal(err)
+	}
+	renamed = nil
+
+	d.Commit()
+
+	// the file that could not be moved is restored rather than left renamed
+	assert.Equal(t, [][2]string{{failed + deleteFileSuffix, failed}}, renamed)
+	// only the file outside the library roots is deleted
+	assert.Equal(t, []string{outside + deleteFileSuffix}, removed)
+	assertNotExists(t, outside+deleteFileSuffix)
+
+	entries, err := d.Trash.List()
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if assert.Len(t, entries, 1) && assert.Len(t, entries[0].Files, 1) {
+		assert.Equal(t, trashed, entries[0].Files[0].OriginalPath)
+	}
+}
@@ -0,0 +1,138 @@
+package file
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// FingerprintXattr is the name of the extended attribute that fingerprints
+// are cached in.
+const FingerprintXattr = "user.stash.fingerprints"
+
+// cachedFingerprints is the value of the fingerprint extended attribute.
+// Fingerprints are only valid while the modification time and size of the
+// file are unchanged.
+type cachedFingerprints struct {
+	ModTime int64 `json:"mtime"`
+	Size    int64 `json:"size"`
+	// Fingerprints maps fingerprint type to value. Phashes are stored in
+	// hexadecimal.
+	Fingerprints map[string]string `json:"fingerprints"`
+}
+
+func (c *cachedFingerprints) matches(f *models.BaseFile) bool {
+	return c.ModTime == f.ModTime.Unix() && c.Size == f.Size
+}
+
+func readFingerprintXattr(path string) (*cachedFingerprints, error) {
+	data, err := fsutil.GetXattr(path, FingerprintXattr)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret cachedFingerprints
+	if err := json.Unmarshal(data, &ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// isCachable returns true if fingerprints can be cached for the file. Only
+// files on the OS file system that are not in zip files can have extended
+// attributes.
+func isCachable(fs models.FS, f *models.BaseFile) bool {
+	if f.ZipFileID != nil || f.ZipFile != nil {
+		return false
+	}
+
+	if mfs, ok := fs.(*MountFS); ok {
+		fs = mfs.Mounted(f.Path)
+	}
+
+	_, isOs := fs.(*OsFS)
+	return isOs
+}
+
+// ReadCachedFingerprints returns the fingerprints cached in the extended
+// attributes of the file. Nil is returned if there are no cached
+// fingerprints, or if the file has been modified since they were cached.
+func ReadCachedFingerprints(f *models.BaseFile) models.Fingerprints {
+	if f.ZipFileID != nil || f.ZipFile != nil {
+		return nil
+	}
+
+	c, err := readFingerprintXattr(f.Path)
+	if err != nil || !c.matches(f) {
+		return nil
+	}
+
+	var ret models.Fingerprints
+	for t, v := range c.Fingerprints {
+		fp := models.Fingerprint{
+			Type:        t,
+			Fingerprint: v,
+		}
+
+		if t == models.FingerprintTypePhash {
+			phash, err := strconv.ParseUint(v, 16, 64)
+			if err != nil {
+				continue
+			}
+			fp.Fingerprint = int64(phash)
+		}
+
+		ret = append(ret, fp)
+	}
+
+	return ret
+}
+
+// WriteCachedFingerprints caches the fingerprints in the extended attributes
+// of the file. Cached fingerprints of other types are kept if the file has
+// not been modified since they were cached.
+func WriteCachedFingerprints(f *models.BaseFile, fp models.Fingerprints) error {
+	if f.ZipFileID != nil || f.ZipFile != nil || len(fp) == 0 {
+		return nil
+	}
+
+	c, err := readFingerprintXattr(f.Path)
+	if err != nil || !c.matches(f) {
+		c = &cachedFingerprints{
+			ModTime:      f.ModTime.Unix(),
+			Size:         f.Size,
+			Fingerprints: make(map[string]string),
+		}
+	}
+
+	changed := false
+	for _, v := range fp {
+		value := v.Value()
+		if c.Fingerprints[v.Type] != value {
+			c.Fingerprints[v.Type] = value
+			changed = true
+		}
+	}
+
+	if !changed {
+		return nil
+	}
+
+	data, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+
+	return fsutil.SetXattr(f.Path, FingerprintXattr, data)
+}
+
+// cachedFingerprintsFile returns a copy of f with the cached fingerprints
+// of the file, for use by FingerprintCalculator.
+func cachedFingerprintsFile(f *models.BaseFile) *models.BaseFile {
+	ret := f.Clone().(*models.BaseFile)
+	ret.Fingerprints = ReadCachedFingerprints(f)
+	return ret
+}
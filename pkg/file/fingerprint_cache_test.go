@@ -0,0 +1,74 @@
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCachedFingerprints(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "video.mp4")
+	if err := os.WriteFile(path, []byte("video"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := fsutil.SetXattr(path, FingerprintXattr, []byte("{}")); err != nil {
+		t.Skipf("extended attributes not supported: %v", err)
+	}
+
+	modTime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	f := &models.BaseFile{
+		Path: path,
+		DirEntry: models.DirEntry{
+			ModTime: modTime,
+		},
+		Size: 5,
+	}
+
+	const phash = int64(-0x123456789abcdef)
+
+	if err := WriteCachedFingerprints(f, models.Fingerprints{
+		{Type: models.FingerprintTypeOshash, Fingerprint: "oshash"},
+		{Type: models.FingerprintTypeMD5, Fingerprint: "md5"},
+	}); err != nil {
+		t.Fatalf("WriteCachedFingerprints() error = %v", err)
+	}
+
+	// cached fingerprints of other types are kept
+	if err := WriteCachedFingerprints(f, models.Fingerprints{
+		{Type: models.FingerprintTypePhash, Fingerprint: phash},
+	}); err != nil {
+		t.Fatalf("WriteCachedFingerprints() error = %v", err)
+	}
+
+	assert.ElementsMatch(t, models.Fingerprints{
+		{Type: models.FingerprintTypeOshash, Fingerprint: "oshash"},
+		{Type: models.FingerprintTypeMD5, Fingerprint: "md5"},
+		{Type: models.FingerprintTypePhash, Fingerprint: phash},
+	}, ReadCachedFingerprints(f))
+
+	// cached fingerprints are ignored if the file is modified
+	modified := *f
+	modified.ModTime = modTime.Add(time.Second)
+	assert.Nil(t, ReadCachedFingerprints(&modified))
+
+	modified = *f
+	modified.Size = 6
+	assert.Nil(t, ReadCachedFingerprints(&modified))
+
+	// cached fingerprints are replaced if the file is modified
+	if err := WriteCachedFingerprints(&modified, models.Fingerprints{
+		{Type: models.FingerprintTypeOshash, Fingerprint: "oshash2"},
+	}); err != nil {
+		t.Fatalf("WriteCachedFingerprints() error = %v", err)
+	}
+
+	assert.Equal(t, models.Fingerprints{
+		{Type: models.FingerprintTypeOshash, Fingerprint: "oshash2"},
+	}, ReadCachedFingerprints(&modified))
+}
@@ -1,6 +1,7 @@
 package file
 
 import (
+	"fmt"
 	"io"
 	"io/fs"
 	"os"
@@ -59,9 +60,85 @@ func (f *OsFS) Open(name string) (fs.ReadDirFile, error) {
 }
 
 func (f *OsFS) OpenZip(name string, size int64) (models.ZipFS, error) {
-	return newZipFS(f, name, size)
+	return openArchiveFS(f, name, size)
 }
 
 func (f *OsFS) IsPathCaseSensitive(path string) (bool, error) {
 	return fsutil.IsFsPathCaseSensitive(path)
 }
+
+// OpenArchiveFS opens the archive file at path in f as a read-only file
+// system. It is intended for models.FS implementations outside of this
+// package.
+func OpenArchiveFS(f models.FS, path string, size int64) (models.ZipFS, error) {
+	return openArchiveFS(f, path, size)
+}
+
+// Mount is a file system serving the files under Path.
+type Mount struct {
+	Path string
+	FS   models.FS
+}
+
+// MountFS is a file system that serves paths within a mount path from the
+// mounted file system. All other paths are served by the OS.
+type MountFS struct {
+	OsFS
+	Mounts []Mount
+}
+
+// Mounted returns the file system serving the named file.
+func (f *MountFS) Mounted(name string) models.FS {
+	for _, m := range f.Mounts {
+		if fsutil.IsPathInDir(m.Path, name) {
+			return m.FS
+		}
+	}
+
+	return &f.OsFS
+}
+
+func (f *MountFS) Stat(name string) (fs.FileInfo, error) {
+	return f.Mounted(name).Stat(name)
+}
+
+func (f *MountFS) Lstat(name string) (fs.FileInfo, error) {
+	return f.Mounted(name).Lstat(name)
+}
+
+func (f *MountFS) Open(name string) (fs.ReadDirFile, error) {
+	return f.Mounted(name).Open(name)
+}
+
+func (f *MountFS) OpenZip(name string, size int64) (models.ZipFS, error) {
+	return f.Mounted(name).OpenZip(name, size)
+}
+
+func (f *MountFS) IsPathCaseSensitive(path string) (bool, error) {
+	return f.Mounted(path).IsPathCaseSensitive(path)
+}
+
+// URLProvider is implemented by remote file systems that can provide a URL
+// for ffmpeg and ffprobe to read a file from.
+type URLProvider interface {
+	URL(name string) (string, error)
+}
+
+// FFMpegInput returns the input for ffmpeg and ffprobe to read the named file
+// in f. This is the path for files on the OS file system, or a URL for
+// remote files. An error is returned if ffmpeg cannot read the file, for
+// example if the file is within a zip file.
+func FFMpegInput(f models.FS, name string) (string, error) {
+	if mfs, ok := f.(*MountFS); ok {
+		f = mfs.Mounted(name)
+	}
+
+	switch ff := f.(type) {
+	case *OsFS:
+		return name, nil
+	case URLProvider:
+		return ff.URL(name)
+	}
+
+	return "", fmt.Errorf("%s cannot be read by ffmpeg", name)
+}
@@ -25,14 +25,15 @@ type Decorator struct {
 func (d *Decorator) Decorate(ctx context.Context, fs models.FS, f models.File) (models.File, error) {
 	base := f.Base()
 
-	// ignore clips in non-OsFS filesystems as ffprobe cannot read them
-	// TODO - copy to temp file if not an OsFS
-	if _, isOs := fs.(*file.OsFS); !isOs {
-		logger.Debugf("assuming ImageFile for non-OsFS file %q", base.Path)
+	// ignore clips in file systems that ffprobe cannot read
+	// TODO - copy to temp file if ffmpeg cannot read the file
+	input, err := file.FFMpegInput(fs, base.Path)
+	if err != nil {
+		logger.Debugf("assuming ImageFile for file %q: %v", base.Path, err)
 		return decorateFallback(fs, f)
 	}
 
-	probe, err := d.FFProbe.NewVideoFile(base.Path)
+	probe, err := d.FFProbe.NewVideoFile(input)
 	if err != nil {
 		logger.Warnf("File %q could not be read with ffprobe: %s, assuming ImageFile", base.Path, err)
 		return decorateFallback(fs, f)
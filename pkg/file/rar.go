@@ -0,0 +1,25 @@
+package file
+
+import (
+	"io/fs"
+
+	"github.com/nwaples/rardecode/v2"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// rarVolumeFS adapts a models.FS so that rardecode can open the volumes of
+// multi-volume archives. Names are file system paths rather than slash
+// separated fs.FS paths.
+type rarVolumeFS struct {
+	fs models.FS
+}
+
+func (f rarVolumeFS) Open(name string) (fs.File, error) {
+	return f.fs.Open(name)
+}
+
+// newRarReader returns a reader for the rar archive at path in f. The
+// archive volumes are opened as needed, so there is nothing to close.
+func newRarReader(f models.FS, path string) (fs.FS, error) {
+	return rardecode.OpenFS(path, rardecode.FileSystem(rarVolumeFS{fs: f}))
+}
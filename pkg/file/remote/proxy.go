@@ -0,0 +1,188 @@
+package remote
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// proxy serves the files of remote file systems over HTTP on the loopback
+// interface. ffmpeg and ffprobe read remote files through the proxy, so that
+// the remote credentials are never part of a command line, where they would
+// be visible in the process list and in logged commands.
+//
+// Requests must include a token that is generated when the proxy is started,
+// so that other local users cannot read library files through the proxy.
+type proxy struct {
+	startOnce sync.Once
+	startErr  error
+	host      string
+	token     string
+
+	mu     sync.Mutex
+	ids    map[models.FS]string
+	mounts map[string]proxiedFS
+	nextID int
+}
+
+type proxiedFS struct {
+	fs   models.FS
+	path string
+}
+
+var defaultProxy = &proxy{}
+
+func (p *proxy) start() error {
+	p.startOnce.Do(func() {
+		token := make([]byte, 16)
+		if _, err := rand.Read(token); err != nil {
+			p.startErr = fmt.Errorf("generating proxy token: %w", err)
+			return
+		}
+
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			p.startErr = fmt.Errorf("starting remote file proxy: %w", err)
+			return
+		}
+
+		p.host = ln.Addr().String()
+		p.token = hex.EncodeToString(token)
+
+		srv := &http.Server{
+			Handler:           p,
+			ReadHeaderTimeout: 30 * time.Second,
+		}
+
+		go func() {
+			if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				logger.Errorf("Remote file proxy stopped: %v", err)
+			}
+		}()
+	})
+
+	return p.startErr
+}
+
+// fileURL returns the proxy URL of the named file in f. m is the mount of f.
+func (p *proxy) fileURL(f models.FS, m mount, name string) (string, error) {
+	rel, err := m.relPath(name)
+	if err != nil {
+		return "", err
+	}
+
+	if err := p.start(); err != nil {
+		return "", err
+	}
+
+	id := p.register(f, m.path)
+
+	u := url.URL{
+		Scheme: "http",
+		Host:   p.host,
+		Path:   "/" + p.token + "/" + id + "/" + rel,
+	}
+	return u.String(), nil
+}
+
+func (p *proxy) register(f models.FS, path string) string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if id, found := p.ids[f]; found {
+		return id
+	}
+
+	if p.ids == nil {
+		p.ids = make(map[models.FS]string)
+		p.mounts = make(map[string]proxiedFS)
+	}
+
+	p.nextID++
+	id := strconv.Itoa(p.nextID)
+	p.ids[f] = id
+	p.mounts[id] = proxiedFS{fs: f, path: path}
+	return id
+}
+
+// unregister stops serving the files of f.
+func (p *proxy) unregister(f models.FS) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if id, found := p.ids[f]; found {
+		delete(p.ids, f)
+		delete(p.mounts, id)
+	}
+}
+
+func (p *proxy) lookup(id string) (proxiedFS, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	ret, found := p.mounts[id]
+	return ret, found
+}
+
+func (p *proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// path is /<token>/<id>/<path relative to the mount>
+	parts := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/"), "/", 3)
+	if len(parts) != 3 || subtle.ConstantTimeCompare([]byte(parts[0]), []byte(p.token)) != 1 || !fs.ValidPath(parts[2]) {
+		http.NotFound(w, r)
+		return
+	}
+
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	m, found := p.lookup(parts[1])
+	if !found {
+		http.NotFound(w, r)
+		return
+	}
+
+	name := filepath.Join(m.path, filepath.FromSlash(parts[2]))
+	f, err := m.fs.Open(name)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			http.NotFound(w, r)
+			return
+		}
+
+		logger.Warnf("Error opening remote file %s: %v", name, err)
+		http.Error(w, http.StatusText(http.StatusBadGateway), http.StatusBadGateway)
+		return
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil || info.IsDir() {
+		http.NotFound(w, r)
+		return
+	}
+
+	rs, ok := f.(io.ReadSeeker)
+	if !ok {
+		http.Error(w, "file does not support seeking", http.StatusInternalServerError)
+		return
+	}
+
+	http.ServeContent(w, r, info.Name(), info.ModTime(), rs)
+}
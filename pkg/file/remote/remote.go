@@ -0,0 +1,179 @@
+// Package remote provides file systems that serve library files from remote
+// servers over SFTP and WebDAV.
+package remote
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// Config is the configuration of a remote file system.
+type Config struct {
+	// Path is the local path that the remote files are served under.
+	Path string
+	// URL is the location of the remote root directory. The scheme must be
+	// sftp, http or https.
+	URL      string
+	Username string
+	Password string
+	// PrivateKeyPath is the path to a private key used for SFTP
+	// authentication.
+	PrivateKeyPath string
+	// HostKey is the expected SFTP host key in authorized_keys format. If
+	// empty, the host key is not verified.
+	HostKey string
+}
+
+// FS is a remote file system.
+type FS interface {
+	models.FS
+	file.URLProvider
+	io.Closer
+}
+
+// New returns the remote file system for the configuration. Connections are
+// established lazily.
+func New(c Config) (FS, error) {
+	u, err := url.Parse(c.URL)
+	if err != nil {
+		return nil, fmt.Errorf("parsing url: %w", err)
+	}
+
+	switch u.Scheme {
+	case "sftp":
+		return newSFTPFS(c, u)
+	case "http", "https":
+		return newWebDAVFS(c, u), nil
+	}
+
+	return nil, fmt.Errorf("unsupported remote url scheme %q", u.Scheme)
+}
+
+// mount maps local paths under path to remote paths under root.
+type mount struct {
+	path string
+	root string
+}
+
+// relPath returns the slash separated path of name relative to the local
+// path.
+func (m mount) relPath(name string) (string, error) {
+	rel, err := filepath.Rel(m.path, name)
+	if err != nil {
+		return "", err
+	}
+
+	rel = filepath.ToSlash(rel)
+	if rel == ".." || strings.HasPrefix(rel, "../") {
+		return "", fmt.Errorf("%s is not in %s", name, m.path)
+	}
+
+	return rel, nil
+}
+
+func (m mount) remotePath(name string) (string, error) {
+	rel, err := m.relPath(name)
+	if err != nil {
+		return "", err
+	}
+
+	return path.Join(m.root, rel), nil
+}
+
+// pathError returns the error for an operation on a remote file. Only
+// not-found errors are returned as *fs.PathError, since the clean task
+// treats path errors as missing files and connection errors must not cause
+// files to be removed from the library.
+func pathError(op string, name string, err error) error {
+	if errors.Is(err, fs.ErrNotExist) {
+		return &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+	}
+
+	return fmt.Errorf("%s %s: %v", op, name, err)
+}
+
+type fileInfo struct {
+	name    string
+	size    int64
+	modTime time.Time
+	isDir   bool
+}
+
+func (i *fileInfo) Name() string       { return i.name }
+func (i *fileInfo) Size() int64        { return i.size }
+func (i *fileInfo) ModTime() time.Time { return i.modTime }
+func (i *fileInfo) IsDir() bool        { return i.isDir }
+func (i *fileInfo) Sys() interface{}   { return nil }
+
+func (i *fileInfo) Mode() fs.FileMode {
+	if i.isDir {
+		return fs.ModeDir | 0555
+	}
+	return 0444
+}
+
+// dirFile is an open remote directory.
+type dirFile struct {
+	info    fs.FileInfo
+	entries []fs.DirEntry
+}
+
+func newDirFile(info fs.FileInfo, entries []fs.FileInfo) *dirFile {
+	ret := &dirFile{
+		info:    info,
+		entries: make([]fs.DirEntry, len(entries)),
+	}
+
+	for i, e := range entries {
+		ret.entries[i] = fs.FileInfoToDirEntry(e)
+	}
+
+	sort.Slice(ret.entries, func(i, j int) bool {
+		return ret.entries[i].Name() < ret.entries[j].Name()
+	})
+
+	return ret
+}
+
+func (d *dirFile) Stat() (fs.FileInfo, error) {
+	return d.info, nil
+}
+
+func (d *dirFile) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.info.Name(), Err: errors.New("is a directory")}
+}
+
+func (d *dirFile) Close() error {
+	return nil
+}
+
+func (d *dirFile) ReadDir(n int) ([]fs.DirEntry, error) {
+	if n <= 0 {
+		ret := d.entries
+		d.entries = nil
+		return ret, nil
+	}
+
+	if len(d.entries) == 0 {
+		return nil, io.EOF
+	}
+
+	if n > len(d.entries) {
+		n = len(d.entries)
+	}
+
+	ret := d.entries[:n]
+	d.entries = d.entries[n:]
+	return ret, nil
+}
@@ -0,0 +1,295 @@
+package remote
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"net/url"
+	"os"
+	"path"
+	"sync"
+
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const defaultSFTPPort = "22"
+
+// sftpDialer connects to the SFTP server. The returned closer closes the
+// underlying connection.
+type sftpDialer func() (*sftp.Client, io.Closer, error)
+
+// sftpFS is a file system served by an SFTP server.
+type sftpFS struct {
+	mount
+	url  *url.URL
+	dial sftpDialer
+
+	mu     sync.Mutex
+	client *sftp.Client
+	closer io.Closer
+}
+
+func newSFTPFS(c Config, u *url.URL) (*sftpFS, error) {
+	username := c.Username
+	if username == "" {
+		username = u.User.Username()
+	}
+	password := c.Password
+	if password == "" {
+		password, _ = u.User.Password()
+	}
+
+	root := u.Path
+	if root == "" {
+		root = "/"
+	}
+
+	sshConfig, err := sftpClientConfig(c, u, username, password)
+	if err != nil {
+		return nil, err
+	}
+
+	host := u.Host
+	if u.Port() == "" {
+		host = net.JoinHostPort(u.Hostname(), defaultSFTPPort)
+	}
+
+	ret := &sftpFS{
+		mount: mount{path: c.Path, root: root},
+		url:   u,
+	}
+
+	ret.dial = func() (*sftp.Client, io.Closer, error) {
+		conn, err := ssh.Dial("tcp", host, sshConfig)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		client, err := sftp.NewClient(conn)
+		if err != nil {
+			conn.Close()
+			return nil, nil, err
+		}
+
+		return client, conn, nil
+	}
+
+	return ret, nil
+}
+
+func sftpClientConfig(c Config, u *url.URL, username string, password string) (*ssh.ClientConfig, error) {
+	ret := &ssh.ClientConfig{
+		User: username,
+	}
+
+	if c.PrivateKeyPath != "" {
+		key, err := os.ReadFile(c.PrivateKeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("reading private key: %w", err)
+		}
+
+		var signer ssh.Signer
+		if password != "" {
+			signer, err = ssh.ParsePrivateKeyWithPassphrase(key, []byte(password))
+		} else {
+			signer, err = ssh.ParsePrivateKey(key)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parsing private key: %w", err)
+		}
+
+		ret.Auth = append(ret.Auth, ssh.PublicKeys(signer))
+	}
+
+	if password != "" {
+		ret.Auth = append(ret.Auth, ssh.Password(password))
+	}
+
+	if c.HostKey != "" {
+		hostKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(c.HostKey))
+		if err != nil {
+			return nil, fmt.Errorf("parsing host key: %w", err)
+		}
+		ret.HostKeyCallback = ssh.FixedHostKey(hostKey)
+	} else {
+		logger.Warnf("No host key set for %s. The SFTP server identity will not be verified.", u.Redacted())
+		ret.HostKeyCallback = ssh.InsecureIgnoreHostKey() //nolint:gosec
+	}
+
+	return ret, nil
+}
+
+func (f *sftpFS) getClient() (*sftp.Client, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.client != nil {
+		return f.client, nil
+	}
+
+	client, closer, err := f.dial()
+	if err != nil {
+		return nil, fmt.Errorf("connecting to %s: %v", f.url.Redacted(), err)
+	}
+
+	f.client = client
+	f.closer = closer
+	return client, nil
+}
+
+// checkConnection discards the client if err indicates that the connection
+// was lost, so that the next operation reconnects.
+func (f *sftpFS) checkConnection(client *sftp.Client, err error) {
+	if !errors.Is(err, sftp.ErrSSHFxConnectionLost) {
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.client == client {
+		f.closeClient()
+	}
+}
+
+func (f *sftpFS) closeClient() {
+	if f.client == nil {
+		return
+	}
+
+	f.client.Close()
+	if f.closer != nil {
+		f.closer.Close()
+	}
+	f.client = nil
+	f.closer = nil
+}
+
+func (f *sftpFS) Close() error {
+	defaultProxy.unregister(f)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.closeClient()
+	return nil
+}
+
+func (f *sftpFS) Stat(name string) (fs.FileInfo, error) {
+	p, err := f.remotePath(name)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := f.getClient()
+	if err != nil {
+		return nil, err
+	}
+
+	ret, err := client.Stat(p)
+	if err != nil {
+		f.checkConnection(client, err)
+		return nil, pathError("stat", name, err)
+	}
+
+	return ret, nil
+}
+
+// Lstat is the same as Stat. Symbolic links are resolved by the server.
+func (f *sftpFS) Lstat(name string) (fs.FileInfo, error) {
+	return f.Stat(name)
+}
+
+func (f *sftpFS) Open(name string) (fs.ReadDirFile, error) {
+	p, err := f.remotePath(name)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := f.getClient()
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := client.Stat(p)
+	if err != nil {
+		f.checkConnection(client, err)
+		return nil, pathError("open", name, err)
+	}
+
+	if info.IsDir() {
+		entries, err := f.readDir(client, p)
+		if err != nil {
+			f.checkConnection(client, err)
+			return nil, pathError("readdir", name, err)
+		}
+
+		return newDirFile(info, entries), nil
+	}
+
+	ret, err := client.Open(p)
+	if err != nil {
+		f.checkConnection(client, err)
+		return nil, pathError("open", name, err)
+	}
+
+	return &sftpFile{File: ret}, nil
+}
+
+func (f *sftpFS) readDir(client *sftp.Client, p string) ([]fs.FileInfo, error) {
+	entries, err := client.ReadDir(p)
+	if err != nil {
+		return nil, err
+	}
+
+	// resolve symbolic links so that they are walked as their targets
+	for i, e := range entries {
+		if e.Mode()&fs.ModeSymlink == 0 {
+			continue
+		}
+
+		target, err := client.Stat(path.Join(p, e.Name()))
+		if err != nil {
+			logger.Warnf("Unable to resolve symbolic link %s: %v", path.Join(p, e.Name()), err)
+			continue
+		}
+
+		entries[i] = &fileInfo{
+			name:    e.Name(),
+			size:    target.Size(),
+			modTime: target.ModTime(),
+			isDir:   target.IsDir(),
+		}
+	}
+
+	return entries, nil
+}
+
+func (f *sftpFS) OpenZip(name string, size int64) (models.ZipFS, error) {
+	return file.OpenArchiveFS(f, name, size)
+}
+
+func (f *sftpFS) IsPathCaseSensitive(path string) (bool, error) {
+	return true, nil
+}
+
+// URL returns the URL of the named file on the local remote file proxy.
+func (f *sftpFS) URL(name string) (string, error) {
+	return defaultProxy.fileURL(f, f.mount, name)
+}
+
+// sftpFile is an open remote file. It supports seeking and ReadAt.
+type sftpFile struct {
+	*sftp.File
+}
+
+func (f *sftpFile) ReadDir(int) ([]fs.DirEntry, error) {
+	return nil, &fs.PathError{Op: "readdir", Path: f.Name(), Err: errors.New("not a directory")}
+}
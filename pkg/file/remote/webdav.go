@@ -0,0 +1,421 @@
+package remote
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const propfindBody = `<?xml version="1.0" encoding="utf-8"?>
+<D:propfind xmlns:D="DAV:"><D:prop><D:resourcetype/><D:getcontentlength/><D:getlastmodified/></D:prop></D:propfind>`
+
+// webDAVFS is a file system served by a WebDAV server.
+type webDAVFS struct {
+	mount
+	url      *url.URL
+	username string
+	password string
+	client   *http.Client
+}
+
+func newWebDAVFS(c Config, u *url.URL) *webDAVFS {
+	username := c.Username
+	if username == "" {
+		username = u.User.Username()
+	}
+	password := c.Password
+	if password == "" {
+		password, _ = u.User.Password()
+	}
+
+	base := *u
+	base.User = nil
+
+	root := base.Path
+	if root == "" {
+		root = "/"
+	}
+
+	return &webDAVFS{
+		mount:    mount{path: c.Path, root: root},
+		url:      &base,
+		username: username,
+		password: password,
+		client: &http.Client{
+			// redirects are handled by propfind, since the client
+			// changes the method of redirected requests to GET
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+func (f *webDAVFS) newRequest(ctx context.Context, method string, p string, body io.Reader) (*http.Request, error) {
+	u := *f.url
+	u.Path = p
+	u.RawPath = ""
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	if f.username != "" || f.password != "" {
+		req.SetBasicAuth(f.username, f.password)
+	}
+
+	return req, nil
+}
+
+type davMultistatus struct {
+	Responses []davResponse `xml:"DAV: response"`
+}
+
+type davResponse struct {
+	Href      string        `xml:"DAV: href"`
+	Propstats []davPropstat `xml:"DAV: propstat"`
+}
+
+type davPropstat struct {
+	Prop   davProp `xml:"DAV: prop"`
+	Status string  `xml:"DAV: status"`
+}
+
+type davProp struct {
+	ResourceType struct {
+		Collection *struct{} `xml:"DAV: collection"`
+	} `xml:"DAV: resourcetype"`
+	ContentLength string `xml:"DAV: getcontentlength"`
+	LastModified  string `xml:"DAV: getlastmodified"`
+}
+
+// davEntry is a file returned by a PROPFIND request.
+type davEntry struct {
+	path string
+	info *fileInfo
+}
+
+func (r davResponse) entry() (*davEntry, error) {
+	href, err := url.Parse(r.Href)
+	if err != nil {
+		return nil, fmt.Errorf("parsing href %q: %w", r.Href, err)
+	}
+
+	p := path.Clean("/" + href.Path)
+	info := &fileInfo{
+		name: path.Base(p),
+	}
+
+	for _, ps := range r.Propstats {
+		// status is of the form "HTTP/1.1 200 OK"
+		if !strings.Contains(ps.Status, " 200") {
+			continue
+		}
+
+		prop := ps.Prop
+		info.isDir = prop.ResourceType.Collection != nil
+
+		if prop.ContentLength != "" {
+			info.size, _ = strconv.ParseInt(prop.ContentLength, 10, 64)
+		}
+
+		if prop.LastModified != "" {
+			info.modTime, _ = http.ParseTime(prop.LastModified)
+		}
+	}
+
+	return &davEntry{path: p, info: info}, nil
+}
+
+// propfind returns the entries of the remote path p. Depth is "0" to return
+// p only, or "1" to include its children.
+func (f *webDAVFS) propfind(p string, depth string) ([]*davEntry, error) {
+	resp, err := f.doPropfind(p, depth)
+	if err != nil {
+		return nil, err
+	}
+
+	// directories are commonly redirected to the path with a trailing slash
+	if resp.StatusCode == http.StatusMovedPermanently || resp.StatusCode == http.StatusPermanentRedirect {
+		resp.Body.Close()
+		if strings.HasSuffix(p, "/") {
+			return nil, fmt.Errorf("too many redirects")
+		}
+
+		resp, err = f.doPropfind(p+"/", depth)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, fs.ErrNotExist
+	case resp.StatusCode != http.StatusMultiStatus:
+		return nil, fmt.Errorf("unexpected response: %s", resp.Status)
+	}
+
+	var ms davMultistatus
+	if err := xml.NewDecoder(resp.Body).Decode(&ms); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+
+	ret := make([]*davEntry, 0, len(ms.Responses))
+	for _, r := range ms.Responses {
+		e, err := r.entry()
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, e)
+	}
+
+	return ret, nil
+}
+
+func (f *webDAVFS) doPropfind(p string, depth string) (*http.Response, error) {
+	req, err := f.newRequest(context.Background(), "PROPFIND", p, strings.NewReader(propfindBody))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Depth", depth)
+	req.Header.Set("Content-Type", "application/xml; charset=utf-8")
+
+	return f.client.Do(req)
+}
+
+func (f *webDAVFS) stat(p string) (*fileInfo, error) {
+	entries, err := f.propfind(p, "0")
+	if err != nil {
+		return nil, err
+	}
+
+	if len(entries) == 0 {
+		return nil, fs.ErrNotExist
+	}
+
+	return entries[0].info, nil
+}
+
+func (f *webDAVFS) Stat(name string) (fs.FileInfo, error) {
+	p, err := f.remotePath(name)
+	if err != nil {
+		return nil, err
+	}
+
+	ret, err := f.stat(p)
+	if err != nil {
+		return nil, pathError("stat", name, err)
+	}
+
+	return ret, nil
+}
+
+// Lstat is the same as Stat. WebDAV does not expose symbolic links.
+func (f *webDAVFS) Lstat(name string) (fs.FileInfo, error) {
+	return f.Stat(name)
+}
+
+func (f *webDAVFS) Open(name string) (fs.ReadDirFile, error) {
+	p, err := f.remotePath(name)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := f.stat(p)
+	if err != nil {
+		return nil, pathError("open", name, err)
+	}
+
+	if !info.isDir {
+		return &webDAVFile{
+			fs:   f,
+			path: p,
+			info: info,
+		}, nil
+	}
+
+	entries, err := f.propfind(strings.TrimSuffix(p, "/")+"/", "1")
+	if err != nil {
+		return nil, pathError("readdir", name, err)
+	}
+
+	var children []fs.FileInfo
+	for _, e := range entries {
+		// the directory itself is included in the response
+		if e.path == path.Clean(p) {
+			continue
+		}
+		children = append(children, e.info)
+	}
+
+	return newDirFile(info, children), nil
+}
+
+func (f *webDAVFS) OpenZip(name string, size int64) (models.ZipFS, error) {
+	return file.OpenArchiveFS(f, name, size)
+}
+
+func (f *webDAVFS) IsPathCaseSensitive(path string) (bool, error) {
+	return true, nil
+}
+
+// URL returns the URL of the named file on the local remote file proxy.
+func (f *webDAVFS) URL(name string) (string, error) {
+	return defaultProxy.fileURL(f, f.mount, name)
+}
+
+func (f *webDAVFS) Close() error {
+	defaultProxy.unregister(f)
+	f.client.CloseIdleConnections()
+	return nil
+}
+
+// get requests the remote file from offset. length is the number of bytes
+// to request, or 0 to request the rest of the file.
+func (f *webDAVFS) get(p string, offset int64, length int64) (io.ReadCloser, error) {
+	req, err := f.newRequest(context.Background(), http.MethodGet, p, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case length > 0:
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", offset, offset+length-1))
+	case offset > 0:
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		return resp.Body, nil
+	case http.StatusOK:
+		// range not supported - skip to the offset
+		if _, err := io.CopyN(io.Discard, resp.Body, offset); err != nil {
+			resp.Body.Close()
+			return nil, err
+		}
+		return resp.Body, nil
+	case http.StatusNotFound:
+		resp.Body.Close()
+		return nil, fs.ErrNotExist
+	}
+
+	resp.Body.Close()
+	return nil, fmt.Errorf("unexpected response: %s", resp.Status)
+}
+
+// webDAVFile is an open remote file. Reads are made using range requests, so
+// the file supports seeking and ReadAt.
+type webDAVFile struct {
+	fs     *webDAVFS
+	path   string
+	info   *fileInfo
+	offset int64
+	body   io.ReadCloser
+}
+
+func (f *webDAVFile) Stat() (fs.FileInfo, error) {
+	return f.info, nil
+}
+
+func (f *webDAVFile) Read(b []byte) (int, error) {
+	if f.offset >= f.info.size {
+		return 0, io.EOF
+	}
+
+	if f.body == nil {
+		body, err := f.fs.get(f.path, f.offset, 0)
+		if err != nil {
+			return 0, pathError("read", f.path, err)
+		}
+		f.body = body
+	}
+
+	n, err := f.body.Read(b)
+	f.offset += int64(n)
+	return n, err
+}
+
+func (f *webDAVFile) ReadAt(b []byte, off int64) (int, error) {
+	if off >= f.info.size {
+		return 0, io.EOF
+	}
+
+	length := int64(len(b))
+	if off+length > f.info.size {
+		length = f.info.size - off
+	}
+
+	body, err := f.fs.get(f.path, off, length)
+	if err != nil {
+		return 0, pathError("read", f.path, err)
+	}
+	defer body.Close()
+
+	n, err := io.ReadFull(body, b[:length])
+	if err == nil && n < len(b) {
+		err = io.EOF
+	}
+	return n, err
+}
+
+func (f *webDAVFile) Seek(offset int64, whence int) (int64, error) {
+	var newOffset int64
+	switch whence {
+	case io.SeekStart:
+		newOffset = offset
+	case io.SeekCurrent:
+		newOffset = f.offset + offset
+	case io.SeekEnd:
+		newOffset = f.info.size + offset
+	default:
+		return 0, errors.New("invalid whence")
+	}
+
+	if newOffset < 0 {
+		return 0, errors.New("negative position")
+	}
+
+	if newOffset != f.offset {
+		f.closeBody()
+		f.offset = newOffset
+	}
+
+	return newOffset, nil
+}
+
+func (f *webDAVFile) ReadDir(int) ([]fs.DirEntry, error) {
+	return nil, &fs.PathError{Op: "readdir", Path: f.path, Err: errors.New("not a directory")}
+}
+
+func (f *webDAVFile) closeBody() {
+	if f.body != nil {
+		f.body.Close()
+		f.body = nil
+	}
+}
+
+func (f *webDAVFile) Close() error {
+	f.closeBody()
+	return nil
+}
@@ -137,6 +137,10 @@ type ScanOptions struct {
 
 	// When true files in path will be rescanned even if they haven't changed
 	Rescan bool
+
+	// When true, fingerprints are cached in the extended attributes of
+	// files, and cached fingerprints are used if the file is unchanged.
+	UseFingerprintXattrs bool
 }
 
 // Scan starts the scanning process.
@@ -343,12 +347,6 @@ func (s *scanJob) acceptEntry(ctx context.Context, path string, info fs.FileInfo
 func (s *scanJob) scanZipFile(ctx context.Context, f scanFile) error {
 	zipFS, err := f.fs.OpenZip(f.Path, f.Size)
 	if err != nil {
-		if errors.Is(err, errNotReaderAt) {
-			// can't walk the zip file
-			// just return
-			return nil
-		}
-
 		return err
 	}
 
@@ -791,12 +789,27 @@ func (s *scanJob) fireHandlers(ctx context.Context, f models.File, oldFile model
 }
 
 func (s *scanJob) calculateFingerprints(fs models.FS, f *models.BaseFile, path string, useExisting bool) (models.Fingerprints, error) {
+	useXattrs := s.options.UseFingerprintXattrs && isCachable(fs, f)
+	var cached models.Fingerprints
+
+	if !useExisting && useXattrs {
+		// use the fingerprints cached in the file if it is unchanged
+		f = cachedFingerprintsFile(f)
+		cached = f.Fingerprints
+		useExisting = len(cached) > 0
+
+		if useExisting {
+			logger.Infof("Using cached fingerprints for %s", path)
+		}
+	}
+
 	// only log if we're (re)calculating fingerprints
 	if !useExisting {
 		logger.Infof("Calculating fingerprints for %s ...", path)
 	}
 
 	// calculate primary fingerprint for the file
+	var fp models.Fingerprints
 	fp, err := s.FingerprintCalculator.CalculateFingerprints(f, &fsOpener{
 		fs:   fs,
 		name: path,
@@ -805,6 +818,19 @@ func (s *scanJob) calculateFingerprints(fs models.FS, f *models.BaseFile, path s
 		return nil, fmt.Errorf("calculating fingerprint for file %q: %w", path, err)
 	}
 
+	if useXattrs {
+		// include cached fingerprints not calculated by the scan, such as phash
+		for _, c := range cached {
+			if fp.For(c.Type) == nil {
+				fp = append(fp, c)
+			}
+		}
+
+		if err := WriteCachedFingerprints(f, fp); err != nil {
+			logger.Debugf("Unable to cache fingerprints for %s: %v", path, err)
+		}
+	}
+
 	return fp, nil
 }
 
@@ -838,7 +864,7 @@ func (s *scanJob) getFileFS(f *models.BaseFile) (models.FS, error) {
 	}
 
 	zipPath := f.ZipFile.Base().Path
-	return fs.OpenZip(zipPath, f.Size)
+	return fs.OpenZip(zipPath, f.ZipFile.Base().Size)
 }
 
 func (s *scanJob) handleRename(ctx context.Context, f models.File, fp []models.Fingerprint) (models.File, error) {
@@ -1065,6 +1091,14 @@ func (s *scanJob) onExistingFile(ctx context.Context, f scanFile, existing model
 			return fmt.Errorf("updating file %q: %w", path, err)
 		}
 
+		// the previous health check result does not apply to the new contents
+		if updatedBase := existing.Base(); updated && updatedBase.Health != nil {
+			if err := s.Repository.File.UpdateHealth(ctx, updatedBase.ID, nil); err != nil {
+				return fmt.Errorf("clearing health of file %q: %w", path, err)
+			}
+			updatedBase.Health = nil
+		}
+
 		if err := s.fireHandlers(ctx, existing, &oldBase); err != nil {
 			return err
 		}
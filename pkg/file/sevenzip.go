@@ -0,0 +1,12 @@
+package file
+
+import (
+	"io"
+	"io/fs"
+
+	"github.com/bodgit/sevenzip"
+)
+
+func newSevenZipReader(r io.ReaderAt, size int64) (fs.FS, error) {
+	return sevenzip.NewReader(r, size)
+}
@@ -0,0 +1,220 @@
+package file
+
+import (
+	"archive/tar"
+	"errors"
+	"io"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// tarFS is a read-only fs.FS over a tar archive. The archive is indexed once
+// when opened and file contents are read directly from the underlying
+// ReaderAt. Directories without an explicit entry are implied from the file
+// paths.
+type tarFS struct {
+	r       io.ReaderAt
+	entries map[string]*tarEntry
+}
+
+type tarEntry struct {
+	name     string
+	header   *tar.Header
+	offset   int64
+	children map[string]*tarEntry
+}
+
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.n += int64(n)
+	return n, err
+}
+
+func newTarReader(r io.ReaderAt, size int64) (fs.FS, error) {
+	ret := &tarFS{
+		r: r,
+		entries: map[string]*tarEntry{
+			".": {name: ".", children: make(map[string]*tarEntry)},
+		},
+	}
+
+	cr := &countingReader{r: io.NewSectionReader(r, 0, size)}
+	tr := tar.NewReader(cr)
+	for {
+		h, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		name := path.Clean(strings.TrimPrefix(h.Name, "/"))
+		if name == "." || !fs.ValidPath(name) {
+			continue
+		}
+
+		switch h.Typeflag {
+		case tar.TypeDir:
+			ret.dir(name).header = h
+		case tar.TypeReg:
+			// other entry types, including sparse files whose contents are
+			// not stored contiguously, are not supported
+			e := &tarEntry{
+				name:   name,
+				header: h,
+				offset: cr.n,
+			}
+			ret.entries[name] = e
+			ret.dir(path.Dir(name)).children[path.Base(name)] = e
+		}
+	}
+
+	return ret, nil
+}
+
+// dir returns the directory entry with the given name, creating it and its
+// parents if they do not exist.
+func (f *tarFS) dir(name string) *tarEntry {
+	if e, ok := f.entries[name]; ok {
+		if e.children == nil {
+			e.children = make(map[string]*tarEntry)
+		}
+		return e
+	}
+
+	e := &tarEntry{
+		name:     name,
+		children: make(map[string]*tarEntry),
+	}
+	f.entries[name] = e
+	f.dir(path.Dir(name)).children[path.Base(name)] = e
+	return e
+}
+
+func (f *tarFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+
+	e := f.entries[name]
+	if e == nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+
+	if e.children != nil {
+		return &tarDir{entry: e}, nil
+	}
+
+	return &tarFile{
+		entry:         e,
+		SectionReader: io.NewSectionReader(f.r, e.offset, e.header.Size),
+	}, nil
+}
+
+type tarFileInfo struct {
+	entry *tarEntry
+}
+
+func (i tarFileInfo) Name() string {
+	return path.Base(i.entry.name)
+}
+
+func (i tarFileInfo) Size() int64 {
+	if i.entry.header == nil || i.entry.children != nil {
+		return 0
+	}
+	return i.entry.header.Size
+}
+
+func (i tarFileInfo) Mode() fs.FileMode {
+	if i.entry.children != nil {
+		if i.entry.header != nil {
+			return i.entry.header.FileInfo().Mode() | fs.ModeDir
+		}
+		return fs.ModeDir | 0555
+	}
+	return i.entry.header.FileInfo().Mode()
+}
+
+func (i tarFileInfo) ModTime() time.Time {
+	if i.entry.header == nil {
+		return time.Time{}
+	}
+	return i.entry.header.ModTime
+}
+
+func (i tarFileInfo) IsDir() bool {
+	return i.entry.children != nil
+}
+
+func (i tarFileInfo) Sys() interface{} {
+	return i.entry.header
+}
+
+type tarFile struct {
+	*io.SectionReader
+	entry *tarEntry
+}
+
+func (f *tarFile) Stat() (fs.FileInfo, error) {
+	return tarFileInfo{entry: f.entry}, nil
+}
+
+func (f *tarFile) Close() error {
+	return nil
+}
+
+type tarDir struct {
+	entry   *tarEntry
+	entries []fs.DirEntry
+	offset  int
+}
+
+func (d *tarDir) Stat() (fs.FileInfo, error) {
+	return tarFileInfo{entry: d.entry}, nil
+}
+
+func (d *tarDir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.entry.name, Err: errors.New("is a directory")}
+}
+
+func (d *tarDir) Close() error {
+	return nil
+}
+
+func (d *tarDir) ReadDir(n int) ([]fs.DirEntry, error) {
+	if d.entries == nil {
+		d.entries = make([]fs.DirEntry, 0, len(d.entry.children))
+		for _, c := range d.entry.children {
+			d.entries = append(d.entries, fs.FileInfoToDirEntry(tarFileInfo{entry: c}))
+		}
+		sort.Slice(d.entries, func(i, j int) bool {
+			return d.entries[i].Name() < d.entries[j].Name()
+		})
+	}
+
+	remaining := d.entries[d.offset:]
+	if n <= 0 {
+		d.offset = len(d.entries)
+		return remaining, nil
+	}
+
+	if len(remaining) == 0 {
+		return nil, io.EOF
+	}
+
+	if n > len(remaining) {
+		n = len(remaining)
+	}
+	d.offset += n
+	return remaining[:n], nil
+}
@@ -0,0 +1,394 @@
+package file
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+// TrashDirName is the name of the directory in each library root that
+// deleted files are moved to.
+const TrashDirName = ".stash_trash"
+
+const (
+	trashManifestFilename = "manifest.json"
+	trashIDFormat         = "20060102-150405.000000000"
+)
+
+var ErrTrashEntryNotFound = errors.New("trash entry not found")
+
+// TrashedFile is a file that was moved to the trash.
+type TrashedFile struct {
+	// Name is the name of the file within the trash entry directory.
+	Name         string `json:"name"`
+	OriginalPath string `json:"original_path"`
+}
+
+// TrashManifest describes the files moved to the trash by a single deletion.
+// A deletion that spans multiple library roots has a manifest in each root,
+// all with the same ID and Data.
+type TrashManifest struct {
+	ID        string        `json:"id"`
+	DeletedAt time.Time     `json:"deleted_at"`
+	Files     []TrashedFile `json:"files"`
+	// Data is used by the caller to restore anything that was deleted
+	// along with the files.
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+// Trash moves deleted files into a trash directory in their library root,
+// from where they can be restored or purged.
+type Trash struct {
+	// Roots are the library root paths. Files outside of these are not
+	// moved to the trash.
+	Roots []string
+}
+
+// Dir returns the trash directory for the provided library root.
+func (t *Trash) Dir(root string) string {
+	return filepath.Join(root, TrashDirName)
+}
+
+// IsTrashPath returns true if the path is in the trash directory of one of
+// the library roots.
+func (t *Trash) IsTrashPath(path string) bool {
+	for _, root := range t.Roots {
+		if fsutil.IsPathInDir(t.Dir(root), path) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (t *Trash) rootForPath(path string) string {
+	// use the longest root in case of nested roots
+	ret := ""
+	for _, root := range t.Roots {
+		if fsutil.IsPathInDir(root, path) && len(root) > len(ret) {
+			ret = root
+		}
+	}
+
+	return ret
+}
+
+// moveToTrash moves the files to the trash. Each element of files is the
+// current path of the file, keyed by its original path. Returns the original
+// paths of the files that are not in a library root, and so were not moved,
+// and the original paths of the files that could not be moved. Files that
+// could not be moved are left at their current path.
+func (t *Trash) moveToTrash(files map[string]string, data interface{}) (notTrashed []string, failed []string, err error) {
+	var dataJSON json.RawMessage
+	if data != nil {
+		dataJSON, err = json.Marshal(data)
+		if err != nil {
+			return nil, nil, fmt.Errorf("encoding trash data: %w", err)
+		}
+	}
+
+	byRoot := make(map[string][]string)
+	for originalPath := range files {
+		root := t.rootForPath(originalPath)
+		if root == "" {
+			notTrashed = append(notTrashed, originalPath)
+			continue
+		}
+
+		byRoot[root] = append(byRoot[root], originalPath)
+	}
+
+	now := time.Now()
+	id := now.Format(trashIDFormat)
+
+	for root, originalPaths := range byRoot {
+		sort.Strings(originalPaths)
+
+		entryDir := filepath.Join(t.Dir(root), id)
+		if err := os.MkdirAll(entryDir, 0755); err != nil {
+			return nil, nil, fmt.Errorf("creating trash directory %q: %w", entryDir, err)
+		}
+
+		manifest := TrashManifest{
+			ID:        id,
+			DeletedAt: now,
+			Data:      dataJSON,
+		}
+
+		for i, originalPath := range originalPaths {
+			// prefix with the index in case of duplicate basenames
+			name := fmt.Sprintf("%d_%s", i, filepath.Base(originalPath))
+			if err := fsutil.SafeMove(files[originalPath], filepath.Join(entryDir, name)); err != nil {
+				logger.Warnf("Error moving %q to trash: %v", originalPath, err)
+				failed = append(failed, originalPath)
+				continue
+			}
+
+			manifest.Files = append(manifest.Files, TrashedFile{
+				Name:         name,
+				OriginalPath: originalPath,
+			})
+		}
+
+		// don't leave an empty entry in the trash
+		if len(manifest.Files) == 0 {
+			if err := os.RemoveAll(entryDir); err != nil {
+				logger.Warnf("Error removing trash entry %q: %v", entryDir, err)
+			}
+			continue
+		}
+
+		if err := writeTrashManifest(entryDir, manifest); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return notTrashed, failed, nil
+}
+
+func writeTrashManifest(entryDir string, manifest TrashManifest) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding trash manifest: %w", err)
+	}
+
+	fn := filepath.Join(entryDir, trashManifestFilename)
+	if err := os.WriteFile(fn, data, 0644); err != nil {
+		return fmt.Errorf("writing trash manifest %q: %w", fn, err)
+	}
+
+	return nil
+}
+
+func readTrashManifest(entryDir string) (*TrashManifest, error) {
+	data, err := os.ReadFile(filepath.Join(entryDir, trashManifestFilename))
+	if err != nil {
+		return nil, err
+	}
+
+	var ret TrashManifest
+	if err := json.Unmarshal(data, &ret); err != nil {
+		return nil, fmt.Errorf("decoding trash manifest in %q: %w", entryDir, err)
+	}
+
+	return &ret, nil
+}
+
+// trashEntry is a trash manifest and the directory containing it.
+type trashEntry struct {
+	dir      string
+	manifest *TrashManifest
+}
+
+func (t *Trash) entries() ([]trashEntry, error) {
+	var ret []trashEntry
+	for _, root := range t.Roots {
+		trashDir := t.Dir(root)
+		dirEntries, err := os.ReadDir(trashDir)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return nil, fmt.Errorf("reading trash directory %q: %w", trashDir, err)
+		}
+
+		for _, d := range dirEntries {
+			if !d.IsDir() {
+				continue
+			}
+
+			entryDir := filepath.Join(trashDir, d.Name())
+			manifest, err := readTrashManifest(entryDir)
+			if err != nil {
+				logger.Warnf("Ignoring trash entry %q: %v", entryDir, err)
+				continue
+			}
+
+			ret = append(ret, trashEntry{
+				dir:      entryDir,
+				manifest: manifest,
+			})
+		}
+	}
+
+	return ret, nil
+}
+
+// List returns the manifests of the entries in the trash, newest first.
+// Entries with the same ID in different library roots are combined.
+func (t *Trash) List() ([]*TrashManifest, error) {
+	entries, err := t.entries()
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]*TrashManifest)
+	var ret []*TrashManifest
+	for _, e := range entries {
+		existing := byID[e.manifest.ID]
+		if existing != nil {
+			existing.Files = append(existing.Files, e.manifest.Files...)
+			continue
+		}
+
+		byID[e.manifest.ID] = e.manifest
+		ret = append(ret, e.manifest)
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].DeletedAt.After(ret[j].DeletedAt)
+	})
+
+	return ret, nil
+}
+
+// Restore moves the files of the trash entry with the provided ID back to
+// their original paths and removes the entry from the trash. Files are not
+// restored over existing files. Returns the combined manifest of the entry.
+//
+// If some files cannot be moved back, the other files are still restored,
+// and the entry is left in the trash with the files that were not restored.
+// The manifest of the restored files is returned along with the error.
+func (t *Trash) Restore(id string) (*TrashManifest, error) {
+	entries, err := t.entries()
+	if err != nil {
+		return nil, err
+	}
+
+	var matched []trashEntry
+	for _, e := range entries {
+		if e.manifest.ID == id {
+			matched = append(matched, e)
+		}
+	}
+
+	if len(matched) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrTrashEntryNotFound, id)
+	}
+
+	// check all files first, so that the entry is not partially restored
+	for _, e := range matched {
+		for _, f := range e.manifest.Files {
+			if exists, _ := fsutil.FileExists(f.OriginalPath); exists {
+				return nil, fmt.Errorf("cannot restore %q: file already exists", f.OriginalPath)
+			}
+		}
+	}
+
+	ret := &TrashManifest{
+		ID:        id,
+		DeletedAt: matched[0].manifest.DeletedAt,
+		Data:      matched[0].manifest.Data,
+	}
+
+	var errs []error
+	for _, e := range matched {
+		var remaining []TrashedFile
+		for _, f := range e.manifest.Files {
+			if err := restoreTrashedFile(e.dir, f); err != nil {
+				errs = append(errs, err)
+				remaining = append(remaining, f)
+				continue
+			}
+
+			ret.Files = append(ret.Files, f)
+		}
+
+		if len(remaining) > 0 {
+			manifest := *e.manifest
+			manifest.Files = remaining
+			if err := writeTrashManifest(e.dir, manifest); err != nil {
+				errs = append(errs, err)
+			}
+			continue
+		}
+
+		if err := os.RemoveAll(e.dir); err != nil {
+			logger.Warnf("Error removing trash entry %q: %v", e.dir, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return ret, fmt.Errorf("restoring trash entry %s: %w", id, errors.Join(errs...))
+	}
+
+	return ret, nil
+}
+
+func restoreTrashedFile(entryDir string, f TrashedFile) error {
+	if err := os.MkdirAll(filepath.Dir(f.OriginalPath), 0755); err != nil {
+		return fmt.Errorf("creating directory for %q: %w", f.OriginalPath, err)
+	}
+
+	if err := fsutil.SafeMove(filepath.Join(entryDir, f.Name), f.OriginalPath); err != nil {
+		return fmt.Errorf("restoring %q: %w", f.OriginalPath, err)
+	}
+
+	return nil
+}
+
+// SetData replaces the data stored in the manifest of the trash entry with
+// the provided ID.
+func (t *Trash) SetData(id string, data interface{}) error {
+	dataJSON, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("encoding trash data: %w", err)
+	}
+
+	entries, err := t.entries()
+	if err != nil {
+		return err
+	}
+
+	found := false
+	for _, e := range entries {
+		if e.manifest.ID != id {
+			continue
+		}
+
+		found = true
+		e.manifest.Data = dataJSON
+		if err := writeTrashManifest(e.dir, *e.manifest); err != nil {
+			return err
+		}
+	}
+
+	if !found {
+		return fmt.Errorf("%w: %s", ErrTrashEntryNotFound, id)
+	}
+
+	return nil
+}
+
+// Purge permanently deletes the trash entries deleted before the provided
+// time. Returns the number of entries removed.
+func (t *Trash) Purge(before time.Time) (int, error) {
+	entries, err := t.entries()
+	if err != nil {
+		return 0, err
+	}
+
+	ret := 0
+	for _, e := range entries {
+		if !e.manifest.DeletedAt.Before(before) {
+			continue
+		}
+
+		if err := os.RemoveAll(e.dir); err != nil {
+			logger.Warnf("Error purging trash entry %q: %v", e.dir, err)
+			continue
+		}
+
+		ret++
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,426 @@
+package file
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeFileContent(t *testing.T, path string, content string) {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func assertFileContent(t *testing.T, path string, content string) {
+	t.Helper()
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Errorf("reading %s: %v", path, err)
+		return
+	}
+	assert.Equal(t, content, string(got), path)
+}
+
+func assertNotExists(t *testing.T, path string) {
+	t.Helper()
+
+	_, err := os.Stat(path)
+	assert.True(t, errors.Is(err, os.ErrNotExist), "%s exists", path)
+}
+
+func TestTrash_moveToTrash(t *testing.T) {
+	dir := t.TempDir()
+	root1 := filepath.Join(dir, "root1")
+	root2 := filepath.Join(dir, "root2")
+	outside := filepath.Join(dir, "outside")
+
+	tests := []struct {
+		name  string
+		files []string
+		// files that do not exist, so cannot be moved
+		missing    []string
+		notTrashed []string
+		// number of files in the trash entry of each root
+		trashed map[string]int
+	}{
+		{
+			"single root",
+			[]string{filepath.Join(root1, "a.mp4")},
+			nil,
+			nil,
+			map[string]int{root1: 1},
+		},
+		{
+			"duplicate basenames",
+			[]string{filepath.Join(root1, "a", "x.mp4"), filepath.Join(root1, "b", "x.mp4")},
+			nil,
+			nil,
+			map[string]int{root1: 2},
+		},
+		{
+			"multiple roots",
+			[]string{filepath.Join(root1, "a.mp4"), filepath.Join(root2, "b.mp4")},
+			nil,
+			nil,
+			map[string]int{root1: 1, root2: 1},
+		},
+		{
+			"outside roots",
+			[]string{filepath.Join(root1, "a.mp4"), filepath.Join(outside, "c.mp4")},
+			nil,
+			[]string{filepath.Join(outside, "c.mp4")},
+			map[string]int{root1: 1},
+		},
+		{
+			"move fails",
+			[]string{filepath.Join(root1, "a.mp4")},
+			[]string{filepath.Join(root1, "b.mp4")},
+			nil,
+			map[string]int{root1: 1},
+		},
+		{
+			"all moves in root fail",
+			[]string{filepath.Join(root1, "a.mp4")},
+			[]string{filepath.Join(root2, "b.mp4")},
+			nil,
+			map[string]int{root1: 1},
+		},
+		{
+			"all moves fail",
+			nil,
+			[]string{filepath.Join(root1, "a.mp4"), filepath.Join(root2, "b.mp4")},
+			nil,
+			nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trash := &Trash{Roots: []string{root1, root2}}
+			defer func() {
+				os.RemoveAll(trash.Dir(root1))
+				os.RemoveAll(trash.Dir(root2))
+			}()
+
+			files := make(map[string]string)
+			for _, f := range tt.files {
+				writeFileContent(t, f, f)
+				files[f] = f
+			}
+			for _, f := range tt.missing {
+				files[f] = f
+			}
+
+			notTrashed, failed, err := trash.moveToTrash(files, map[string]string{"key": "value"})
+			if err != nil {
+				t.Fatalf("moveToTrash() error = %v", err)
+			}
+			assert.ElementsMatch(t, tt.notTrashed, notTrashed)
+			assert.ElementsMatch(t, tt.missing, failed)
+
+			entries, err := trash.List()
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+
+			// roots where every move failed have no entry
+			for _, root := range []string{root1, root2} {
+				if tt.trashed[root] == 0 {
+					dirEntries, _ := os.ReadDir(trash.Dir(root))
+					assert.Empty(t, dirEntries, "trash directory of %s", root)
+				}
+			}
+
+			if len(tt.trashed) == 0 {
+				assert.Len(t, entries, 0)
+				return
+			}
+			if !assert.Len(t, entries, 1) {
+				return
+			}
+
+			entry := entries[0]
+			assert.Len(t, entry.Files, len(tt.files)-len(tt.notTrashed))
+			assert.JSONEq(t, `{"key":"value"}`, string(entry.Data))
+
+			for root, n := range tt.trashed {
+				entryDir := filepath.Join(trash.Dir(root), entry.ID)
+				manifest, err := readTrashManifest(entryDir)
+				if err != nil {
+					t.Fatalf("reading manifest in %s: %v", root, err)
+				}
+				assert.Len(t, manifest.Files, n)
+
+				for _, f := range manifest.Files {
+					assertNotExists(t, f.OriginalPath)
+					assertFileContent(t, filepath.Join(entryDir, f.Name), f.OriginalPath)
+				}
+			}
+
+			for _, f := range tt.notTrashed {
+				assertFileContent(t, f, f)
+				os.Remove(f)
+			}
+		})
+	}
+}
+
+func TestTrash_Restore(t *testing.T) {
+	tests := []struct {
+		name string
+		// files to trash, relative to the test directory
+		files []string
+		// files created after trashing, relative to the test directory
+		existing []string
+		wantErr  bool
+	}{
+		{"single root", []string{"root1/a.mp4", "root1/sub/b.mp4"}, nil, false},
+		{"multiple roots", []string{"root1/a.mp4", "root2/b.mp4"}, nil, false},
+		{"existing file", []string{"root1/a.mp4", "root1/b.mp4"}, []string{"root1/b.mp4"}, true},
+		{"existing file in other root", []string{"root1/a.mp4", "root2/b.mp4"}, []string{"root2/b.mp4"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			trash := &Trash{Roots: []string{filepath.Join(dir, "root1"), filepath.Join(dir, "root2")}}
+
+			files := make(map[string]string)
+			for _, f := range tt.files {
+				p := filepath.Join(dir, filepath.FromSlash(f))
+				writeFileContent(t, p, f)
+				files[p] = p
+			}
+
+			if _, _, err := trash.moveToTrash(files, nil); err != nil {
+				t.Fatalf("moveToTrash() error = %v", err)
+			}
+
+			entries, err := trash.List()
+			if err != nil || len(entries) != 1 {
+				t.Fatalf("List() = %v, %v", entries, err)
+			}
+			id := entries[0].ID
+
+			for _, f := range tt.existing {
+				writeFileContent(t, filepath.Join(dir, filepath.FromSlash(f)), "existing")
+			}
+
+			got, err := trash.Restore(id)
+			if tt.wantErr {
+				assert.NotNil(t, err)
+
+				// nothing is restored, and the entry remains in the trash
+				entries, _ := trash.List()
+				if assert.Len(t, entries, 1) {
+					assert.Len(t, entries[0].Files, len(tt.files))
+				}
+				for _, f := range tt.files {
+					if !contains(tt.existing, f) {
+						assertNotExists(t, filepath.Join(dir, filepath.FromSlash(f)))
+					}
+				}
+				for _, f := range tt.existing {
+					assertFileContent(t, filepath.Join(dir, filepath.FromSlash(f)), "existing")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Restore() error = %v", err)
+			}
+			assert.Equal(t, id, got.ID)
+			assert.Len(t, got.Files, len(tt.files))
+
+			for _, f := range tt.files {
+				assertFileContent(t, filepath.Join(dir, filepath.FromSlash(f)), f)
+			}
+
+			entries, _ = trash.List()
+			assert.Len(t, entries, 0)
+		})
+	}
+}
+
+func contains(s []string, v string) bool {
+	for _, e := range s {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
+
+func TestTrash_RestoreNotFound(t *testing.T) {
+	trash := &Trash{Roots: []string{t.TempDir()}}
+
+	_, err := trash.Restore("missing")
+	assert.True(t, errors.Is(err, ErrTrashEntryNotFound), "Restore() error = %v", err)
+}
+
+func TestTrash_Purge(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name      string
+		deletedAt []time.Time
+		before    time.Time
+		want      int
+	}{
+		{"none expired", []time.Time{now.Add(-time.Hour)}, now.Add(-2 * time.Hour), 0},
+		{"all expired", []time.Time{now.Add(-3 * time.Hour), now.Add(-4 * time.Hour)}, now.Add(-2 * time.Hour), 2},
+		{"some expired", []time.Time{now.Add(-time.Hour), now.Add(-3 * time.Hour)}, now.Add(-2 * time.Hour), 1},
+		{"empty", nil, now, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			trash := &Trash{Roots: []string{root}}
+
+			var expired []string
+			for _, d := range tt.deletedAt {
+				id := d.Format(trashIDFormat)
+				entryDir := filepath.Join(trash.Dir(root), id)
+				writeFileContent(t, filepath.Join(entryDir, "0_a.mp4"), "a")
+				if err := writeTrashManifest(entryDir, TrashManifest{
+					ID:        id,
+					DeletedAt: d,
+					Files:     []TrashedFile{{Name: "0_a.mp4", OriginalPath: filepath.Join(root, "a.mp4")}},
+				}); err != nil {
+					t.Fatal(err)
+				}
+
+				if d.Before(tt.before) {
+					expired = append(expired, entryDir)
+				}
+			}
+
+			got, err := trash.Purge(tt.before)
+			if err != nil {
+				t.Fatalf("Purge() error = %v", err)
+			}
+			assert.Equal(t, tt.want, got)
+
+			for _, d := range expired {
+				assertNotExists(t, d)
+			}
+
+			entries, _ := trash.List()
+			assert.Len(t, entries, len(tt.deletedAt)-tt.want)
+		})
+	}
+}
+
+func TestTrash_RestorePartial(t *testing.T) {
+	dir := t.TempDir()
+	trash := &Trash{Roots: []string{filepath.Join(dir, "root1"), filepath.Join(dir, "root2")}}
+
+	restored := []string{"root1/a.mp4", "root2/c.mp4"}
+	blocked := "root1/sub/b.mp4"
+
+	files := make(map[string]string)
+	for _, f := range append([]string{blocked}, restored...) {
+		p := filepath.Join(dir, filepath.FromSlash(f))
+		writeFileContent(t, p, f)
+		files[p] = p
+	}
+
+	if _, _, err := trash.moveToTrash(files, nil); err != nil {
+		t.Fatalf("moveToTrash() error = %v", err)
+	}
+
+	entries, err := trash.List()
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("List() = %v, %v", entries, err)
+	}
+	id := entries[0].ID
+
+	// a file in place of the directory prevents the file being restored
+	blocker := filepath.Join(dir, "root1", "sub")
+	if err := os.Remove(blocker); err != nil {
+		t.Fatal(err)
+	}
+	writeFileContent(t, blocker, "blocker")
+
+	got, err := trash.Restore(id)
+	assert.NotNil(t, err)
+	if assert.NotNil(t, got) {
+		assert.Len(t, got.Files, len(restored))
+	}
+
+	for _, f := range restored {
+		assertFileContent(t, filepath.Join(dir, filepath.FromSlash(f)), f)
+	}
+
+	// only the file that was not restored remains in the trash
+	entries, _ = trash.List()
+	if assert.Len(t, entries, 1) && assert.Len(t, entries[0].Files, 1) {
+		assert.Equal(t, filepath.Join(dir, filepath.FromSlash(blocked)), entries[0].Files[0].OriginalPath)
+	}
+
+	// restoring again succeeds once the blocker is removed
+	if err := os.Remove(blocker); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err = trash.Restore(id)
+	if err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+	assert.Len(t, got.Files, 1)
+	assertFileContent(t, filepath.Join(dir, filepath.FromSlash(blocked)), blocked)
+
+	entries, _ = trash.List()
+	assert.Len(t, entries, 0)
+}
+
+func TestTrash_SetData(t *testing.T) {
+	dir := t.TempDir()
+	trash := &Trash{Roots: []string{filepath.Join(dir, "root1"), filepath.Join(dir, "root2")}}
+
+	files := make(map[string]string)
+	for _, f := range []string{"root1/a.mp4", "root2/b.mp4"} {
+		p := filepath.Join(dir, filepath.FromSlash(f))
+		writeFileContent(t, p, f)
+		files[p] = p
+	}
+
+	if _, _, err := trash.moveToTrash(files, map[string]string{"key": "old"}); err != nil {
+		t.Fatalf("moveToTrash() error = %v", err)
+	}
+
+	entries, _ := trash.List()
+	if len(entries) != 1 {
+		t.Fatalf("List() = %v", entries)
+	}
+	id := entries[0].ID
+
+	if err := trash.SetData(id, map[string]string{"key": "new"}); err != nil {
+		t.Fatalf("SetData() error = %v", err)
+	}
+
+	// the data is replaced in every root
+	for _, root := range trash.Roots {
+		manifest, err := readTrashManifest(filepath.Join(trash.Dir(root), id))
+		if err != nil {
+			t.Fatalf("reading manifest in %s: %v", root, err)
+		}
+		assert.JSONEq(t, `{"key":"new"}`, string(manifest.Data))
+		assert.Len(t, manifest.Files, 1)
+	}
+
+	err := trash.SetData("missing", nil)
+	assert.True(t, errors.Is(err, ErrTrashEntryNotFound), "SetData() error = %v", err)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 
 	"github.com/stashapp/stash/pkg/ffmpeg"
 	"github.com/stashapp/stash/pkg/file"
@@ -21,18 +22,21 @@ func (d *Decorator) Decorate(ctx context.Context, fs models.FS, f models.File) (
 	}
 
 	base := f.Base()
-	// TODO - copy to temp file if not an OsFS
-	if _, isOs := fs.(*file.OsFS); !isOs {
-		return f, fmt.Errorf("video.constructFile: only OsFS is supported")
+	// TODO - copy to temp file if ffmpeg cannot read the file
+	input, err := file.FFMpegInput(fs, base.Path)
+	if err != nil {
+		return f, fmt.Errorf("video.constructFile: %w", err)
 	}
 
 	probe := d.FFProbe
-	videoFile, err := probe.NewVideoFile(base.Path)
+	videoFile, err := probe.NewVideoFile(input)
 	if err != nil {
 		return f, fmt.Errorf("running ffprobe on %q: %w", base.Path, err)
 	}
 
-	container, err := ffmpeg.MatchContainer(videoFile.Container, base.Path)
+	container, err := ffmpeg.MatchContainerOpener(videoFile.Container, func() (io.ReadCloser, error) {
+		return fs.Open(base.Path)
+	})
 	if err != nil {
 		return f, fmt.Errorf("matching container for %q: %w", base.Path, err)
 	}
@@ -0,0 +1,223 @@
+package file
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+// WatchHandler is called by Watcher once filesystem events have settled.
+// changed contains the paths that were created or modified and still exist,
+// removed contains the paths that no longer exist.
+type WatchHandler func(ctx context.Context, changed []string, removed []string)
+
+// Watcher watches directory trees for changes and reports the affected paths
+// in batches. Events are debounced, so that the handler is only called once
+// no events have been received for the Debounce duration.
+type Watcher struct {
+	// Debounce is the quiet period after the last event before the handler is called.
+	Debounce time.Duration
+
+	// Filter is used to determine if a created or modified file should be
+	// reported. Directories and removed paths are always reported.
+	Filter func(path string) bool
+
+	// Exclude is used to determine if a directory should not be watched.
+	Exclude func(path string) bool
+
+	Handler WatchHandler
+
+	watcher *fsnotify.Watcher
+	cancel  context.CancelFunc
+	done    chan struct{}
+
+	mutex   sync.Mutex
+	pending map[string]struct{}
+}
+
+// Start starts watching the provided directory trees. The handler is called
+// from a separate goroutine until Close is called.
+func (w *Watcher) Start(ctx context.Context, paths []string) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+
+	w.watcher = watcher
+	w.pending = make(map[string]struct{})
+	w.done = make(chan struct{})
+
+	for _, p := range paths {
+		w.addTree(p)
+	}
+
+	ctx, w.cancel = context.WithCancel(ctx)
+	go w.run(ctx)
+
+	return nil
+}
+
+// Close stops watching and waits for the event loop to finish.
+func (w *Watcher) Close() error {
+	if w.watcher == nil {
+		return nil
+	}
+
+	w.cancel()
+	err := w.watcher.Close()
+	<-w.done
+	w.watcher = nil
+	return err
+}
+
+// addTree adds watches for the directory and all of its subdirectories.
+func (w *Watcher) addTree(root string) {
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			logger.Warnf("Error walking %s for watching: %v", path, err)
+			return nil
+		}
+
+		if !d.IsDir() {
+			return nil
+		}
+
+		if w.Exclude != nil && w.Exclude(path) {
+			return filepath.SkipDir
+		}
+
+		if err := w.watcher.Add(path); err != nil {
+			// most likely the inotify watch limit has been reached
+			logger.Warnf("Error watching %s: %v", path, err)
+			return filepath.SkipDir
+		}
+
+		return nil
+	})
+	if err != nil {
+		logger.Warnf("Error watching %s: %v", root, err)
+	}
+}
+
+func (w *Watcher) run(ctx context.Context) {
+	defer close(w.done)
+
+	timer := time.NewTimer(w.Debounce)
+	timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case event, ok := <-w.watcher.Events:
+			if !ok {
+				return
+			}
+
+			if w.handleEvent(event) {
+				timer.Reset(w.Debounce)
+			}
+		case err, ok := <-w.watcher.Errors:
+			if !ok {
+				return
+			}
+
+			if errors.Is(err, fsnotify.ErrEventOverflow) {
+				logger.Warnf("Filesystem watcher event queue overflowed, some changes may be missed until the next scan")
+			} else {
+				logger.Warnf("Filesystem watcher error: %v", err)
+			}
+		case <-timer.C:
+			w.flush(ctx)
+		}
+	}
+}
+
+// handleEvent records the path of the event. Returns true if the event
+// is of interest.
+func (w *Watcher) handleEvent(event fsnotify.Event) bool {
+	path := filepath.Clean(event.Name)
+
+	switch {
+	case event.Has(fsnotify.Create):
+		info, err := os.Lstat(path)
+		if err == nil && info.IsDir() {
+			if w.Exclude != nil && w.Exclude(path) {
+				return false
+			}
+
+			// new directories need to be watched as well
+			w.addTree(path)
+		} else if w.Filter != nil && !w.Filter(path) {
+			return false
+		}
+	case event.Has(fsnotify.Write):
+		if w.Filter != nil && !w.Filter(path) {
+			return false
+		}
+	case event.Has(fsnotify.Remove), event.Has(fsnotify.Rename):
+		// the destination of a rename is reported as a separate create event
+	default:
+		return false
+	}
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.pending[path] = struct{}{}
+	return true
+}
+
+func (w *Watcher) flush(ctx context.Context) {
+	w.mutex.Lock()
+	pending := w.pending
+	w.pending = make(map[string]struct{})
+	w.mutex.Unlock()
+
+	if len(pending) == 0 {
+		return
+	}
+
+	var changed, removed []string
+	for p := range pending {
+		// classify by the current state, since a path may have been
+		// removed and recreated within the debounce period
+		if _, err := os.Lstat(p); err == nil {
+			changed = append(changed, p)
+		} else {
+			removed = append(removed, p)
+		}
+	}
+
+	w.Handler(ctx, topLevelPaths(changed), topLevelPaths(removed))
+}
+
+// topLevelPaths returns the sorted paths, excluding those that are contained
+// in another of the paths.
+func topLevelPaths(paths []string) []string {
+	sort.Strings(paths)
+
+	var ret []string
+	for _, p := range paths {
+		if len(ret) > 0 {
+			last := ret[len(ret)-1]
+			if p == last || strings.HasPrefix(p, last+string(filepath.Separator)) {
+				continue
+			}
+		}
+
+		ret = append(ret, p)
+	}
+
+	return ret
+}
@@ -0,0 +1,182 @@
+package file
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testWatchDebounce = 200 * time.Millisecond
+
+type watchBatch struct {
+	changed []string
+	removed []string
+}
+
+// startTestWatcher starts a watcher on dir, reporting .mp4 files and
+// excluding directories named excluded.
+func startTestWatcher(t *testing.T, dir string) <-chan watchBatch {
+	t.Helper()
+
+	batches := make(chan watchBatch, 10)
+	w := &Watcher{
+		Debounce: testWatchDebounce,
+		Filter: func(path string) bool {
+			return strings.HasSuffix(path, ".mp4")
+		},
+		Exclude: func(path string) bool {
+			return filepath.Base(path) == "excluded"
+		},
+		Handler: func(ctx context.Context, changed []string, removed []string) {
+			batches <- watchBatch{changed: changed, removed: removed}
+		},
+	}
+
+	if err := w.Start(context.Background(), []string{dir}); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	t.Cleanup(func() {
+		w.Close()
+	})
+
+	return batches
+}
+
+func waitForBatch(t *testing.T, batches <-chan watchBatch) watchBatch {
+	t.Helper()
+
+	select {
+	case b := <-batches:
+		return b
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for watch handler")
+	}
+
+	return watchBatch{}
+}
+
+func assertNoBatch(t *testing.T, batches <-chan watchBatch) {
+	t.Helper()
+
+	select {
+	case b := <-batches:
+		t.Errorf("unexpected watch handler call: %+v", b)
+	case <-time.After(3 * testWatchDebounce):
+	}
+}
+
+func TestWatcher_Debounce(t *testing.T) {
+	dir := t.TempDir()
+	batches := startTestWatcher(t, dir)
+
+	var want []string
+	for _, name := range []string{"a.mp4", "b.mp4", "c.mp4"} {
+		p := filepath.Join(dir, name)
+		writeFileContent(t, p, name)
+		want = append(want, p)
+		time.Sleep(testWatchDebounce / 4)
+	}
+
+	b := waitForBatch(t, batches)
+	assert.Equal(t, want, b.changed)
+	assert.Empty(t, b.removed)
+
+	// all events are reported in a single call
+	assertNoBatch(t, batches)
+}
+
+func TestWatcher_Filter(t *testing.T) {
+	dir := t.TempDir()
+	batches := startTestWatcher(t, dir)
+
+	writeFileContent(t, filepath.Join(dir, "a.txt"), "a")
+	writeFileContent(t, filepath.Join(dir, "b.mp4"), "b")
+
+	b := waitForBatch(t, batches)
+	assert.Equal(t, []string{filepath.Join(dir, "b.mp4")}, b.changed)
+}
+
+func TestWatcher_Exclude(t *testing.T) {
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "excluded")
+	if err := os.Mkdir(existing, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	batches := startTestWatcher(t, dir)
+
+	// excluded directories are not watched, whether they exist when the
+	// watcher starts or are created later
+	writeFileContent(t, filepath.Join(existing, "a.mp4"), "a")
+	writeFileContent(t, filepath.Join(dir, "sub", "excluded", "b.mp4"), "b")
+
+	b := waitForBatch(t, batches)
+	assert.Equal(t, []string{filepath.Join(dir, "sub")}, b.changed)
+
+	writeFileContent(t, filepath.Join(dir, "sub", "excluded", "c.mp4"), "c")
+	assertNoBatch(t, batches)
+}
+
+func TestWatcher_NewDirectory(t *testing.T) {
+	dir := t.TempDir()
+	batches := startTestWatcher(t, dir)
+
+	sub := filepath.Join(dir, "sub")
+	writeFileContent(t, filepath.Join(sub, "a.mp4"), "a")
+
+	// files in new directories are reported as the directory
+	b := waitForBatch(t, batches)
+	assert.Equal(t, []string{sub}, b.changed)
+
+	// the new directory is watched
+	p := filepath.Join(sub, "b.mp4")
+	writeFileContent(t, p, "b")
+
+	b = waitForBatch(t, batches)
+	assert.Equal(t, []string{p}, b.changed)
+}
+
+func TestWatcher_Removed(t *testing.T) {
+	dir := t.TempDir()
+	p := filepath.Join(dir, "a.mp4")
+	writeFileContent(t, p, "a")
+
+	batches := startTestWatcher(t, dir)
+
+	if err := os.Remove(p); err != nil {
+		t.Fatal(err)
+	}
+
+	b := waitForBatch(t, batches)
+	assert.Empty(t, b.changed)
+	assert.Equal(t, []string{p}, b.removed)
+}
+
+func TestTopLevelPaths(t *testing.T) {
+	a := filepath.FromSlash("/library/a")
+	ab := filepath.Join(a, "b")
+	abc := filepath.Join(ab, "c.mp4")
+	ax := filepath.FromSlash("/library/ax")
+
+	tests := []struct {
+		name  string
+		paths []string
+		want  []string
+	}{
+		{"empty", nil, nil},
+		{"nested", []string{abc, a, ab}, []string{a}},
+		{"sibling with common prefix", []string{ax, a}, []string{a, ax}},
+		{"duplicates", []string{ab, ab}, []string{ab}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, topLevelPaths(tt.paths))
+		})
+	}
+}
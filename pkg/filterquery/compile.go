@@ -0,0 +1,435 @@
+package filterquery
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// Resolver finds the objects referred to by name in queries.
+type Resolver interface {
+	// FindIDs returns the IDs of the objects of the filter mode with the
+	// provided name. Returns an empty slice if none are found.
+	FindIDs(ctx context.Context, mode models.FilterMode, name string) ([]string, error)
+}
+
+// keyAliases maps shorthand query keys to filter fields.
+var keyAliases = map[string]string{
+	"rating":    "rating100",
+	"o":         "o_counter",
+	"tag":       "tags",
+	"performer": "performers",
+	"studio":    "studios",
+	"group":     "groups",
+	"movie":     "movies",
+	"gallery":   "galleries",
+	"scene":     "scenes",
+	"parent":    "parents",
+	"child":     "children",
+}
+
+// filterField is a criterion field of a filter type.
+type filterField struct {
+	name  string
+	index []int
+	typ   reflect.Type
+}
+
+type compiler struct {
+	ctx      context.Context
+	query    string
+	resolver Resolver
+
+	filterType   reflect.Type
+	fields       map[string]filterField
+	hasOperators bool
+}
+
+// Compile compiles query into a filter of type T, which must be one of the
+// filter types of the models package. Objects referred to by name are found
+// using r. Returns nil if the query is empty. Errors in the query are
+// returned as *Error.
+func Compile[T any](ctx context.Context, query string, r Resolver) (*T, error) {
+	n, err := parse(query)
+	if err != nil || n == nil {
+		return nil, err
+	}
+
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	_, hasOperators := t.FieldByName("And")
+
+	c := &compiler{
+		ctx:          ctx,
+		query:        query,
+		resolver:     r,
+		filterType:   t,
+		fields:       filterFields(t),
+		hasOperators: hasOperators,
+	}
+
+	v, err := c.compile(n)
+	if err != nil {
+		return nil, err
+	}
+
+	return v.Interface().(*T), nil
+}
+
+// And returns a filter matching the objects matched by both a and b, either
+// of which may be nil. a or b may be modified. Returns an error if the
+// filters cannot be combined.
+func And[T any](a, b *T) (*T, error) {
+	if a == nil {
+		return b, nil
+	}
+	if b == nil {
+		return a, nil
+	}
+
+	av := reflect.ValueOf(a)
+	bv := reflect.ValueOf(b)
+
+	if _, ok := av.Elem().Type().FieldByName("And"); !ok {
+		return nil, fmt.Errorf("%s filters cannot be combined", av.Elem().Type().Name())
+	}
+
+	if tail := andTail(av); tail.IsValid() {
+		tail.Elem().FieldByName("And").Set(bv)
+		return a, nil
+	}
+
+	if tail := andTail(bv); tail.IsValid() {
+		tail.Elem().FieldByName("And").Set(av)
+		return b, nil
+	}
+
+	return nil, fmt.Errorf("%s filters cannot be combined", av.Elem().Type().Name())
+}
+
+// andTail returns the last filter in the chain of AND sub-filters of v, if it
+// has no other sub-filters. Otherwise returns the zero Value.
+func andTail(v reflect.Value) reflect.Value {
+	for {
+		e := v.Elem()
+		if !e.FieldByName("Or").IsNil() || !e.FieldByName("Not").IsNil() {
+			return reflect.Value{}
+		}
+
+		and := e.FieldByName("And")
+		if and.IsNil() {
+			return v
+		}
+		v = and
+	}
+}
+
+// filterFields returns the criterion fields of the filter type t by JSON
+// name. Sub-filters of related objects are not included.
+func filterFields(t reflect.Type) map[string]filterField {
+	ret := make(map[string]filterField)
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Anonymous || !f.IsExported() {
+			continue
+		}
+
+		if f.Type.Kind() == reflect.Ptr && f.Type.Elem().Kind() == reflect.Struct && strings.HasSuffix(f.Type.Elem().Name(), "FilterType") {
+			continue
+		}
+
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+
+		ret[name] = filterField{
+			name:  name,
+			index: f.Index,
+			typ:   f.Type,
+		}
+	}
+
+	return ret
+}
+
+func (c *compiler) errorf(offset int, format string, args ...interface{}) error {
+	return newError(c.query, offset, format, args...)
+}
+
+func (c *compiler) field(t *termNode) (filterField, error) {
+	if alias, ok := keyAliases[t.key]; ok {
+		if f, ok := c.fields[alias]; ok {
+			return f, nil
+		}
+	}
+
+	if f, ok := c.fields[t.key]; ok {
+		return f, nil
+	}
+
+	if f, ok := c.fields[t.key+"s"]; ok {
+		return f, nil
+	}
+
+	return filterField{}, c.errorf(t.pos, "unknown field %q", t.key)
+}
+
+func (c *compiler) newFilter() reflect.Value {
+	return reflect.New(c.filterType)
+}
+
+func (c *compiler) isFlat(v reflect.Value) bool {
+	if !c.hasOperators {
+		return true
+	}
+
+	e := v.Elem()
+	return e.FieldByName("And").IsNil() && e.FieldByName("Or").IsNil() && e.FieldByName("Not").IsNil()
+}
+
+// setSub sets the sub-filter op of v to sub.
+func (c *compiler) setSub(v reflect.Value, op string, sub reflect.Value, offset int) error {
+	if !c.hasOperators {
+		return c.errorf(offset, "%s cannot be used with %s", strings.ToUpper(op), c.filterType.Name())
+	}
+
+	v.Elem().FieldByName(op).Set(sub)
+	return nil
+}
+
+func (c *compiler) compile(n node) (reflect.Value, error) {
+	switch n := n.(type) {
+	case *termNode:
+		return c.compileAnd([]node{n}, n.pos)
+	case *notNode:
+		return c.compileAnd([]node{n}, n.pos)
+	case *andNode:
+		return c.compileAnd(n.items, n.pos)
+	case *orNode:
+		return c.compileOr(n)
+	}
+
+	panic(fmt.Sprintf("unknown node type %T", n))
+}
+
+// flattenAnd flattens nested AND groups and removes double negations.
+func flattenAnd(items []node) []node {
+	var ret []node
+	for _, item := range items {
+		for {
+			n, ok := item.(*notNode)
+			if !ok {
+				break
+			}
+			inner, ok := n.expr.(*notNode)
+			if !ok {
+				break
+			}
+			item = inner.expr
+		}
+
+		if and, ok := item.(*andNode); ok {
+			ret = append(ret, flattenAnd(and.items)...)
+			continue
+		}
+
+		ret = append(ret, item)
+	}
+
+	return ret
+}
+
+// compileAnd compiles a group of items which must all match. Criteria are
+// set on a chain of filters joined by AND. Negated items that cannot be
+// expressed with a modifier are combined into a single NOT sub-filter. At
+// most one OR group or NOT sub-filter can be used in the group.
+func (c *compiler) compileAnd(items []node, offset int) (reflect.Value, error) {
+	var (
+		criteria       []*criterion
+		subs           []reflect.Value
+		subOffsets     []int
+		negated        []reflect.Value
+		negatedOffsets []int
+	)
+
+	for _, item := range flattenAnd(items) {
+		term, _ := item.(*termNode)
+		negate := false
+		if n, ok := item.(*notNode); ok {
+			term, negate = n.expr.(*termNode)
+		}
+
+		if term != nil {
+			crit, err := c.criterion(term)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			if negate {
+				crit.negate()
+			}
+
+			if !crit.inverted {
+				criteria = append(criteria, crit)
+				continue
+			}
+
+			v := c.newFilter()
+			v.Elem().FieldByIndex(crit.field.index).Set(crit.value)
+			negated = append(negated, v)
+			negatedOffsets = append(negatedOffsets, item.offset())
+			continue
+		}
+
+		switch n := item.(type) {
+		case *notNode:
+			v, err := c.compile(n.expr)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			negated = append(negated, v)
+			negatedOffsets = append(negatedOffsets, n.pos)
+		default:
+			v, err := c.compile(n)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			subs = append(subs, v)
+			subOffsets = append(subOffsets, n.offset())
+		}
+	}
+
+	ret := c.newFilter()
+	chain := []reflect.Value{ret}
+
+	for _, crit := range criteria {
+		placed := false
+		for _, level := range chain {
+			if crit.place(level) {
+				placed = true
+				break
+			}
+		}
+
+		if placed {
+			continue
+		}
+
+		if !c.hasOperators {
+			return reflect.Value{}, c.errorf(crit.node.pos, "%q cannot be used more than once with %s", crit.field.name, c.filterType.Name())
+		}
+
+		level := c.newFilter()
+		crit.place(level)
+		chain[len(chain)-1].Elem().FieldByName("And").Set(level)
+		chain = append(chain, level)
+	}
+
+	var terminal reflect.Value
+	terminalOp := ""
+
+	if len(negated) > 0 {
+		v, err := c.orChain(negated, negatedOffsets)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		terminal = v
+		terminalOp = "Not"
+	}
+
+	for i, sub := range subs {
+		if terminal.IsValid() {
+			return reflect.Value{}, c.errorf(subOffsets[i], "cannot combine more than one OR group or negated group")
+		}
+		terminal = sub
+		terminalOp = "And"
+	}
+
+	if !terminal.IsValid() {
+		return ret, nil
+	}
+
+	// a single sub-filter does not need to be wrapped
+	if len(criteria) == 0 && terminalOp == "And" {
+		return terminal, nil
+	}
+
+	if err := c.setSub(chain[len(chain)-1], terminalOp, terminal, offset); err != nil {
+		return reflect.Value{}, err
+	}
+
+	return ret, nil
+}
+
+func (c *compiler) compileOr(n *orNode) (reflect.Value, error) {
+	var (
+		filters []reflect.Value
+		offsets []int
+	)
+
+	var add func(items []node) error
+	add = func(items []node) error {
+		for _, item := range items {
+			if or, ok := item.(*orNode); ok {
+				if err := add(or.items); err != nil {
+					return err
+				}
+				continue
+			}
+
+			v, err := c.compile(item)
+			if err != nil {
+				return err
+			}
+			filters = append(filters, v)
+			offsets = append(offsets, item.offset())
+		}
+		return nil
+	}
+
+	if err := add(n.items); err != nil {
+		return reflect.Value{}, err
+	}
+
+	if !c.hasOperators {
+		return reflect.Value{}, c.errorf(n.pos, "%s cannot be used with %s", keywordOr, c.filterType.Name())
+	}
+
+	return c.orChain(filters, offsets)
+}
+
+// orChain returns a filter matching any of filters, chained with OR. At most
+// one of the filters may have sub-filters.
+func (c *compiler) orChain(filters []reflect.Value, offsets []int) (reflect.Value, error) {
+	var (
+		flat    []reflect.Value
+		complex reflect.Value
+	)
+
+	for i, f := range filters {
+		if c.isFlat(f) {
+			flat = append(flat, f)
+			continue
+		}
+
+		if complex.IsValid() {
+			return reflect.Value{}, c.errorf(offsets[i], "cannot combine more than one group in an OR")
+		}
+		complex = f
+	}
+
+	if complex.IsValid() {
+		flat = append(flat, complex)
+	}
+
+	for i := 1; i < len(flat); i++ {
+		if err := c.setSub(flat[i-1], "Or", flat[i], offsets[0]); err != nil {
+			return reflect.Value{}, err
+		}
+	}
+
+	return flat[0], nil
+}
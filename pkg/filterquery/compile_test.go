@@ -0,0 +1,273 @@
+package filterquery
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+var testCtx = context.Background()
+
+type mapResolver map[models.FilterMode]map[string][]string
+
+func (r mapResolver) FindIDs(ctx context.Context, mode models.FilterMode, name string) ([]string, error) {
+	return r[mode][name], nil
+}
+
+var testResolver = mapResolver{
+	models.FilterModeTags: {
+		"outdoor": {"1"},
+		"vr":      {"2"},
+		"indoor":  {"3"},
+	},
+	models.FilterModePerformers: {
+		"alice": {"10"},
+		"bob":   {"11", "12"},
+	},
+	models.FilterModeStudios: {
+		"acme": {"20"},
+	},
+}
+
+func intPtr(i int) *int       { return &i }
+func strPtr(s string) *string { return &s }
+func boolPtr(b bool) *bool    { return &b }
+func hierarchical(m models.CriterionModifier, ids ...string) *models.HierarchicalMultiCriterionInput {
+	return &models.HierarchicalMultiCriterionInput{Value: ids, Modifier: m}
+}
+func multi(m models.CriterionModifier, ids ...string) *models.MultiCriterionInput {
+	return &models.MultiCriterionInput{Value: ids, Modifier: m}
+}
+
+func TestCompileScene(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  *models.SceneFilterType
+	}{
+		{
+			"example",
+			`tag:"outdoor" -tag:"vr" performer:alice rating>=80 duration>20m resolution>=1080p`,
+			&models.SceneFilterType{
+				Tags: &models.HierarchicalMultiCriterionInput{
+					Value:    []string{"1"},
+					Modifier: models.CriterionModifierIncludes,
+					Excludes: []string{"2"},
+				},
+				Performers: multi(models.CriterionModifierIncludes, "10"),
+				Rating100:  &models.IntCriterionInput{Value: 79, Modifier: models.CriterionModifierGreaterThan},
+				Duration:   &models.IntCriterionInput{Value: 1200, Modifier: models.CriterionModifierGreaterThan},
+				Resolution: &models.ResolutionCriterionInput{Value: models.ResolutionEnumStandardHd, Modifier: models.CriterionModifierGreaterThan},
+			},
+		},
+		{
+			"includes all",
+			`tag:outdoor tag:indoor`,
+			&models.SceneFilterType{
+				Tags: hierarchical(models.CriterionModifierIncludesAll, "1", "3"),
+			},
+		},
+		{
+			"multiple matches",
+			`performer:bob`,
+			&models.SceneFilterType{
+				Performers: multi(models.CriterionModifierIncludes, "11", "12"),
+			},
+		},
+		{
+			"id fallback",
+			`studio:5 gallery:6`,
+			&models.SceneFilterType{
+				Studios:   hierarchical(models.CriterionModifierIncludes, "5"),
+				Galleries: multi(models.CriterionModifierIncludes, "6"),
+			},
+		},
+		{
+			"strings",
+			`title:foo path~"^/a" -details:bar code=null`,
+			&models.SceneFilterType{
+				Title:   &models.StringCriterionInput{Value: "foo", Modifier: models.CriterionModifierIncludes},
+				Path:    &models.StringCriterionInput{Value: "^/a", Modifier: models.CriterionModifierMatchesRegex},
+				Details: &models.StringCriterionInput{Value: "bar", Modifier: models.CriterionModifierExcludes},
+				Code:    &models.StringCriterionInput{Modifier: models.CriterionModifierIsNull},
+			},
+		},
+		{
+			"ranges",
+			`o:2..5 play_duration<=1h30m date:2023`,
+			&models.SceneFilterType{
+				OCounter:     &models.IntCriterionInput{Value: 2, Value2: intPtr(5), Modifier: models.CriterionModifierBetween},
+				PlayDuration: &models.IntCriterionInput{Value: 5401, Modifier: models.CriterionModifierLessThan},
+				Date:         &models.DateCriterionInput{Value: "2023-01-01", Value2: strPtr("2023-12-31"), Modifier: models.CriterionModifierBetween},
+			},
+		},
+		{
+			"dates",
+			`date>=2023-02 -date<2020`,
+			&models.SceneFilterType{
+				Date: &models.DateCriterionInput{Value: "2023-01-31", Modifier: models.CriterionModifierGreaterThan},
+				OperatorFilter: models.OperatorFilter[models.SceneFilterType]{
+					And: &models.SceneFilterType{
+						Date: &models.DateCriterionInput{Value: "2019-12-31", Modifier: models.CriterionModifierGreaterThan},
+					},
+				},
+			},
+		},
+		{
+			"booleans and enums",
+			`organized:yes -interactive:true is_missing:studio`,
+			&models.SceneFilterType{
+				Organized:   boolPtr(true),
+				Interactive: boolPtr(false),
+				IsMissing:   strPtr("studio"),
+			},
+		},
+		{
+			"or",
+			`studio:acme OR tag:vr`,
+			&models.SceneFilterType{
+				Studios: hierarchical(models.CriterionModifierIncludes, "20"),
+				OperatorFilter: models.OperatorFilter[models.SceneFilterType]{
+					Or: &models.SceneFilterType{
+						Tags: hierarchical(models.CriterionModifierIncludes, "2"),
+					},
+				},
+			},
+		},
+		{
+			"and with or group",
+			`performer:alice (tag:outdoor OR tag:indoor)`,
+			&models.SceneFilterType{
+				Performers: multi(models.CriterionModifierIncludes, "10"),
+				OperatorFilter: models.OperatorFilter[models.SceneFilterType]{
+					And: &models.SceneFilterType{
+						Tags: hierarchical(models.CriterionModifierIncludes, "1"),
+						OperatorFilter: models.OperatorFilter[models.SceneFilterType]{
+							Or: &models.SceneFilterType{
+								Tags: hierarchical(models.CriterionModifierIncludes, "3"),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			"negated group",
+			`organized:true -(tag:vr studio:acme)`,
+			&models.SceneFilterType{
+				Organized: boolPtr(true),
+				OperatorFilter: models.OperatorFilter[models.SceneFilterType]{
+					Not: &models.SceneFilterType{
+						Tags:    hierarchical(models.CriterionModifierIncludes, "2"),
+						Studios: hierarchical(models.CriterionModifierIncludes, "20"),
+					},
+				},
+			},
+		},
+		{
+			"inverted",
+			`resolution<=8k -NOT orientation:portrait`,
+			&models.SceneFilterType{
+				Orientation: &models.OrientationCriterionInput{Value: []models.OrientationEnum{models.OrientationPortrait}},
+				Resolution:  &models.ResolutionCriterionInput{Value: models.ResolutionEnumHuge, Modifier: models.CriterionModifierLessThan},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Compile[models.SceneFilterType](testCtx, tt.query, testResolver)
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCompileOtherModes(t *testing.T) {
+	performer, err := Compile[models.PerformerFilterType](testCtx, `gender:female rating>90 tag:vr`, testResolver)
+	if assert.NoError(t, err) {
+		assert.Equal(t, &models.PerformerFilterType{
+			Gender:    &models.GenderCriterionInput{Value: models.GenderEnumFemale, Modifier: models.CriterionModifierEquals},
+			Rating100: &models.IntCriterionInput{Value: 90, Modifier: models.CriterionModifierGreaterThan},
+			Tags:      hierarchical(models.CriterionModifierIncludes, "2"),
+		}, performer)
+	}
+
+	tag, err := Compile[models.TagFilterType](testCtx, `parent:outdoor`, testResolver)
+	if assert.NoError(t, err) {
+		assert.Equal(t, &models.TagFilterType{
+			Parents: hierarchical(models.CriterionModifierIncludes, "1"),
+		}, tag)
+	}
+
+	marker, err := Compile[models.SceneMarkerFilterType](testCtx, `tag:vr scene_date>2020`, testResolver)
+	if assert.NoError(t, err) {
+		assert.Equal(t, &models.SceneMarkerFilterType{
+			Tags:      hierarchical(models.CriterionModifierIncludes, "2"),
+			SceneDate: &models.DateCriterionInput{Value: "2020-12-31", Modifier: models.CriterionModifierGreaterThan},
+		}, marker)
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	tests := []struct {
+		query string
+		want  Error
+	}{
+		{`foo:1`, Error{Pos: 1, Msg: `unknown field "foo"`}},
+		{`tag:nope`, Error{Pos: 5, Msg: `"nope" not found`}},
+		{`rating>abc`, Error{Pos: 8, Msg: `invalid number "abc"`}},
+		{`title>abc`, Error{Pos: 6, Msg: `operator ">" cannot be used with "title"`}},
+		{`duration>soon`, Error{Pos: 10, Msg: `invalid duration "soon"`}},
+		{`resolution:huge-ish`, Error{Pos: 12, Msg: `invalid resolution "huge-ish"`}},
+		{`(tag:vr OR organized:true) (tag:outdoor OR organized:false)`, Error{Pos: 28, Msg: "cannot combine more than one OR group or negated group"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			_, err := Compile[models.SceneFilterType](testCtx, tt.query, testResolver)
+
+			var got *Error
+			if assert.True(t, errors.As(err, &got), "expected *Error, got %v", err) {
+				assert.Equal(t, tt.want, *got)
+			}
+		})
+	}
+
+	_, err := Compile[models.SceneMarkerFilterType](testCtx, `tag:vr OR tag:outdoor`, testResolver)
+	assert.Error(t, err)
+}
+
+func TestAnd(t *testing.T) {
+	a := &models.SceneFilterType{Organized: boolPtr(true)}
+	b := &models.SceneFilterType{
+		Title: &models.StringCriterionInput{Value: "x", Modifier: models.CriterionModifierEquals},
+		OperatorFilter: models.OperatorFilter[models.SceneFilterType]{
+			Or: &models.SceneFilterType{Organized: boolPtr(false)},
+		},
+	}
+
+	got, err := And(a, b)
+	if assert.NoError(t, err) {
+		assert.Same(t, a, got)
+		assert.Same(t, b, got.And)
+	}
+
+	c := &models.SceneFilterType{
+		OperatorFilter: models.OperatorFilter[models.SceneFilterType]{
+			Not: &models.SceneFilterType{Organized: boolPtr(false)},
+		},
+	}
+	d := &models.SceneFilterType{
+		OperatorFilter: models.OperatorFilter[models.SceneFilterType]{
+			Or: &models.SceneFilterType{Organized: boolPtr(false)},
+		},
+	}
+	_, err = And(c, d)
+	assert.Error(t, err)
+}
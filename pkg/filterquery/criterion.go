@@ -0,0 +1,839 @@
+package filterquery
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// criterion is the value of a filter field compiled from a term.
+type criterion struct {
+	node  *termNode
+	field filterField
+	// value is assignable to the filter field.
+	value reflect.Value
+	// inverted is true if the term matches the objects not matched by value.
+	inverted bool
+}
+
+var negatedModifiers = map[models.CriterionModifier]models.CriterionModifier{
+	models.CriterionModifierEquals:          models.CriterionModifierNotEquals,
+	models.CriterionModifierNotEquals:       models.CriterionModifierEquals,
+	models.CriterionModifierIncludes:        models.CriterionModifierExcludes,
+	models.CriterionModifierExcludes:        models.CriterionModifierIncludes,
+	models.CriterionModifierMatchesRegex:    models.CriterionModifierNotMatchesRegex,
+	models.CriterionModifierNotMatchesRegex: models.CriterionModifierMatchesRegex,
+	models.CriterionModifierIsNull:          models.CriterionModifierNotNull,
+	models.CriterionModifierNotNull:         models.CriterionModifierIsNull,
+	models.CriterionModifierBetween:         models.CriterionModifierNotBetween,
+	models.CriterionModifierNotBetween:      models.CriterionModifierBetween,
+}
+
+// negate changes the criterion to match the objects it did not match,
+// using the opposite modifier where possible.
+func (c *criterion) negate() {
+	if c.inverted {
+		c.inverted = false
+		return
+	}
+
+	switch v := c.value.Interface().(type) {
+	case *models.StringCriterionInput:
+		if m, ok := negatedModifiers[v.Modifier]; ok {
+			v.Modifier = m
+			return
+		}
+	case *models.IntCriterionInput:
+		switch v.Modifier {
+		case models.CriterionModifierGreaterThan:
+			v.Modifier = models.CriterionModifierLessThan
+			v.Value++
+			return
+		case models.CriterionModifierLessThan:
+			v.Modifier = models.CriterionModifierGreaterThan
+			v.Value--
+			return
+		}
+		if m, ok := negatedModifiers[v.Modifier]; ok {
+			v.Modifier = m
+			return
+		}
+	case *models.FloatCriterionInput:
+		if m, ok := negatedModifiers[v.Modifier]; ok {
+			v.Modifier = m
+			return
+		}
+	case *models.DateCriterionInput:
+		switch v.Modifier {
+		case models.CriterionModifierGreaterThan:
+			if d, err := time.Parse(dateFormat, v.Value); err == nil {
+				v.Modifier = models.CriterionModifierLessThan
+				v.Value = d.AddDate(0, 0, 1).Format(dateFormat)
+				return
+			}
+		case models.CriterionModifierLessThan:
+			if d, err := time.Parse(dateFormat, v.Value); err == nil {
+				v.Modifier = models.CriterionModifierGreaterThan
+				v.Value = d.AddDate(0, 0, -1).Format(dateFormat)
+				return
+			}
+		}
+		if m, ok := negatedModifiers[v.Modifier]; ok {
+			v.Modifier = m
+			return
+		}
+	case *models.TimestampCriterionInput:
+		if m, ok := negatedModifiers[v.Modifier]; ok {
+			v.Modifier = m
+			return
+		}
+	case *models.ResolutionCriterionInput:
+		i := resolutionIndex(v.Value)
+		switch {
+		case v.Modifier == models.CriterionModifierGreaterThan && i+1 < len(resolutions):
+			v.Modifier = models.CriterionModifierLessThan
+			v.Value = resolutions[i+1]
+			return
+		case v.Modifier == models.CriterionModifierLessThan && i > 0:
+			v.Modifier = models.CriterionModifierGreaterThan
+			v.Value = resolutions[i-1]
+			return
+		}
+		if m, ok := negatedModifiers[v.Modifier]; ok {
+			v.Modifier = m
+			return
+		}
+	case *models.MultiCriterionInput:
+		if m, ok := negatedModifiers[v.Modifier]; ok {
+			v.Modifier = m
+			return
+		}
+	case *models.HierarchicalMultiCriterionInput:
+		if m, ok := negatedModifiers[v.Modifier]; ok {
+			v.Modifier = m
+			return
+		}
+	case *models.GenderCriterionInput:
+		if m, ok := negatedModifiers[v.Modifier]; ok {
+			v.Modifier = m
+			return
+		}
+	case *bool:
+		*v = !*v
+		return
+	}
+
+	c.inverted = true
+}
+
+// place sets the criterion on the filter v, merging it with an existing
+// criterion of the same field if possible. Returns false if the field is
+// already set and the criteria cannot be merged.
+func (c *criterion) place(v reflect.Value) bool {
+	f := v.Elem().FieldByIndex(c.field.index)
+	if f.IsNil() {
+		f.Set(c.value)
+		return true
+	}
+
+	switch existing := f.Interface().(type) {
+	case *models.MultiCriterionInput:
+		n := c.value.Interface().(*models.MultiCriterionInput)
+		return mergeMulti(&existing.Value, &existing.Modifier, &existing.Excludes, n.Value, n.Modifier)
+	case *models.HierarchicalMultiCriterionInput:
+		n := c.value.Interface().(*models.HierarchicalMultiCriterionInput)
+		return mergeMulti(&existing.Value, &existing.Modifier, &existing.Excludes, n.Value, n.Modifier)
+	}
+
+	return false
+}
+
+// mergeMulti merges the values of a multi criterion into an existing one, so
+// that both must match.
+func mergeMulti(value *[]string, modifier *models.CriterionModifier, excludes *[]string, newValue []string, newModifier models.CriterionModifier) bool {
+	switch newModifier {
+	case models.CriterionModifierExcludes:
+		switch *modifier {
+		case models.CriterionModifierExcludes:
+			*value = append(*value, newValue...)
+			return true
+		case models.CriterionModifierIncludes, models.CriterionModifierIncludesAll:
+			*excludes = append(*excludes, newValue...)
+			return true
+		}
+	case models.CriterionModifierIncludes:
+		// only a single included object can be required by INCLUDES_ALL
+		if len(newValue) != 1 {
+			return false
+		}
+
+		switch *modifier {
+		case models.CriterionModifierExcludes:
+			*excludes = append(*excludes, *value...)
+			*value = newValue
+			*modifier = models.CriterionModifierIncludesAll
+			return true
+		case models.CriterionModifierIncludes, models.CriterionModifierIncludesAll:
+			if *modifier == models.CriterionModifierIncludes && len(*value) != 1 {
+				return false
+			}
+			*value = append(*value, newValue...)
+			*modifier = models.CriterionModifierIncludesAll
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *compiler) criterion(t *termNode) (*criterion, error) {
+	field, err := c.field(t)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &criterion{
+		node:  t,
+		field: field,
+	}
+
+	var v interface{}
+
+	switch field.typ {
+	case reflect.TypeOf(&models.StringCriterionInput{}):
+		v, err = c.stringCriterion(t)
+	case reflect.TypeOf(&models.IntCriterionInput{}):
+		v, err = c.intCriterion(t, isDurationField(field.name))
+	case reflect.TypeOf(&models.FloatCriterionInput{}):
+		v, ret.inverted, err = c.floatCriterion(t)
+	case reflect.TypeOf(&models.DateCriterionInput{}):
+		v, err = c.dateCriterion(t)
+	case reflect.TypeOf(&models.TimestampCriterionInput{}):
+		v, ret.inverted, err = c.timestampCriterion(t)
+	case reflect.TypeOf(&models.ResolutionCriterionInput{}):
+		v, ret.inverted, err = c.resolutionCriterion(t)
+	case reflect.TypeOf(&models.MultiCriterionInput{}):
+		var ids []string
+		var m models.CriterionModifier
+		ids, m, err = c.multiValue(t, field)
+		v = &models.MultiCriterionInput{Value: ids, Modifier: m}
+	case reflect.TypeOf(&models.HierarchicalMultiCriterionInput{}):
+		var ids []string
+		var m models.CriterionModifier
+		ids, m, err = c.multiValue(t, field)
+		v = &models.HierarchicalMultiCriterionInput{Value: ids, Modifier: m}
+	case reflect.TypeOf(&models.GenderCriterionInput{}):
+		v, err = c.genderCriterion(t)
+	case reflect.TypeOf(&models.OrientationCriterionInput{}):
+		v, ret.inverted, err = c.orientationCriterion(t)
+	case reflect.TypeOf((*bool)(nil)):
+		v, err = c.boolValue(t)
+	default:
+		v, ret.inverted, err = c.scalarValue(t, field)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	ret.value = reflect.ValueOf(v)
+	return ret, nil
+}
+
+func (c *compiler) operatorError(t *termNode) error {
+	return c.errorf(t.opPos, "operator %q cannot be used with %q", t.op, t.key)
+}
+
+func (c *compiler) valueError(t *termNode, what string) error {
+	return c.errorf(t.valuePos, "invalid %s %q", what, t.value)
+}
+
+// isNull returns true if the value of the term is the null keyword.
+func isNull(t *termNode) bool {
+	return !t.quoted && strings.EqualFold(t.value, "null")
+}
+
+func (c *compiler) nullModifier(t *termNode) (models.CriterionModifier, error) {
+	switch t.op {
+	case opContains, opEquals:
+		return models.CriterionModifierIsNull, nil
+	case opNotEquals:
+		return models.CriterionModifierNotNull, nil
+	}
+
+	return "", c.operatorError(t)
+}
+
+// splitRange splits a range value such as 10..20.
+func splitRange(t *termNode) (string, string, bool) {
+	if t.quoted {
+		return "", "", false
+	}
+
+	switch t.op {
+	case opContains, opEquals, opNotEquals:
+		return strings.Cut(t.value, "..")
+	}
+
+	return "", "", false
+}
+
+func (c *compiler) stringCriterion(t *termNode) (*models.StringCriterionInput, error) {
+	if isNull(t) {
+		m, err := c.nullModifier(t)
+		return &models.StringCriterionInput{Modifier: m}, err
+	}
+
+	var m models.CriterionModifier
+	switch t.op {
+	case opContains:
+		m = models.CriterionModifierIncludes
+	case opEquals:
+		m = models.CriterionModifierEquals
+	case opNotEquals:
+		m = models.CriterionModifierNotEquals
+	case opMatches:
+		m = models.CriterionModifierMatchesRegex
+	case opNotMatches:
+		m = models.CriterionModifierNotMatchesRegex
+	default:
+		return nil, c.operatorError(t)
+	}
+
+	return &models.StringCriterionInput{Value: t.value, Modifier: m}, nil
+}
+
+// isDurationField returns true if the integer field is a number of seconds.
+func isDurationField(name string) bool {
+	return strings.Contains(name, "duration") || name == "resume_time"
+}
+
+// parseDuration parses a duration such as 90, 20m, 1h30m or 1:30:00 as a
+// number of seconds.
+func parseDuration(s string) (int, error) {
+	if v, err := strconv.Atoi(s); err == nil {
+		return v, nil
+	}
+
+	if strings.Contains(s, ":") {
+		ret := 0
+		for _, part := range strings.Split(s, ":") {
+			v, err := strconv.Atoi(part)
+			if err != nil {
+				return 0, err
+			}
+			ret = ret*60 + v
+		}
+		return ret, nil
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(math.Round(d.Seconds())), nil
+}
+
+func (c *compiler) intCriterion(t *termNode, duration bool) (*models.IntCriterionInput, error) {
+	if isNull(t) {
+		m, err := c.nullModifier(t)
+		return &models.IntCriterionInput{Modifier: m}, err
+	}
+
+	parseInt := strconv.Atoi
+	what := "number"
+	if duration {
+		parseInt = parseDuration
+		what = "duration"
+	}
+
+	if lo, hi, ok := splitRange(t); ok {
+		v, err := parseInt(lo)
+		if err != nil {
+			return nil, c.valueError(t, what)
+		}
+		v2, err := parseInt(hi)
+		if err != nil {
+			return nil, c.valueError(t, what)
+		}
+
+		m := models.CriterionModifierBetween
+		if t.op == opNotEquals {
+			m = models.CriterionModifierNotBetween
+		}
+		return &models.IntCriterionInput{Value: v, Value2: &v2, Modifier: m}, nil
+	}
+
+	v, err := parseInt(t.value)
+	if err != nil {
+		return nil, c.valueError(t, what)
+	}
+
+	ret := &models.IntCriterionInput{Value: v}
+	switch t.op {
+	case opContains, opEquals:
+		ret.Modifier = models.CriterionModifierEquals
+	case opNotEquals:
+		ret.Modifier = models.CriterionModifierNotEquals
+	case opGreater:
+		ret.Modifier = models.CriterionModifierGreaterThan
+	case opGreaterEquals:
+		ret.Modifier = models.CriterionModifierGreaterThan
+		ret.Value--
+	case opLess:
+		ret.Modifier = models.CriterionModifierLessThan
+	case opLessEquals:
+		ret.Modifier = models.CriterionModifierLessThan
+		ret.Value++
+	default:
+		return nil, c.operatorError(t)
+	}
+
+	return ret, nil
+}
+
+func (c *compiler) floatCriterion(t *termNode) (*models.FloatCriterionInput, bool, error) {
+	if isNull(t) {
+		m, err := c.nullModifier(t)
+		return &models.FloatCriterionInput{Modifier: m}, false, err
+	}
+
+	if lo, hi, ok := splitRange(t); ok {
+		v, err := strconv.ParseFloat(lo, 64)
+		if err != nil {
+			return nil, false, c.valueError(t, "number")
+		}
+		v2, err := strconv.ParseFloat(hi, 64)
+		if err != nil {
+			return nil, false, c.valueError(t, "number")
+		}
+
+		m := models.CriterionModifierBetween
+		if t.op == opNotEquals {
+			m = models.CriterionModifierNotBetween
+		}
+		return &models.FloatCriterionInput{Value: v, Value2: &v2, Modifier: m}, false, nil
+	}
+
+	v, err := strconv.ParseFloat(t.value, 64)
+	if err != nil {
+		return nil, false, c.valueError(t, "number")
+	}
+
+	ret := &models.FloatCriterionInput{Value: v}
+	inverted := false
+	switch t.op {
+	case opContains, opEquals:
+		ret.Modifier = models.CriterionModifierEquals
+	case opNotEquals:
+		ret.Modifier = models.CriterionModifierNotEquals
+	case opGreater:
+		ret.Modifier = models.CriterionModifierGreaterThan
+	case opGreaterEquals:
+		ret.Modifier = models.CriterionModifierLessThan
+		inverted = true
+	case opLess:
+		ret.Modifier = models.CriterionModifierLessThan
+	case opLessEquals:
+		ret.Modifier = models.CriterionModifierGreaterThan
+		inverted = true
+	default:
+		return nil, false, c.operatorError(t)
+	}
+
+	return ret, inverted, nil
+}
+
+const dateFormat = "2006-01-02"
+
+// dateRange returns the first and last days of a date, which may be a year,
+// a month or a day.
+func dateRange(s string) (time.Time, time.Time, error) {
+	if d, err := time.Parse(dateFormat, s); err == nil {
+		return d, d, nil
+	}
+
+	if d, err := time.Parse("2006-01", s); err == nil {
+		return d, d.AddDate(0, 1, -1), nil
+	}
+
+	d, err := time.Parse("2006", s)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	return d, d.AddDate(1, 0, -1), nil
+}
+
+func (c *compiler) dateCriterion(t *termNode) (*models.DateCriterionInput, error) {
+	if isNull(t) {
+		m, err := c.nullModifier(t)
+		return &models.DateCriterionInput{Modifier: m}, err
+	}
+
+	var start, end time.Time
+	if lo, hi, ok := splitRange(t); ok {
+		var err error
+		start, _, err = dateRange(lo)
+		if err != nil {
+			return nil, c.valueError(t, "date")
+		}
+		_, end, err = dateRange(hi)
+		if err != nil {
+			return nil, c.valueError(t, "date")
+		}
+	} else {
+		var err error
+		start, end, err = dateRange(t.value)
+		if err != nil {
+			return nil, c.valueError(t, "date")
+		}
+	}
+
+	ret := &models.DateCriterionInput{}
+	switch t.op {
+	case opContains, opEquals, opNotEquals:
+		ret.Value = start.Format(dateFormat)
+		ret.Modifier = models.CriterionModifierEquals
+		if !start.Equal(end) {
+			value2 := end.Format(dateFormat)
+			ret.Value2 = &value2
+			ret.Modifier = models.CriterionModifierBetween
+		}
+
+		if t.op == opNotEquals {
+			ret.Modifier = negatedModifiers[ret.Modifier]
+		}
+	case opGreater:
+		ret.Value = end.Format(dateFormat)
+		ret.Modifier = models.CriterionModifierGreaterThan
+	case opGreaterEquals:
+		ret.Value = start.AddDate(0, 0, -1).Format(dateFormat)
+		ret.Modifier = models.CriterionModifierGreaterThan
+	case opLess:
+		ret.Value = start.Format(dateFormat)
+		ret.Modifier = models.CriterionModifierLessThan
+	case opLessEquals:
+		ret.Value = end.AddDate(0, 0, 1).Format(dateFormat)
+		ret.Modifier = models.CriterionModifierLessThan
+	default:
+		return nil, c.operatorError(t)
+	}
+
+	return ret, nil
+}
+
+func (c *compiler) timestampCriterion(t *termNode) (*models.TimestampCriterionInput, bool, error) {
+	if isNull(t) {
+		m, err := c.nullModifier(t)
+		return &models.TimestampCriterionInput{Modifier: m}, false, err
+	}
+
+	if lo, hi, ok := splitRange(t); ok {
+		m := models.CriterionModifierBetween
+		if t.op == opNotEquals {
+			m = models.CriterionModifierNotBetween
+		}
+		return &models.TimestampCriterionInput{Value: lo, Value2: &hi, Modifier: m}, false, nil
+	}
+
+	ret := &models.TimestampCriterionInput{Value: t.value}
+	inverted := false
+	switch t.op {
+	case opContains, opEquals:
+		ret.Modifier = models.CriterionModifierEquals
+	case opNotEquals:
+		ret.Modifier = models.CriterionModifierNotEquals
+	case opGreater:
+		ret.Modifier = models.CriterionModifierGreaterThan
+	case opGreaterEquals:
+		ret.Modifier = models.CriterionModifierLessThan
+		inverted = true
+	case opLess:
+		ret.Modifier = models.CriterionModifierLessThan
+	case opLessEquals:
+		ret.Modifier = models.CriterionModifierGreaterThan
+		inverted = true
+	default:
+		return nil, false, c.operatorError(t)
+	}
+
+	return ret, inverted, nil
+}
+
+// resolutions are the resolutions in ascending order. The deprecated VR_HD
+// range overlaps FOUR_K, so is not included.
+var resolutions = func() []models.ResolutionEnum {
+	var ret []models.ResolutionEnum
+	for _, r := range models.AllResolutionEnum {
+		if r != models.ResolutionEnumVrHd {
+			ret = append(ret, r)
+		}
+	}
+	return ret
+}()
+
+// resolutionNames maps the common names of resolutions.
+var resolutionNames = map[string]models.ResolutionEnum{
+	"144p":  models.ResolutionEnumVeryLow,
+	"240p":  models.ResolutionEnumLow,
+	"360p":  models.ResolutionEnumR360p,
+	"480p":  models.ResolutionEnumStandard,
+	"540p":  models.ResolutionEnumWebHd,
+	"720p":  models.ResolutionEnumStandardHd,
+	"1080p": models.ResolutionEnumFullHd,
+	"1440p": models.ResolutionEnumQuadHd,
+	"2160p": models.ResolutionEnumFourK,
+	"4k":    models.ResolutionEnumFourK,
+	"5k":    models.ResolutionEnumFiveK,
+	"6k":    models.ResolutionEnumSixK,
+	"7k":    models.ResolutionEnumSevenK,
+	"8k":    models.ResolutionEnumEightK,
+}
+
+func resolutionIndex(r models.ResolutionEnum) int {
+	for i, rr := range resolutions {
+		if rr == r {
+			return i
+		}
+	}
+	return -1
+}
+
+func parseResolution(s string) (models.ResolutionEnum, bool) {
+	if r, ok := resolutionNames[strings.ToLower(s)]; ok {
+		return r, true
+	}
+
+	r := models.ResolutionEnum(enumName(s))
+	return r, r.IsValid() && r != models.ResolutionEnumVrHd
+}
+
+func (c *compiler) resolutionCriterion(t *termNode) (*models.ResolutionCriterionInput, bool, error) {
+	r, ok := parseResolution(t.value)
+	if !ok {
+		return nil, false, c.valueError(t, "resolution")
+	}
+
+	i := resolutionIndex(r)
+	ret := &models.ResolutionCriterionInput{Value: r}
+	inverted := false
+
+	switch t.op {
+	case opContains, opEquals:
+		ret.Modifier = models.CriterionModifierEquals
+	case opNotEquals:
+		ret.Modifier = models.CriterionModifierNotEquals
+	case opGreater:
+		ret.Modifier = models.CriterionModifierGreaterThan
+	case opLess:
+		ret.Modifier = models.CriterionModifierLessThan
+	case opGreaterEquals:
+		if i > 0 {
+			ret.Value = resolutions[i-1]
+			ret.Modifier = models.CriterionModifierGreaterThan
+		} else {
+			ret.Modifier = models.CriterionModifierLessThan
+			inverted = true
+		}
+	case opLessEquals:
+		if i+1 < len(resolutions) {
+			ret.Value = resolutions[i+1]
+			ret.Modifier = models.CriterionModifierLessThan
+		} else {
+			ret.Modifier = models.CriterionModifierGreaterThan
+			inverted = true
+		}
+	default:
+		return nil, false, c.operatorError(t)
+	}
+
+	return ret, inverted, nil
+}
+
+// referencedMode returns the type of object referenced by a multi criterion
+// field, or false if the objects cannot be found by name.
+func (c *compiler) referencedMode(field string) (models.FilterMode, bool) {
+	switch {
+	case field == "tags" || strings.HasSuffix(field, "_tags"):
+		return models.FilterModeTags, true
+	case field == "performers":
+		return models.FilterModePerformers, true
+	case field == "studios":
+		return models.FilterModeStudios, true
+	case field == "groups" || field == "movies" || field == "containing_groups" || field == "sub_groups":
+		return models.FilterModeGroups, true
+	case field == "galleries":
+		return models.FilterModeGalleries, true
+	case field == "parents" || field == "children":
+		switch c.filterType {
+		case reflect.TypeOf(models.TagFilterType{}):
+			return models.FilterModeTags, true
+		case reflect.TypeOf(models.StudioFilterType{}):
+			return models.FilterModeStudios, true
+		}
+	}
+
+	return "", false
+}
+
+// multiValue returns the IDs and modifier of a multi criterion.
+func (c *compiler) multiValue(t *termNode, field filterField) ([]string, models.CriterionModifier, error) {
+	var m models.CriterionModifier
+	switch t.op {
+	case opContains, opEquals:
+		m = models.CriterionModifierIncludes
+	case opNotEquals:
+		m = models.CriterionModifierExcludes
+	default:
+		return nil, "", c.operatorError(t)
+	}
+
+	if isNull(t) {
+		m, err := c.nullModifier(t)
+		return nil, m, err
+	}
+
+	mode, ok := c.referencedMode(field.name)
+	if ok && c.resolver != nil {
+		ids, err := c.resolver.FindIDs(c.ctx, mode, t.value)
+		if err != nil {
+			return nil, "", fmt.Errorf("finding %s %q: %w", field.name, t.value, err)
+		}
+		if len(ids) > 0 {
+			return ids, m, nil
+		}
+	}
+
+	// fall back to treating the value as an ID
+	if _, err := strconv.Atoi(t.value); err != nil {
+		if ok {
+			return nil, "", c.errorf(t.valuePos, "%q not found", t.value)
+		}
+		return nil, "", c.valueError(t, "ID")
+	}
+
+	return []string{t.value}, m, nil
+}
+
+// enumName converts a value such as full-hd to the GraphQL enum name FULL_HD.
+func enumName(s string) string {
+	return strings.ToUpper(strings.NewReplacer("-", "_", " ", "_").Replace(s))
+}
+
+func (c *compiler) genderCriterion(t *termNode) (*models.GenderCriterionInput, error) {
+	if isNull(t) {
+		m, err := c.nullModifier(t)
+		return &models.GenderCriterionInput{Modifier: m}, err
+	}
+
+	g := models.GenderEnum(enumName(t.value))
+	if !g.IsValid() {
+		return nil, c.valueError(t, "gender")
+	}
+
+	ret := &models.GenderCriterionInput{Value: g}
+	switch t.op {
+	case opContains, opEquals:
+		ret.Modifier = models.CriterionModifierEquals
+	case opNotEquals:
+		ret.Modifier = models.CriterionModifierNotEquals
+	default:
+		return nil, c.operatorError(t)
+	}
+
+	return ret, nil
+}
+
+func (c *compiler) orientationCriterion(t *termNode) (*models.OrientationCriterionInput, bool, error) {
+	o := models.OrientationEnum(enumName(t.value))
+	if !o.IsValid() {
+		return nil, false, c.valueError(t, "orientation")
+	}
+
+	ret := &models.OrientationCriterionInput{Value: []models.OrientationEnum{o}}
+	switch t.op {
+	case opContains, opEquals:
+		return ret, false, nil
+	case opNotEquals:
+		return ret, true, nil
+	}
+
+	return nil, false, c.operatorError(t)
+}
+
+func parseBool(s string) (bool, bool) {
+	switch strings.ToLower(s) {
+	case "true", "yes", "1":
+		return true, true
+	case "false", "no", "0":
+		return false, true
+	}
+	return false, false
+}
+
+func (c *compiler) boolValue(t *termNode) (*bool, error) {
+	v, ok := parseBool(t.value)
+	if !ok {
+		return nil, c.valueError(t, "boolean")
+	}
+
+	switch t.op {
+	case opContains, opEquals:
+	case opNotEquals:
+		v = !v
+	default:
+		return nil, c.operatorError(t)
+	}
+
+	return &v, nil
+}
+
+// scalarValue returns the value of a plain string, integer or enum field,
+// such as is_missing.
+func (c *compiler) scalarValue(t *termNode, field filterField) (interface{}, bool, error) {
+	if field.typ.Kind() != reflect.Ptr {
+		return nil, false, c.errorf(t.pos, "%q cannot be used in queries", field.name)
+	}
+
+	inverted := false
+	switch t.op {
+	case opContains, opEquals:
+	case opNotEquals:
+		inverted = true
+	default:
+		return nil, false, c.operatorError(t)
+	}
+
+	elem := field.typ.Elem()
+	v := reflect.New(elem)
+
+	switch elem.Kind() {
+	case reflect.String:
+		s := t.value
+		if valid, ok := v.Interface().(interface{ IsValid() bool }); ok {
+			s = enumName(s)
+			v.Elem().SetString(s)
+			if !valid.IsValid() {
+				return nil, false, c.valueError(t, "value")
+			}
+		}
+		v.Elem().SetString(s)
+	case reflect.Int:
+		i, err := strconv.Atoi(t.value)
+		if err != nil {
+			return nil, false, c.valueError(t, "number")
+		}
+		v.Elem().SetInt(int64(i))
+	default:
+		return nil, false, c.errorf(t.pos, "%q cannot be used in queries", field.name)
+	}
+
+	return v.Interface(), inverted, nil
+}
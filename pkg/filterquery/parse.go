@@ -0,0 +1,411 @@
+// Package filterquery compiles compact filter query strings, such as
+// `tag:"outdoor" -tag:vr performer:alice rating>=80 duration>20m`, into the
+// filter types of the models package.
+package filterquery
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Error is an error in a query.
+type Error struct {
+	// Pos is the 1-based position in the query of the error, in characters.
+	Pos int
+	Msg string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s at position %d", e.Msg, e.Pos)
+}
+
+// newError returns an error at the byte offset of query.
+func newError(query string, offset int, format string, args ...interface{}) *Error {
+	if offset > len(query) {
+		offset = len(query)
+	}
+
+	return &Error{
+		Pos: utf8.RuneCountInString(query[:offset]) + 1,
+		Msg: fmt.Sprintf(format, args...),
+	}
+}
+
+type operator string
+
+const (
+	opContains      operator = ":"
+	opEquals        operator = "="
+	opNotEquals     operator = "!="
+	opGreater       operator = ">"
+	opGreaterEquals operator = ">="
+	opLess          operator = "<"
+	opLessEquals    operator = "<="
+	opMatches       operator = "~"
+	opNotMatches    operator = "!~"
+)
+
+// operators are ordered so that the longest operators are matched first.
+var operators = []operator{
+	opGreaterEquals,
+	opLessEquals,
+	opNotEquals,
+	opNotMatches,
+	opContains,
+	opEquals,
+	opGreater,
+	opLess,
+	opMatches,
+}
+
+// node is a node of a parsed query. offset is the byte offset of the node in
+// the query.
+type node interface {
+	offset() int
+}
+
+type termNode struct {
+	pos   int
+	key   string
+	op    operator
+	opPos int
+	value string
+	// quoted is true if the value was quoted, so is never a keyword such as
+	// null.
+	quoted   bool
+	valuePos int
+}
+
+type notNode struct {
+	pos  int
+	expr node
+}
+
+type andNode struct {
+	pos   int
+	items []node
+}
+
+type orNode struct {
+	pos   int
+	items []node
+}
+
+func (n *termNode) offset() int { return n.pos }
+func (n *notNode) offset() int  { return n.pos }
+func (n *andNode) offset() int  { return n.pos }
+func (n *orNode) offset() int   { return n.pos }
+
+const (
+	keywordAnd = "AND"
+	keywordOr  = "OR"
+	keywordNot = "NOT"
+)
+
+// parser parses queries with the grammar:
+//
+//	query = or
+//	or    = and { "OR" and }
+//	and   = unary { [ "AND" ] unary }
+//	unary = ( "-" | "NOT" ) unary | "(" or ")" | term
+//	term  = key operator value
+//	value = quoted string | characters up to whitespace or ")"
+type parser struct {
+	query string
+	pos   int
+}
+
+// parse parses query, returning nil if the query is empty.
+func parse(query string) (node, error) {
+	p := &parser{query: query}
+
+	n, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipSpace()
+	if !p.eof() {
+		return nil, p.errorf(p.pos, "unexpected %q", p.peek())
+	}
+
+	return n, nil
+}
+
+func (p *parser) errorf(offset int, format string, args ...interface{}) error {
+	return newError(p.query, offset, format, args...)
+}
+
+func (p *parser) eof() bool {
+	return p.pos >= len(p.query)
+}
+
+func (p *parser) peek() rune {
+	r, _ := utf8.DecodeRuneInString(p.query[p.pos:])
+	return r
+}
+
+func (p *parser) skipSpace() {
+	for !p.eof() {
+		r, size := utf8.DecodeRuneInString(p.query[p.pos:])
+		if !unicode.IsSpace(r) {
+			return
+		}
+		p.pos += size
+	}
+}
+
+// atKeyword returns true if the keyword is next in the query, followed by
+// whitespace, a group or the end of the query.
+func (p *parser) atKeyword(keyword string) bool {
+	rest := p.query[p.pos:]
+	if !strings.HasPrefix(rest, keyword) {
+		return false
+	}
+
+	rest = rest[len(keyword):]
+	if rest == "" {
+		return true
+	}
+
+	r, _ := utf8.DecodeRuneInString(rest)
+	return unicode.IsSpace(r) || r == '(' || r == '-'
+}
+
+func (p *parser) parseOr() (node, error) {
+	start := p.pos
+
+	first, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	items := []node{first}
+
+	for {
+		p.skipSpace()
+		if !p.atKeyword(keywordOr) {
+			break
+		}
+
+		orPos := p.pos
+		if first == nil {
+			return nil, p.errorf(orPos, "expected term before %s", keywordOr)
+		}
+
+		p.pos += len(keywordOr)
+
+		n, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		if n == nil {
+			return nil, p.errorf(orPos, "expected term after %s", keywordOr)
+		}
+
+		items = append(items, n)
+	}
+
+	if len(items) == 1 {
+		return first, nil
+	}
+
+	return &orNode{pos: start, items: items}, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	p.skipSpace()
+	start := p.pos
+
+	var items []node
+
+	for {
+		p.skipSpace()
+		if p.eof() || p.peek() == ')' || p.atKeyword(keywordOr) {
+			break
+		}
+
+		if p.atKeyword(keywordAnd) {
+			andPos := p.pos
+			if len(items) == 0 {
+				return nil, p.errorf(andPos, "expected term before %s", keywordAnd)
+			}
+
+			p.pos += len(keywordAnd)
+			p.skipSpace()
+			if p.eof() || p.peek() == ')' || p.atKeyword(keywordOr) {
+				return nil, p.errorf(andPos, "expected term after %s", keywordAnd)
+			}
+		}
+
+		n, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, n)
+	}
+
+	switch len(items) {
+	case 0:
+		return nil, nil
+	case 1:
+		return items[0], nil
+	}
+
+	return &andNode{pos: start, items: items}, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	start := p.pos
+
+	switch {
+	case p.peek() == '-':
+		p.pos++
+		if p.eof() || unicode.IsSpace(p.peek()) {
+			return nil, p.errorf(start, "expected term after -")
+		}
+		n, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &notNode{pos: start, expr: n}, nil
+	case p.atKeyword(keywordNot):
+		p.pos += len(keywordNot)
+		p.skipSpace()
+		if p.eof() || p.peek() == ')' {
+			return nil, p.errorf(start, "expected term after %s", keywordNot)
+		}
+		n, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &notNode{pos: start, expr: n}, nil
+	case p.peek() == '(':
+		p.pos++
+		n, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		p.skipSpace()
+		if p.eof() || p.peek() != ')' {
+			return nil, p.errorf(start, "missing closing parenthesis")
+		}
+		p.pos++
+
+		if n == nil {
+			return nil, p.errorf(start, "empty group")
+		}
+
+		// errors in a group are reported at the opening parenthesis
+		switch n := n.(type) {
+		case *andNode:
+			n.pos = start
+		case *orNode:
+			n.pos = start
+		}
+		return n, nil
+	}
+
+	return p.parseTerm()
+}
+
+func isKeyRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func (p *parser) parseTerm() (node, error) {
+	start := p.pos
+
+	for !p.eof() {
+		r, size := utf8.DecodeRuneInString(p.query[p.pos:])
+		if !isKeyRune(r) {
+			break
+		}
+		p.pos += size
+	}
+
+	key := p.query[start:p.pos]
+	if key == "" {
+		return nil, p.errorf(start, "unexpected %q", p.peek())
+	}
+
+	var op operator
+	for _, o := range operators {
+		if strings.HasPrefix(p.query[p.pos:], string(o)) {
+			op = o
+			break
+		}
+	}
+
+	if op == "" {
+		return nil, p.errorf(p.pos, "expected an operator such as %q after %q", opContains, key)
+	}
+	opPos := p.pos
+	p.pos += len(op)
+
+	ret := &termNode{
+		pos:      start,
+		key:      strings.ToLower(key),
+		op:       op,
+		opPos:    opPos,
+		valuePos: p.pos,
+	}
+
+	if !p.eof() && p.peek() == '"' {
+		value, err := p.parseQuoted()
+		if err != nil {
+			return nil, err
+		}
+		ret.value = value
+		ret.quoted = true
+		return ret, nil
+	}
+
+	valueStart := p.pos
+	for !p.eof() {
+		r, size := utf8.DecodeRuneInString(p.query[p.pos:])
+		if unicode.IsSpace(r) || r == ')' {
+			break
+		}
+		p.pos += size
+	}
+
+	ret.value = p.query[valueStart:p.pos]
+	if ret.value == "" {
+		return nil, p.errorf(valueStart, "expected value after %q", key+string(op))
+	}
+
+	return ret, nil
+}
+
+// parseQuoted parses a double quoted string, in which backslash escapes the
+// next character.
+func (p *parser) parseQuoted() (string, error) {
+	start := p.pos
+	p.pos++
+
+	var sb strings.Builder
+	for !p.eof() {
+		r, size := utf8.DecodeRuneInString(p.query[p.pos:])
+		p.pos += size
+
+		switch r {
+		case '"':
+			return sb.String(), nil
+		case '\\':
+			if p.eof() {
+				return "", p.errorf(start, "unterminated string")
+			}
+			r, size = utf8.DecodeRuneInString(p.query[p.pos:])
+			p.pos += size
+		}
+
+		sb.WriteRune(r)
+	}
+
+	return "", p.errorf(start, "unterminated string")
+}
@@ -0,0 +1,86 @@
+package filterquery
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  node
+	}{
+		{"empty", "  ", nil},
+		{
+			"term",
+			`tag:outdoor`,
+			&termNode{pos: 0, key: "tag", op: opContains, opPos: 3, value: "outdoor", valuePos: 4},
+		},
+		{
+			"quoted",
+			`Title="a \"b\" c"`,
+			&termNode{pos: 0, key: "title", op: opEquals, opPos: 5, value: `a "b" c`, quoted: true, valuePos: 6},
+		},
+		{
+			"and",
+			`-tag:vr rating>=80`,
+			&andNode{pos: 0, items: []node{
+				&notNode{pos: 0, expr: &termNode{pos: 1, key: "tag", op: opContains, opPos: 4, value: "vr", valuePos: 5}},
+				&termNode{pos: 8, key: "rating", op: opGreaterEquals, opPos: 14, value: "80", valuePos: 16},
+			}},
+		},
+		{
+			"or group",
+			`(a:1 OR b:2) AND NOT c!=3`,
+			&andNode{pos: 0, items: []node{
+				&orNode{pos: 0, items: []node{
+					&termNode{pos: 1, key: "a", op: opContains, opPos: 2, value: "1", valuePos: 3},
+					&termNode{pos: 8, key: "b", op: opContains, opPos: 9, value: "2", valuePos: 10},
+				}},
+				&notNode{pos: 17, expr: &termNode{pos: 21, key: "c", op: opNotEquals, opPos: 22, value: "3", valuePos: 24}},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parse(tt.query)
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		query string
+		want  Error
+	}{
+		{`tag`, Error{Pos: 4, Msg: `expected an operator such as ":" after "tag"`}},
+		{`tag:`, Error{Pos: 5, Msg: `expected value after "tag:"`}},
+		{`title:"abc`, Error{Pos: 7, Msg: "unterminated string"}},
+		{`(a:1 b:2`, Error{Pos: 1, Msg: "missing closing parenthesis"}},
+		{`a:1 ()`, Error{Pos: 5, Msg: "empty group"}},
+		{`a:1)`, Error{Pos: 4, Msg: `unexpected ')'`}},
+		{`OR a:1`, Error{Pos: 1, Msg: "expected term before OR"}},
+		{`a:1 OR`, Error{Pos: 5, Msg: "expected term after OR"}},
+		{`a:1 - b:2`, Error{Pos: 5, Msg: "expected term after -"}},
+		{`é:1 "x"`, Error{Pos: 5, Msg: `unexpected '"'`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			_, err := parse(tt.query)
+
+			var got *Error
+			if assert.True(t, errors.As(err, &got), "expected *Error, got %v", err) {
+				assert.Equal(t, tt.want, *got)
+			}
+		})
+	}
+}
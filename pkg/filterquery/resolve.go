@@ -0,0 +1,126 @@
+package filterquery
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/performer"
+	"github.com/stashapp/stash/pkg/studio"
+	"github.com/stashapp/stash/pkg/tag"
+)
+
+type PerformerFinder interface {
+	models.PerformerQueryer
+	FindByNames(ctx context.Context, names []string, nocase bool) ([]*models.Performer, error)
+}
+
+type GroupFinder interface {
+	FindByNames(ctx context.Context, names []string, nocase bool) ([]*models.Group, error)
+}
+
+// RepositoryResolver finds objects by name, falling back to aliases where
+// the object type has them. Names are matched case-insensitively.
+type RepositoryResolver struct {
+	Performer PerformerFinder
+	Studio    models.StudioQueryer
+	Tag       models.TagQueryer
+	Group     GroupFinder
+	Gallery   models.GalleryQueryer
+}
+
+func NewRepositoryResolver(r models.Repository) *RepositoryResolver {
+	return &RepositoryResolver{
+		Performer: r.Performer,
+		Studio:    r.Studio,
+		Tag:       r.Tag,
+		Group:     r.Group,
+		Gallery:   r.Gallery,
+	}
+}
+
+func (r *RepositoryResolver) FindIDs(ctx context.Context, mode models.FilterMode, name string) ([]string, error) {
+	switch mode {
+	case models.FilterModePerformers:
+		performers, err := r.Performer.FindByNames(ctx, []string{name}, true)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(performers) == 0 {
+			performers, err = performer.ByAlias(ctx, r.Performer, name)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		ret := make([]string, len(performers))
+		for i, p := range performers {
+			ret[i] = strconv.Itoa(p.ID)
+		}
+		return ret, nil
+	case models.FilterModeStudios:
+		s, err := studio.ByName(ctx, r.Studio, name)
+		if err != nil {
+			return nil, err
+		}
+
+		if s == nil {
+			s, err = studio.ByAlias(ctx, r.Studio, name)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if s == nil {
+			return nil, nil
+		}
+		return []string{strconv.Itoa(s.ID)}, nil
+	case models.FilterModeTags:
+		t, err := tag.ByName(ctx, r.Tag, name)
+		if err != nil {
+			return nil, err
+		}
+
+		if t == nil {
+			t, err = tag.ByAlias(ctx, r.Tag, name)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if t == nil {
+			return nil, nil
+		}
+		return []string{strconv.Itoa(t.ID)}, nil
+	case models.FilterModeGroups, models.FilterModeMovies:
+		groups, err := r.Group.FindByNames(ctx, []string{name}, true)
+		if err != nil {
+			return nil, err
+		}
+
+		ret := make([]string, len(groups))
+		for i, g := range groups {
+			ret[i] = strconv.Itoa(g.ID)
+		}
+		return ret, nil
+	case models.FilterModeGalleries:
+		galleries, _, err := r.Gallery.Query(ctx, &models.GalleryFilterType{
+			Title: &models.StringCriterionInput{
+				Value:    name,
+				Modifier: models.CriterionModifierEquals,
+			},
+		}, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		ret := make([]string, len(galleries))
+		for i, g := range galleries {
+			ret[i] = strconv.Itoa(g.ID)
+		}
+		return ret, nil
+	}
+
+	return nil, nil
+}
@@ -107,6 +107,38 @@ func EmptyDir(path string) error {
 	return nil
 }
 
+// LinkOrCopyDir recreates the directory tree at srcpath at dstpath. Files are
+// hard linked where possible, and copied otherwise, for example when dstpath
+// is on a different device. The files must not be modified in place after
+// linking, since the change would apply to both paths.
+func LinkOrCopyDir(srcpath, dstpath string) error {
+	return filepath.WalkDir(srcpath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(srcpath, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dstpath, rel)
+
+		if d.IsDir() {
+			return EnsureDirAll(target)
+		}
+
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		if err := os.Link(path, target); err == nil {
+			return nil
+		}
+
+		return CopyFile(path, target)
+	})
+}
+
 // GetIntraDir returns a string that can be added to filepath.Join to implement directory depth, "" on error
 // eg given a pattern of 0af63ce3c99162e9df23a997f62621c5 and a depth of 2 length of 3
 // returns 0af/63c or 0af\63c ( dependin on os)  that can be later used like this  filepath.Join(directory, intradir, basename)
@@ -0,0 +1,7 @@
+package fsutil
+
+import "errors"
+
+// ErrXattrNotSupported is returned by GetXattr and SetXattr on platforms
+// that do not support extended attributes.
+var ErrXattrNotSupported = errors.New("extended attributes are not supported on this platform")
@@ -0,0 +1,14 @@
+//go:build !linux && !darwin
+// +build !linux,!darwin
+
+package fsutil
+
+// GetXattr returns ErrXattrNotSupported on this platform.
+func GetXattr(path string, name string) ([]byte, error) {
+	return nil, ErrXattrNotSupported
+}
+
+// SetXattr returns ErrXattrNotSupported on this platform.
+func SetXattr(path string, name string, value []byte) error {
+	return ErrXattrNotSupported
+}
@@ -0,0 +1,40 @@
+//go:build linux || darwin
+// +build linux darwin
+
+package fsutil
+
+import (
+	"errors"
+
+	"golang.org/x/sys/unix"
+)
+
+// GetXattr returns the value of the named extended attribute of the file at
+// path.
+func GetXattr(path string, name string) ([]byte, error) {
+	size, err := unix.Getxattr(path, name, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, size)
+	for {
+		n, err := unix.Getxattr(path, name, buf)
+		if errors.Is(err, unix.ERANGE) {
+			// value grew since the size was read
+			buf = make([]byte, len(buf)*2+64)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		return buf[:n], nil
+	}
+}
+
+// SetXattr sets the value of the named extended attribute of the file at
+// path. The modification time of the file is not changed.
+func SetXattr(path string, name string, value []byte) error {
+	return unix.Setxattr(path, name, value, 0)
+}
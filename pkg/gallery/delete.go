@@ -14,7 +14,7 @@ func (s *Service) Destroy(ctx context.Context, i *models.Gallery, fileDeleter *i
 	// chapter deletion is done via delete cascade, so we don't need to do anything here
 
 	// if this is a zip-based gallery, delete the images as well first
-	zipImgsDestroyed, err := s.destroyZipFileImages(ctx, i, fileDeleter, deleteGenerated, deleteFile)
+	zipImgsDestroyed, zipFiles, err := s.destroyZipFileImages(ctx, i, fileDeleter, deleteGenerated)
 	if err != nil {
 		return nil, err
 	}
@@ -38,6 +38,21 @@ func (s *Service) Destroy(ctx context.Context, i *models.Gallery, fileDeleter *i
 		return nil, err
 	}
 
+	// zip files are deleted after the gallery, so that the deleted gallery
+	// can be identified by its files
+	if deleteFile {
+		destroyer := &file.ZipDestroyer{
+			FileDestroyer:   s.File,
+			FolderDestroyer: s.Folder,
+		}
+
+		for _, f := range zipFiles {
+			if err := destroyer.DestroyZip(ctx, f, fileDeleter.Deleter, deleteFile); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	return imgsDestroyed, nil
 }
 
@@ -45,24 +60,23 @@ func DestroyChapter(ctx context.Context, galleryChapter *models.GalleryChapter,
 	return qb.Destroy(ctx, galleryChapter.ID)
 }
 
-func (s *Service) destroyZipFileImages(ctx context.Context, i *models.Gallery, fileDeleter *image.FileDeleter, deleteGenerated, deleteFile bool) ([]*models.Image, error) {
+// destroyZipFileImages destroys the images in the zip files of the gallery.
+// Returns the destroyed images, and the zip files that are not used by other
+// galleries.
+func (s *Service) destroyZipFileImages(ctx context.Context, i *models.Gallery, fileDeleter *image.FileDeleter, deleteGenerated bool) ([]*models.Image, []models.File, error) {
 	if err := i.LoadFiles(ctx, s.Repository); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	var imgsDestroyed []*models.Image
-
-	destroyer := &file.ZipDestroyer{
-		FileDestroyer:   s.File,
-		FolderDestroyer: s.Folder,
-	}
+	var zipFiles []models.File
 
 	// for zip-based galleries, delete the images as well first
 	for _, f := range i.Files.List() {
 		// only do this where there are no other galleries related to the file
 		otherGalleries, err := s.Repository.FindByFileID(ctx, f.Base().ID)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		if len(otherGalleries) > 1 {
@@ -72,17 +86,12 @@ func (s *Service) destroyZipFileImages(ctx context.Context, i *models.Gallery, f
 
 		thisDestroyed, err := s.ImageService.DestroyZipImages(ctx, f, fileDeleter, deleteGenerated)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		imgsDestroyed = append(imgsDestroyed, thisDestroyed...)
-
-		if deleteFile {
-			if err := destroyer.DestroyZip(ctx, f, fileDeleter.Deleter, deleteFile); err != nil {
-				return nil, err
-			}
-		}
+		zipFiles = append(zipFiles, f)
 	}
 
-	return imgsDestroyed, nil
+	return imgsDestroyed, zipFiles, nil
 }
@@ -0,0 +1,39 @@
+package imagephash
+
+import (
+	"fmt"
+	"image"
+
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+
+	"github.com/corona10/goimagehash"
+	_ "golang.org/x/image/bmp"
+	_ "golang.org/x/image/webp"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// Generate returns the perceptual hash of the provided image file.
+// The file is opened using the provided filesystem, so images in zip
+// files are supported.
+func Generate(fs models.FS, imageFile *models.ImageFile) (*uint64, error) {
+	reader, err := imageFile.Open(fs)
+	if err != nil {
+		return nil, fmt.Errorf("opening image: %w", err)
+	}
+	defer reader.Close()
+
+	img, _, err := image.Decode(reader)
+	if err != nil {
+		return nil, fmt.Errorf("decoding image: %w", err)
+	}
+
+	hash, err := goimagehash.PerceptionHash(img)
+	if err != nil {
+		return nil, fmt.Errorf("computing phash from image: %w", err)
+	}
+	hashValue := hash.GetHash()
+	return &hashValue, nil
+}
@@ -0,0 +1,75 @@
+// Package history records the changes made to objects in the edit history.
+package history
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/session"
+)
+
+type key int
+
+const (
+	sourceKey key = iota
+	actionKey
+)
+
+// Source describes where a change was made.
+type Source struct {
+	Origin models.HistoryOrigin
+	// User is the name of the user making the change, if any.
+	User string
+	// PluginID is the id of the plugin making the change, if any.
+	PluginID string
+}
+
+// WithSource returns a context where changes are recorded as made by source.
+// Changes are only recorded when the context has a source.
+func WithSource(ctx context.Context, source Source) context.Context {
+	return context.WithValue(ctx, sourceKey, source)
+}
+
+// RequestSource returns the source of the changes made by the current
+// request, using the session values of ctx.
+func RequestSource(ctx context.Context) Source {
+	ret := Source{
+		Origin: models.HistoryOriginUI,
+	}
+
+	if user := session.GetCurrentUserID(ctx); user != nil {
+		ret.User = *user
+	}
+
+	if pluginID := session.GetCurrentPluginID(ctx); pluginID != "" {
+		ret.Origin = models.HistoryOriginPlugin
+		ret.PluginID = pluginID
+	} else if session.IsUsingAPIKey(ctx) {
+		ret.Origin = models.HistoryOriginAPI
+	}
+
+	return ret
+}
+
+func getSource(ctx context.Context) *Source {
+	if v, ok := ctx.Value(sourceKey).(Source); ok {
+		return &v
+	}
+
+	return nil
+}
+
+// WithAction returns a context where changes are recorded with action,
+// rather than the action of the store operation. It is used to record the
+// changes made by a merge.
+func WithAction(ctx context.Context, action models.HistoryAction) context.Context {
+	return context.WithValue(ctx, actionKey, action)
+}
+
+func getAction(ctx context.Context, def models.HistoryAction) models.HistoryAction {
+	if v, ok := ctx.Value(actionKey).(models.HistoryAction); ok {
+		return v
+	}
+
+	return def
+}
@@ -0,0 +1,77 @@
+package history
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/pkg/group"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// GroupServiceRepository is the repository used by the group service.
+type GroupServiceRepository interface {
+	group.CreatorUpdater
+	models.URLLoader
+	models.TagIDLoader
+}
+
+// WrapGroupServiceRepository returns r, recording the changes made through
+// it in the edit history. The group service modifies sub-groups directly,
+// rather than by updating the group.
+func WrapGroupServiceRepository(r GroupServiceRepository, writer models.EditHistoryWriter) group.CreatorUpdater {
+	return &groupServiceStore{
+		GroupServiceRepository: r,
+		recorder: recorder{
+			writer:     writer,
+			entityType: models.HistoryEntityTypeGroup,
+			snapshot:   groupSnapshot(r),
+		},
+	}
+}
+
+type groupServiceStore struct {
+	GroupServiceRepository
+	recorder
+}
+
+func (s *groupServiceStore) Create(ctx context.Context, newObject *models.Group) error {
+	if err := s.GroupServiceRepository.Create(ctx, newObject); err != nil {
+		return err
+	}
+
+	return s.created(ctx, newObject.ID)
+}
+
+func (s *groupServiceStore) Update(ctx context.Context, updatedObject *models.Group) error {
+	return s.update(ctx, updatedObject.ID, func() error {
+		return s.GroupServiceRepository.Update(ctx, updatedObject)
+	})
+}
+
+func (s *groupServiceStore) UpdatePartial(ctx context.Context, id int, partial models.GroupPartial) (*models.Group, error) {
+	var ret *models.Group
+	err := s.update(ctx, id, func() error {
+		var err error
+		ret, err = s.GroupServiceRepository.UpdatePartial(ctx, id, partial)
+		return err
+	})
+
+	return ret, err
+}
+
+func (s *groupServiceStore) AddSubGroups(ctx context.Context, groupID int, subGroups []models.GroupIDDescription, insertIndex *int) error {
+	return s.update(ctx, groupID, func() error {
+		return s.GroupServiceRepository.AddSubGroups(ctx, groupID, subGroups, insertIndex)
+	})
+}
+
+func (s *groupServiceStore) RemoveSubGroups(ctx context.Context, groupID int, subGroupIDs []int) error {
+	return s.update(ctx, groupID, func() error {
+		return s.GroupServiceRepository.RemoveSubGroups(ctx, groupID, subGroupIDs)
+	})
+}
+
+func (s *groupServiceStore) ReorderSubGroups(ctx context.Context, groupID int, subGroupIDs []int, insertID int, insertAfter bool) error {
+	return s.update(ctx, groupID, func() error {
+		return s.GroupServiceRepository.ReorderSubGroups(ctx, groupID, subGroupIDs, insertID, insertAfter)
+	})
+}
@@ -0,0 +1,214 @@
+package history
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// change is a change to a single field of an object. Values are JSON
+// encoded, and nil if the object did not exist.
+type change struct {
+	field    string
+	oldValue *string
+	newValue *string
+}
+
+func encodeValue(v interface{}) (string, error) {
+	ret, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+
+	return string(ret), nil
+}
+
+// isEmptyValue returns true if the encoded value is the zero value of its
+// type.
+func isEmptyValue(v string) bool {
+	switch v {
+	case "null", `""`, "[]", "false":
+		return true
+	}
+
+	return false
+}
+
+// diff returns the changes between the before and after snapshots of an
+// object. If before is nil, the non-empty fields of after are returned as
+// created. If after is nil, the non-empty fields of before are returned as
+// destroyed.
+func diff(before snapshot, after snapshot) ([]change, error) {
+	fields := make(map[string]struct{})
+	for k := range before {
+		fields[k] = struct{}{}
+	}
+	for k := range after {
+		fields[k] = struct{}{}
+	}
+
+	var keys []string
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var ret []change
+	for _, k := range keys {
+		var oldValue, newValue *string
+
+		if before != nil {
+			v, err := encodeValue(before[k])
+			if err != nil {
+				return nil, fmt.Errorf("encoding %s: %w", k, err)
+			}
+			oldValue = &v
+		}
+
+		if after != nil {
+			v, err := encodeValue(after[k])
+			if err != nil {
+				return nil, fmt.Errorf("encoding %s: %w", k, err)
+			}
+			newValue = &v
+		}
+
+		switch {
+		case oldValue == nil && newValue == nil:
+			continue
+		case oldValue == nil:
+			if isEmptyValue(*newValue) {
+				continue
+			}
+		case newValue == nil:
+			if isEmptyValue(*oldValue) {
+				continue
+			}
+		case *oldValue == *newValue:
+			continue
+		}
+
+		ret = append(ret, change{
+			field:    k,
+			oldValue: oldValue,
+			newValue: newValue,
+		})
+	}
+
+	return ret, nil
+}
+
+// recorder records the changes made to objects of a single type.
+type recorder struct {
+	writer     models.EditHistoryWriter
+	entityType models.HistoryEntityType
+	snapshot   snapshotFunc
+}
+
+// created records the creation of the object with id.
+func (r recorder) created(ctx context.Context, id int) error {
+	source := getSource(ctx)
+	if source == nil {
+		return nil
+	}
+
+	after, err := r.snapshot(ctx, id)
+	if err != nil {
+		return fmt.Errorf("getting created %s %d: %w", r.entityType, id, err)
+	}
+
+	return r.record(ctx, *source, id, models.HistoryActionCreate, nil, after)
+}
+
+// update records the changes made to the object with id by fn.
+func (r recorder) update(ctx context.Context, id int, fn func() error) error {
+	source := getSource(ctx)
+	if source == nil {
+		return fn()
+	}
+
+	before, err := r.snapshot(ctx, id)
+	if err != nil {
+		return fmt.Errorf("getting %s %d before update: %w", r.entityType, id, err)
+	}
+
+	if err := fn(); err != nil {
+		return err
+	}
+
+	after, err := r.snapshot(ctx, id)
+	if err != nil {
+		return fmt.Errorf("getting %s %d after update: %w", r.entityType, id, err)
+	}
+
+	return r.record(ctx, *source, id, models.HistoryActionUpdate, before, after)
+}
+
+// destroy records the destruction of the objects with ids by fn.
+func (r recorder) destroy(ctx context.Context, ids []int, fn func() error) error {
+	source := getSource(ctx)
+	if source == nil {
+		return fn()
+	}
+
+	before := make([]snapshot, len(ids))
+	for i, id := range ids {
+		s, err := r.snapshot(ctx, id)
+		if err != nil {
+			return fmt.Errorf("getting %s %d before destroy: %w", r.entityType, id, err)
+		}
+		before[i] = s
+	}
+
+	if err := fn(); err != nil {
+		return err
+	}
+
+	for i, id := range ids {
+		if err := r.record(ctx, *source, id, models.HistoryActionDestroy, before[i], nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r recorder) record(ctx context.Context, source Source, id int, action models.HistoryAction, before snapshot, after snapshot) error {
+	changes, err := diff(before, after)
+	if err != nil {
+		return fmt.Errorf("comparing %s %d: %w", r.entityType, id, err)
+	}
+
+	action = getAction(ctx, action)
+	now := time.Now()
+
+	for _, c := range changes {
+		entry := models.EditHistoryEntry{
+			EntityType: r.entityType,
+			EntityID:   id,
+			Action:     action,
+			Field:      c.field,
+			OldValue:   c.oldValue,
+			NewValue:   c.newValue,
+			Origin:     source.Origin,
+			CreatedAt:  now,
+		}
+
+		if source.User != "" {
+			entry.User = &source.User
+		}
+		if source.PluginID != "" {
+			entry.PluginID = &source.PluginID
+		}
+
+		if err := r.writer.Create(ctx, &entry); err != nil {
+			return fmt.Errorf("recording change to %s %d: %w", r.entityType, id, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package history
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func TestDiff(t *testing.T) {
+	rating := 50
+
+	tests := []struct {
+		name   string
+		before snapshot
+		after  snapshot
+		want   []change
+	}{
+		{
+			"create skips empty fields",
+			nil,
+			snapshot{"title": "title", "details": "", "rating100": (*int)(nil), "tag_ids": []string{}, "organized": false},
+			[]change{{field: "title", newValue: strPtr(`"title"`)}},
+		},
+		{
+			"destroy skips empty fields",
+			snapshot{"title": "title", "rating100": &rating, "tag_ids": []string{}},
+			nil,
+			[]change{
+				{field: "rating100", oldValue: strPtr("50")},
+				{field: "title", oldValue: strPtr(`"title"`)},
+			},
+		},
+		{
+			"update returns changed fields",
+			snapshot{"title": "title", "rating100": &rating, "tag_ids": []string{"1"}},
+			snapshot{"title": "title", "rating100": (*int)(nil), "tag_ids": []string{"1", "2"}},
+			[]change{
+				{field: "rating100", oldValue: strPtr("50"), newValue: strPtr("null")},
+				{field: "tag_ids", oldValue: strPtr(`["1"]`), newValue: strPtr(`["1","2"]`)},
+			},
+		},
+		{
+			"unchanged",
+			snapshot{"title": "title"},
+			snapshot{"title": "title"},
+			nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := diff(tt.before, tt.after)
+			if err != nil {
+				t.Errorf("diff() error = %v", err)
+				return
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,333 @@
+package history
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// WrapRepository returns r with the object stores replaced by stores that
+// record the changes made through them in the edit history.
+func WrapRepository(r models.Repository) models.Repository {
+	r.Scene = &sceneStore{
+		SceneReaderWriter: r.Scene,
+		recorder: recorder{
+			writer:     r.EditHistory,
+			entityType: models.HistoryEntityTypeScene,
+			snapshot:   sceneSnapshot(r.Scene),
+		},
+	}
+	r.Image = &imageStore{
+		ImageReaderWriter: r.Image,
+		recorder: recorder{
+			writer:     r.EditHistory,
+			entityType: models.HistoryEntityTypeImage,
+			snapshot:   imageSnapshot(r.Image),
+		},
+	}
+	r.Gallery = &galleryStore{
+		GalleryReaderWriter: r.Gallery,
+		recorder: recorder{
+			writer:     r.EditHistory,
+			entityType: models.HistoryEntityTypeGallery,
+			snapshot:   gallerySnapshot(r.Gallery),
+		},
+	}
+	r.Performer = &performerStore{
+		PerformerReaderWriter: r.Performer,
+		recorder: recorder{
+			writer:     r.EditHistory,
+			entityType: models.HistoryEntityTypePerformer,
+			snapshot:   performerSnapshot(r.Performer),
+		},
+	}
+	r.Studio = &studioStore{
+		StudioReaderWriter: r.Studio,
+		recorder: recorder{
+			writer:     r.EditHistory,
+			entityType: models.HistoryEntityTypeStudio,
+			snapshot:   studioSnapshot(r.Studio),
+		},
+	}
+	r.Tag = &tagStore{
+		TagReaderWriter: r.Tag,
+		recorder: recorder{
+			writer:     r.EditHistory,
+			entityType: models.HistoryEntityTypeTag,
+			snapshot:   tagSnapshot(r.Tag),
+		},
+	}
+	r.Group = &groupStore{
+		GroupReaderWriter: r.Group,
+		recorder: recorder{
+			writer:     r.EditHistory,
+			entityType: models.HistoryEntityTypeGroup,
+			snapshot:   groupSnapshot(r.Group),
+		},
+	}
+
+	return r
+}
+
+type sceneStore struct {
+	models.SceneReaderWriter
+	recorder
+}
+
+func (s *sceneStore) Create(ctx context.Context, newObject *models.Scene, fileIDs []models.FileID) error {
+	if err := s.SceneReaderWriter.Create(ctx, newObject, fileIDs); err != nil {
+		return err
+	}
+
+	return s.created(ctx, newObject.ID)
+}
+
+func (s *sceneStore) Update(ctx context.Context, updatedObject *models.Scene) error {
+	return s.update(ctx, updatedObject.ID, func() error {
+		return s.SceneReaderWriter.Update(ctx, updatedObject)
+	})
+}
+
+func (s *sceneStore) UpdatePartial(ctx context.Context, id int, partial models.ScenePartial) (*models.Scene, error) {
+	var ret *models.Scene
+	err := s.update(ctx, id, func() error {
+		var err error
+		ret, err = s.SceneReaderWriter.UpdatePartial(ctx, id, partial)
+		return err
+	})
+
+	return ret, err
+}
+
+func (s *sceneStore) Destroy(ctx context.Context, id int) error {
+	return s.destroy(ctx, []int{id}, func() error {
+		return s.SceneReaderWriter.Destroy(ctx, id)
+	})
+}
+
+type imageStore struct {
+	models.ImageReaderWriter
+	recorder
+}
+
+func (s *imageStore) Create(ctx context.Context, newObject *models.Image, fileIDs []models.FileID) error {
+	if err := s.ImageReaderWriter.Create(ctx, newObject, fileIDs); err != nil {
+		return err
+	}
+
+	return s.created(ctx, newObject.ID)
+}
+
+func (s *imageStore) Update(ctx context.Context, updatedObject *models.Image) error {
+	return s.update(ctx, updatedObject.ID, func() error {
+		return s.ImageReaderWriter.Update(ctx, updatedObject)
+	})
+}
+
+func (s *imageStore) UpdatePartial(ctx context.Context, id int, partial models.ImagePartial) (*models.Image, error) {
+	var ret *models.Image
+	err := s.update(ctx, id, func() error {
+		var err error
+		ret, err = s.ImageReaderWriter.UpdatePartial(ctx, id, partial)
+		return err
+	})
+
+	return ret, err
+}
+
+func (s *imageStore) Destroy(ctx context.Context, id int) error {
+	return s.destroy(ctx, []int{id}, func() error {
+		return s.ImageReaderWriter.Destroy(ctx, id)
+	})
+}
+
+type galleryStore struct {
+	models.GalleryReaderWriter
+	recorder
+}
+
+func (s *galleryStore) Create(ctx context.Context, newObject *models.Gallery, fileIDs []models.FileID) error {
+	if err := s.GalleryReaderWriter.Create(ctx, newObject, fileIDs); err != nil {
+		return err
+	}
+
+	return s.created(ctx, newObject.ID)
+}
+
+func (s *galleryStore) Update(ctx context.Context, updatedObject *models.Gallery) error {
+	return s.update(ctx, updatedObject.ID, func() error {
+		return s.GalleryReaderWriter.Update(ctx, updatedObject)
+	})
+}
+
+func (s *galleryStore) UpdatePartial(ctx context.Context, id int, partial models.GalleryPartial) (*models.Gallery, error) {
+	var ret *models.Gallery
+	err := s.update(ctx, id, func() error {
+		var err error
+		ret, err = s.GalleryReaderWriter.UpdatePartial(ctx, id, partial)
+		return err
+	})
+
+	return ret, err
+}
+
+func (s *galleryStore) Destroy(ctx context.Context, id int) error {
+	return s.destroy(ctx, []int{id}, func() error {
+		return s.GalleryReaderWriter.Destroy(ctx, id)
+	})
+}
+
+type performerStore struct {
+	models.PerformerReaderWriter
+	recorder
+}
+
+func (s *performerStore) Create(ctx context.Context, newObject *models.Performer) error {
+	if err := s.PerformerReaderWriter.Create(ctx, newObject); err != nil {
+		return err
+	}
+
+	return s.created(ctx, newObject.ID)
+}
+
+func (s *performerStore) Update(ctx context.Context, updatedObject *models.Performer) error {
+	return s.update(ctx, updatedObject.ID, func() error {
+		return s.PerformerReaderWriter.Update(ctx, updatedObject)
+	})
+}
+
+func (s *performerStore) UpdatePartial(ctx context.Context, id int, partial models.PerformerPartial) (*models.Performer, error) {
+	var ret *models.Performer
+	err := s.update(ctx, id, func() error {
+		var err error
+		ret, err = s.PerformerReaderWriter.UpdatePartial(ctx, id, partial)
+		return err
+	})
+
+	return ret, err
+}
+
+func (s *performerStore) Destroy(ctx context.Context, id int) error {
+	return s.destroy(ctx, []int{id}, func() error {
+		return s.PerformerReaderWriter.Destroy(ctx, id)
+	})
+}
+
+type studioStore struct {
+	models.StudioReaderWriter
+	recorder
+}
+
+func (s *studioStore) Create(ctx context.Context, newObject *models.Studio) error {
+	if err := s.StudioReaderWriter.Create(ctx, newObject); err != nil {
+		return err
+	}
+
+	return s.created(ctx, newObject.ID)
+}
+
+func (s *studioStore) Update(ctx context.Context, updatedObject *models.Studio) error {
+	return s.update(ctx, updatedObject.ID, func() error {
+		return s.StudioReaderWriter.Update(ctx, updatedObject)
+	})
+}
+
+func (s *studioStore) UpdatePartial(ctx context.Context, partial models.StudioPartial) (*models.Studio, error) {
+	var ret *models.Studio
+	err := s.update(ctx, partial.ID, func() error {
+		var err error
+		ret, err = s.StudioReaderWriter.UpdatePartial(ctx, partial)
+		return err
+	})
+
+	return ret, err
+}
+
+func (s *studioStore) Destroy(ctx context.Context, id int) error {
+	return s.destroy(ctx, []int{id}, func() error {
+		return s.StudioReaderWriter.Destroy(ctx, id)
+	})
+}
+
+type tagStore struct {
+	models.TagReaderWriter
+	recorder
+}
+
+func (s *tagStore) Create(ctx context.Context, newObject *models.Tag) error {
+	if err := s.TagReaderWriter.Create(ctx, newObject); err != nil {
+		return err
+	}
+
+	return s.created(ctx, newObject.ID)
+}
+
+func (s *tagStore) Update(ctx context.Context, updatedObject *models.Tag) error {
+	return s.update(ctx, updatedObject.ID, func() error {
+		return s.TagReaderWriter.Update(ctx, updatedObject)
+	})
+}
+
+func (s *tagStore) UpdatePartial(ctx context.Context, id int, partial models.TagPartial) (*models.Tag, error) {
+	var ret *models.Tag
+	err := s.update(ctx, id, func() error {
+		var err error
+		ret, err = s.TagReaderWriter.UpdatePartial(ctx, id, partial)
+		return err
+	})
+
+	return ret, err
+}
+
+func (s *tagStore) Destroy(ctx context.Context, id int) error {
+	return s.destroy(ctx, []int{id}, func() error {
+		return s.TagReaderWriter.Destroy(ctx, id)
+	})
+}
+
+// Merge records the changes to the destination tag, and the destruction of
+// the source tags.
+func (s *tagStore) Merge(ctx context.Context, source []int, destination int) error {
+	ctx = WithAction(ctx, models.HistoryActionMerge)
+	return s.destroy(ctx, source, func() error {
+		return s.update(ctx, destination, func() error {
+			return s.TagReaderWriter.Merge(ctx, source, destination)
+		})
+	})
+}
+
+type groupStore struct {
+	models.GroupReaderWriter
+	recorder
+}
+
+func (s *groupStore) Create(ctx context.Context, newObject *models.Group) error {
+	if err := s.GroupReaderWriter.Create(ctx, newObject); err != nil {
+		return err
+	}
+
+	return s.created(ctx, newObject.ID)
+}
+
+func (s *groupStore) Update(ctx context.Context, updatedObject *models.Group) error {
+	return s.update(ctx, updatedObject.ID, func() error {
+		return s.GroupReaderWriter.Update(ctx, updatedObject)
+	})
+}
+
+func (s *groupStore) UpdatePartial(ctx context.Context, id int, partial models.GroupPartial) (*models.Group, error) {
+	var ret *models.Group
+	err := s.update(ctx, id, func() error {
+		var err error
+		ret, err = s.GroupReaderWriter.UpdatePartial(ctx, id, partial)
+		return err
+	})
+
+	return ret, err
+}
+
+func (s *groupStore) Destroy(ctx context.Context, id int) error {
+	return s.destroy(ctx, []int{id}, func() error {
+		return s.GroupReaderWriter.Destroy(ctx, id)
+	})
+}
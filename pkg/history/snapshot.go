@@ -0,0 +1,375 @@
+package history
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sliceutil/intslice"
+)
+
+// snapshot is the recorded state of an object. Keys are the fields of the
+// update input of the object, and values are in the format of the input.
+type snapshot map[string]interface{}
+
+// snapshotFunc returns the snapshot of the object with id. Returns nil if the
+// object does not exist.
+type snapshotFunc func(ctx context.Context, id int) (snapshot, error)
+
+func idString(id *int) *string {
+	if id == nil {
+		return nil
+	}
+
+	ret := strconv.Itoa(*id)
+	return &ret
+}
+
+func idStrings(ids []int) []string {
+	return stringList(intslice.IntSliceToStringSlice(ids))
+}
+
+// stringList returns v, or an empty slice if v is nil, so that unset and empty
+// lists are recorded the same way.
+func stringList(v []string) []string {
+	if v == nil {
+		return []string{}
+	}
+
+	return v
+}
+
+func dateString(d *models.Date) *string {
+	if d == nil {
+		return nil
+	}
+
+	ret := d.String()
+	return &ret
+}
+
+func stashIDs(v []models.StashID) []models.StashID {
+	if v == nil {
+		return []models.StashID{}
+	}
+
+	return v
+}
+
+type groupDescriptionInput struct {
+	GroupID     string `json:"group_id"`
+	Description string `json:"description"`
+}
+
+func groupDescriptions(v []models.GroupIDDescription) []groupDescriptionInput {
+	ret := []groupDescriptionInput{}
+	for _, g := range v {
+		ret = append(ret, groupDescriptionInput{
+			GroupID:     strconv.Itoa(g.GroupID),
+			Description: g.Description,
+		})
+	}
+
+	return ret
+}
+
+func performerRelationships(v []models.PerformerRelationship) []models.PerformerRelationshipInput {
+	ret := []models.PerformerRelationshipInput{}
+	for _, r := range v {
+		ret = append(ret, models.PerformerRelationshipInput{
+			PerformerID: strconv.Itoa(r.PerformerID),
+			Type:        r.Type,
+		})
+	}
+
+	return ret
+}
+
+type sceneGroupInput struct {
+	GroupID    string `json:"group_id"`
+	SceneIndex *int   `json:"scene_index"`
+}
+
+func sceneSnapshot(r models.SceneReader) snapshotFunc {
+	return func(ctx context.Context, id int) (snapshot, error) {
+		s, err := r.Find(ctx, id)
+		if err != nil || s == nil {
+			return nil, err
+		}
+
+		if err := s.LoadURLs(ctx, r); err != nil {
+			return nil, err
+		}
+		if err := s.LoadGalleryIDs(ctx, r); err != nil {
+			return nil, err
+		}
+		if err := s.LoadPerformerIDs(ctx, r); err != nil {
+			return nil, err
+		}
+		if err := s.LoadTagIDs(ctx, r); err != nil {
+			return nil, err
+		}
+		if err := s.LoadGroups(ctx, r); err != nil {
+			return nil, err
+		}
+		if err := s.LoadStashIDs(ctx, r); err != nil {
+			return nil, err
+		}
+
+		groups := []sceneGroupInput{}
+		for _, g := range s.Groups.List() {
+			groups = append(groups, sceneGroupInput{
+				GroupID:    strconv.Itoa(g.GroupID),
+				SceneIndex: g.SceneIndex,
+			})
+		}
+
+		return snapshot{
+			"title":         s.Title,
+			"code":          s.Code,
+			"details":       s.Details,
+			"director":      s.Director,
+			"urls":          stringList(s.URLs.List()),
+			"date":          dateString(s.Date),
+			"rating100":     s.Rating,
+			"organized":     s.Organized,
+			"studio_id":     idString(s.StudioID),
+			"gallery_ids":   idStrings(s.GalleryIDs.List()),
+			"performer_ids": idStrings(s.PerformerIDs.List()),
+			"tag_ids":       idStrings(s.TagIDs.List()),
+			"groups":        groups,
+			"stash_ids":     stashIDs(s.StashIDs.List()),
+		}, nil
+	}
+}
+
+func imageSnapshot(r models.ImageReader) snapshotFunc {
+	return func(ctx context.Context, id int) (snapshot, error) {
+		i, err := r.Find(ctx, id)
+		if err != nil || i == nil {
+			return nil, err
+		}
+
+		if err := i.LoadURLs(ctx, r); err != nil {
+			return nil, err
+		}
+		if err := i.LoadGalleryIDs(ctx, r); err != nil {
+			return nil, err
+		}
+		if err := i.LoadPerformerIDs(ctx, r); err != nil {
+			return nil, err
+		}
+		if err := i.LoadTagIDs(ctx, r); err != nil {
+			return nil, err
+		}
+
+		return snapshot{
+			"title":         i.Title,
+			"code":          i.Code,
+			"details":       i.Details,
+			"photographer":  i.Photographer,
+			"urls":          stringList(i.URLs.List()),
+			"date":          dateString(i.Date),
+			"rating100":     i.Rating,
+			"organized":     i.Organized,
+			"studio_id":     idString(i.StudioID),
+			"gallery_ids":   idStrings(i.GalleryIDs.List()),
+			"performer_ids": idStrings(i.PerformerIDs.List()),
+			"tag_ids":       idStrings(i.TagIDs.List()),
+		}, nil
+	}
+}
+
+func gallerySnapshot(r models.GalleryReader) snapshotFunc {
+	return func(ctx context.Context, id int) (snapshot, error) {
+		g, err := r.Find(ctx, id)
+		if err != nil || g == nil {
+			return nil, err
+		}
+
+		if err := g.LoadURLs(ctx, r); err != nil {
+			return nil, err
+		}
+		if err := g.LoadSceneIDs(ctx, r); err != nil {
+			return nil, err
+		}
+		if err := g.LoadPerformerIDs(ctx, r); err != nil {
+			return nil, err
+		}
+		if err := g.LoadTagIDs(ctx, r); err != nil {
+			return nil, err
+		}
+
+		return snapshot{
+			"title":         g.Title,
+			"code":          g.Code,
+			"details":       g.Details,
+			"photographer":  g.Photographer,
+			"urls":          stringList(g.URLs.List()),
+			"date":          dateString(g.Date),
+			"rating100":     g.Rating,
+			"organized":     g.Organized,
+			"studio_id":     idString(g.StudioID),
+			"scene_ids":     idStrings(g.SceneIDs.List()),
+			"performer_ids": idStrings(g.PerformerIDs.List()),
+			"tag_ids":       idStrings(g.TagIDs.List()),
+		}, nil
+	}
+}
+
+func performerSnapshot(r models.PerformerReader) snapshotFunc {
+	return func(ctx context.Context, id int) (snapshot, error) {
+		p, err := r.Find(ctx, id)
+		if err != nil || p == nil {
+			return nil, err
+		}
+
+		if err := p.LoadRelationships(ctx, r); err != nil {
+			return nil, err
+		}
+		if err := p.LoadURLs(ctx, r); err != nil {
+			return nil, err
+		}
+
+		return snapshot{
+			"name":               p.Name,
+			"disambiguation":     p.Disambiguation,
+			"gender":             p.Gender,
+			"birthdate":          dateString(p.Birthdate),
+			"death_date":         dateString(p.DeathDate),
+			"ethnicity":          p.Ethnicity,
+			"country":            p.Country,
+			"eye_color":          p.EyeColor,
+			"hair_color":         p.HairColor,
+			"height_cm":          p.Height,
+			"weight":             p.Weight,
+			"measurements":       p.Measurements,
+			"fake_tits":          p.FakeTits,
+			"penis_length":       p.PenisLength,
+			"circumcised":        p.Circumcised,
+			"career_length":      p.CareerLength,
+			"tattoos":            p.Tattoos,
+			"piercings":          p.Piercings,
+			"details":            p.Details,
+			"rating100":          p.Rating,
+			"favorite":           p.Favorite,
+			"ignore_auto_tag":    p.IgnoreAutoTag,
+			"is_group":           p.IsGroup,
+			"alias_list":         stringList(p.Aliases.List()),
+			"urls":               stringList(p.URLs.List()),
+			"tag_ids":            idStrings(p.TagIDs.List()),
+			"stash_ids":          stashIDs(p.StashIDs.List()),
+			"related_performers": performerRelationships(p.RelatedPerformers.List()),
+		}, nil
+	}
+}
+
+func studioSnapshot(r models.StudioReader) snapshotFunc {
+	return func(ctx context.Context, id int) (snapshot, error) {
+		s, err := r.Find(ctx, id)
+		if err != nil || s == nil {
+			return nil, err
+		}
+
+		if err := s.LoadAliases(ctx, r); err != nil {
+			return nil, err
+		}
+		if err := s.LoadTagIDs(ctx, r); err != nil {
+			return nil, err
+		}
+		if err := s.LoadStashIDs(ctx, r); err != nil {
+			return nil, err
+		}
+
+		return snapshot{
+			"name":            s.Name,
+			"url":             s.URL,
+			"parent_id":       idString(s.ParentID),
+			"details":         s.Details,
+			"rating100":       s.Rating,
+			"favorite":        s.Favorite,
+			"ignore_auto_tag": s.IgnoreAutoTag,
+			"aliases":         stringList(s.Aliases.List()),
+			"tag_ids":         idStrings(s.TagIDs.List()),
+			"stash_ids":       stashIDs(s.StashIDs.List()),
+		}, nil
+	}
+}
+
+func tagSnapshot(r models.TagReader) snapshotFunc {
+	return func(ctx context.Context, id int) (snapshot, error) {
+		t, err := r.Find(ctx, id)
+		if err != nil || t == nil {
+			return nil, err
+		}
+
+		if err := t.LoadAliases(ctx, r); err != nil {
+			return nil, err
+		}
+		if err := t.LoadStashIDs(ctx, r); err != nil {
+			return nil, err
+		}
+		if err := t.LoadParentIDs(ctx, r); err != nil {
+			return nil, err
+		}
+		if err := t.LoadChildIDs(ctx, r); err != nil {
+			return nil, err
+		}
+
+		return snapshot{
+			"name":            t.Name,
+			"description":     t.Description,
+			"favorite":        t.Favorite,
+			"ignore_auto_tag": t.IgnoreAutoTag,
+			"aliases":         stringList(t.Aliases.List()),
+			"parent_ids":      idStrings(t.ParentIDs.List()),
+			"child_ids":       idStrings(t.ChildIDs.List()),
+			"stash_ids":       stashIDs(t.StashIDs.List()),
+		}, nil
+	}
+}
+
+type groupSnapshotReader interface {
+	models.GroupGetter
+	models.URLLoader
+	models.TagIDLoader
+	models.ContainingGroupLoader
+	models.SubGroupLoader
+}
+
+func groupSnapshot(r groupSnapshotReader) snapshotFunc {
+	return func(ctx context.Context, id int) (snapshot, error) {
+		g, err := r.Find(ctx, id)
+		if err != nil || g == nil {
+			return nil, err
+		}
+
+		if err := g.LoadURLs(ctx, r); err != nil {
+			return nil, err
+		}
+		if err := g.LoadTagIDs(ctx, r); err != nil {
+			return nil, err
+		}
+		if err := g.LoadContainingGroupIDs(ctx, r); err != nil {
+			return nil, err
+		}
+		if err := g.LoadSubGroupIDs(ctx, r); err != nil {
+			return nil, err
+		}
+
+		return snapshot{
+			"name":              g.Name,
+			"aliases":           g.Aliases,
+			"duration":          g.Duration,
+			"date":              dateString(g.Date),
+			"rating100":         g.Rating,
+			"studio_id":         idString(g.StudioID),
+			"director":          g.Director,
+			"synopsis":          g.Synopsis,
+			"urls":              stringList(g.URLs.List()),
+			"tag_ids":           idStrings(g.TagIDs.List()),
+			"containing_groups": groupDescriptions(g.ContainingGroups.List()),
+			"sub_groups":        groupDescriptions(g.SubGroups.List()),
+		}, nil
+	}
+}
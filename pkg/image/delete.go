@@ -146,8 +146,10 @@ func (s *Service) DestroyFolderImages(ctx context.Context, folderID models.Folde
 
 // Destroy destroys an image, optionally marking the file and generated files for deletion.
 func (s *Service) destroyImage(ctx context.Context, i *models.Image, fileDeleter *FileDeleter, deleteGenerated, deleteFile bool) error {
+	// files are deleted after the image, so that the deleted image can be
+	// identified by its files
 	if deleteFile {
-		if err := s.deleteFiles(ctx, i, fileDeleter); err != nil {
+		if err := i.LoadFiles(ctx, s.Repository); err != nil {
 			return err
 		}
 	}
@@ -158,15 +160,22 @@ func (s *Service) destroyImage(ctx context.Context, i *models.Image, fileDeleter
 		}
 	}
 
-	return s.Repository.Destroy(ctx, i.ID)
-}
-
-// deleteFiles deletes files for the image from the database and file system, if they are not in use by other images
-func (s *Service) deleteFiles(ctx context.Context, i *models.Image, fileDeleter *FileDeleter) error {
-	if err := i.LoadFiles(ctx, s.Repository); err != nil {
+	if err := s.Repository.Destroy(ctx, i.ID); err != nil {
 		return err
 	}
 
+	if deleteFile {
+		if err := s.deleteFiles(ctx, i, fileDeleter); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deleteFiles deletes files for the image from the database and file system, if they are not in use by other images.
+// The image must have been destroyed, and its files loaded beforehand.
+func (s *Service) deleteFiles(ctx context.Context, i *models.Image, fileDeleter *FileDeleter) error {
 	for _, f := range i.Files.List() {
 		// only delete files where there is no other associated image
 		otherImages, err := s.Repository.FindByFileID(ctx, f.Base().ID)
@@ -174,7 +183,7 @@ func (s *Service) deleteFiles(ctx context.Context, i *models.Image, fileDeleter
 			return err
 		}
 
-		if len(otherImages) > 1 {
+		if len(otherImages) > 0 {
 			// other image associated, don't remove
 			continue
 		}
@@ -30,6 +30,8 @@ var (
 )
 
 type ThumbnailEncoder struct {
+	// FS is the file system used to read images. Defaults to the OS file system.
+	FS                 models.FS
 	FFMpeg             *ffmpeg.FFMpeg
 	FFProbe            *ffmpeg.FFProbe
 	ClipPreviewOptions ClipPreviewOptions
@@ -51,6 +53,7 @@ func GetVipsPath() string {
 
 func NewThumbnailEncoder(ffmpegEncoder *ffmpeg.FFMpeg, ffProbe *ffmpeg.FFProbe, clipPreviewOptions ClipPreviewOptions) ThumbnailEncoder {
 	ret := ThumbnailEncoder{
+		FS:                 &file.OsFS{},
 		FFMpeg:             ffmpegEncoder,
 		FFProbe:            ffProbe,
 		ClipPreviewOptions: clipPreviewOptions,
@@ -70,7 +73,7 @@ func NewThumbnailEncoder(ffmpegEncoder *ffmpeg.FFMpeg, ffProbe *ffmpeg.FFProbe,
 // It returns nil and an error if an error occurs reading, decoding or encoding
 // the image, or if the image is not suitable for thumbnails.
 func (e *ThumbnailEncoder) GetThumbnail(f models.File, maxSize int) ([]byte, error) {
-	reader, err := f.Open(&file.OsFS{})
+	reader, err := f.Open(e.FS)
 	if err != nil {
 		return nil, err
 	}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/performer"
+	"github.com/stashapp/stash/pkg/sliceutil"
 	"github.com/stashapp/stash/pkg/studio"
 	"github.com/stashapp/stash/pkg/tag"
 )
@@ -66,6 +67,33 @@ func ScrapedPerformer(ctx context.Context, qb PerformerFinder, p *models.Scraped
 	return nil
 }
 
+// ScrapedPerformerMergedIDs matches the stash-box IDs merged into the provided
+// performer with the performers in the database, and sets the SamePersonIDs
+// field to the performers found. The performer matched by StoredID is not
+// included.
+func ScrapedPerformerMergedIDs(ctx context.Context, qb PerformerFinder, p *models.ScrapedPerformer, stashBoxEndpoint string) error {
+	for _, mergedID := range p.MergedIDs {
+		performers, err := qb.FindByStashID(ctx, models.StashID{
+			StashID:  mergedID,
+			Endpoint: stashBoxEndpoint,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, performer := range performers {
+			id := strconv.Itoa(performer.ID)
+			if p.StoredID != nil && *p.StoredID == id {
+				continue
+			}
+
+			p.SamePersonIDs = sliceutil.AppendUnique(p.SamePersonIDs, performer.ID)
+		}
+	}
+
+	return nil
+}
+
 type StudioFinder interface {
 	models.StudioQueryer
 	FindByStashID(ctx context.Context, stashID models.StashID) ([]*models.Studio, error)
@@ -141,13 +169,34 @@ func ScrapedGroup(ctx context.Context, qb GroupNamesFinder, storedID *string, na
 	return
 }
 
+type TagFinder interface {
+	models.TagQueryer
+	FindByStashID(ctx context.Context, stashID models.StashID) ([]*models.Tag, error)
+}
+
 // ScrapedTag matches the provided tag with the tags
 // in the database and sets the ID field if one is found.
-func ScrapedTag(ctx context.Context, qb models.TagQueryer, s *models.ScrapedTag) error {
+func ScrapedTag(ctx context.Context, qb TagFinder, s *models.ScrapedTag, stashBoxEndpoint *string) error {
 	if s.StoredID != nil {
 		return nil
 	}
 
+	// Check if a tag with the StashID already exists
+	if stashBoxEndpoint != nil && s.RemoteSiteID != nil {
+		tags, err := qb.FindByStashID(ctx, models.StashID{
+			StashID:  *s.RemoteSiteID,
+			Endpoint: *stashBoxEndpoint,
+		})
+		if err != nil {
+			return err
+		}
+		if len(tags) > 0 {
+			id := strconv.Itoa(tags[0].ID)
+			s.StoredID = &id
+			return nil
+		}
+	}
+
 	t, err := tag.ByName(ctx, qb, s.Name)
 
 	if err != nil {
@@ -0,0 +1,50 @@
+package match
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+)
+
+func TestScrapedPerformerMergedIDs(t *testing.T) {
+	const endpoint = "endpoint"
+
+	ctx := context.Background()
+	db := mocks.NewDatabase()
+
+	db.Performer.On("FindByStashID", ctx, models.StashID{StashID: "merged1", Endpoint: endpoint}).Return([]*models.Performer{{ID: 1}, {ID: 2}}, nil)
+	db.Performer.On("FindByStashID", ctx, models.StashID{StashID: "merged2", Endpoint: endpoint}).Return([]*models.Performer{{ID: 2}}, nil)
+	db.Performer.On("FindByStashID", ctx, models.StashID{StashID: "missing", Endpoint: endpoint}).Return(nil, nil)
+
+	storedID := "1"
+
+	tests := []struct {
+		name      string
+		storedID  *string
+		mergedIDs []string
+		want      []int
+	}{
+		{"none", nil, nil, nil},
+		{"missing", nil, []string{"missing"}, nil},
+		{"matched", nil, []string{"merged1", "merged2", "missing"}, []int{1, 2}},
+		{"excludes stored performer", &storedID, []string{"merged1"}, []int{2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &models.ScrapedPerformer{
+				StoredID:  tt.storedID,
+				MergedIDs: tt.mergedIDs,
+			}
+
+			if err := ScrapedPerformerMergedIDs(ctx, db.Performer, p, endpoint); err != nil {
+				t.Fatalf("ScrapedPerformerMergedIDs() error = %v", err)
+			}
+			assert.Equal(t, tt.want, p.SamePersonIDs)
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package models
+
+import "context"
+
+type EditHistoryReader interface {
+	Find(ctx context.Context, id int) (*EditHistoryEntry, error)
+	// FindByEntity returns the history of an object, most recent first.
+	FindByEntity(ctx context.Context, entityType HistoryEntityType, id int) ([]*EditHistoryEntry, error)
+}
+
+type EditHistoryWriter interface {
+	Create(ctx context.Context, obj *EditHistoryEntry) error
+}
+
+type EditHistoryReaderWriter interface {
+	EditHistoryReader
+	EditHistoryWriter
+}
@@ -10,4 +10,8 @@ var (
 	ErrConversion = errors.New("conversion error")
 
 	ErrScraperSource = errors.New("invalid ScraperSource")
+
+	// ErrStatsTimelineTooLong signifies timelines with more than
+	// MaxStatsTimelineBuckets buckets
+	ErrStatsTimelineTooLong = errors.New("timeline has too many points, use a longer interval or a shorter range")
 )
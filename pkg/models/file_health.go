@@ -0,0 +1,65 @@
+package models
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+type FileHealthStatus string
+
+const (
+	// FileHealthStatusOk indicates that the file decoded without errors.
+	FileHealthStatusOk FileHealthStatus = "OK"
+	// FileHealthStatusCorrupt indicates that errors were reported while
+	// probing or decoding the file.
+	FileHealthStatusCorrupt FileHealthStatus = "CORRUPT"
+)
+
+var AllFileHealthStatus = []FileHealthStatus{
+	FileHealthStatusOk,
+	FileHealthStatusCorrupt,
+}
+
+func (e FileHealthStatus) IsValid() bool {
+	switch e {
+	case FileHealthStatusOk, FileHealthStatusCorrupt:
+		return true
+	}
+	return false
+}
+
+func (e FileHealthStatus) String() string {
+	return string(e)
+}
+
+func (e *FileHealthStatus) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = FileHealthStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid FileHealthStatus", str)
+	}
+	return nil
+}
+
+func (e FileHealthStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// FileHealth is the result of the last health check of a file.
+type FileHealth struct {
+	Status FileHealthStatus `json:"status"`
+	// Error contains the errors reported while checking the file.
+	Error     string    `json:"error"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+type FileHealthCriterionInput struct {
+	Value    []FileHealthStatus `json:"value"`
+	Modifier CriterionModifier  `json:"modifier"`
+}
@@ -19,7 +19,8 @@ type FS interface {
 	IsPathCaseSensitive(path string) (bool, error)
 }
 
-// ZipFS represents a zip file system.
+// ZipFS represents a read-only file system backed by an archive file, such as
+// a zip, 7z, rar or tar file.
 type ZipFS interface {
 	FS
 	io.Closer
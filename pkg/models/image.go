@@ -11,6 +11,8 @@ type ImageFilterType struct {
 	Photographer *StringCriterionInput `json:"photographer"`
 	// Filter by file checksum
 	Checksum *StringCriterionInput `json:"checksum"`
+	// Filter by file phash distance
+	PhashDistance *PhashDistanceCriterionInput `json:"phash_distance"`
 	// Filter by path
 	Path *StringCriterionInput `json:"path"`
 	// Filter by file count
@@ -29,6 +31,8 @@ type ImageFilterType struct {
 	Resolution *ResolutionCriterionInput `json:"resolution"`
 	// Filter by landscape/portrait
 	Orientation *OrientationCriterionInput `json:"orientation"`
+	// Filter by file health check status
+	FileHealth *FileHealthCriterionInput `json:"file_health"`
 	// Filter to only include images missing this property
 	IsMissing *string `json:"is_missing"`
 	// Filter to only include images with this studio
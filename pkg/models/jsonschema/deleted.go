@@ -0,0 +1,55 @@
+package jsonschema
+
+import (
+	"fmt"
+	"os"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stashapp/stash/pkg/models/json"
+)
+
+// DeletedFiles identifies a deleted scene or image by its file paths.
+type DeletedFiles struct {
+	Files []string `json:"files"`
+}
+
+// DeletedPerformer identifies a deleted performer.
+type DeletedPerformer struct {
+	Name           string `json:"name"`
+	Disambiguation string `json:"disambiguation,omitempty"`
+}
+
+// Deleted lists the objects deleted since the time of an incremental export.
+type Deleted struct {
+	Since      json.JSONTime      `json:"since"`
+	Scenes     []DeletedFiles     `json:"scenes,omitempty"`
+	Images     []DeletedFiles     `json:"images,omitempty"`
+	Galleries  []GalleryRef       `json:"galleries,omitempty"`
+	Performers []DeletedPerformer `json:"performers,omitempty"`
+	Studios    []string           `json:"studios,omitempty"`
+	Tags       []string           `json:"tags,omitempty"`
+	Groups     []string           `json:"groups,omitempty"`
+}
+
+func LoadDeletedFile(filePath string) (*Deleted, error) {
+	var deleted Deleted
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	var json = jsoniter.ConfigCompatibleWithStandardLibrary
+	jsonParser := json.NewDecoder(file)
+	err = jsonParser.Decode(&deleted)
+	if err != nil {
+		return nil, err
+	}
+	return &deleted, nil
+}
+
+func SaveDeletedFile(filePath string, deleted *Deleted) error {
+	if deleted == nil {
+		return fmt.Errorf("deleted must not be nil")
+	}
+	return marshalToFile(filePath, deleted)
+}
@@ -109,6 +109,12 @@ func LoadFileFile(filePath string) (DirEntry, error) {
 		return nil, err
 	}
 
+	return ParseDirEntry(data)
+}
+
+// ParseDirEntry decodes the JSON of a file or folder into the DirEntry
+// implementation matching its type.
+func ParseDirEntry(data []byte) (DirEntry, error) {
 	var json = jsoniter.ConfigCompatibleWithStandardLibrary
 	jsonParser := json.NewDecoder(bytes.NewReader(data))
 
@@ -33,6 +33,14 @@ func (s *StringOrStringList) UnmarshalJSON(data []byte) error {
 	return err
 }
 
+// PerformerRelationship is a relationship to the performer with the given
+// name and disambiguation.
+type PerformerRelationship struct {
+	Name           string `json:"name,omitempty"`
+	Disambiguation string `json:"disambiguation,omitempty"`
+	Type           string `json:"type,omitempty"`
+}
+
 type Performer struct {
 	Name           string   `json:"name,omitempty"`
 	Disambiguation string   `json:"disambiguation,omitempty"`
@@ -64,6 +72,9 @@ type Performer struct {
 	Weight        int                `json:"weight,omitempty"`
 	StashIDs      []models.StashID   `json:"stash_ids,omitempty"`
 	IgnoreAutoTag bool               `json:"ignore_auto_tag,omitempty"`
+	IsGroup       bool               `json:"is_group,omitempty"`
+
+	RelatedPerformers []PerformerRelationship `json:"related_performers,omitempty"`
 
 	// deprecated - for import only
 	URL       string `json:"url,omitempty"`
@@ -376,3 +376,17 @@ func (_m *FileReaderWriter) UpdateCaptions(ctx context.Context, fileID models.Fi
 
 	return r0
 }
+
+// UpdateHealth provides a mock function with given fields: ctx, fileID, health
+func (_m *FileReaderWriter) UpdateHealth(ctx context.Context, fileID models.FileID, health *models.FileHealth) error {
+	ret := _m.Called(ctx, fileID, health)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.FileID, *models.FileHealth) error); ok {
+		r0 = rf(ctx, fileID, health)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -586,6 +586,29 @@ func (_m *GalleryReaderWriter) Query(ctx context.Context, galleryFilter *models.
 	return r0, r1, r2
 }
 
+// QueryFacets provides a mock function with given fields: ctx, galleryFilter, findFilter, limit
+func (_m *GalleryReaderWriter) QueryFacets(ctx context.Context, galleryFilter *models.GalleryFilterType, findFilter *models.FindFilterType, limit int) (*models.QueryFacets, error) {
+	ret := _m.Called(ctx, galleryFilter, findFilter, limit)
+
+	var r0 *models.QueryFacets
+	if rf, ok := ret.Get(0).(func(context.Context, *models.GalleryFilterType, *models.FindFilterType, int) *models.QueryFacets); ok {
+		r0 = rf(ctx, galleryFilter, findFilter, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.QueryFacets)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *models.GalleryFilterType, *models.FindFilterType, int) error); ok {
+		r1 = rf(ctx, galleryFilter, findFilter, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // QueryCount provides a mock function with given fields: ctx, galleryFilter, findFilter
 func (_m *GalleryReaderWriter) QueryCount(ctx context.Context, galleryFilter *models.GalleryFilterType, findFilter *models.FindFilterType) (int, error) {
 	ret := _m.Called(ctx, galleryFilter, findFilter)
@@ -370,6 +370,29 @@ func (_m *ImageReaderWriter) FindByZipFileID(ctx context.Context, zipFileID mode
 	return r0, r1
 }
 
+// FindDuplicates provides a mock function with given fields: ctx, distance
+func (_m *ImageReaderWriter) FindDuplicates(ctx context.Context, distance int) ([][]*models.Image, error) {
+	ret := _m.Called(ctx, distance)
+
+	var r0 [][]*models.Image
+	if rf, ok := ret.Get(0).(func(context.Context, int) [][]*models.Image); ok {
+		r0 = rf(ctx, distance)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([][]*models.Image)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, distance)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindMany provides a mock function with given fields: ctx, ids
 func (_m *ImageReaderWriter) FindMany(ctx context.Context, ids []int) ([]*models.Image, error) {
 	ret := _m.Called(ctx, ids)
@@ -617,6 +640,29 @@ func (_m *ImageReaderWriter) Query(ctx context.Context, options models.ImageQuer
 	return r0, r1
 }
 
+// QueryFacets provides a mock function with given fields: ctx, imageFilter, findFilter, limit
+func (_m *ImageReaderWriter) QueryFacets(ctx context.Context, imageFilter *models.ImageFilterType, findFilter *models.FindFilterType, limit int) (*models.QueryFacets, error) {
+	ret := _m.Called(ctx, imageFilter, findFilter, limit)
+
+	var r0 *models.QueryFacets
+	if rf, ok := ret.Get(0).(func(context.Context, *models.ImageFilterType, *models.FindFilterType, int) *models.QueryFacets); ok {
+		r0 = rf(ctx, imageFilter, findFilter, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.QueryFacets)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *models.ImageFilterType, *models.FindFilterType, int) error); ok {
+		r1 = rf(ctx, imageFilter, findFilter, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // QueryCount provides a mock function with given fields: ctx, imageFilter, findFilter
 func (_m *ImageReaderWriter) QueryCount(ctx context.Context, imageFilter *models.ImageFilterType, findFilter *models.FindFilterType) (int, error) {
 	ret := _m.Called(ctx, imageFilter, findFilter)
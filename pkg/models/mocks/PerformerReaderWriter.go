@@ -7,6 +7,8 @@ import (
 
 	models "github.com/stashapp/stash/pkg/models"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // PerformerReaderWriter is an autogenerated mock type for the PerformerReaderWriter type
@@ -337,6 +339,29 @@ func (_m *PerformerReaderWriter) GetImage(ctx context.Context, performerID int)
 	return r0, r1
 }
 
+// GetRelatedPerformers provides a mock function with given fields: ctx, performerID
+func (_m *PerformerReaderWriter) GetRelatedPerformers(ctx context.Context, performerID int) ([]models.PerformerRelationship, error) {
+	ret := _m.Called(ctx, performerID)
+
+	var r0 []models.PerformerRelationship
+	if rf, ok := ret.Get(0).(func(context.Context, int) []models.PerformerRelationship); ok {
+		r0 = rf(ctx, performerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.PerformerRelationship)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, performerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetStashIDs provides a mock function with given fields: ctx, relatedID
 func (_m *PerformerReaderWriter) GetStashIDs(ctx context.Context, relatedID int) ([]models.StashID, error) {
 	ret := _m.Called(ctx, relatedID)
@@ -551,3 +576,17 @@ func (_m *PerformerReaderWriter) UpdatePartial(ctx context.Context, id int, upda
 
 	return r0, r1
 }
+
+// UpdateStashIDUpdatedAt provides a mock function with given fields: ctx, relatedID, endpoint, updatedAt
+func (_m *PerformerReaderWriter) UpdateStashIDUpdatedAt(ctx context.Context, relatedID int, endpoint string, updatedAt time.Time) error {
+	ret := _m.Called(ctx, relatedID, endpoint, updatedAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string, time.Time) error); ok {
+		r0 = rf(ctx, relatedID, endpoint, updatedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -1183,6 +1183,29 @@ func (_m *SceneReaderWriter) Query(ctx context.Context, options models.SceneQuer
 	return r0, r1
 }
 
+// QueryFacets provides a mock function with given fields: ctx, sceneFilter, findFilter, limit
+func (_m *SceneReaderWriter) QueryFacets(ctx context.Context, sceneFilter *models.SceneFilterType, findFilter *models.FindFilterType, limit int) (*models.QueryFacets, error) {
+	ret := _m.Called(ctx, sceneFilter, findFilter, limit)
+
+	var r0 *models.QueryFacets
+	if rf, ok := ret.Get(0).(func(context.Context, *models.SceneFilterType, *models.FindFilterType, int) *models.QueryFacets); ok {
+		r0 = rf(ctx, sceneFilter, findFilter, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.QueryFacets)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *models.SceneFilterType, *models.FindFilterType, int) error); ok {
+		r1 = rf(ctx, sceneFilter, findFilter, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // QueryCount provides a mock function with given fields: ctx, sceneFilter, findFilter
 func (_m *SceneReaderWriter) QueryCount(ctx context.Context, sceneFilter *models.SceneFilterType, findFilter *models.FindFilterType) (int, error) {
 	ret := _m.Called(ctx, sceneFilter, findFilter)
@@ -1288,6 +1311,29 @@ func (_m *SceneReaderWriter) Size(ctx context.Context) (float64, error) {
 	return r0, r1
 }
 
+// StatsTimeline provides a mock function with given fields: ctx, options
+func (_m *SceneReaderWriter) StatsTimeline(ctx context.Context, options models.StatsTimelineOptions) ([]*models.StatsTimelineSeries, error) {
+	ret := _m.Called(ctx, options)
+
+	var r0 []*models.StatsTimelineSeries
+	if rf, ok := ret.Get(0).(func(context.Context, models.StatsTimelineOptions) []*models.StatsTimelineSeries); ok {
+		r0 = rf(ctx, options)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.StatsTimelineSeries)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, models.StatsTimelineOptions) error); ok {
+		r1 = rf(ctx, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Update provides a mock function with given fields: ctx, updatedScene
 func (_m *SceneReaderWriter) Update(ctx context.Context, updatedScene *models.Scene) error {
 	ret := _m.Called(ctx, updatedScene)
@@ -1339,6 +1385,20 @@ func (_m *SceneReaderWriter) UpdatePartial(ctx context.Context, id int, updatedS
 	return r0, r1
 }
 
+// UpdateStashIDUpdatedAt provides a mock function with given fields: ctx, relatedID, endpoint, updatedAt
+func (_m *SceneReaderWriter) UpdateStashIDUpdatedAt(ctx context.Context, relatedID int, endpoint string, updatedAt time.Time) error {
+	ret := _m.Called(ctx, relatedID, endpoint, updatedAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string, time.Time) error); ok {
+		r0 = rf(ctx, relatedID, endpoint, updatedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Wall provides a mock function with given fields: ctx, q
 func (_m *SceneReaderWriter) Wall(ctx context.Context, q *string) ([]*models.Scene, error) {
 	ret := _m.Called(ctx, q)
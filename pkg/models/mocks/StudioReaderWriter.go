@@ -7,6 +7,8 @@ import (
 
 	models "github.com/stashapp/stash/pkg/models"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // StudioReaderWriter is an autogenerated mock type for the StudioReaderWriter type
@@ -505,3 +507,17 @@ func (_m *StudioReaderWriter) UpdatePartial(ctx context.Context, updatedStudio m
 
 	return r0, r1
 }
+
+// UpdateStashIDUpdatedAt provides a mock function with given fields: ctx, relatedID, endpoint, updatedAt
+func (_m *StudioReaderWriter) UpdateStashIDUpdatedAt(ctx context.Context, relatedID int, endpoint string, updatedAt time.Time) error {
+	ret := _m.Called(ctx, relatedID, endpoint, updatedAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string, time.Time) error); ok {
+		r0 = rf(ctx, relatedID, endpoint, updatedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
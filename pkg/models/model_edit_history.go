@@ -0,0 +1,171 @@
+package models
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// HistoryEntityType is the type of object recorded in the edit history.
+type HistoryEntityType string
+
+const (
+	HistoryEntityTypeScene     HistoryEntityType = "SCENE"
+	HistoryEntityTypeImage     HistoryEntityType = "IMAGE"
+	HistoryEntityTypeGallery   HistoryEntityType = "GALLERY"
+	HistoryEntityTypePerformer HistoryEntityType = "PERFORMER"
+	HistoryEntityTypeStudio    HistoryEntityType = "STUDIO"
+	HistoryEntityTypeTag       HistoryEntityType = "TAG"
+	HistoryEntityTypeGroup     HistoryEntityType = "GROUP"
+)
+
+var AllHistoryEntityType = []HistoryEntityType{
+	HistoryEntityTypeScene,
+	HistoryEntityTypeImage,
+	HistoryEntityTypeGallery,
+	HistoryEntityTypePerformer,
+	HistoryEntityTypeStudio,
+	HistoryEntityTypeTag,
+	HistoryEntityTypeGroup,
+}
+
+func (e HistoryEntityType) IsValid() bool {
+	switch e {
+	case HistoryEntityTypeScene, HistoryEntityTypeImage, HistoryEntityTypeGallery, HistoryEntityTypePerformer, HistoryEntityTypeStudio, HistoryEntityTypeTag, HistoryEntityTypeGroup:
+		return true
+	}
+	return false
+}
+
+func (e HistoryEntityType) String() string {
+	return string(e)
+}
+
+func (e *HistoryEntityType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = HistoryEntityType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid HistoryEntityType", str)
+	}
+	return nil
+}
+
+func (e HistoryEntityType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// HistoryAction is the type of change recorded in the edit history.
+type HistoryAction string
+
+const (
+	HistoryActionCreate  HistoryAction = "CREATE"
+	HistoryActionUpdate  HistoryAction = "UPDATE"
+	HistoryActionMerge   HistoryAction = "MERGE"
+	HistoryActionDestroy HistoryAction = "DESTROY"
+)
+
+var AllHistoryAction = []HistoryAction{
+	HistoryActionCreate,
+	HistoryActionUpdate,
+	HistoryActionMerge,
+	HistoryActionDestroy,
+}
+
+func (e HistoryAction) IsValid() bool {
+	switch e {
+	case HistoryActionCreate, HistoryActionUpdate, HistoryActionMerge, HistoryActionDestroy:
+		return true
+	}
+	return false
+}
+
+func (e HistoryAction) String() string {
+	return string(e)
+}
+
+func (e *HistoryAction) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = HistoryAction(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid HistoryAction", str)
+	}
+	return nil
+}
+
+func (e HistoryAction) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// HistoryOrigin is the source of a change recorded in the edit history.
+type HistoryOrigin string
+
+const (
+	HistoryOriginUI       HistoryOrigin = "UI"
+	HistoryOriginAPI      HistoryOrigin = "API"
+	HistoryOriginIdentify HistoryOrigin = "IDENTIFY"
+	HistoryOriginPlugin   HistoryOrigin = "PLUGIN"
+)
+
+var AllHistoryOrigin = []HistoryOrigin{
+	HistoryOriginUI,
+	HistoryOriginAPI,
+	HistoryOriginIdentify,
+	HistoryOriginPlugin,
+}
+
+func (e HistoryOrigin) IsValid() bool {
+	switch e {
+	case HistoryOriginUI, HistoryOriginAPI, HistoryOriginIdentify, HistoryOriginPlugin:
+		return true
+	}
+	return false
+}
+
+func (e HistoryOrigin) String() string {
+	return string(e)
+}
+
+func (e *HistoryOrigin) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = HistoryOrigin(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid HistoryOrigin", str)
+	}
+	return nil
+}
+
+func (e HistoryOrigin) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// EditHistoryEntry is a change to a single field of an object.
+type EditHistoryEntry struct {
+	ID         int               `json:"id"`
+	EntityType HistoryEntityType `json:"entity_type"`
+	EntityID   int               `json:"entity_id"`
+	Action     HistoryAction     `json:"action"`
+	Field      string            `json:"field"`
+	// OldValue and NewValue are JSON encoded, in the format of the update
+	// input field. Nil if the field was not set.
+	OldValue *string `json:"old_value"`
+	NewValue *string `json:"new_value"`
+	// User is the name of the user that made the change, if authentication
+	// is enabled.
+	User      *string       `json:"user"`
+	Origin    HistoryOrigin `json:"origin"`
+	PluginID  *string       `json:"plugin_id"`
+	CreatedAt time.Time     `json:"created_at"`
+}
@@ -130,6 +130,9 @@ type BaseFile struct {
 
 	Size int64 `json:"size"`
 
+	// Health is nil if the file has not been checked.
+	Health *FileHealth `json:"health"`
+
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
@@ -291,6 +294,11 @@ func (f VideoFile) GetFormat() string {
 	return f.Format
 }
 
+// IsAudio returns true if the file has an audio stream and no video stream.
+func (f VideoFile) IsAudio() bool {
+	return f.VideoCodec == "" && f.AudioCodec != ""
+}
+
 func (f VideoFile) Clone() (ret File) {
 	clone := f
 	clone.BaseFile = f.BaseFile.Clone().(*BaseFile)
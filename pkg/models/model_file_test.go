@@ -0,0 +1,31 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVideoFile_IsAudio(t *testing.T) {
+	tests := []struct {
+		name       string
+		videoCodec string
+		audioCodec string
+		want       bool
+	}{
+		{"video and audio", "h264", "aac", false},
+		{"video only", "h264", "", false},
+		{"audio only", "", "mp3", true},
+		{"no streams", "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := VideoFile{
+				VideoCodec: tt.videoCodec,
+				AudioCodec: tt.audioCodec,
+			}
+			assert.Equal(t, tt.want, f.IsAudio())
+		})
+	}
+}
@@ -73,3 +73,9 @@ type GroupIDDescription struct {
 	GroupID     int    `json:"group_id"`
 	Description string `json:"description"`
 }
+
+// PerformerRelationship is a relationship to another performer.
+type PerformerRelationship struct {
+	PerformerID int                       `json:"performer_id"`
+	Type        PerformerRelationshipType `json:"type"`
+}
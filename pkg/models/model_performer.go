@@ -32,11 +32,13 @@ type Performer struct {
 	HairColor     string `json:"hair_color"`
 	Weight        *int   `json:"weight"`
 	IgnoreAutoTag bool   `json:"ignore_auto_tag"`
+	IsGroup       bool   `json:"is_group"`
 
-	Aliases  RelatedStrings  `json:"aliases"`
-	URLs     RelatedStrings  `json:"urls"`
-	TagIDs   RelatedIDs      `json:"tag_ids"`
-	StashIDs RelatedStashIDs `json:"stash_ids"`
+	Aliases           RelatedStrings                `json:"aliases"`
+	URLs              RelatedStrings                `json:"urls"`
+	TagIDs            RelatedIDs                    `json:"tag_ids"`
+	StashIDs          RelatedStashIDs               `json:"stash_ids"`
+	RelatedPerformers RelatedPerformerRelationships `json:"related_performers"`
 }
 
 func NewPerformer() Performer {
@@ -76,10 +78,12 @@ type PerformerPartial struct {
 	HairColor     OptionalString
 	Weight        OptionalInt
 	IgnoreAutoTag OptionalBool
+	IsGroup       OptionalBool
 
-	Aliases  *UpdateStrings
-	TagIDs   *UpdateIDs
-	StashIDs *UpdateStashIDs
+	Aliases           *UpdateStrings
+	TagIDs            *UpdateIDs
+	StashIDs          *UpdateStashIDs
+	RelatedPerformers *UpdatePerformerRelationships
 }
 
 func NewPerformerPartial() PerformerPartial {
@@ -113,6 +117,12 @@ func (s *Performer) LoadStashIDs(ctx context.Context, l StashIDLoader) error {
 	})
 }
 
+func (s *Performer) LoadRelatedPerformers(ctx context.Context, l RelatedPerformerLoader) error {
+	return s.RelatedPerformers.load(func() ([]PerformerRelationship, error) {
+		return l.GetRelatedPerformers(ctx, s.ID)
+	})
+}
+
 func (s *Performer) LoadRelationships(ctx context.Context, l PerformerReader) error {
 	if err := s.LoadAliases(ctx, l); err != nil {
 		return err
@@ -126,5 +136,9 @@ func (s *Performer) LoadRelationships(ctx context.Context, l PerformerReader) er
 		return err
 	}
 
+	if err := s.LoadRelatedPerformers(ctx, l); err != nil {
+		return err
+	}
+
 	return nil
 }
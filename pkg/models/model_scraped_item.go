@@ -131,10 +131,28 @@ type ScrapedPerformer struct {
 	HairColor    *string  `json:"hair_color"`
 	Weight       *string  `json:"weight"`
 	RemoteSiteID *string  `json:"remote_site_id"`
+
+	// MergedIDs are the stash-box IDs of the performers merged into this
+	// performer. Not exposed in the graphql schema.
+	MergedIDs []string `json:"-"`
+	// SamePersonIDs are the IDs of the stored performers matched from
+	// MergedIDs. These are the same person as this performer.
+	SamePersonIDs []int `json:"-"`
 }
 
 func (ScrapedPerformer) IsScrapedContent() {}
 
+func (p *ScrapedPerformer) samePersonRelationships() []PerformerRelationship {
+	var ret []PerformerRelationship
+	for _, id := range p.SamePersonIDs {
+		ret = append(ret, PerformerRelationship{
+			PerformerID: id,
+			Type:        PerformerRelationshipTypeSamePerson,
+		})
+	}
+	return ret
+}
+
 func (p *ScrapedPerformer) ToPerformer(endpoint string, excluded map[string]bool) *Performer {
 	ret := NewPerformer()
 	ret.Name = *p.Name
@@ -250,6 +268,10 @@ func (p *ScrapedPerformer) ToPerformer(endpoint string, excluded map[string]bool
 		})
 	}
 
+	if len(p.SamePersonIDs) > 0 && !excluded["related_performers"] {
+		ret.RelatedPerformers = NewRelatedPerformerRelationships(p.samePersonRelationships())
+	}
+
 	return &ret
 }
 
@@ -380,17 +402,100 @@ func (p *ScrapedPerformer) ToPartial(endpoint string, excluded map[string]bool,
 		})
 	}
 
+	if len(p.SamePersonIDs) > 0 && !excluded["related_performers"] {
+		ret.RelatedPerformers = &UpdatePerformerRelationships{
+			Relationships: p.samePersonRelationships(),
+			Mode:          RelationshipUpdateModeAdd,
+		}
+	}
+
 	return ret
 }
 
 type ScrapedTag struct {
 	// Set if tag matched
-	StoredID *string `json:"stored_id"`
-	Name     string  `json:"name"`
+	StoredID     *string     `json:"stored_id"`
+	Name         string      `json:"name"`
+	Description  *string     `json:"description"`
+	Aliases      []string    `json:"aliases"`
+	Parent       *ScrapedTag `json:"parent"`
+	RemoteSiteID *string     `json:"remote_site_id"`
 }
 
 func (ScrapedTag) IsScrapedContent() {}
 
+func (t *ScrapedTag) ToTag(endpoint string, excluded map[string]bool) *Tag {
+	// Populate a new tag from the input
+	ret := NewTag()
+	ret.Name = t.Name
+
+	if t.RemoteSiteID != nil && endpoint != "" {
+		ret.StashIDs = NewRelatedStashIDs([]StashID{
+			{
+				Endpoint: endpoint,
+				StashID:  *t.RemoteSiteID,
+			},
+		})
+	}
+
+	if t.Description != nil && !excluded["description"] {
+		ret.Description = *t.Description
+	}
+
+	if len(t.Aliases) > 0 && !excluded["aliases"] {
+		ret.Aliases = NewRelatedStrings(t.Aliases)
+	}
+
+	if t.Parent != nil && t.Parent.StoredID != nil && !excluded["parent"] {
+		parentID, _ := strconv.Atoi(*t.Parent.StoredID)
+		ret.ParentIDs = NewRelatedIDs([]int{parentID})
+	}
+
+	return &ret
+}
+
+func (t *ScrapedTag) ToPartial(endpoint string, excluded map[string]bool, existingStashIDs []StashID) TagPartial {
+	ret := NewTagPartial()
+
+	if t.Name != "" && !excluded["name"] {
+		ret.Name = NewOptionalString(t.Name)
+	}
+
+	if t.Description != nil && !excluded["description"] {
+		ret.Description = NewOptionalString(*t.Description)
+	}
+
+	if len(t.Aliases) > 0 && !excluded["aliases"] {
+		ret.Aliases = &UpdateStrings{
+			Values: t.Aliases,
+			Mode:   RelationshipUpdateModeAdd,
+		}
+	}
+
+	if t.Parent != nil && t.Parent.StoredID != nil && !excluded["parent"] {
+		parentID, _ := strconv.Atoi(*t.Parent.StoredID)
+		if parentID > 0 {
+			ret.ParentIDs = &UpdateIDs{
+				IDs:  []int{parentID},
+				Mode: RelationshipUpdateModeAdd,
+			}
+		}
+	}
+
+	if t.RemoteSiteID != nil && endpoint != "" {
+		ret.StashIDs = &UpdateStashIDs{
+			StashIDs: existingStashIDs,
+			Mode:     RelationshipUpdateModeSet,
+		}
+		ret.StashIDs.Set(StashID{
+			Endpoint: endpoint,
+			StashID:  *t.RemoteSiteID,
+		})
+	}
+
+	return ret
+}
+
 // A movie from a scraping operation...
 type ScrapedMovie struct {
 	StoredID *string        `json:"stored_id"`
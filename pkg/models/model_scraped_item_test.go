@@ -233,6 +233,22 @@ func Test_scrapedToPerformerInput(t *testing.T) {
 				}),
 			},
 		},
+		{
+			"set same person",
+			&ScrapedPerformer{
+				Name:          &name,
+				MergedIDs:     []string{"mergedID"},
+				SamePersonIDs: []int{1, 2},
+			},
+			endpoint,
+			&Performer{
+				Name: name,
+				RelatedPerformers: NewRelatedPerformerRelationships([]PerformerRelationship{
+					{PerformerID: 1, Type: PerformerRelationshipTypeSamePerson},
+					{PerformerID: 2, Type: PerformerRelationshipTypeSamePerson},
+				}),
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -263,7 +279,7 @@ func TestScrapedStudio_ToPartial(t *testing.T) {
 		images            = []string{image}
 
 		existingEndpoint = "existingEndpoint"
-		existingStashID  = StashID{"existingStashID", existingEndpoint}
+		existingStashID  = StashID{StashID: "existingStashID", Endpoint: existingEndpoint}
 		existingStashIDs = []StashID{existingStashID}
 	)
 
@@ -367,3 +383,38 @@ func TestScrapedStudio_ToPartial(t *testing.T) {
 		})
 	}
 }
+
+func TestScrapedPerformer_ToPartial_samePerson(t *testing.T) {
+	name := "name"
+	p := ScrapedPerformer{
+		Name:          &name,
+		SamePersonIDs: []int{1},
+	}
+
+	tests := []struct {
+		name     string
+		excluded map[string]bool
+		want     *UpdatePerformerRelationships
+	}{
+		{
+			"not excluded",
+			nil,
+			&UpdatePerformerRelationships{
+				Relationships: []PerformerRelationship{{PerformerID: 1, Type: PerformerRelationshipTypeSamePerson}},
+				Mode:          RelationshipUpdateModeAdd,
+			},
+		},
+		{
+			"excluded",
+			map[string]bool{"related_performers": true},
+			nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := p.ToPartial("endpoint", tt.excluded, nil)
+			assert.Equal(t, tt.want, got.RelatedPerformers)
+		})
+	}
+}
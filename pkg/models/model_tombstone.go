@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// Tombstone records the deletion of an object. The object is identified the
+// same way as in exported JSON, so that the deletion can be applied to other
+// instances.
+type Tombstone struct {
+	ID         int
+	ObjectType HistoryEntityType
+	// Name is the name of a performer, studio, tag or group, or the title of
+	// a gallery.
+	Name           string
+	Disambiguation string
+	// Paths are the file paths of a scene or image, or the zip file paths of
+	// a gallery.
+	Paths      []string
+	FolderPath string
+	DeletedAt  time.Time
+}
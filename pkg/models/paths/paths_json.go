@@ -12,6 +12,10 @@ type JSONPaths struct {
 
 	ScrapedFile string
 
+	// Deleted lists the objects deleted since the time of an incremental
+	// export.
+	Deleted string
+
 	Performers string
 	Scenes     string
 	Images     string
@@ -26,6 +30,7 @@ func newJSONPaths(baseDir string) *JSONPaths {
 	jp := JSONPaths{}
 	jp.Metadata = baseDir
 	jp.ScrapedFile = filepath.Join(baseDir, "scraped.json")
+	jp.Deleted = filepath.Join(baseDir, "deleted.json")
 	jp.Performers = filepath.Join(baseDir, "performers")
 	jp.Scenes = filepath.Join(baseDir, "scenes")
 	jp.Images = filepath.Join(baseDir, "images")
@@ -107,6 +107,76 @@ type CircumcisionCriterionInput struct {
 	Modifier CriterionModifier `json:"modifier"`
 }
 
+// PerformerRelationshipType is the type of a relationship between two
+// performers, from the point of view of the performer it belongs to.
+type PerformerRelationshipType string
+
+const (
+	// The related performer is the same person, such as an alter ego
+	PerformerRelationshipTypeSamePerson PerformerRelationshipType = "SAME_PERSON"
+	PerformerRelationshipTypeSibling    PerformerRelationshipType = "SIBLING"
+	// The performer is a member of the related group performer
+	PerformerRelationshipTypeMemberOf PerformerRelationshipType = "MEMBER_OF"
+	// The related performer is a member of the group performer
+	PerformerRelationshipTypeHasMember PerformerRelationshipType = "HAS_MEMBER"
+)
+
+var AllPerformerRelationshipType = []PerformerRelationshipType{
+	PerformerRelationshipTypeSamePerson,
+	PerformerRelationshipTypeSibling,
+	PerformerRelationshipTypeMemberOf,
+	PerformerRelationshipTypeHasMember,
+}
+
+func (e PerformerRelationshipType) IsValid() bool {
+	switch e {
+	case PerformerRelationshipTypeSamePerson, PerformerRelationshipTypeSibling, PerformerRelationshipTypeMemberOf, PerformerRelationshipTypeHasMember:
+		return true
+	}
+	return false
+}
+
+func (e PerformerRelationshipType) String() string {
+	return string(e)
+}
+
+// Inverse returns the type of the relationship from the point of view of the
+// related performer.
+func (e PerformerRelationshipType) Inverse() PerformerRelationshipType {
+	switch e {
+	case PerformerRelationshipTypeMemberOf:
+		return PerformerRelationshipTypeHasMember
+	case PerformerRelationshipTypeHasMember:
+		return PerformerRelationshipTypeMemberOf
+	}
+	return e
+}
+
+func (e *PerformerRelationshipType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = PerformerRelationshipType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid PerformerRelationshipType", str)
+	}
+	return nil
+}
+
+func (e PerformerRelationshipType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type PerformerRelationshipCriterionInput struct {
+	// Related performer IDs. If empty, matches relationships with any performer.
+	Value []string `json:"value"`
+	// Relationship types. If empty, matches relationships of any type.
+	Type     []PerformerRelationshipType `json:"type"`
+	Modifier CriterionModifier           `json:"modifier"`
+}
+
 type PerformerFilterType struct {
 	OperatorFilter[PerformerFilterType]
 	Name           *StringCriterionInput `json:"name"`
@@ -182,6 +252,10 @@ type PerformerFilterType struct {
 	Performers *MultiCriterionInput `json:"performers"`
 	// Filter by autotag ignore value
 	IgnoreAutoTag *bool `json:"ignore_auto_tag"`
+	// Filter by group performers
+	IsGroup *bool `json:"is_group"`
+	// Filter by related performers
+	RelatedPerformers *PerformerRelationshipCriterionInput `json:"related_performers"`
 	// Filter by birthdate
 	Birthdate *DateCriterionInput `json:"birth_date"`
 	// Filter by death date
@@ -234,6 +308,9 @@ type PerformerCreateInput struct {
 	HairColor     *string   `json:"hair_color"`
 	Weight        *int      `json:"weight"`
 	IgnoreAutoTag *bool     `json:"ignore_auto_tag"`
+	IsGroup       *bool     `json:"is_group"`
+
+	RelatedPerformers []PerformerRelationshipInput `json:"related_performers"`
 }
 
 type PerformerUpdateInput struct {
@@ -271,4 +348,12 @@ type PerformerUpdateInput struct {
 	HairColor     *string   `json:"hair_color"`
 	Weight        *int      `json:"weight"`
 	IgnoreAutoTag *bool     `json:"ignore_auto_tag"`
+	IsGroup       *bool     `json:"is_group"`
+
+	RelatedPerformers []PerformerRelationshipInput `json:"related_performers"`
+}
+
+type PerformerRelationshipInput struct {
+	PerformerID string                    `json:"performer_id"`
+	Type        PerformerRelationshipType `json:"type"`
 }
@@ -9,3 +9,23 @@ type QueryResult struct {
 	IDs   []int
 	Count int
 }
+
+// FacetValue is a value of a facet of query results, with the number of
+// results having the value.
+type FacetValue struct {
+	// Value is the ID of the object, or the value itself for resolutions and
+	// years.
+	Value string `json:"value"`
+	Label string `json:"label"`
+	Count int    `json:"count"`
+}
+
+// QueryFacets are the most common values of the results of a query, most
+// common first.
+type QueryFacets struct {
+	Tags        []*FacetValue `json:"tags"`
+	Performers  []*FacetValue `json:"performers"`
+	Studios     []*FacetValue `json:"studios"`
+	Resolutions []*FacetValue `json:"resolutions"`
+	Years       []*FacetValue `json:"years"`
+}
@@ -2,6 +2,7 @@ package models
 
 import (
 	"context"
+	"time"
 
 	"github.com/stashapp/stash/pkg/sliceutil"
 )
@@ -51,6 +52,12 @@ type StashIDLoader interface {
 	GetStashIDs(ctx context.Context, relatedID int) ([]StashID, error)
 }
 
+// StashIDUpdatedAtSetter provides a method to record when a stash id was last
+// synchronised, without modifying the object that it belongs to.
+type StashIDUpdatedAtSetter interface {
+	UpdateStashIDUpdatedAt(ctx context.Context, relatedID int, endpoint string, updatedAt time.Time) error
+}
+
 type VideoFileLoader interface {
 	GetFiles(ctx context.Context, relatedID int) ([]*VideoFile, error)
 }
@@ -63,6 +70,10 @@ type AliasLoader interface {
 	GetAliases(ctx context.Context, relatedID int) ([]string, error)
 }
 
+type RelatedPerformerLoader interface {
+	GetRelatedPerformers(ctx context.Context, performerID int) ([]PerformerRelationship, error)
+}
+
 type URLLoader interface {
 	GetURLs(ctx context.Context, relatedID int) ([]string, error)
 }
@@ -271,6 +282,69 @@ func (r *RelatedGroupDescriptions) load(fn func() ([]GroupIDDescription, error))
 	return nil
 }
 
+type RelatedPerformerRelationships struct {
+	list []PerformerRelationship
+}
+
+// NewRelatedPerformerRelationships returns a loaded RelatedPerformerRelationships object with the provided relationships.
+// Loaded will return true when called on the returned object if the provided slice is not nil.
+func NewRelatedPerformerRelationships(list []PerformerRelationship) RelatedPerformerRelationships {
+	return RelatedPerformerRelationships{
+		list: list,
+	}
+}
+
+// Loaded returns true if the relationship has been loaded.
+func (r RelatedPerformerRelationships) Loaded() bool {
+	return r.list != nil
+}
+
+func (r RelatedPerformerRelationships) mustLoaded() {
+	if !r.Loaded() {
+		panic("list has not been loaded")
+	}
+}
+
+// List returns the related performers. Panics if the relationship has not been loaded.
+func (r RelatedPerformerRelationships) List() []PerformerRelationship {
+	r.mustLoaded()
+
+	return r.list
+}
+
+// IDs returns the IDs of the related performers. Panics if the relationship has not been loaded.
+func (r RelatedPerformerRelationships) IDs() []int {
+	r.mustLoaded()
+
+	return sliceutil.Map(r.list, func(v PerformerRelationship) int { return v.PerformerID })
+}
+
+// Add adds the provided relationships to the list. Panics if the relationship has not been loaded.
+func (r *RelatedPerformerRelationships) Add(relationships ...PerformerRelationship) {
+	r.mustLoaded()
+
+	r.list = append(r.list, relationships...)
+}
+
+func (r *RelatedPerformerRelationships) load(fn func() ([]PerformerRelationship, error)) error {
+	if r.Loaded() {
+		return nil
+	}
+
+	list, err := fn()
+	if err != nil {
+		return err
+	}
+
+	if list == nil {
+		list = []PerformerRelationship{}
+	}
+
+	r.list = list
+
+	return nil
+}
+
 type RelatedStashIDs struct {
 	list []StashID
 }
@@ -77,12 +77,19 @@ type FileFingerprintWriter interface {
 	DestroyFingerprints(ctx context.Context, fileID FileID, types []string) error
 }
 
+// FileHealthUpdater provides methods to set the health check result of files.
+type FileHealthUpdater interface {
+	// UpdateHealth sets the health of the file. A nil health clears it.
+	UpdateHealth(ctx context.Context, fileID FileID, health *FileHealth) error
+}
+
 // FileWriter provides all methods to modify files.
 type FileWriter interface {
 	FileCreator
 	FileUpdater
 	FileDestroyer
 	FileFingerprintWriter
+	FileHealthUpdater
 
 	UpdateCaptions(ctx context.Context, fileID FileID, captions []*VideoCaption) error
 }
@@ -27,6 +27,7 @@ type GalleryFinder interface {
 type GalleryQueryer interface {
 	Query(ctx context.Context, galleryFilter *GalleryFilterType, findFilter *FindFilterType) ([]*Gallery, int, error)
 	QueryCount(ctx context.Context, galleryFilter *GalleryFilterType, findFilter *FindFilterType) (int, error)
+	QueryFacets(ctx context.Context, galleryFilter *GalleryFilterType, findFilter *FindFilterType, limit int) (*QueryFacets, error)
 }
 
 // GalleryCounter provides methods to count galleries.
@@ -79,6 +79,7 @@ type PerformerReader interface {
 	StashIDLoader
 	TagIDLoader
 	URLLoader
+	RelatedPerformerLoader
 
 	All(ctx context.Context) ([]*Performer, error)
 	GetImage(ctx context.Context, performerID int) ([]byte, error)
@@ -90,6 +91,8 @@ type PerformerWriter interface {
 	PerformerCreator
 	PerformerUpdater
 	PerformerDestroyer
+
+	StashIDUpdatedAtSetter
 }
 
 // PerformerReaderWriter provides all performer methods.
@@ -31,6 +31,7 @@ type SceneFinder interface {
 type SceneQueryer interface {
 	Query(ctx context.Context, options SceneQueryOptions) (*SceneQueryResult, error)
 	QueryCount(ctx context.Context, sceneFilter *SceneFilterType, findFilter *FindFilterType) (int, error)
+	QueryFacets(ctx context.Context, sceneFilter *SceneFilterType, findFilter *FindFilterType, limit int) (*QueryFacets, error)
 }
 
 // SceneCounter provides methods to count scenes.
@@ -105,6 +106,7 @@ type SceneReader interface {
 	Size(ctx context.Context) (float64, error)
 	Duration(ctx context.Context) (float64, error)
 	PlayDuration(ctx context.Context) (float64, error)
+	StatsTimeline(ctx context.Context, options StatsTimelineOptions) ([]*StatsTimelineSeries, error)
 	GetCover(ctx context.Context, sceneID int) ([]byte, error)
 	HasCover(ctx context.Context, sceneID int) (bool, error)
 }
@@ -130,6 +132,7 @@ type SceneWriter interface {
 	AddFileID(ctx context.Context, id int, fileID FileID) error
 	AddGalleryIDs(ctx context.Context, sceneID int, galleryIDs []int) error
 	AssignFiles(ctx context.Context, sceneID int, fileID []FileID) error
+	StashIDUpdatedAtSetter
 
 	OHistoryWriter
 	ViewHistoryWriter
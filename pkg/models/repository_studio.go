@@ -88,6 +88,8 @@ type StudioWriter interface {
 	StudioCreator
 	StudioUpdater
 	StudioDestroyer
+
+	StashIDUpdatedAtSetter
 }
 
 // StudioReaderWriter provides all studio methods.
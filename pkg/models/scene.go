@@ -39,6 +39,8 @@ type SceneFilterType struct {
 	Resolution *ResolutionCriterionInput `json:"resolution"`
 	// Filter by orientation
 	Orientation *OrientationCriterionInput `json:"orientation"`
+	// Filter by file health check status
+	FileHealth *FileHealthCriterionInput `json:"file_health"`
 	// Filter by framerate
 	Framerate *IntCriterionInput `json:"framerate"`
 	// Filter by bitrate
@@ -1,8 +1,34 @@
 package models
 
+import (
+	"encoding/json"
+	"time"
+)
+
 type StashID struct {
 	StashID  string `db:"stash_id" json:"stash_id"`
 	Endpoint string `db:"endpoint" json:"endpoint"`
+	// UpdatedAt is the time the entity was last synchronised with the stash-box endpoint
+	UpdatedAt time.Time `db:"updated_at" json:"updated_at"`
+}
+
+// MarshalJSON omits updated_at for stash ids that have never been
+// synchronised, so that exports don't contain a zero time.
+func (s StashID) MarshalJSON() ([]byte, error) {
+	v := struct {
+		StashID   string     `json:"stash_id"`
+		Endpoint  string     `json:"endpoint"`
+		UpdatedAt *time.Time `json:"updated_at,omitempty"`
+	}{
+		StashID:  s.StashID,
+		Endpoint: s.Endpoint,
+	}
+
+	if !s.UpdatedAt.IsZero() {
+		v.UpdatedAt = &s.UpdatedAt
+	}
+
+	return json.Marshal(v)
 }
 
 type UpdateStashIDs struct {
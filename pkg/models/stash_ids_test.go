@@ -0,0 +1,38 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStashID_MarshalJSON(t *testing.T) {
+	updatedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		s    StashID
+		want string
+	}{
+		{"never synchronised", StashID{StashID: "id", Endpoint: "endpoint"}, `{"stash_id":"id","endpoint":"endpoint"}`},
+		{"synchronised", StashID{StashID: "id", Endpoint: "endpoint", UpdatedAt: updatedAt}, `{"stash_id":"id","endpoint":"endpoint","updated_at":"2024-01-02T03:04:05Z"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.s)
+			if err != nil {
+				t.Fatalf("MarshalJSON() error = %v", err)
+			}
+			assert.JSONEq(t, tt.want, string(got))
+
+			var unmarshalled StashID
+			if err := json.Unmarshal(got, &unmarshalled); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			assert.Equal(t, tt.s, unmarshalled)
+		})
+	}
+}
@@ -0,0 +1,215 @@
+package models
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// StatsMetric is a scene activity measured over time.
+type StatsMetric string
+
+const (
+	// Number of scene plays
+	StatsMetricPlays StatsMetric = "PLAYS"
+	// Number of scene o-counts
+	StatsMetricOCount StatsMetric = "O_COUNT"
+	// Number of scenes added
+	StatsMetricScenesAdded StatsMetric = "SCENES_ADDED"
+	// Total size in bytes of the primary files of scenes added
+	StatsMetricSizeAdded StatsMetric = "SIZE_ADDED"
+	// Number of scenes marked as organized
+	StatsMetricScenesOrganized StatsMetric = "SCENES_ORGANIZED"
+	// Play duration in seconds
+	StatsMetricPlayDuration StatsMetric = "PLAY_DURATION"
+)
+
+var AllStatsMetric = []StatsMetric{
+	StatsMetricPlays,
+	StatsMetricOCount,
+	StatsMetricScenesAdded,
+	StatsMetricSizeAdded,
+	StatsMetricScenesOrganized,
+	StatsMetricPlayDuration,
+}
+
+func (e StatsMetric) IsValid() bool {
+	switch e {
+	case StatsMetricPlays, StatsMetricOCount, StatsMetricScenesAdded, StatsMetricSizeAdded, StatsMetricScenesOrganized, StatsMetricPlayDuration:
+		return true
+	}
+	return false
+}
+
+func (e StatsMetric) String() string {
+	return string(e)
+}
+
+func (e *StatsMetric) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = StatsMetric(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid StatsMetric", str)
+	}
+	return nil
+}
+
+func (e StatsMetric) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// StatsInterval is the size of the buckets of a statistics timeline.
+type StatsInterval string
+
+const (
+	StatsIntervalDay   StatsInterval = "DAY"
+	StatsIntervalWeek  StatsInterval = "WEEK"
+	StatsIntervalMonth StatsInterval = "MONTH"
+)
+
+var AllStatsInterval = []StatsInterval{
+	StatsIntervalDay,
+	StatsIntervalWeek,
+	StatsIntervalMonth,
+}
+
+func (e StatsInterval) IsValid() bool {
+	switch e {
+	case StatsIntervalDay, StatsIntervalWeek, StatsIntervalMonth:
+		return true
+	}
+	return false
+}
+
+func (e StatsInterval) String() string {
+	return string(e)
+}
+
+func (e *StatsInterval) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = StatsInterval(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid StatsInterval", str)
+	}
+	return nil
+}
+
+func (e StatsInterval) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// Start returns the start of the bucket containing t, in UTC. Weeks start on
+// Monday.
+func (e StatsInterval) Start(t time.Time) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+
+	switch e {
+	case StatsIntervalWeek:
+		// Sunday is 0
+		offset := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -offset)
+	case StatsIntervalMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	return day
+}
+
+// Next returns the start of the bucket following the bucket starting at t.
+func (e StatsInterval) Next(t time.Time) time.Time {
+	switch e {
+	case StatsIntervalWeek:
+		return t.AddDate(0, 0, 7)
+	case StatsIntervalMonth:
+		return t.AddDate(0, 1, 0)
+	}
+
+	return t.AddDate(0, 0, 1)
+}
+
+// StatsBreakdown is the type of object by which a statistics timeline is
+// broken down.
+type StatsBreakdown string
+
+const (
+	StatsBreakdownStudio    StatsBreakdown = "STUDIO"
+	StatsBreakdownPerformer StatsBreakdown = "PERFORMER"
+	StatsBreakdownTag       StatsBreakdown = "TAG"
+)
+
+var AllStatsBreakdown = []StatsBreakdown{
+	StatsBreakdownStudio,
+	StatsBreakdownPerformer,
+	StatsBreakdownTag,
+}
+
+func (e StatsBreakdown) IsValid() bool {
+	switch e {
+	case StatsBreakdownStudio, StatsBreakdownPerformer, StatsBreakdownTag:
+		return true
+	}
+	return false
+}
+
+func (e StatsBreakdown) String() string {
+	return string(e)
+}
+
+func (e *StatsBreakdown) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = StatsBreakdown(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid StatsBreakdown", str)
+	}
+	return nil
+}
+
+func (e StatsBreakdown) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// MaxStatsTimelineBuckets is the maximum number of buckets, and so points per
+// series, of a statistics timeline.
+const MaxStatsTimelineBuckets = 5000
+
+type StatsTimelineOptions struct {
+	Metric   StatsMetric
+	Interval StatsInterval
+	// From and To limit the timeline to the events in [From, To), if set.
+	From *time.Time
+	To   *time.Time
+	// Breakdown splits the timeline into a series per object, if set.
+	Breakdown *StatsBreakdown
+	// Limit is the maximum number of series returned when broken down. The
+	// series with the highest totals are returned.
+	Limit int
+}
+
+type StatsTimelinePoint struct {
+	// Date is the start date of the bucket, formatted as YYYY-MM-DD.
+	Date  string  `json:"date"`
+	Value float64 `json:"value"`
+}
+
+type StatsTimelineSeries struct {
+	// ID and Name are the object of the breakdown, and nil if the timeline
+	// is not broken down.
+	ID     *int                  `json:"id"`
+	Name   *string               `json:"name"`
+	Total  float64               `json:"total"`
+	Points []*StatsTimelinePoint `json:"points"`
+}
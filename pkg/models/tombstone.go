@@ -0,0 +1,21 @@
+package models
+
+import (
+	"context"
+	"time"
+)
+
+type TombstoneReader interface {
+	// FindSince returns the tombstones of the objects deleted at or after t,
+	// oldest first.
+	FindSince(ctx context.Context, t time.Time) ([]*Tombstone, error)
+}
+
+type TombstoneWriter interface {
+	Create(ctx context.Context, obj *Tombstone) error
+}
+
+type TombstoneReaderWriter interface {
+	TombstoneReader
+	TombstoneWriter
+}
@@ -198,3 +198,68 @@ func (u *UpdateGroupDescriptions) applyRemove(existing []GroupIDDescription) []G
 
 	return ret
 }
+
+type UpdatePerformerRelationships struct {
+	Relationships []PerformerRelationship `json:"relationships"`
+	Mode          RelationshipUpdateMode  `json:"mode"`
+}
+
+// Apply applies the update to a list of existing relationships, returning the result.
+// A performer has at most one relationship with each other performer.
+func (u *UpdatePerformerRelationships) Apply(existing []PerformerRelationship) []PerformerRelationship {
+	if u == nil {
+		return existing
+	}
+
+	switch u.Mode {
+	case RelationshipUpdateModeAdd:
+		return u.applyAdd(existing)
+	case RelationshipUpdateModeRemove:
+		return u.applyRemove(existing)
+	case RelationshipUpdateModeSet:
+		return u.Relationships
+	}
+
+	return nil
+}
+
+func (u *UpdatePerformerRelationships) applyAdd(existing []PerformerRelationship) []PerformerRelationship {
+	// overwrite any existing values with the same performer id
+	ret := append([]PerformerRelationship{}, existing...)
+	for _, v := range u.Relationships {
+		found := false
+		for i, vv := range ret {
+			if vv.PerformerID == v.PerformerID {
+				ret[i] = v
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			ret = append(ret, v)
+		}
+	}
+
+	return ret
+}
+
+func (u *UpdatePerformerRelationships) applyRemove(existing []PerformerRelationship) []PerformerRelationship {
+	// remove any existing values with the same performer id
+	var ret []PerformerRelationship
+	for _, v := range existing {
+		found := false
+		for _, vv := range u.Relationships {
+			if vv.PerformerID == v.PerformerID {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			ret = append(ret, v)
+		}
+	}
+
+	return ret
+}
@@ -0,0 +1,161 @@
+// Package nfo reads and writes Kodi-style NFO metadata files, as used by
+// Kodi, Jellyfin, Emby and other media managers.
+package nfo
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Extension is the file extension of NFO files.
+const Extension = ".nfo"
+
+// movieFilename is the name of the NFO file used for a folder containing a
+// single movie.
+const movieFilename = "movie.nfo"
+
+// Actor is an actor in a movie NFO.
+type Actor struct {
+	Name  string `xml:"name"`
+	Role  string `xml:"role,omitempty"`
+	Thumb string `xml:"thumb,omitempty"`
+}
+
+// Set is a movie set (collection) in a movie NFO.
+type Set struct {
+	Name string `xml:"name"`
+}
+
+// UniqueID is an identifier of the movie in another database.
+type UniqueID struct {
+	Type    string `xml:"type,attr,omitempty"`
+	Default bool   `xml:"default,attr,omitempty"`
+	Value   string `xml:",chardata"`
+}
+
+// Movie is the root element of a movie NFO file. Only the elements used by
+// stash are included.
+type Movie struct {
+	XMLName       xml.Name   `xml:"movie"`
+	Title         string     `xml:"title,omitempty"`
+	OriginalTitle string     `xml:"originaltitle,omitempty"`
+	UserRating    int        `xml:"userrating,omitempty"`
+	Outline       string     `xml:"outline,omitempty"`
+	Plot          string     `xml:"plot,omitempty"`
+	Runtime       int        `xml:"runtime,omitempty"`
+	UniqueIDs     []UniqueID `xml:"uniqueid,omitempty"`
+	Genres        []string   `xml:"genre,omitempty"`
+	Tags          []string   `xml:"tag,omitempty"`
+	Sets          []Set      `xml:"set,omitempty"`
+	Directors     []string   `xml:"director,omitempty"`
+	Premiered     string     `xml:"premiered,omitempty"`
+	ReleaseDate   string     `xml:"releasedate,omitempty"`
+	Aired         string     `xml:"aired,omitempty"`
+	Year          string     `xml:"year,omitempty"`
+	Studios       []string   `xml:"studio,omitempty"`
+	Actors        []Actor    `xml:"actor,omitempty"`
+}
+
+// GetDate returns the first populated date element.
+func (m *Movie) GetDate() string {
+	for _, d := range []string{m.Premiered, m.ReleaseDate, m.Aired} {
+		if d = strings.TrimSpace(d); d != "" {
+			return d
+		}
+	}
+
+	return ""
+}
+
+// GetDetails returns the plot, or the outline if the plot is empty.
+func (m *Movie) GetDetails() string {
+	if m.Plot != "" {
+		return m.Plot
+	}
+
+	return m.Outline
+}
+
+// ReadMovie parses a movie NFO.
+func ReadMovie(r io.Reader) (*Movie, error) {
+	var ret Movie
+	if err := xml.NewDecoder(r).Decode(&ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// ReadMovieFile parses the movie NFO file at the provided path.
+func ReadMovieFile(path string) (*Movie, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	ret, err := ReadMovie(f)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	return ret, nil
+}
+
+// WriteMovie writes the movie NFO, including the XML header.
+func WriteMovie(w io.Writer, m *Movie) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(m); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// WriteMovieFile writes the movie NFO to the file at the provided path.
+func WriteMovieFile(path string, m *Movie) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := WriteMovie(f, m); err != nil {
+		f.Close()
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+
+	return f.Close()
+}
+
+// SidecarPath returns the path of the NFO file for the provided video path.
+func SidecarPath(videoPath string) string {
+	return strings.TrimSuffix(videoPath, filepath.Ext(videoPath)) + Extension
+}
+
+// FindMovieFile returns the path of the NFO file for the provided video
+// path. The NFO with the same name as the video is preferred over movie.nfo
+// in the same folder. Returns an empty string if neither exist.
+func FindMovieFile(videoPath string) string {
+	candidates := []string{
+		SidecarPath(videoPath),
+		filepath.Join(filepath.Dir(videoPath), movieFilename),
+	}
+
+	for _, c := range candidates {
+		if info, err := os.Stat(c); err == nil && !info.IsDir() {
+			return c
+		}
+	}
+
+	return ""
+}
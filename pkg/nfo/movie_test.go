@@ -0,0 +1,112 @@
+package nfo
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const kodiMovie = `<?xml version="1.0" encoding="UTF-8" standalone="yes" ?>
+<movie>
+    <title>Movie Title</title>
+    <originaltitle>Original Title</originaltitle>
+    <userrating>8</userrating>
+    <outline>Outline</outline>
+    <plot>Plot</plot>
+    <runtime>90</runtime>
+    <uniqueid type="imdb" default="true">tt0000000</uniqueid>
+    <genre>Genre 1</genre>
+    <genre>Genre 2</genre>
+    <tag>Tag</tag>
+    <set>
+        <name>Set Name</name>
+        <overview></overview>
+    </set>
+    <director>Director</director>
+    <premiered>2020-01-02</premiered>
+    <year>2020</year>
+    <studio>Studio</studio>
+    <actor>
+        <name>Actor 1</name>
+        <role>Role</role>
+        <order>0</order>
+    </actor>
+    <actor>
+        <name>Actor 2</name>
+    </actor>
+    <fileinfo>
+        <streamdetails />
+    </fileinfo>
+</movie>
+`
+
+func TestReadMovie(t *testing.T) {
+	m, err := ReadMovie(strings.NewReader(kodiMovie))
+	if err != nil {
+		t.Fatalf("ReadMovie() error = %v", err)
+	}
+
+	assert.Equal(t, "Movie Title", m.Title)
+	assert.Equal(t, 8, m.UserRating)
+	assert.Equal(t, "Plot", m.GetDetails())
+	assert.Equal(t, "2020-01-02", m.GetDate())
+	assert.Equal(t, []string{"Genre 1", "Genre 2"}, m.Genres)
+	assert.Equal(t, []string{"Tag"}, m.Tags)
+	assert.Equal(t, []Set{{Name: "Set Name"}}, m.Sets)
+	assert.Equal(t, []string{"Director"}, m.Directors)
+	assert.Equal(t, []string{"Studio"}, m.Studios)
+	assert.Equal(t, []Actor{{Name: "Actor 1", Role: "Role"}, {Name: "Actor 2"}}, m.Actors)
+	assert.Equal(t, []UniqueID{{Type: "imdb", Default: true, Value: "tt0000000"}}, m.UniqueIDs)
+}
+
+func TestWriteMovie(t *testing.T) {
+	m := &Movie{
+		Title:     "Title",
+		Plot:      "Plot & details",
+		Premiered: "2020-01-02",
+		Studios:   []string{"Studio"},
+		Actors:    []Actor{{Name: "Actor"}},
+		Tags:      []string{"Tag 1", "Tag 2"},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteMovie(&buf, m); err != nil {
+		t.Fatalf("WriteMovie() error = %v", err)
+	}
+
+	assert.True(t, strings.HasPrefix(buf.String(), "<?xml"))
+
+	got, err := ReadMovie(&buf)
+	if err != nil {
+		t.Fatalf("ReadMovie() error = %v", err)
+	}
+
+	got.XMLName = m.XMLName
+	assert.Equal(t, m, got)
+}
+
+func TestMovie_GetDate(t *testing.T) {
+	tests := []struct {
+		name  string
+		movie Movie
+		want  string
+	}{
+		{"premiered", Movie{Premiered: "2020-01-02", Aired: "2021-01-02"}, "2020-01-02"},
+		{"release date", Movie{ReleaseDate: "2020-01-02", Aired: "2021-01-02"}, "2020-01-02"},
+		{"aired", Movie{Aired: "2021-01-02", Year: "2020"}, "2021-01-02"},
+		{"year only", Movie{Year: "2020"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.movie.GetDate())
+		})
+	}
+}
+
+func TestSidecarPath(t *testing.T) {
+	assert.Equal(t, "dir/video.nfo", SidecarPath("dir/video.mp4"))
+	assert.Equal(t, "dir/video.name.nfo", SidecarPath("dir/video.name.mkv"))
+}
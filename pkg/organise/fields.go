@@ -0,0 +1,210 @@
+package organise
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// resolutionNames is the label of each resolution. The deprecated VR_HD range
+// overlaps FOUR_K, and HUGE files are labelled 8k, so neither has a label.
+var resolutionNames = map[models.ResolutionEnum]string{
+	models.ResolutionEnumVeryLow:    "144p",
+	models.ResolutionEnumLow:        "240p",
+	models.ResolutionEnumR360p:      "360p",
+	models.ResolutionEnumStandard:   "480p",
+	models.ResolutionEnumWebHd:      "540p",
+	models.ResolutionEnumStandardHd: "720p",
+	models.ResolutionEnumFullHd:     "1080p",
+	models.ResolutionEnumQuadHd:     "1440p",
+	models.ResolutionEnumFourK:      "4k",
+	models.ResolutionEnumFiveK:      "5k",
+	models.ResolutionEnumSixK:       "6k",
+	models.ResolutionEnumSevenK:     "7k",
+	models.ResolutionEnumEightK:     "8k",
+}
+
+type resolutionLabel struct {
+	min   int
+	label string
+}
+
+// resolutionLabels maps the minimum dimension of a file to its resolution
+// label, in descending order. The minimums are those of the resolution enum,
+// so that labels agree with the resolution filter.
+var resolutionLabels = func() []resolutionLabel {
+	var ret []resolutionLabel
+	for i := len(models.AllResolutionEnum) - 1; i >= 0; i-- {
+		r := models.AllResolutionEnum[i]
+		if label, ok := resolutionNames[r]; ok {
+			ret = append(ret, resolutionLabel{r.GetMinResolution(), label})
+		}
+	}
+	return ret
+}()
+
+// ResolutionLabel returns a label such as "1080p" or "4k" for the file.
+func ResolutionLabel(f models.VisualFile) string {
+	res := models.GetMinResolution(f)
+	if res <= 0 {
+		return ""
+	}
+
+	for _, l := range resolutionLabels {
+		if res >= l.min {
+			return l.label
+		}
+	}
+
+	return strconv.Itoa(res) + "p"
+}
+
+// FieldReader reads the related objects used to populate template fields.
+type FieldReader struct {
+	Studio    models.StudioGetter
+	Performer models.PerformerFinder
+}
+
+// SceneFields returns the template fields for the scene and one of its files.
+func (r *FieldReader) SceneFields(ctx context.Context, s *models.Scene, f *models.VideoFile) (Fields, error) {
+	ret := fileFields(f)
+	setVisualFields(ret, f)
+	setDateFields(ret, s.Date)
+	ret[FieldID] = strconv.Itoa(s.ID)
+	ret[FieldTitle] = s.Title
+	ret[FieldCode] = s.Code
+	ret[FieldDirector] = s.Director
+
+	if err := r.setStudioFields(ctx, ret, s.StudioID); err != nil {
+		return nil, err
+	}
+
+	performers, err := r.Performer.FindBySceneID(ctx, s.ID)
+	if err != nil {
+		return nil, fmt.Errorf("finding scene performers: %w", err)
+	}
+	setPerformerFields(ret, performers)
+
+	return ret, nil
+}
+
+// ImageFields returns the template fields for the image and one of its files.
+func (r *FieldReader) ImageFields(ctx context.Context, i *models.Image, f models.File) (Fields, error) {
+	ret := fileFields(f)
+	if vf, ok := f.(models.VisualFile); ok {
+		setVisualFields(ret, vf)
+	}
+	setDateFields(ret, i.Date)
+	ret[FieldID] = strconv.Itoa(i.ID)
+	ret[FieldTitle] = i.Title
+	ret[FieldCode] = i.Code
+	ret[FieldPhotographer] = i.Photographer
+
+	if err := r.setStudioFields(ctx, ret, i.StudioID); err != nil {
+		return nil, err
+	}
+
+	performers, err := r.Performer.FindByImageID(ctx, i.ID)
+	if err != nil {
+		return nil, fmt.Errorf("finding image performers: %w", err)
+	}
+	setPerformerFields(ret, performers)
+
+	return ret, nil
+}
+
+// GalleryFields returns the template fields for the gallery and its zip file.
+func (r *FieldReader) GalleryFields(ctx context.Context, g *models.Gallery, f models.File) (Fields, error) {
+	ret := fileFields(f)
+	setDateFields(ret, g.Date)
+	ret[FieldID] = strconv.Itoa(g.ID)
+	ret[FieldTitle] = g.Title
+	ret[FieldCode] = g.Code
+	ret[FieldPhotographer] = g.Photographer
+
+	if err := r.setStudioFields(ctx, ret, g.StudioID); err != nil {
+		return nil, err
+	}
+
+	performers, err := r.Performer.FindByGalleryID(ctx, g.ID)
+	if err != nil {
+		return nil, fmt.Errorf("finding gallery performers: %w", err)
+	}
+	setPerformerFields(ret, performers)
+
+	return ret, nil
+}
+
+func fileFields(f models.File) Fields {
+	basename := f.Base().Basename
+	ext := filepath.Ext(basename)
+
+	return Fields{
+		FieldBasename: strings.TrimSuffix(basename, ext),
+		FieldExt:      strings.TrimPrefix(ext, "."),
+	}
+}
+
+func setVisualFields(fields Fields, f models.VisualFile) {
+	fields[FieldResolution] = ResolutionLabel(f)
+	if f.GetWidth() > 0 && f.GetHeight() > 0 {
+		fields[FieldWidth] = strconv.Itoa(f.GetWidth())
+		fields[FieldHeight] = strconv.Itoa(f.GetHeight())
+	}
+}
+
+func setDateFields(fields Fields, date *models.Date) {
+	if date == nil {
+		return
+	}
+
+	fields[FieldDate] = date.String()
+	fields[FieldYear] = date.Format("2006")
+	fields[FieldMonth] = date.Format("01")
+	fields[FieldDay] = date.Format("02")
+}
+
+func (r *FieldReader) setStudioFields(ctx context.Context, fields Fields, studioID *int) error {
+	if studioID == nil {
+		return nil
+	}
+
+	studio, err := r.Studio.Find(ctx, *studioID)
+	if err != nil {
+		return fmt.Errorf("finding studio: %w", err)
+	}
+
+	if studio == nil {
+		return nil
+	}
+
+	fields[FieldStudio] = studio.Name
+
+	if studio.ParentID != nil {
+		parent, err := r.Studio.Find(ctx, *studio.ParentID)
+		if err != nil {
+			return fmt.Errorf("finding parent studio: %w", err)
+		}
+
+		if parent != nil {
+			fields[FieldParentStudio] = parent.Name
+		}
+	}
+
+	return nil
+}
+
+func setPerformerFields(fields Fields, performers []*models.Performer) {
+	names := make([]string, len(performers))
+	for i, p := range performers {
+		names[i] = p.Name
+	}
+
+	sort.Strings(names)
+	fields[FieldPerformers] = strings.Join(names, ", ")
+}
@@ -0,0 +1,36 @@
+package organise
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func TestResolutionLabel(t *testing.T) {
+	tests := []struct {
+		name   string
+		width  int
+		height int
+		want   string
+	}{
+		{"unknown", 0, 0, ""},
+		{"below lowest", 100, 100, "100p"},
+		{"very low", 256, 144, "144p"},
+		{"1080p", 1920, 1080, "1080p"},
+		{"portrait 1080p", 1080, 1920, "1080p"},
+		{"just below 1080p", 1918, 1079, "720p"},
+		{"4k", 3840, 2160, "4k"},
+		{"5k", 5120, 2880, "5k"},
+		{"8k", 7680, 4320, "8k"},
+		{"huge", 12288, 6480, "8k"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &models.VideoFile{Width: tt.width, Height: tt.height}
+			assert.Equal(t, tt.want, ResolutionLabel(f))
+		})
+	}
+}
@@ -0,0 +1,210 @@
+// Package organise renders object metadata into file paths.
+package organise
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxSegmentLength is the maximum length in bytes of a rendered path
+// segment, leaving room for the extension within common filesystem limits.
+const maxSegmentLength = 200
+
+var (
+	fieldRE         = regexp.MustCompile(`\{([a-z_]+)\}`)
+	emptyBracketsRE = regexp.MustCompile(`\(\s*\)|\[\s*\]`)
+	repeatedDashRE  = regexp.MustCompile(`(?:\s*-\s*){2,}`)
+	multiWSRE       = regexp.MustCompile(`\s{2,}`)
+)
+
+// invalidChars are removed from field values, since they are not valid in
+// paths on all platforms.
+const invalidChars = `<>:"|?*`
+
+// trimChars are trimmed from the ends of each path segment, so that
+// separators around empty fields are removed.
+const trimChars = " -_."
+
+// Fields are the values of template fields, keyed by field name.
+type Fields map[string]string
+
+const (
+	FieldID           = "id"
+	FieldTitle        = "title"
+	FieldCode         = "code"
+	FieldDate         = "date"
+	FieldYear         = "year"
+	FieldMonth        = "month"
+	FieldDay          = "day"
+	FieldStudio       = "studio"
+	FieldParentStudio = "parent_studio"
+	FieldPerformers   = "performers"
+	FieldDirector     = "director"
+	FieldPhotographer = "photographer"
+	FieldResolution   = "resolution"
+	FieldWidth        = "width"
+	FieldHeight       = "height"
+	FieldBasename     = "basename"
+	FieldExt          = "ext"
+)
+
+// ValidFields are the fields that may be used in a template.
+var ValidFields = []string{
+	FieldID,
+	FieldTitle,
+	FieldCode,
+	FieldDate,
+	FieldYear,
+	FieldMonth,
+	FieldDay,
+	FieldStudio,
+	FieldParentStudio,
+	FieldPerformers,
+	FieldDirector,
+	FieldPhotographer,
+	FieldResolution,
+	FieldWidth,
+	FieldHeight,
+	FieldBasename,
+	FieldExt,
+}
+
+func isValidField(field string) bool {
+	for _, f := range ValidFields {
+		if f == field {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Template is a path template, such as "{studio}/{date} - {title}.{ext}".
+// Paths are rendered relative to the library path containing the file.
+type Template struct {
+	segments []string
+}
+
+// ParseTemplate parses and validates a path template. Both forward and
+// back slashes are treated as path separators.
+func ParseTemplate(pattern string) (*Template, error) {
+	pattern = strings.ReplaceAll(pattern, `\`, "/")
+	if strings.TrimSpace(pattern) == "" {
+		return nil, errors.New("template is empty")
+	}
+
+	if strings.HasPrefix(pattern, "/") || filepath.VolumeName(pattern) != "" {
+		return nil, fmt.Errorf("template %q must be relative to the library path", pattern)
+	}
+
+	for _, m := range fieldRE.FindAllStringSubmatch(pattern, -1) {
+		if !isValidField(m[1]) {
+			return nil, fmt.Errorf("invalid template field %q", m[0])
+		}
+	}
+
+	var segments []string
+	for _, s := range strings.Split(pattern, "/") {
+		if s == ".." {
+			return nil, fmt.Errorf("template %q must not contain parent directory references", pattern)
+		}
+
+		if s == "" || s == "." {
+			continue
+		}
+
+		segments = append(segments, s)
+	}
+
+	return &Template{
+		segments: segments,
+	}, nil
+}
+
+// Render returns the relative path for the provided field values. Segments
+// that render to nothing are omitted. The extension of the file is appended
+// to the basename if it is not already present, and the original basename
+// is used if the basename renders to nothing.
+func (t *Template) Render(fields Fields) string {
+	ext := fields[FieldExt]
+	if ext != "" {
+		ext = "." + ext
+	}
+
+	var segments []string
+	for i, s := range t.segments {
+		rendered := renderSegment(s, fields)
+
+		if i == len(t.segments)-1 {
+			rendered = renderBasename(rendered, fields[FieldBasename], ext)
+		} else {
+			rendered = cleanSegment(rendered)
+		}
+
+		if rendered != "" {
+			segments = append(segments, rendered)
+		}
+	}
+
+	return filepath.Join(segments...)
+}
+
+func renderSegment(s string, fields Fields) string {
+	return fieldRE.ReplaceAllStringFunc(s, func(m string) string {
+		return sanitiseValue(fields[m[1:len(m)-1]])
+	})
+}
+
+func renderBasename(rendered string, basename string, ext string) string {
+	name := rendered
+	if ext != "" && strings.HasSuffix(strings.ToLower(name), strings.ToLower(ext)) {
+		name = name[:len(name)-len(ext)]
+	}
+
+	name = cleanSegment(name)
+	if name == "" {
+		name = basename
+	}
+
+	return name + ext
+}
+
+func sanitiseValue(v string) string {
+	v = strings.Map(func(r rune) rune {
+		switch {
+		case r == '/' || r == '\\':
+			return '-'
+		case r < 0x20 || strings.ContainsRune(invalidChars, r):
+			return -1
+		}
+		return r
+	}, v)
+
+	return strings.TrimSpace(v)
+}
+
+func cleanSegment(s string) string {
+	s = emptyBracketsRE.ReplaceAllString(s, "")
+	s = repeatedDashRE.ReplaceAllString(s, " - ")
+	s = multiWSRE.ReplaceAllString(s, " ")
+	s = strings.Trim(s, trimChars)
+
+	return truncate(s, maxSegmentLength)
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+
+	s = s[:n]
+	for !utf8.ValidString(s) {
+		s = s[:len(s)-1]
+	}
+
+	return strings.TrimRight(s, trimChars)
+}
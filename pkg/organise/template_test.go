@@ -0,0 +1,116 @@
+package organise
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTemplate(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		wantErr bool
+	}{
+		{"valid", "{studio}/{date} - {title}.{ext}", false},
+		{"back slashes", `{studio}\{title}.{ext}`, false},
+		{"empty", " ", true},
+		{"absolute", "/{title}.{ext}", true},
+		{"parent directory", "../{title}.{ext}", true},
+		{"invalid field", "{studio}/{invalid}.{ext}", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseTemplate(tt.pattern)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseTemplate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTemplate_Render(t *testing.T) {
+	fields := Fields{
+		FieldTitle:      "A Title: Part 1/2",
+		FieldDate:       "2024-01-02",
+		FieldStudio:     "Studio",
+		FieldResolution: "1080p",
+		FieldBasename:   "original",
+		FieldExt:        "mp4",
+	}
+
+	tests := []struct {
+		name    string
+		pattern string
+		fields  Fields
+		want    string
+	}{
+		{
+			"all fields",
+			"{studio}/{date} - {title} [{resolution}].{ext}",
+			fields,
+			filepath.Join("Studio", "2024-01-02 - A Title Part 1-2 [1080p].mp4"),
+		},
+		{
+			"missing extension",
+			"{studio}/{title}",
+			fields,
+			filepath.Join("Studio", "A Title Part 1-2.mp4"),
+		},
+		{
+			"empty fields",
+			"{parent_studio}/{studio}/{code} - {title}.{ext}",
+			Fields{
+				FieldTitle:    "Title",
+				FieldBasename: "original",
+				FieldExt:      "mp4",
+			},
+			"Title.mp4",
+		},
+		{
+			"empty brackets",
+			"{title} [{resolution}] ({code}).{ext}",
+			Fields{
+				FieldTitle:    "Title",
+				FieldBasename: "original",
+				FieldExt:      "mp4",
+			},
+			"Title.mp4",
+		},
+		{
+			"empty basename",
+			"{studio}/{title}.{ext}",
+			Fields{
+				FieldStudio:   "Studio",
+				FieldBasename: "original",
+				FieldExt:      "mp4",
+			},
+			filepath.Join("Studio", "original.mp4"),
+		},
+		{
+			"duplicate separators",
+			"{studio} - {code} - {title}.{ext}",
+			Fields{
+				FieldStudio:   "Studio",
+				FieldTitle:    "Title",
+				FieldBasename: "original",
+				FieldExt:      "mp4",
+			},
+			"Studio - Title.mp4",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := ParseTemplate(tt.pattern)
+			if err != nil {
+				t.Errorf("ParseTemplate() error = %v", err)
+				return
+			}
+
+			assert.Equal(t, tt.want, tmpl.Render(tt.fields))
+		})
+	}
+}
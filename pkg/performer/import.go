@@ -24,6 +24,7 @@ type Importer struct {
 	Input               jsonschema.Performer
 	MissingRefBehaviour models.ImportMissingRefEnum
 
+	// ID is the ID of the created or updated performer, set by PostImport.
 	ID        int
 	performer models.Performer
 	imageData []byte
@@ -116,6 +117,8 @@ func createTags(ctx context.Context, tagWriter models.TagFinderCreator, names []
 }
 
 func (i *Importer) PostImport(ctx context.Context, id int) error {
+	i.ID = id
+
 	if len(i.imageData) > 0 {
 		if err := i.ReaderWriter.UpdateImage(ctx, id, i.imageData); err != nil {
 			return fmt.Errorf("error setting performer image: %v", err)
@@ -130,17 +133,21 @@ func (i *Importer) Name() string {
 }
 
 func (i *Importer) FindExistingID(ctx context.Context) (*int, error) {
+	return findByNameDisambiguation(ctx, i.ReaderWriter, i.Input.Name, i.Input.Disambiguation)
+}
+
+func findByNameDisambiguation(ctx context.Context, qb models.PerformerQueryer, name string, disambiguation string) (*int, error) {
 	// use disambiguation as well
 	performerFilter := models.PerformerFilterType{
 		Name: &models.StringCriterionInput{
-			Value:    i.Input.Name,
+			Value:    name,
 			Modifier: models.CriterionModifierEquals,
 		},
 	}
 
-	if i.Input.Disambiguation != "" {
+	if disambiguation != "" {
 		performerFilter.Disambiguation = &models.StringCriterionInput{
-			Value:    i.Input.Disambiguation,
+			Value:    disambiguation,
 			Modifier: models.CriterionModifierEquals,
 		}
 	}
@@ -150,7 +157,7 @@ func (i *Importer) FindExistingID(ctx context.Context) (*int, error) {
 		PerPage: &pp,
 	}
 
-	existing, _, err := i.ReaderWriter.Query(ctx, &performerFilter, &findFilter)
+	existing, _, err := qb.Query(ctx, &performerFilter, &findFilter)
 	if err != nil {
 		return nil, err
 	}
@@ -201,6 +208,7 @@ func performerJSONToPerformer(performerJSON jsonschema.Performer) models.Perform
 		HairColor:      performerJSON.HairColor,
 		Favorite:       performerJSON.Favorite,
 		IgnoreAutoTag:  performerJSON.IgnoreAutoTag,
+		IsGroup:        performerJSON.IsGroup,
 		CreatedAt:      performerJSON.CreatedAt.GetTime(),
 		UpdatedAt:      performerJSON.UpdatedAt.GetTime(),
 
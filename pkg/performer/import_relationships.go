@@ -0,0 +1,87 @@
+package performer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/jsonschema"
+)
+
+// RelationshipImporter sets the relationships of an imported performer.
+// Relationships refer to other performers, so they are imported once all
+// performers have been imported.
+type RelationshipImporter struct {
+	ReaderWriter ImporterReaderWriter
+	// ID is the ID of the imported performer
+	ID                  int
+	Input               jsonschema.Performer
+	MissingRefBehaviour models.ImportMissingRefEnum
+}
+
+func (i *RelationshipImporter) Import(ctx context.Context) error {
+	if len(i.Input.RelatedPerformers) == 0 {
+		return nil
+	}
+
+	var relationships []models.PerformerRelationship
+	for _, r := range i.Input.RelatedPerformers {
+		t := models.PerformerRelationshipType(r.Type)
+		if !t.IsValid() {
+			return fmt.Errorf("invalid relationship type %q with performer <%s>", r.Type, r.Name)
+		}
+
+		relatedID, err := i.getRelatedPerformer(ctx, r)
+		if err != nil {
+			return err
+		}
+
+		if relatedID != nil {
+			relationships = append(relationships, models.PerformerRelationship{
+				PerformerID: *relatedID,
+				Type:        t,
+			})
+		}
+	}
+
+	if _, err := i.ReaderWriter.UpdatePartial(ctx, i.ID, models.PerformerPartial{
+		RelatedPerformers: &models.UpdatePerformerRelationships{
+			Relationships: relationships,
+			Mode:          models.RelationshipUpdateModeSet,
+		},
+	}); err != nil {
+		return fmt.Errorf("error setting related performers: %v", err)
+	}
+
+	return nil
+}
+
+// getRelatedPerformer returns the ID of the related performer, or nil if it
+// does not exist and missing references are ignored.
+func (i *RelationshipImporter) getRelatedPerformer(ctx context.Context, r jsonschema.PerformerRelationship) (*int, error) {
+	id, err := findByNameDisambiguation(ctx, i.ReaderWriter, r.Name, r.Disambiguation)
+	if err != nil {
+		return nil, fmt.Errorf("error finding related performer by name: %v", err)
+	}
+
+	if id != nil {
+		return id, nil
+	}
+
+	switch i.MissingRefBehaviour {
+	case models.ImportMissingRefEnumFail:
+		return nil, fmt.Errorf("related performer <%s> does not exist", r.Name)
+	case models.ImportMissingRefEnumCreate:
+		newPerformer := models.NewPerformer()
+		newPerformer.Name = r.Name
+		newPerformer.Disambiguation = r.Disambiguation
+
+		if err := i.ReaderWriter.Create(ctx, &newPerformer); err != nil {
+			return nil, fmt.Errorf("error creating related performer: %v", err)
+		}
+
+		return &newPerformer.ID, nil
+	}
+
+	return nil, nil
+}
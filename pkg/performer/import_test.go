@@ -300,3 +300,66 @@ func TestUpdate(t *testing.T) {
 
 	db.AssertExpectations(t)
 }
+
+func TestRelationshipImporter(t *testing.T) {
+	db := mocks.NewDatabase()
+
+	const missingPerformerName = "missingPerformerName"
+
+	pp := 1
+	findFilter := &models.FindFilterType{
+		PerPage: &pp,
+	}
+
+	performerFilter := func(name string) *models.PerformerFilterType {
+		return &models.PerformerFilterType{
+			Name: &models.StringCriterionInput{
+				Value:    name,
+				Modifier: models.CriterionModifierEquals,
+			},
+		}
+	}
+
+	db.Performer.On("Query", testCtx, performerFilter(existingPerformerName), findFilter).Return([]*models.Performer{
+		{
+			ID: existingPerformerID,
+		},
+	}, 1, nil)
+	db.Performer.On("Query", testCtx, performerFilter(missingPerformerName), findFilter).Return(nil, 0, nil)
+
+	db.Performer.On("UpdatePartial", testCtx, performerID, models.PerformerPartial{
+		RelatedPerformers: &models.UpdatePerformerRelationships{
+			Relationships: []models.PerformerRelationship{
+				{PerformerID: existingPerformerID, Type: models.PerformerRelationshipTypeMemberOf},
+			},
+			Mode: models.RelationshipUpdateModeSet,
+		},
+	}).Return(nil, nil).Once()
+
+	i := RelationshipImporter{
+		ReaderWriter: db.Performer,
+		ID:           performerID,
+		Input: jsonschema.Performer{
+			Name: performerName,
+			RelatedPerformers: []jsonschema.PerformerRelationship{
+				{Name: existingPerformerName, Type: models.PerformerRelationshipTypeMemberOf.String()},
+				{Name: missingPerformerName, Type: models.PerformerRelationshipTypeSibling.String()},
+			},
+		},
+		MissingRefBehaviour: models.ImportMissingRefEnumIgnore,
+	}
+
+	err := i.Import(testCtx)
+	assert.Nil(t, err)
+
+	i.MissingRefBehaviour = models.ImportMissingRefEnumFail
+	err = i.Import(testCtx)
+	assert.NotNil(t, err)
+
+	i.MissingRefBehaviour = models.ImportMissingRefEnumIgnore
+	i.Input.RelatedPerformers[0].Type = "invalid"
+	err = i.Import(testCtx)
+	assert.NotNil(t, err)
+
+	db.AssertExpectations(t)
+}
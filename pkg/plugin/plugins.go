@@ -263,7 +263,7 @@ func (c Cache) makeServerConnection(ctx context.Context) common.StashServerConne
 // name provided. Returns an error if the plugin or the operation could not be
 // resolved.
 func (c Cache) CreateTask(ctx context.Context, pluginID string, operationName *string, args OperationInput, progress chan float64) (Task, error) {
-	serverConnection := c.makeServerConnection(ctx)
+	serverConnection := c.makeServerConnection(session.SetCurrentPluginID(ctx, pluginID))
 
 	if c.pluginDisabled(pluginID) {
 		return nil, fmt.Errorf("plugin %s is disabled", pluginID)
@@ -296,7 +296,7 @@ func (c Cache) CreateTask(ctx context.Context, pluginID string, operationName *s
 }
 
 func (c Cache) RunPlugin(ctx context.Context, pluginID string, args OperationInput) (interface{}, error) {
-	serverConnection := c.makeServerConnection(ctx)
+	serverConnection := c.makeServerConnection(session.SetCurrentPluginID(ctx, pluginID))
 
 	if c.pluginDisabled(pluginID) {
 		return nil, fmt.Errorf("plugin %s is disabled", pluginID)
@@ -402,6 +402,7 @@ func (c Cache) executePostHooks(ctx context.Context, hookType hook.TriggerEnum,
 
 		for _, h := range hooks {
 			newCtx := session.AddVisitedPluginHook(ctx, p.id, hookType)
+			newCtx = session.SetCurrentPluginID(newCtx, p.id)
 			serverConnection := c.makeServerConnection(newCtx)
 
 			pluginInput := buildPluginInput(&p, &h.OperationConfig, serverConnection, nil)
@@ -108,6 +108,14 @@ func (d *FileDeleter) MarkMarkerFiles(scene *models.Scene, seconds int) error {
 // Destroy deletes a scene and its associated relationships from the
 // database.
 func (s *Service) Destroy(ctx context.Context, scene *models.Scene, fileDeleter *FileDeleter, deleteGenerated, deleteFile bool) error {
+	// files are deleted after the scene, so that the deleted scene can be
+	// identified by its files
+	if deleteFile {
+		if err := scene.LoadFiles(ctx, s.Repository); err != nil {
+			return err
+		}
+	}
+
 	mqb := s.MarkerRepository
 	markers, err := mqb.FindBySceneID(ctx, scene.ID)
 	if err != nil {
@@ -120,12 +128,6 @@ func (s *Service) Destroy(ctx context.Context, scene *models.Scene, fileDeleter
 		}
 	}
 
-	if deleteFile {
-		if err := s.deleteFiles(ctx, scene, fileDeleter); err != nil {
-			return err
-		}
-	}
-
 	if deleteGenerated {
 		if err := fileDeleter.MarkGeneratedFiles(scene); err != nil {
 			return err
@@ -136,15 +138,18 @@ func (s *Service) Destroy(ctx context.Context, scene *models.Scene, fileDeleter
 		return err
 	}
 
+	if deleteFile {
+		if err := s.deleteFiles(ctx, scene, fileDeleter); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
-// deleteFiles deletes files from the database and file system
+// deleteFiles deletes files from the database and file system. The scene
+// must have been destroyed, and its files loaded beforehand.
 func (s *Service) deleteFiles(ctx context.Context, scene *models.Scene, fileDeleter *FileDeleter) error {
-	if err := scene.LoadFiles(ctx, s.Repository); err != nil {
-		return err
-	}
-
 	for _, f := range scene.Files.List() {
 		// only delete files where there is no other associated scene
 		otherScenes, err := s.Repository.FindByFileID(ctx, f.ID)
@@ -152,7 +157,7 @@ func (s *Service) deleteFiles(ctx context.Context, scene *models.Scene, fileDele
 			return err
 		}
 
-		if len(otherScenes) > 1 {
+		if len(otherScenes) > 0 {
 			// other scenes associated, don't remove
 			continue
 		}
@@ -44,6 +44,25 @@ func (g Generator) Screenshot(ctx context.Context, input string, videoWidth int,
 	return ret, nil
 }
 
+// Waveform generates an image of the waveform of an audio-only file, for use
+// as its cover.
+func (g Generator) Waveform(ctx context.Context, input string) ([]byte, error) {
+	lockCtx := g.LockManager.ReadLock(ctx, input)
+	defer lockCtx.Cancel()
+
+	logger.Infof("Creating waveform for %s", input)
+
+	return g.generateBytes(lockCtx, g.ScenePaths, jpgPattern, func(lockCtx *fsutil.LockContext, tmpFn string) error {
+		args := transcoder.ScreenshotWaveform(input, transcoder.ScreenshotOptions{
+			OutputPath: tmpFn,
+			OutputType: transcoder.ScreenshotOutputTypeImage2,
+			Quality:    screenshotQuality,
+		})
+
+		return g.generate(lockCtx, args)
+	})
+}
+
 type screenshotOptions struct {
 	Time    float64
 	Width   int
@@ -0,0 +1,334 @@
+package scene
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/nfo"
+	"github.com/stashapp/stash/pkg/sliceutil"
+)
+
+// nfoUniqueIDType is the uniqueid type used for the scene ID in exported
+// NFO files.
+const nfoUniqueIDType = "stash"
+
+// NFOSceneUpdater updates scenes with metadata read from NFO files.
+type NFOSceneUpdater interface {
+	models.PerformerIDLoader
+	models.TagIDLoader
+	UpdatePartial(ctx context.Context, id int, updatedScene models.ScenePartial) (*models.Scene, error)
+}
+
+// NFODecorator populates unorganised scenes with the metadata from the movie
+// NFO file next to the scanned video file. Only empty fields are populated,
+// and performers and tags are added to the existing ones. Missing studios,
+// performers and tags are created. Scenes are not updated if the NFO file adds
+// nothing to them.
+type NFODecorator struct {
+	ReaderWriter    NFOSceneUpdater
+	StudioWriter    models.StudioFinderCreator
+	PerformerWriter models.PerformerFinderCreator
+	TagWriter       models.TagFinderCreator
+}
+
+func (d *NFODecorator) Decorate(ctx context.Context, s *models.Scene, f *models.VideoFile) error {
+	if s.Organized || f.ZipFileID != nil {
+		return nil
+	}
+
+	path := nfo.FindMovieFile(f.Path)
+	if path == "" {
+		return nil
+	}
+
+	movie, err := nfo.ReadMovieFile(path)
+	if err != nil {
+		// don't fail the scan for an invalid NFO file
+		logger.Warnf("Error reading NFO file: %v", err)
+		return nil
+	}
+
+	partial, err := d.scenePartial(ctx, s, movie)
+	if err != nil {
+		return err
+	}
+
+	// don't touch updated_at if the scene already has everything in the NFO
+	if partial == (models.ScenePartial{}) {
+		return nil
+	}
+	partial.UpdatedAt = models.NewOptionalTime(time.Now())
+
+	logger.Infof("Updating scene %s from %s", s.DisplayName(), path)
+
+	updated, err := d.ReaderWriter.UpdatePartial(ctx, s.ID, partial)
+	if err != nil {
+		return fmt.Errorf("updating scene from NFO: %w", err)
+	}
+
+	*s = *updated
+	return nil
+}
+
+func (d *NFODecorator) scenePartial(ctx context.Context, s *models.Scene, movie *nfo.Movie) (models.ScenePartial, error) {
+	var ret models.ScenePartial
+
+	if s.Title == "" && movie.Title != "" {
+		ret.Title = models.NewOptionalString(strings.TrimSpace(movie.Title))
+	}
+
+	if details := movie.GetDetails(); s.Details == "" && details != "" {
+		ret.Details = models.NewOptionalString(strings.TrimSpace(details))
+	}
+
+	if s.Director == "" && len(movie.Directors) > 0 {
+		ret.Director = models.NewOptionalString(strings.Join(movie.Directors, ", "))
+	}
+
+	if s.Date == nil {
+		if date := movie.GetDate(); date != "" {
+			parsed, err := models.ParseDate(date)
+			if err != nil {
+				logger.Warnf("Ignoring invalid NFO date %q: %v", date, err)
+			} else {
+				ret.Date = models.NewOptionalDate(parsed)
+			}
+		}
+	}
+
+	// NFO user ratings are out of 10
+	if s.Rating == nil && movie.UserRating > 0 && movie.UserRating <= 10 {
+		ret.Rating = models.NewOptionalInt(movie.UserRating * 10)
+	}
+
+	if s.StudioID == nil && len(movie.Studios) > 0 {
+		studioID, err := d.getOrCreateStudio(ctx, strings.TrimSpace(movie.Studios[0]))
+		if err != nil {
+			return ret, err
+		}
+		ret.StudioID = models.NewOptionalInt(studioID)
+	}
+
+	var performerNames []string
+	for _, a := range movie.Actors {
+		if name := strings.TrimSpace(a.Name); name != "" {
+			performerNames = sliceutil.AppendUnique(performerNames, name)
+		}
+	}
+
+	if len(performerNames) > 0 {
+		performerIDs, err := d.getOrCreatePerformers(ctx, performerNames)
+		if err != nil {
+			return ret, err
+		}
+
+		if err := s.LoadPerformerIDs(ctx, d.ReaderWriter); err != nil {
+			return ret, fmt.Errorf("loading scene performers: %w", err)
+		}
+
+		if performerIDs = sliceutil.Exclude(performerIDs, s.PerformerIDs.List()); len(performerIDs) > 0 {
+			ret.PerformerIDs = &models.UpdateIDs{
+				IDs:  performerIDs,
+				Mode: models.RelationshipUpdateModeAdd,
+			}
+		}
+	}
+
+	var tagNames []string
+	nfoTags := append([]string{}, movie.Genres...)
+	nfoTags = append(nfoTags, movie.Tags...)
+	for _, t := range nfoTags {
+		if name := strings.TrimSpace(t); name != "" {
+			tagNames = sliceutil.AppendUnique(tagNames, name)
+		}
+	}
+
+	if len(tagNames) > 0 {
+		tagIDs, err := d.getOrCreateTags(ctx, tagNames)
+		if err != nil {
+			return ret, err
+		}
+
+		if err := s.LoadTagIDs(ctx, d.ReaderWriter); err != nil {
+			return ret, fmt.Errorf("loading scene tags: %w", err)
+		}
+
+		if tagIDs = sliceutil.Exclude(tagIDs, s.TagIDs.List()); len(tagIDs) > 0 {
+			ret.TagIDs = &models.UpdateIDs{
+				IDs:  tagIDs,
+				Mode: models.RelationshipUpdateModeAdd,
+			}
+		}
+	}
+
+	return ret, nil
+}
+
+func (d *NFODecorator) getOrCreateStudio(ctx context.Context, name string) (int, error) {
+	studio, err := d.StudioWriter.FindByName(ctx, name, true)
+	if err != nil {
+		return 0, fmt.Errorf("finding studio %q: %w", name, err)
+	}
+
+	if studio != nil {
+		return studio.ID, nil
+	}
+
+	newStudio := models.NewStudio()
+	newStudio.Name = name
+
+	if err := d.StudioWriter.Create(ctx, &newStudio); err != nil {
+		return 0, fmt.Errorf("creating studio %q: %w", name, err)
+	}
+
+	return newStudio.ID, nil
+}
+
+func (d *NFODecorator) getOrCreatePerformers(ctx context.Context, names []string) ([]int, error) {
+	performers, err := d.PerformerWriter.FindByNames(ctx, names, true)
+	if err != nil {
+		return nil, fmt.Errorf("finding performers: %w", err)
+	}
+
+	var ret []int
+	var found []string
+	for _, p := range performers {
+		ret = append(ret, p.ID)
+		found = append(found, strings.ToLower(p.Name))
+	}
+
+	for _, name := range names {
+		if sliceutil.Contains(found, strings.ToLower(name)) {
+			continue
+		}
+
+		newPerformer := models.NewPerformer()
+		newPerformer.Name = name
+
+		if err := d.PerformerWriter.Create(ctx, &newPerformer); err != nil {
+			return nil, fmt.Errorf("creating performer %q: %w", name, err)
+		}
+
+		ret = append(ret, newPerformer.ID)
+	}
+
+	return ret, nil
+}
+
+func (d *NFODecorator) getOrCreateTags(ctx context.Context, names []string) ([]int, error) {
+	tags, err := d.TagWriter.FindByNames(ctx, names, true)
+	if err != nil {
+		return nil, fmt.Errorf("finding tags: %w", err)
+	}
+
+	var ret []int
+	var found []string
+	for _, t := range tags {
+		ret = append(ret, t.ID)
+		found = append(found, strings.ToLower(t.Name))
+	}
+
+	for _, name := range names {
+		if sliceutil.Contains(found, strings.ToLower(name)) {
+			continue
+		}
+
+		newTag := models.NewTag()
+		newTag.Name = name
+
+		if err := d.TagWriter.Create(ctx, &newTag); err != nil {
+			return nil, fmt.Errorf("creating tag %q: %w", name, err)
+		}
+
+		ret = append(ret, newTag.ID)
+	}
+
+	return ret, nil
+}
+
+// NFOReader reads the related objects of a scene to populate its NFO.
+type NFOReader struct {
+	Studio    models.StudioGetter
+	Performer models.PerformerFinder
+	Tag       TagFinder
+	Group     models.GroupGetter
+}
+
+// ToNFO returns the movie NFO for the scene. The scene's groups must be
+// loaded.
+func (r *NFOReader) ToNFO(ctx context.Context, s *models.Scene, f *models.VideoFile) (*nfo.Movie, error) {
+	ret := &nfo.Movie{
+		Title: s.Title,
+		Plot:  s.Details,
+		UniqueIDs: []nfo.UniqueID{
+			{
+				Type:    nfoUniqueIDType,
+				Default: true,
+				Value:   strconv.Itoa(s.ID),
+			},
+		},
+	}
+
+	if ret.Title == "" {
+		ret.Title = s.GetTitle()
+	}
+
+	if s.Director != "" {
+		ret.Directors = []string{s.Director}
+	}
+
+	if s.Date != nil {
+		ret.Premiered = s.Date.String()
+		ret.Year = s.Date.Format("2006")
+	}
+
+	// NFO user ratings are out of 10
+	if s.Rating != nil {
+		ret.UserRating = (*s.Rating + 5) / 10
+	}
+
+	if f != nil && f.Duration > 0 {
+		ret.Runtime = int(f.Duration / 60)
+	}
+
+	studio, err := GetStudioName(ctx, r.Studio, s)
+	if err != nil {
+		return nil, fmt.Errorf("getting scene studio: %w", err)
+	}
+
+	if studio != "" {
+		ret.Studios = []string{studio}
+	}
+
+	performers, err := r.Performer.FindBySceneID(ctx, s.ID)
+	if err != nil {
+		return nil, fmt.Errorf("getting scene performers: %w", err)
+	}
+
+	for _, p := range performers {
+		ret.Actors = append(ret.Actors, nfo.Actor{Name: p.Name})
+	}
+
+	ret.Tags, err = GetTagNames(ctx, r.Tag, s)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, sg := range s.Groups.List() {
+		group, err := r.Group.Find(ctx, sg.GroupID)
+		if err != nil {
+			return nil, fmt.Errorf("getting scene group: %w", err)
+		}
+
+		if group != nil {
+			ret.Sets = append(ret.Sets, nfo.Set{Name: group.Name})
+		}
+	}
+
+	return ret, nil
+}
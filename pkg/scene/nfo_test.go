@@ -0,0 +1,164 @@
+package scene
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stashapp/stash/pkg/nfo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestNFODecorator_scenePartial(t *testing.T) {
+	const (
+		createdPerformerID = 201
+		createdTagID       = 202
+	)
+
+	db := mocks.NewDatabase()
+
+	d := &NFODecorator{
+		ReaderWriter:    db.Scene,
+		StudioWriter:    db.Studio,
+		PerformerWriter: db.Performer,
+		TagWriter:       db.Tag,
+	}
+
+	movie := &nfo.Movie{
+		Title:      "NFO Title",
+		Outline:    "Outline",
+		Premiered:  "2020-01-02",
+		UserRating: 8,
+		Directors:  []string{"Director"},
+		Studios:    []string{existingStudioName},
+		Actors: []nfo.Actor{
+			{Name: existingPerformerName},
+			{Name: missingPerformerName},
+		},
+		Genres: []string{existingTagName},
+		Tags:   []string{missingTagName, existingTagName},
+	}
+
+	db.Studio.On("FindByName", testCtx, existingStudioName, true).Return(&models.Studio{
+		ID: existingStudioID,
+	}, nil).Once()
+
+	db.Performer.On("FindByNames", testCtx, []string{existingPerformerName, missingPerformerName}, true).Return([]*models.Performer{
+		{
+			ID:   existingPerformerID,
+			Name: existingPerformerName,
+		},
+	}, nil).Once()
+	db.Performer.On("Create", testCtx, mock.AnythingOfType("*models.Performer")).Run(func(args mock.Arguments) {
+		p := args.Get(1).(*models.Performer)
+		p.ID = createdPerformerID
+	}).Return(nil).Once()
+
+	db.Tag.On("FindByNames", testCtx, []string{existingTagName, missingTagName}, true).Return([]*models.Tag{
+		{
+			ID:   existingTagID,
+			Name: existingTagName,
+		},
+	}, nil).Once()
+	db.Tag.On("Create", testCtx, mock.AnythingOfType("*models.Tag")).Run(func(args mock.Arguments) {
+		tag := args.Get(1).(*models.Tag)
+		tag.ID = createdTagID
+	}).Return(nil).Once()
+
+	// existing title must not be overwritten, and existing performers
+	// must not be added again
+	s := &models.Scene{
+		ID:           sceneID,
+		Title:        title,
+		PerformerIDs: models.NewRelatedIDs([]int{existingPerformerID}),
+		TagIDs:       models.NewRelatedIDs([]int{}),
+	}
+
+	got, err := d.scenePartial(testCtx, s, movie)
+	if err != nil {
+		t.Fatalf("scenePartial() error = %v", err)
+	}
+
+	date, _ := models.ParseDate("2020-01-02")
+
+	assert.False(t, got.Title.Set)
+	assert.False(t, got.UpdatedAt.Set)
+	assert.Equal(t, models.NewOptionalString("Outline"), got.Details)
+	assert.Equal(t, models.NewOptionalString("Director"), got.Director)
+	assert.Equal(t, models.NewOptionalDate(date), got.Date)
+	assert.Equal(t, models.NewOptionalInt(80), got.Rating)
+	assert.Equal(t, models.NewOptionalInt(existingStudioID), got.StudioID)
+	assert.Equal(t, &models.UpdateIDs{
+		IDs:  []int{createdPerformerID},
+		Mode: models.RelationshipUpdateModeAdd,
+	}, got.PerformerIDs)
+	assert.Equal(t, &models.UpdateIDs{
+		IDs:  []int{existingTagID, createdTagID},
+		Mode: models.RelationshipUpdateModeAdd,
+	}, got.TagIDs)
+
+	db.AssertExpectations(t)
+}
+
+func TestNFODecorator_Decorate(t *testing.T) {
+	nfoContent := `<movie><title>NFO Title</title><tag>` + existingTagName + `</tag></movie>`
+
+	tests := []struct {
+		name        string
+		scene       models.Scene
+		wantUpdated bool
+	}{
+		{
+			"populates missing fields",
+			models.Scene{ID: sceneID, TagIDs: models.NewRelatedIDs([]int{})},
+			true,
+		},
+		{
+			"nothing to update",
+			models.Scene{ID: sceneID, Title: title, TagIDs: models.NewRelatedIDs([]int{existingTagID})},
+			false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			videoPath := filepath.Join(dir, "video.mp4")
+			if err := os.WriteFile(filepath.Join(dir, "video.nfo"), []byte(nfoContent), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			db := mocks.NewDatabase()
+			d := &NFODecorator{
+				ReaderWriter:    db.Scene,
+				StudioWriter:    db.Studio,
+				PerformerWriter: db.Performer,
+				TagWriter:       db.Tag,
+			}
+
+			db.Tag.On("FindByNames", testCtx, []string{existingTagName}, true).Return([]*models.Tag{
+				{
+					ID:   existingTagID,
+					Name: existingTagName,
+				},
+			}, nil).Once()
+
+			if tt.wantUpdated {
+				db.Scene.On("UpdatePartial", testCtx, sceneID, mock.MatchedBy(func(p models.ScenePartial) bool {
+					return p.UpdatedAt.Set && p.Title.Value == "NFO Title"
+				})).Return(&models.Scene{ID: sceneID, Title: "NFO Title"}, nil).Once()
+			}
+
+			s := tt.scene
+			f := &models.VideoFile{BaseFile: &models.BaseFile{Path: videoPath}}
+			if err := d.Decorate(testCtx, &s, f); err != nil {
+				t.Fatalf("Decorate() error = %v", err)
+			}
+
+			db.AssertExpectations(t)
+		})
+	}
+}
@@ -32,10 +32,18 @@ type ScanGenerator interface {
 	Generate(ctx context.Context, s *models.Scene, f *models.VideoFile) error
 }
 
+// ScanDecorator applies additional metadata to a scene when one of its
+// files is scanned. It is called within the scan transaction.
+type ScanDecorator interface {
+	Decorate(ctx context.Context, s *models.Scene, f *models.VideoFile) error
+}
+
 type ScanHandler struct {
 	CreatorUpdater ScanCreatorUpdater
 
-	ScanGenerator  ScanGenerator
+	ScanGenerator ScanGenerator
+	// ScanDecorator is optional
+	ScanDecorator  ScanDecorator
 	CaptionUpdater video.CaptionUpdater
 	PluginCache    *plugin.Cache
 
@@ -113,6 +121,14 @@ func (h *ScanHandler) Handle(ctx context.Context, f models.File, oldFile models.
 		existing = []*models.Scene{&newScene}
 	}
 
+	if h.ScanDecorator != nil {
+		for _, s := range existing {
+			if err := h.ScanDecorator.Decorate(ctx, s, videoFile); err != nil {
+				return fmt.Errorf("decorating scene %s: %w", s.DisplayName(), err)
+			}
+		}
+	}
+
 	if oldFile != nil {
 		// migrate hashes from the old file to the new
 		oldHash := GetHash(oldFile, h.FileNamingAlgorithm)
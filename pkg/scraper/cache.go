@@ -69,6 +69,7 @@ type StudioFinder interface {
 type TagFinder interface {
 	models.TagGetter
 	models.TagAutoTagQueryer
+	match.TagFinder
 }
 
 type GalleryFinder interface {
@@ -365,6 +366,48 @@ func (c Cache) ScrapeID(ctx context.Context, scraperID string, id int, ty Scrape
 	return c.postScrape(ctx, ret)
 }
 
+// ScrapeFingerprint scrapes scenes using the fingerprints of the primary
+// file of the scene with the provided id.
+func (c Cache) ScrapeFingerprint(ctx context.Context, scraperID string, sceneID int) ([]*ScrapedScene, error) {
+	s := c.findScraper(scraperID)
+	if s == nil {
+		return nil, fmt.Errorf("%w: id %s", ErrNotFound, scraperID)
+	}
+
+	fs, ok := s.(fingerprintScraper)
+	if !ok {
+		return nil, fmt.Errorf("%w: cannot use scraper %s to scrape by fingerprint", ErrNotSupported, scraperID)
+	}
+
+	scene, err := c.getScene(ctx, sceneID)
+	if err != nil {
+		return nil, fmt.Errorf("scraper %s: unable to load scene id %v: %w", scraperID, sceneID, err)
+	}
+
+	scenes, err := fs.viaFingerprint(ctx, c.client, scene)
+	if err != nil {
+		return nil, fmt.Errorf("error while fingerprint scraping with scraper %s: %w", scraperID, err)
+	}
+
+	var ret []*ScrapedScene
+	for _, ss := range scenes {
+		if ss == nil {
+			continue
+		}
+
+		content, err := c.postScrape(ctx, ss)
+		if err != nil {
+			return nil, fmt.Errorf("error while post-scraping with scraper %s: %w", scraperID, err)
+		}
+
+		if scraped, ok := content.(ScrapedScene); ok {
+			ret = append(ret, &scraped)
+		}
+	}
+
+	return ret, nil
+}
+
 func (c Cache) getScene(ctx context.Context, sceneID int) (*models.Scene, error) {
 	var ret *models.Scene
 	r := c.repository
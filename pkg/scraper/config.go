@@ -33,6 +33,9 @@ type config struct {
 	// Configuration for querying gallery by a Gallery fragment
 	GalleryByFragment *scraperTypeConfig `yaml:"galleryByFragment"`
 
+	// Configuration for querying scenes by the fingerprints of the primary file
+	SceneByFingerprint *scraperTypeConfig `yaml:"sceneByFingerprint"`
+
 	// Configuration for querying scenes by name
 	SceneByName *scraperTypeConfig `yaml:"sceneByName"`
 
@@ -88,6 +91,12 @@ func (c config) validate() error {
 		}
 	}
 
+	if c.SceneByFingerprint != nil {
+		if err := c.SceneByFingerprint.validate(); err != nil {
+			return err
+		}
+	}
+
 	for _, s := range c.PerformerByURL {
 		if err := s.validate(); err != nil {
 			return err
@@ -136,6 +145,10 @@ func (c scraperTypeConfig) validate() error {
 		return errors.New("script is mandatory for script scraper action")
 	}
 
+	if c.Action == scraperActionJS && len(c.Script) == 0 {
+		return errors.New("script is mandatory for js scraper action")
+	}
+
 	return nil
 }
 
@@ -268,6 +281,9 @@ func (c config) spec() Scraper {
 	if c.SceneByName != nil && c.SceneByQueryFragment != nil {
 		scene.SupportedScrapes = append(scene.SupportedScrapes, ScrapeTypeName)
 	}
+	if c.SceneByFingerprint != nil {
+		scene.SupportedScrapes = append(scene.SupportedScrapes, ScrapeTypeFingerprint)
+	}
 	if len(c.SceneByURL) > 0 {
 		scene.SupportedScrapes = append(scene.SupportedScrapes, ScrapeTypeURL)
 		for _, v := range c.SceneByURL {
@@ -315,7 +331,7 @@ func (c config) supports(ty ScrapeContentType) bool {
 	case ScrapeContentTypePerformer:
 		return c.PerformerByName != nil || c.PerformerByFragment != nil || len(c.PerformerByURL) > 0
 	case ScrapeContentTypeScene:
-		return (c.SceneByName != nil && c.SceneByQueryFragment != nil) || c.SceneByFragment != nil || len(c.SceneByURL) > 0
+		return (c.SceneByName != nil && c.SceneByQueryFragment != nil) || c.SceneByFragment != nil || c.SceneByFingerprint != nil || len(c.SceneByURL) > 0
 	case ScrapeContentTypeGallery:
 		return c.GalleryByFragment != nil || len(c.GalleryByURL) > 0
 	case ScrapeContentTypeMovie, ScrapeContentTypeGroup:
@@ -66,6 +66,15 @@ func (g group) viaScene(ctx context.Context, client *http.Client, scene *models.
 	return s.scrapeSceneByScene(ctx, scene)
 }
 
+func (g group) viaFingerprint(ctx context.Context, client *http.Client, scene *models.Scene) ([]*ScrapedScene, error) {
+	if g.config.SceneByFingerprint == nil {
+		return nil, ErrNotSupported
+	}
+
+	s := g.config.getScraper(*g.config.SceneByFingerprint, client, g.globalConf)
+	return s.scrapeScenesByFingerprint(ctx, scene)
+}
+
 func (g group) viaGallery(ctx context.Context, client *http.Client, gallery *models.Gallery) (*ScrapedGallery, error) {
 	if g.config.GalleryByFragment == nil {
 		return nil, ErrNotSupported
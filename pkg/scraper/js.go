@@ -0,0 +1,313 @@
+package scraper
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"path/filepath"
+	"strings"
+
+	"github.com/antchfx/htmlquery"
+	"github.com/dop251/goja"
+	"golang.org/x/net/html/charset"
+
+	"github.com/stashapp/stash/pkg/javascript"
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+// jsScraper runs scraper scripts in the embedded javascript VM.
+// It accepts the same input and produces the same output as the script
+// scraper, without requiring an external interpreter.
+type jsScraper struct {
+	scraper      scraperTypeConfig
+	config       config
+	globalConfig GlobalConfig
+	client       *http.Client
+}
+
+func newJSScraper(scraper scraperTypeConfig, client *http.Client, config config, globalConfig GlobalConfig) *scriptScraper {
+	s := &jsScraper{
+		scraper:      scraper,
+		config:       config,
+		globalConfig: globalConfig,
+		client:       client,
+	}
+
+	return &scriptScraper{
+		scraper:      scraper,
+		config:       config,
+		globalConfig: globalConfig,
+		run:          s.runScraperScript,
+	}
+}
+
+func (s *jsScraper) scriptPath() string {
+	return filepath.Join(filepath.Dir(s.config.path), s.scraper.Script[0])
+}
+
+func (s *jsScraper) initVM(ctx context.Context, vm *javascript.VM, inString string, progress chan float64) error {
+	var input interface{}
+	if err := json.Unmarshal([]byte(inString), &input); err != nil {
+		return fmt.Errorf("error decoding input: %w", err)
+	}
+
+	if err := vm.Set("input", input); err != nil {
+		return fmt.Errorf("error setting input: %w", err)
+	}
+
+	if err := vm.Set("args", s.scraper.Script[1:]); err != nil {
+		return fmt.Errorf("error setting args: %w", err)
+	}
+
+	const scraperPrefix = "[Scrape / %s] "
+
+	log := &javascript.Log{
+		Logger:       logger.Logger,
+		Prefix:       fmt.Sprintf(scraperPrefix, s.config.Name),
+		ProgressChan: progress,
+	}
+
+	if err := log.AddToVM("log", vm); err != nil {
+		return fmt.Errorf("error adding log API: %w", err)
+	}
+
+	util := &javascript.Util{}
+	if err := util.AddToVM("util", vm); err != nil {
+		return fmt.Errorf("error adding util API: %w", err)
+	}
+
+	jar, err := s.config.jar()
+	if err != nil {
+		return fmt.Errorf("error creating cookie jar: %w", err)
+	}
+
+	f := &jsFetch{
+		ctx:          ctx,
+		client:       s.client,
+		jar:          jar,
+		config:       s.config,
+		globalConfig: s.globalConfig,
+	}
+	if err := vm.Set("fetch", f.fetch); err != nil {
+		return fmt.Errorf("error adding fetch API: %w", err)
+	}
+
+	h := &jsHTML{}
+	if err := h.AddToVM("html", vm); err != nil {
+		return fmt.Errorf("error adding html API: %w", err)
+	}
+
+	return nil
+}
+
+func (s *jsScraper) runScraperScript(ctx context.Context, inString string, out interface{}) error {
+	script, err := javascript.Compile(s.scriptPath())
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrScraperScript, err)
+	}
+
+	vm := javascript.NewVM()
+	progress := make(chan float64)
+	if err := s.initVM(ctx, vm, inString, progress); err != nil {
+		return err
+	}
+
+	// discard progress and stop the script if the scrape is cancelled
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			select {
+			case <-progress:
+			case <-ctx.Done():
+				vm.Interrupt(ctx.Err())
+				return
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	logger.Debugf("Scraper script <%s> started", s.scraper.Script[0])
+
+	output, err := vm.RunProgram(script)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrScraperScript, err)
+	}
+
+	logger.Debugf("Scraper script finished")
+
+	if output == nil || goja.IsUndefined(output) || goja.IsNull(output) {
+		return nil
+	}
+
+	data, err := json.Marshal(output.Export())
+	if err != nil {
+		return fmt.Errorf("could not marshal script output: %w", err)
+	}
+
+	// First, perform a decode where unknown fields are disallowed.
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.DisallowUnknownFields()
+	strictErr := d.Decode(out)
+
+	if strictErr != nil {
+		// allow unknown fields in the decode
+		if lenientErr := json.Unmarshal(data, out); lenientErr != nil {
+			logger.Errorf("could not unmarshal json from script output: %v", lenientErr)
+			return fmt.Errorf("could not unmarshal json from script output: %w", lenientErr)
+		}
+
+		// Lenient decode succeeded, print a warning, but use the decode
+		logger.Warnf("reading script result: %v", strictErr)
+	}
+
+	return nil
+}
+
+// jsFetchOptions are the options accepted by the fetch function.
+type jsFetchOptions struct {
+	Method  string            `json:"method"`
+	Headers map[string]string `json:"headers"`
+	Body    string            `json:"body"`
+}
+
+// jsFetchResponse is the value returned by the fetch function.
+type jsFetchResponse struct {
+	Status  int               `json:"status"`
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers"`
+	Body    string            `json:"body"`
+}
+
+// jsFetch performs HTTP requests for javascript scrapers using the scraper
+// http client. Cookies from the scraper configuration are sent with each
+// request, and cookies set by responses are retained for the duration of
+// the script.
+type jsFetch struct {
+	ctx          context.Context
+	client       *http.Client
+	jar          *cookiejar.Jar
+	config       config
+	globalConfig GlobalConfig
+}
+
+func (f *jsFetch) fetch(url string, options *jsFetchOptions) (*jsFetchResponse, error) {
+	var opts jsFetchOptions
+	if options != nil {
+		opts = *options
+	}
+
+	method := http.MethodGet
+	if opts.Method != "" {
+		method = strings.ToUpper(opts.Method)
+	}
+
+	var body io.Reader
+	if opts.Body != "" {
+		body = strings.NewReader(opts.Body)
+	}
+
+	req, err := http.NewRequestWithContext(f.ctx, method, url, body)
+	if err != nil {
+		return nil, err
+	}
+
+	setRequestHeaders(req, f.jar, f.config, f.globalConfig)
+
+	// headers passed to fetch override the configured ones
+	for k, v := range opts.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	f.jar.SetCookies(resp.Request.URL, resp.Cookies())
+
+	r, err := charset.NewReader(resp.Body, resp.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if f.config.DebugOptions != nil && f.config.DebugOptions.PrintHTML {
+		logger.Infof("fetch (%s) response: \n%s", url, string(b))
+	}
+
+	ret := &jsFetchResponse{
+		Status:  resp.StatusCode,
+		URL:     resp.Request.URL.String(),
+		Headers: make(map[string]string),
+		Body:    string(b),
+	}
+
+	for k := range resp.Header {
+		ret.Headers[strings.ToLower(k)] = resp.Header.Get(k)
+	}
+
+	return ret, nil
+}
+
+// jsHTML provides HTML parsing and XPath querying to javascript scrapers.
+type jsHTML struct{}
+
+// xpath returns the text of all nodes in the HTML document matching the
+// selector. Text is trimmed in the same way as the xpath scraper.
+func (h *jsHTML) xpath(doc string, selector string) ([]string, error) {
+	node, err := htmlquery.Parse(strings.NewReader(doc))
+	if err != nil {
+		return nil, fmt.Errorf("parsing html: %w", err)
+	}
+
+	q := &xpathQuery{doc: node}
+	return q.runQuery(selector)
+}
+
+// xpathHTML returns the outer HTML of all nodes in the HTML document
+// matching the selector.
+func (h *jsHTML) xpathHTML(doc string, selector string) ([]string, error) {
+	node, err := htmlquery.Parse(strings.NewReader(doc))
+	if err != nil {
+		return nil, fmt.Errorf("parsing html: %w", err)
+	}
+
+	found, err := htmlquery.QueryAll(node, selector)
+	if err != nil {
+		return nil, fmt.Errorf("selector '%s': parse error: %v", selector, err)
+	}
+
+	ret := make([]string, len(found))
+	for i, n := range found {
+		ret[i] = htmlquery.OutputHTML(n, true)
+	}
+
+	return ret, nil
+}
+
+func (h *jsHTML) AddToVM(globalName string, vm *javascript.VM) error {
+	obj := vm.NewObject()
+	if err := javascript.SetAll(obj,
+		javascript.ObjectValueDef{Name: "XPath", Value: h.xpath},
+		javascript.ObjectValueDef{Name: "XPathHTML", Value: h.xpathHTML},
+	); err != nil {
+		return err
+	}
+
+	if err := vm.Set(globalName, obj); err != nil {
+		return fmt.Errorf("unable to set html: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,180 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+)
+
+func TestJSScraper(t *testing.T) {
+	const performerHTML = `
+	<div>
+		<h1>  The   name </h1>
+		<span class="alias">alias 1</span>
+		<span class="alias">alias 2</span>
+	</div>
+	`
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if c, err := r.Cookie("session"); err != nil || c.Value != "abc" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		fmt.Fprint(w, performerHTML)
+	}))
+	defer ts.Close()
+
+	const script = `
+	var resp = fetch(input.url);
+	if (resp.status !== 200) {
+		throw new Error("unexpected status " + resp.status);
+	}
+
+	({
+		name: html.XPath(resp.body, "//h1")[0],
+		aliases: html.XPath(resp.body, "//span[@class='alias']").join(", "),
+		details: args.join(" "),
+	});
+	`
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "scraper.js"), []byte(script), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	yamlStr := `name: Test
+performerByURL:
+  - action: js
+    url:
+      - ` + ts.URL + `
+    script:
+      - scraper.js
+      - performer
+driver:
+  cookies:
+    - CookieURL: ` + ts.URL + `
+      Cookies:
+        - Name: session
+          Value: abc
+          Path: /
+`
+
+	c := &config{}
+	if err := yaml.Unmarshal([]byte(yamlStr), &c); err != nil {
+		t.Fatalf("Error loading yaml: %s", err.Error())
+	}
+	c.path = filepath.Join(dir, "test.yml")
+
+	if err := c.validate(); err != nil {
+		t.Fatalf("Error validating config: %s", err.Error())
+	}
+
+	s := newGroupScraper(*c, mockGlobalConfig{})
+	us, ok := s.(urlScraper)
+	if !ok {
+		t.Fatal("couldn't convert scraper into url scraper")
+	}
+
+	content, err := us.viaURL(context.Background(), &http.Client{}, ts.URL, ScrapeContentTypePerformer)
+	if err != nil {
+		t.Fatalf("Error scraping performer: %s", err.Error())
+	}
+
+	performer, ok := content.(*models.ScrapedPerformer)
+	if !ok {
+		t.Fatal("couldn't convert scraped content into a performer")
+	}
+
+	verifyField(t, "The name", performer.Name, "Name")
+	verifyField(t, "alias 1, alias 2", performer.Aliases, "Aliases")
+	verifyField(t, "performer", performer.Details, "Details")
+}
+
+func TestJSScraperError(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "scraper.js"), []byte(`throw new Error("failed");`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	c := config{
+		Name: "Test",
+		path: filepath.Join(dir, "test.yml"),
+	}
+	st := scraperTypeConfig{
+		Action: scraperActionJS,
+		Script: []string{"scraper.js"},
+	}
+
+	s := newJSScraper(st, &http.Client{}, c, mockGlobalConfig{})
+	_, err := s.scrapeByURL(context.Background(), "http://example.com", ScrapeContentTypePerformer)
+	assert.ErrorIs(t, err, ErrScraperScript)
+}
+
+func TestJSScraperFingerprint(t *testing.T) {
+	const script = `
+	var fp = {};
+	input.fingerprints.forEach(function (f) {
+		fp[f.type] = f.fingerprint;
+	});
+
+	[{
+		title: fp.oshash + " " + fp.md5 + " " + fp.phash,
+		details: String(input.duration),
+	}];
+	`
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "scraper.js"), []byte(script), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	c := config{
+		Name: "Test",
+		path: filepath.Join(dir, "test.yml"),
+		SceneByFingerprint: &scraperTypeConfig{
+			Action: scraperActionJS,
+			Script: []string{"scraper.js"},
+		},
+	}
+
+	scene := &models.Scene{ID: 1}
+	scene.Files.Set([]*models.VideoFile{
+		{
+			BaseFile: &models.BaseFile{
+				Fingerprints: models.Fingerprints{
+					{Type: models.FingerprintTypeOshash, Fingerprint: "abc"},
+					{Type: models.FingerprintTypeMD5, Fingerprint: "def"},
+					{Type: models.FingerprintTypePhash, Fingerprint: int64(255)},
+				},
+			},
+			Duration: 12.5,
+		},
+	})
+
+	s := newGroupScraper(c, mockGlobalConfig{})
+	fs, ok := s.(fingerprintScraper)
+	if !ok {
+		t.Fatal("couldn't convert scraper into fingerprint scraper")
+	}
+
+	scenes, err := fs.viaFingerprint(context.Background(), &http.Client{}, scene)
+	if err != nil {
+		t.Fatalf("Error scraping scene: %s", err.Error())
+	}
+
+	if !assert.Len(t, scenes, 1) {
+		return
+	}
+
+	verifyField(t, "abc def ff", scenes[0].Title, "Title")
+	verifyField(t, "12.5", scenes[0].Details, "Details")
+}
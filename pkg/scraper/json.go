@@ -190,6 +190,31 @@ func (s *jsonScraper) scrapeSceneByScene(ctx context.Context, scene *models.Scen
 	return scraper.scrapeScene(ctx, q)
 }
 
+func (s *jsonScraper) scrapeScenesByFingerprint(ctx context.Context, scene *models.Scene) ([]*ScrapedScene, error) {
+	// construct the URL
+	queryURL := queryURLParametersFromFingerprints(scene)
+	if s.scraper.QueryURLReplacements != nil {
+		queryURL.applyReplacements(s.scraper.QueryURLReplacements)
+	}
+	url := queryURL.constructURL(s.scraper.QueryURL)
+
+	scraper := s.getJsonScraper()
+
+	if scraper == nil {
+		return nil, errors.New("json scraper with name " + s.scraper.Scraper + " not found in config")
+	}
+
+	doc, err := s.loadURL(ctx, url)
+
+	if err != nil {
+		return nil, err
+	}
+
+	q := s.getJsonQuery(doc)
+	q.setType(SearchQuery)
+	return scraper.scrapeScenes(ctx, q)
+}
+
 func (s *jsonScraper) scrapeByFragment(ctx context.Context, input Input) (ScrapedContent, error) {
 	switch {
 	case input.Gallery != nil:
@@ -1,7 +1,9 @@
 package scraper
 
 import (
+	"math"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/stashapp/stash/pkg/models"
@@ -26,6 +28,25 @@ func queryURLParametersFromScene(scene *models.Scene) queryURLParameters {
 	return ret
 }
 
+func queryURLParametersFromFingerprints(scene *models.Scene) queryURLParameters {
+	ret := make(queryURLParameters)
+	in := sceneFingerprintInputFromScene(scene)
+	for _, fp := range in.Fingerprints {
+		ret[fp.Type] = fp.Fingerprint
+	}
+
+	// md5 is referred to as checksum elsewhere
+	if v, found := ret[models.FingerprintTypeMD5]; found {
+		ret["checksum"] = v
+	}
+
+	if in.Duration > 0 {
+		ret["duration"] = strconv.Itoa(int(math.Round(in.Duration)))
+	}
+
+	return ret
+}
+
 func queryURLParametersFromScrapedScene(scene ScrapedSceneInput) queryURLParameters {
 	ret := make(queryURLParameters)
 
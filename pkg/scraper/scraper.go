@@ -105,17 +105,20 @@ const (
 	ScrapeTypeFragment ScrapeType = "FRAGMENT"
 	// From URL
 	ScrapeTypeURL ScrapeType = "URL"
+	// From file fingerprints
+	ScrapeTypeFingerprint ScrapeType = "FINGERPRINT"
 )
 
 var AllScrapeType = []ScrapeType{
 	ScrapeTypeName,
 	ScrapeTypeFragment,
 	ScrapeTypeURL,
+	ScrapeTypeFingerprint,
 }
 
 func (e ScrapeType) IsValid() bool {
 	switch e {
-	case ScrapeTypeName, ScrapeTypeFragment, ScrapeTypeURL:
+	case ScrapeTypeName, ScrapeTypeFragment, ScrapeTypeURL, ScrapeTypeFingerprint:
 		return true
 	}
 	return false
@@ -225,6 +228,14 @@ type sceneScraper interface {
 	viaScene(ctx context.Context, client *http.Client, scene *models.Scene) (*ScrapedScene, error)
 }
 
+// fingerprintScraper is a scraper which supports scene scrapes using the
+// fingerprints of the scene's primary file as the input.
+type fingerprintScraper interface {
+	scraper
+
+	viaFingerprint(ctx context.Context, client *http.Client, scene *models.Scene) ([]*ScrapedScene, error)
+}
+
 // galleryScraper is a scraper which supports gallery scrapes with
 // gallery data as the input.
 type galleryScraper interface {
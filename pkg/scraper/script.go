@@ -151,6 +151,39 @@ func sceneInputFromScene(scene *models.Scene) sceneInput {
 	return ret
 }
 
+// sceneFingerprintInput is the input passed to the scraper for a fingerprint
+// query of an existing scene. Fingerprints and duration are taken from the
+// primary file of the scene.
+type sceneFingerprintInput struct {
+	ID           string             `json:"id"`
+	Fingerprints []fingerprintInput `json:"fingerprints"`
+	Duration     float64            `json:"duration,omitempty"`
+}
+
+func sceneFingerprintInputFromScene(scene *models.Scene) sceneFingerprintInput {
+	ret := sceneFingerprintInput{
+		ID: strconv.Itoa(scene.ID),
+	}
+
+	f := scene.Files.Primary()
+	if f == nil {
+		return ret
+	}
+
+	ret.Duration = f.Duration
+
+	for _, t := range []string{models.FingerprintTypeOshash, models.FingerprintTypeMD5, models.FingerprintTypePhash} {
+		if fp := f.Fingerprints.For(t); fp != nil {
+			ret.Fingerprints = append(ret.Fingerprints, fingerprintInput{
+				Type:        fp.Type,
+				Fingerprint: fp.Value(),
+			})
+		}
+	}
+
+	return ret
+}
+
 type galleryInput struct {
 	ID      string   `json:"id"`
 	Title   string   `json:"title"`
@@ -207,18 +240,28 @@ func galleryInputFromGallery(gallery *models.Gallery) galleryInput {
 
 var ErrScraperScript = errors.New("scraper script error")
 
+// scriptRunner runs a scraper script with the JSON-encoded input string,
+// decoding the script output into out.
+type scriptRunner func(ctx context.Context, inString string, out interface{}) error
+
 type scriptScraper struct {
 	scraper      scraperTypeConfig
 	config       config
 	globalConfig GlobalConfig
+
+	// run executes the script. Defaults to runScraperScript, which executes
+	// the script as an external process.
+	run scriptRunner
 }
 
 func newScriptScraper(scraper scraperTypeConfig, config config, globalConfig GlobalConfig) *scriptScraper {
-	return &scriptScraper{
+	ret := &scriptScraper{
 		scraper:      scraper,
 		config:       config,
 		globalConfig: globalConfig,
 	}
+	ret.run = ret.runScraperScript
+	return ret
 }
 
 func (s *scriptScraper) runScraperScript(ctx context.Context, inString string, out interface{}) error {
@@ -320,7 +363,7 @@ func (s *scriptScraper) scrapeByName(ctx context.Context, name string, ty Scrape
 	switch ty {
 	case ScrapeContentTypePerformer:
 		var performers []models.ScrapedPerformer
-		err = s.runScraperScript(ctx, input, &performers)
+		err = s.run(ctx, input, &performers)
 		if err == nil {
 			for _, p := range performers {
 				v := p
@@ -329,7 +372,7 @@ func (s *scriptScraper) scrapeByName(ctx context.Context, name string, ty Scrape
 		}
 	case ScrapeContentTypeScene:
 		var scenes []ScrapedScene
-		err = s.runScraperScript(ctx, input, &scenes)
+		err = s.run(ctx, input, &scenes)
 		if err == nil {
 			for _, s := range scenes {
 				v := s
@@ -374,19 +417,19 @@ func (s *scriptScraper) scrape(ctx context.Context, input string, ty ScrapeConte
 	switch ty {
 	case ScrapeContentTypePerformer:
 		var performer *models.ScrapedPerformer
-		err := s.runScraperScript(ctx, input, &performer)
+		err := s.run(ctx, input, &performer)
 		return performer, err
 	case ScrapeContentTypeGallery:
 		var gallery *ScrapedGallery
-		err := s.runScraperScript(ctx, input, &gallery)
+		err := s.run(ctx, input, &gallery)
 		return gallery, err
 	case ScrapeContentTypeScene:
 		var scene *ScrapedScene
-		err := s.runScraperScript(ctx, input, &scene)
+		err := s.run(ctx, input, &scene)
 		return scene, err
 	case ScrapeContentTypeMovie, ScrapeContentTypeGroup:
 		var movie *models.ScrapedMovie
-		err := s.runScraperScript(ctx, input, &movie)
+		err := s.run(ctx, input, &movie)
 		return movie, err
 	}
 
@@ -402,7 +445,21 @@ func (s *scriptScraper) scrapeSceneByScene(ctx context.Context, scene *models.Sc
 
 	var ret *ScrapedScene
 
-	err = s.runScraperScript(ctx, string(inString), &ret)
+	err = s.run(ctx, string(inString), &ret)
+
+	return ret, err
+}
+
+func (s *scriptScraper) scrapeScenesByFingerprint(ctx context.Context, scene *models.Scene) ([]*ScrapedScene, error) {
+	inString, err := json.Marshal(sceneFingerprintInputFromScene(scene))
+
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []*ScrapedScene
+
+	err = s.run(ctx, string(inString), &ret)
 
 	return ret, err
 }
@@ -416,7 +473,7 @@ func (s *scriptScraper) scrapeGalleryByGallery(ctx context.Context, gallery *mod
 
 	var ret *ScrapedGallery
 
-	err = s.runScraperScript(ctx, string(inString), &ret)
+	err = s.run(ctx, string(inString), &ret)
 
 	return ret, err
 }
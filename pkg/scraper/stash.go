@@ -262,6 +262,46 @@ func (s *stashScraper) scrapeSceneByScene(ctx context.Context, scene *models.Sce
 	return ret, nil
 }
 
+func (s *stashScraper) scrapeScenesByFingerprint(ctx context.Context, scene *models.Scene) ([]*ScrapedScene, error) {
+	// stash servers can only be queried by hash
+	var q struct {
+		FindScene *scrapedSceneStash `graphql:"findSceneByHash(input: $c)"`
+	}
+
+	type SceneHashInput struct {
+		Checksum *string `graphql:"checksum" json:"checksum"`
+		Oshash   *string `graphql:"oshash" json:"oshash"`
+	}
+
+	checksum := scene.Checksum
+	oshash := scene.OSHash
+
+	input := SceneHashInput{
+		Checksum: &checksum,
+		Oshash:   &oshash,
+	}
+
+	vars := map[string]interface{}{
+		"c": &input,
+	}
+
+	client := s.getStashClient()
+	if err := client.Query(ctx, &q, vars); err != nil {
+		return nil, err
+	}
+
+	if q.FindScene == nil {
+		return nil, nil
+	}
+
+	ret, err := s.scrapedStashSceneToScrapedScene(ctx, q.FindScene)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*ScrapedScene{ret}, nil
+}
+
 type scrapedGalleryStash struct {
 	ID         string                   `graphql:"id" json:"id"`
 	Title      *string                  `graphql:"title" json:"title"`
@@ -18,10 +18,17 @@ type StashBoxGraphQLClient interface {
 	FindPerformerByID(ctx context.Context, id string, httpRequestOptions ...client.HTTPRequestOption) (*FindPerformerByID, error)
 	FindSceneByID(ctx context.Context, id string, httpRequestOptions ...client.HTTPRequestOption) (*FindSceneByID, error)
 	FindStudio(ctx context.Context, id *string, name *string, httpRequestOptions ...client.HTTPRequestOption) (*FindStudio, error)
+	FindTag(ctx context.Context, id *string, name *string, httpRequestOptions ...client.HTTPRequestOption) (*FindTag, error)
+	QueryTags(ctx context.Context, input TagQueryInput, httpRequestOptions ...client.HTTPRequestOption) (*QueryTags, error)
 	SubmitFingerprint(ctx context.Context, input FingerprintSubmission, httpRequestOptions ...client.HTTPRequestOption) (*SubmitFingerprint, error)
 	Me(ctx context.Context, httpRequestOptions ...client.HTTPRequestOption) (*Me, error)
 	SubmitSceneDraft(ctx context.Context, input SceneDraftInput, httpRequestOptions ...client.HTTPRequestOption) (*SubmitSceneDraft, error)
 	SubmitPerformerDraft(ctx context.Context, input PerformerDraftInput, httpRequestOptions ...client.HTTPRequestOption) (*SubmitPerformerDraft, error)
+	QuerySites(ctx context.Context, httpRequestOptions ...client.HTTPRequestOption) (*QuerySites, error)
+	QueryTagCategories(ctx context.Context, httpRequestOptions ...client.HTTPRequestOption) (*QueryTagCategories, error)
+	CreateImage(ctx context.Context, input ImageCreateInput, httpRequestOptions ...client.HTTPRequestOption) (*CreateImage, error)
+	SubmitStudioEdit(ctx context.Context, input StudioEditInput, httpRequestOptions ...client.HTTPRequestOption) (*SubmitStudioEdit, error)
+	SubmitTagEdit(ctx context.Context, input TagEditInput, httpRequestOptions ...client.HTTPRequestOption) (*SubmitTagEdit, error)
 }
 
 type Client struct {
@@ -139,6 +146,17 @@ type TagFragment struct {
 	Name string "json:\"name\" graphql:\"name\""
 	ID   string "json:\"id\" graphql:\"id\""
 }
+type TagDetailsFragment struct {
+	Name        string   "json:\"name\" graphql:\"name\""
+	ID          string   "json:\"id\" graphql:\"id\""
+	Description *string  "json:\"description\" graphql:\"description\""
+	Aliases     []string "json:\"aliases\" graphql:\"aliases\""
+	Category    *struct {
+		Name  string       "json:\"name\" graphql:\"name\""
+		ID    string       "json:\"id\" graphql:\"id\""
+		Group TagGroupEnum "json:\"group\" graphql:\"group\""
+	} "json:\"category\" graphql:\"category\""
+}
 type FuzzyDateFragment struct {
 	Date     string           "json:\"date\" graphql:\"date\""
 	Accuracy DateAccuracyEnum "json:\"accuracy\" graphql:\"accuracy\""
@@ -223,6 +241,15 @@ type FindSceneByID struct {
 type FindStudio struct {
 	FindStudio *StudioFragment "json:\"findStudio\" graphql:\"findStudio\""
 }
+type FindTag struct {
+	FindTag *TagDetailsFragment "json:\"findTag\" graphql:\"findTag\""
+}
+type QueryTags struct {
+	QueryTags struct {
+		Count int                   "json:\"count\" graphql:\"count\""
+		Tags  []*TagDetailsFragment "json:\"tags\" graphql:\"tags\""
+	} "json:\"queryTags\" graphql:\"queryTags\""
+}
 type SubmitFingerprint struct {
 	SubmitFingerprint bool "json:\"submitFingerprint\" graphql:\"submitFingerprint\""
 }
@@ -241,6 +268,40 @@ type SubmitPerformerDraft struct {
 		ID *string "json:\"id\" graphql:\"id\""
 	} "json:\"submitPerformerDraft\" graphql:\"submitPerformerDraft\""
 }
+type QuerySites struct {
+	QuerySites struct {
+		Sites []*struct {
+			ID         string              "json:\"id\" graphql:\"id\""
+			Name       string              "json:\"name\" graphql:\"name\""
+			URL        *string             "json:\"url\" graphql:\"url\""
+			Regex      *string             "json:\"regex\" graphql:\"regex\""
+			ValidTypes []ValidSiteTypeEnum "json:\"valid_types\" graphql:\"valid_types\""
+		} "json:\"sites\" graphql:\"sites\""
+	} "json:\"querySites\" graphql:\"querySites\""
+}
+type QueryTagCategories struct {
+	QueryTagCategories struct {
+		TagCategories []*struct {
+			ID   string "json:\"id\" graphql:\"id\""
+			Name string "json:\"name\" graphql:\"name\""
+		} "json:\"tag_categories\" graphql:\"tag_categories\""
+	} "json:\"queryTagCategories\" graphql:\"queryTagCategories\""
+}
+type CreateImage struct {
+	ImageCreate *struct {
+		ID string "json:\"id\" graphql:\"id\""
+	} "json:\"imageCreate\" graphql:\"imageCreate\""
+}
+type SubmitStudioEdit struct {
+	StudioEdit struct {
+		ID string "json:\"id\" graphql:\"id\""
+	} "json:\"studioEdit\" graphql:\"studioEdit\""
+}
+type SubmitTagEdit struct {
+	TagEdit struct {
+		ID string "json:\"id\" graphql:\"id\""
+	} "json:\"tagEdit\" graphql:\"tagEdit\""
+}
 
 const FindSceneByFingerprintDocument = `query FindSceneByFingerprint ($fingerprint: FingerprintQueryInput!) {
 	findSceneByFingerprint(fingerprint: $fingerprint) {
@@ -1113,6 +1174,72 @@ func (c *Client) FindStudio(ctx context.Context, id *string, name *string, httpR
 	return &res, nil
 }
 
+const FindTagDocument = `query FindTag ($id: ID, $name: String) {
+	findTag(id: $id, name: $name) {
+		... TagDetailsFragment
+	}
+}
+fragment TagDetailsFragment on Tag {
+	name
+	id
+	description
+	aliases
+	category {
+		name
+		id
+		group
+	}
+}
+`
+
+func (c *Client) FindTag(ctx context.Context, id *string, name *string, httpRequestOptions ...client.HTTPRequestOption) (*FindTag, error) {
+	vars := map[string]interface{}{
+		"id":   id,
+		"name": name,
+	}
+
+	var res FindTag
+	if err := c.Client.Post(ctx, "FindTag", FindTagDocument, &res, vars, httpRequestOptions...); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+const QueryTagsDocument = `query QueryTags ($input: TagQueryInput!) {
+	queryTags(input: $input) {
+		count
+		tags {
+			... TagDetailsFragment
+		}
+	}
+}
+fragment TagDetailsFragment on Tag {
+	name
+	id
+	description
+	aliases
+	category {
+		name
+		id
+		group
+	}
+}
+`
+
+func (c *Client) QueryTags(ctx context.Context, input TagQueryInput, httpRequestOptions ...client.HTTPRequestOption) (*QueryTags, error) {
+	vars := map[string]interface{}{
+		"input": input,
+	}
+
+	var res QueryTags
+	if err := c.Client.Post(ctx, "QueryTags", QueryTagsDocument, &res, vars, httpRequestOptions...); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
 const SubmitFingerprintDocument = `mutation SubmitFingerprint ($input: FingerprintSubmission!) {
 	submitFingerprint(input: $input)
 }
@@ -1188,3 +1315,108 @@ func (c *Client) SubmitPerformerDraft(ctx context.Context, input PerformerDraftI
 
 	return &res, nil
 }
+
+const QuerySitesDocument = `query QuerySites {
+	querySites {
+		sites {
+			id
+			name
+			url
+			regex
+			valid_types
+		}
+	}
+}
+`
+
+func (c *Client) QuerySites(ctx context.Context, httpRequestOptions ...client.HTTPRequestOption) (*QuerySites, error) {
+	vars := map[string]interface{}{}
+
+	var res QuerySites
+	if err := c.Client.Post(ctx, "QuerySites", QuerySitesDocument, &res, vars, httpRequestOptions...); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+const QueryTagCategoriesDocument = `query QueryTagCategories {
+	queryTagCategories {
+		tag_categories {
+			id
+			name
+		}
+	}
+}
+`
+
+func (c *Client) QueryTagCategories(ctx context.Context, httpRequestOptions ...client.HTTPRequestOption) (*QueryTagCategories, error) {
+	vars := map[string]interface{}{}
+
+	var res QueryTagCategories
+	if err := c.Client.Post(ctx, "QueryTagCategories", QueryTagCategoriesDocument, &res, vars, httpRequestOptions...); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+const CreateImageDocument = `mutation CreateImage ($input: ImageCreateInput!) {
+	imageCreate(input: $input) {
+		id
+	}
+}
+`
+
+func (c *Client) CreateImage(ctx context.Context, input ImageCreateInput, httpRequestOptions ...client.HTTPRequestOption) (*CreateImage, error) {
+	vars := map[string]interface{}{
+		"input": input,
+	}
+
+	var res CreateImage
+	if err := c.Client.Post(ctx, "CreateImage", CreateImageDocument, &res, vars, httpRequestOptions...); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+const SubmitStudioEditDocument = `mutation SubmitStudioEdit ($input: StudioEditInput!) {
+	studioEdit(input: $input) {
+		id
+	}
+}
+`
+
+func (c *Client) SubmitStudioEdit(ctx context.Context, input StudioEditInput, httpRequestOptions ...client.HTTPRequestOption) (*SubmitStudioEdit, error) {
+	vars := map[string]interface{}{
+		"input": input,
+	}
+
+	var res SubmitStudioEdit
+	if err := c.Client.Post(ctx, "SubmitStudioEdit", SubmitStudioEditDocument, &res, vars, httpRequestOptions...); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+const SubmitTagEditDocument = `mutation SubmitTagEdit ($input: TagEditInput!) {
+	tagEdit(input: $input) {
+		id
+	}
+}
+`
+
+func (c *Client) SubmitTagEdit(ctx context.Context, input TagEditInput, httpRequestOptions ...client.HTTPRequestOption) (*SubmitTagEdit, error) {
+	vars := map[string]interface{}{
+		"input": input,
+	}
+
+	var res SubmitTagEdit
+	if err := c.Client.Post(ctx, "SubmitTagEdit", SubmitTagEditDocument, &res, vars, httpRequestOptions...); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
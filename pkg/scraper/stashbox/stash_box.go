@@ -10,6 +10,7 @@ import (
 	"io"
 	"mime/multipart"
 	"net/http"
+	"regexp"
 	"strconv"
 	"strings"
 
@@ -50,11 +51,15 @@ type StudioReader interface {
 	models.StudioGetter
 	match.StudioFinder
 	models.StashIDLoader
+	GetImage(ctx context.Context, studioID int) ([]byte, error)
 }
 
 type TagFinder interface {
-	models.TagQueryer
+	match.TagFinder
+	models.AliasLoader
+	models.StashIDLoader
 	FindBySceneID(ctx context.Context, sceneID int) ([]*models.Tag, error)
+	FindByChildTagID(ctx context.Context, childID int) ([]*models.Tag, error)
 }
 
 type Repository struct {
@@ -139,6 +144,21 @@ func (c Client) FindStashBoxSceneByFingerprints(ctx context.Context, sceneID int
 	return nil, err
 }
 
+// FindStashBoxSceneByID queries stash-box for a scene using its stash-box ID.
+// Returns nil if the scene was not found.
+func (c Client) FindStashBoxSceneByID(ctx context.Context, id string) (*scraper.ScrapedScene, error) {
+	scene, err := c.client.FindSceneByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if scene.FindScene == nil {
+		return nil, nil
+	}
+
+	return c.sceneFragmentToScrapedScene(ctx, scene.FindScene)
+}
+
 // FindStashBoxScenesByFingerprints queries stash-box for scenes using every
 // scene's MD5/OSHASH checksum, or PHash, and returns results in the same order
 // as the input slice.
@@ -633,6 +653,7 @@ func performerFragmentToScrapedPerformer(p graphql.PerformerFragment) *models.Sc
 		Piercings:      formatBodyModifications(p.Piercings),
 		Twitter:        findURL(p.Urls, "TWITTER"),
 		RemoteSiteID:   &p.ID,
+		MergedIDs:      p.MergedIds,
 		Images:         images,
 		// TODO - tags not currently supported
 		// graphql schema change to accommodate this. Leave off for now.
@@ -812,15 +833,20 @@ func (c Client) sceneFragmentToScrapedScene(ctx context.Context, s *graphql.Scen
 				return err
 			}
 
+			if err := match.ScrapedPerformerMergedIDs(ctx, pqb, sp, c.box.Endpoint); err != nil {
+				return err
+			}
+
 			ss.Performers = append(ss.Performers, sp)
 		}
 
 		for _, t := range s.Tags {
 			st := &models.ScrapedTag{
-				Name: t.Name,
+				Name:         t.Name,
+				RemoteSiteID: &t.ID,
 			}
 
-			err := match.ScrapedTag(ctx, tqb, st)
+			err := match.ScrapedTag(ctx, tqb, st, &c.box.Endpoint)
 			if err != nil {
 				return err
 			}
@@ -850,8 +876,11 @@ func (c Client) FindStashBoxPerformerByID(ctx context.Context, id string) (*mode
 
 	r := c.repository
 	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
-		err := match.ScrapedPerformer(ctx, r.Performer, ret, &c.box.Endpoint)
-		return err
+		if err := match.ScrapedPerformer(ctx, r.Performer, ret, &c.box.Endpoint); err != nil {
+			return err
+		}
+
+		return match.ScrapedPerformerMergedIDs(ctx, r.Performer, ret, c.box.Endpoint)
 	}); err != nil {
 		return nil, err
 	}
@@ -878,8 +907,11 @@ func (c Client) FindStashBoxPerformerByName(ctx context.Context, name string) (*
 
 	r := c.repository
 	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
-		err := match.ScrapedPerformer(ctx, r.Performer, ret, &c.box.Endpoint)
-		return err
+		if err := match.ScrapedPerformer(ctx, r.Performer, ret, &c.box.Endpoint); err != nil {
+			return err
+		}
+
+		return match.ScrapedPerformerMergedIDs(ctx, r.Performer, ret, c.box.Endpoint)
 	}); err != nil {
 		return nil, err
 	}
@@ -938,6 +970,97 @@ func (c Client) FindStashBoxStudio(ctx context.Context, query string) (*models.S
 	return ret, nil
 }
 
+func tagFragmentToScrapedTag(t graphql.TagDetailsFragment) *models.ScrapedTag {
+	ret := &models.ScrapedTag{
+		Name:         t.Name,
+		Description:  t.Description,
+		Aliases:      t.Aliases,
+		RemoteSiteID: &t.ID,
+	}
+
+	// stash-box has no tag hierarchy, so the category is represented
+	// as a parent tag
+	if t.Category != nil {
+		ret.Parent = &models.ScrapedTag{
+			Name: t.Category.Name,
+		}
+	}
+
+	return ret
+}
+
+// FindStashBoxTag finds a tag on the stash-box instance by stash ID, or
+// by exact name or alias if query is not a valid stash ID.
+func (c Client) FindStashBoxTag(ctx context.Context, query string) (*models.ScrapedTag, error) {
+	var found *graphql.TagDetailsFragment
+
+	_, err := uuid.FromString(query)
+	if err == nil {
+		// Confirmed the user passed in a Stash ID
+		tag, err := c.client.FindTag(ctx, &query, nil)
+		if err != nil {
+			return nil, err
+		}
+		found = tag.FindTag
+	} else {
+		// Otherwise search on names and aliases, and only accept an exact match
+		tags, err := c.client.QueryTags(ctx, graphql.TagQueryInput{
+			Names:     &query,
+			Page:      1,
+			PerPage:   25,
+			Direction: graphql.SortDirectionEnumAsc,
+			Sort:      graphql.TagSortEnumName,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		found = findTagByNameOrAlias(tags.QueryTags.Tags, query)
+	}
+
+	if found == nil {
+		return nil, nil
+	}
+
+	ret := tagFragmentToScrapedTag(*found)
+
+	r := c.repository
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		if err := match.ScrapedTag(ctx, r.Tag, ret, &c.box.Endpoint); err != nil {
+			return err
+		}
+
+		if ret.Parent != nil {
+			return match.ScrapedTag(ctx, r.Tag, ret.Parent, nil)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func findTagByNameOrAlias(tags []*graphql.TagDetailsFragment, name string) *graphql.TagDetailsFragment {
+	// prefer a name match over an alias match
+	for _, t := range tags {
+		if strings.EqualFold(t.Name, name) {
+			return t
+		}
+	}
+
+	for _, t := range tags {
+		for _, a := range t.Aliases {
+			if strings.EqualFold(a, name) {
+				return t
+			}
+		}
+	}
+
+	return nil
+}
+
 func (c Client) GetUser(ctx context.Context) (*graphql.Me, error) {
 	return c.client.Me(ctx)
 }
@@ -1238,6 +1361,216 @@ func (c Client) SubmitPerformerDraft(ctx context.Context, performer *models.Perf
 	// return id, nil
 }
 
+// SubmitStudioEdit submits the studio to stash-box as a studio edit, and returns
+// the ID of the edit. stash-box does not support studio drafts, so the edit is
+// created directly. If the studio is already linked to the stash-box instance,
+// the edit modifies the linked studio, otherwise it creates a new one. Aliases
+// are not sent, as stash-box studio edits have no aliases field.
+func (c Client) SubmitStudioEdit(ctx context.Context, studio *models.Studio) (*string, error) {
+	sqb := c.repository.Studio
+	endpoint := c.box.Endpoint
+
+	if err := studio.LoadStashIDs(ctx, sqb); err != nil {
+		return nil, err
+	}
+
+	details := graphql.StudioEditDetailsInput{
+		Name: &studio.Name,
+	}
+
+	if studio.ParentID != nil {
+		parentStashIDs, err := sqb.GetStashIDs(ctx, *studio.ParentID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, v := range parentStashIDs {
+			if v.Endpoint == endpoint {
+				parentID := v.StashID
+				details.ParentID = &parentID
+				break
+			}
+		}
+
+		if details.ParentID == nil {
+			logger.Warnf("Parent studio of %s is not linked to %s, omitting from edit", studio.Name, endpoint)
+		}
+	}
+
+	if studio.URL != "" {
+		urls, err := c.siteURLs(ctx, []string{studio.URL}, graphql.ValidSiteTypeEnumStudio)
+		if err != nil {
+			return nil, err
+		}
+		details.Urls = urls
+	}
+
+	img, err := sqb.GetImage(ctx, studio.ID)
+	if err != nil {
+		return nil, fmt.Errorf("getting studio image: %w", err)
+	}
+	if len(img) > 0 {
+		imageID, err := c.uploadImage(ctx, bytes.NewReader(img))
+		if err != nil {
+			return nil, fmt.Errorf("uploading studio image: %w", err)
+		}
+		details.ImageIds = []string{imageID}
+	}
+
+	ret, err := c.client.SubmitStudioEdit(ctx, graphql.StudioEditInput{
+		Edit:    editInput(studio.StashIDs.ForEndpoint(endpoint)),
+		Details: &details,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ret.StudioEdit.ID, nil
+}
+
+// SubmitTagEdit submits the tag to stash-box as a tag edit, and returns the
+// ID of the edit. As with studios, the edit is created directly. The category
+// is set to the stash-box tag category matching the name of a parent tag, which
+// is the inverse of how batch tagging maps categories to parent tags.
+func (c Client) SubmitTagEdit(ctx context.Context, tag *models.Tag) (*string, error) {
+	tqb := c.repository.Tag
+	endpoint := c.box.Endpoint
+
+	if err := tag.LoadAliases(ctx, tqb); err != nil {
+		return nil, err
+	}
+
+	if err := tag.LoadStashIDs(ctx, tqb); err != nil {
+		return nil, err
+	}
+
+	details := graphql.TagEditDetailsInput{
+		Name: &tag.Name,
+	}
+
+	if tag.Description != "" {
+		details.Description = &tag.Description
+	}
+
+	if len(tag.Aliases.List()) > 0 {
+		details.Aliases = tag.Aliases.List()
+	}
+
+	categoryID, err := c.tagCategoryID(ctx, tag)
+	if err != nil {
+		return nil, err
+	}
+	details.CategoryID = categoryID
+
+	ret, err := c.client.SubmitTagEdit(ctx, graphql.TagEditInput{
+		Edit:    editInput(tag.StashIDs.ForEndpoint(endpoint)),
+		Details: &details,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ret.TagEdit.ID, nil
+}
+
+// tagCategoryID returns the ID of the stash-box tag category with the same name
+// as one of the parent tags of tag, or nil if there is none.
+func (c Client) tagCategoryID(ctx context.Context, tag *models.Tag) (*string, error) {
+	parents, err := c.repository.Tag.FindByChildTagID(ctx, tag.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(parents) == 0 {
+		return nil, nil
+	}
+
+	categories, err := c.client.QueryTagCategories(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("querying tag categories: %w", err)
+	}
+
+	for _, p := range parents {
+		for _, cat := range categories.QueryTagCategories.TagCategories {
+			if strings.EqualFold(p.Name, cat.Name) {
+				return &cat.ID, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// editInput returns an edit modifying the object with the provided stash ID,
+// or creating a new object if stashID is nil.
+func editInput(stashID *models.StashID) *graphql.EditInput {
+	if stashID == nil {
+		return &graphql.EditInput{
+			Operation: graphql.OperationEnumCreate,
+		}
+	}
+
+	return &graphql.EditInput{
+		ID:        &stashID.StashID,
+		Operation: graphql.OperationEnumModify,
+	}
+}
+
+// siteURLs returns the provided urls paired with the stash-box site that
+// matches them. URLs that do not match a site of the provided type are omitted.
+func (c Client) siteURLs(ctx context.Context, urls []string, siteType graphql.ValidSiteTypeEnum) ([]*graphql.URLInput, error) {
+	sites, err := c.client.QuerySites(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []*graphql.URLInput
+	for _, u := range urls {
+		var siteID string
+		for _, s := range sites.QuerySites.Sites {
+			if s.Regex == nil || *s.Regex == "" || !sliceutil.Contains(s.ValidTypes, siteType) {
+				continue
+			}
+
+			re, err := regexp.Compile(*s.Regex)
+			if err != nil {
+				logger.Debugf("Invalid regex for stash-box site %s: %v", s.Name, err)
+				continue
+			}
+
+			if re.MatchString(u) {
+				siteID = s.ID
+				break
+			}
+		}
+
+		if siteID == "" {
+			logger.Warnf("URL %s does not match a stash-box site, omitting from edit", u)
+			continue
+		}
+
+		ret = append(ret, &graphql.URLInput{
+			URL:    u,
+			SiteID: siteID,
+		})
+	}
+
+	return ret, nil
+}
+
+func (c *Client) uploadImage(ctx context.Context, image io.Reader) (string, error) {
+	var ret graphql.CreateImage
+	if err := c.submitMultipart(ctx, graphql.CreateImageDocument, graphql.ImageCreateInput{}, "file", image, &ret); err != nil {
+		return "", err
+	}
+
+	if ret.ImageCreate == nil {
+		return "", errors.New("no image returned")
+	}
+
+	return ret.ImageCreate.ID, nil
+}
+
 // we can't currently use this due to https://github.com/Yamashou/gqlgenc/issues/109
 // func uploadImage(image io.Reader) client.HTTPRequestOption {
 // 	return func(req *http.Request) {
@@ -1277,6 +1610,12 @@ func (c Client) SubmitPerformerDraft(ctx context.Context, performer *models.Perf
 // }
 
 func (c *Client) submitDraft(ctx context.Context, query string, input interface{}, image io.Reader, ret interface{}) error {
+	return c.submitMultipart(ctx, query, input, "image", image, ret)
+}
+
+// submitMultipart posts the query as a multipart request, uploading image
+// as the fileField field of the input variable if it is not nil.
+func (c *Client) submitMultipart(ctx context.Context, query string, input interface{}, fileField string, image io.Reader, ret interface{}) error {
 	vars := map[string]interface{}{
 		"input": input,
 	}
@@ -1299,7 +1638,7 @@ func (c *Client) submitDraft(ctx context.Context, query string, input interface{
 	}
 
 	if image != nil {
-		if err := writer.WriteField("map", "{ \"0\": [\"variables.input.image\"] }"); err != nil {
+		if err := writer.WriteField("map", fmt.Sprintf("{ \"0\": [\"variables.input.%s\"] }", fileField)); err != nil {
 			return err
 		}
 		part, _ := writer.CreateFormFile("0", "draft")
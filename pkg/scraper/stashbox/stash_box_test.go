@@ -0,0 +1,266 @@
+package stashbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+)
+
+// testStashBox is a stash-box server recording the edits submitted to it.
+type testStashBox struct {
+	// inputs are the inputs of the submitted edits, by operation name
+	inputs map[string]json.RawMessage
+	// images is the number of images uploaded
+	images int
+}
+
+func (b *testStashBox) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// images are uploaded as multipart requests
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		b.images++
+		w.Write([]byte(`{"data":{"imageCreate":{"id":"image-1"}}}`))
+		return
+	}
+
+	var req struct {
+		OperationName string `json:"operationName"`
+		Variables     struct {
+			Input json.RawMessage `json:"input"`
+		} `json:"variables"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	b.inputs[req.OperationName] = req.Variables.Input
+
+	switch req.OperationName {
+	case "QuerySites":
+		w.Write([]byte(`{"data":{"querySites":{"sites":[{"id":"site-1","name":"Site","regex":"^https://site\\.com/","valid_types":["STUDIO"]}]}}}`))
+	case "QueryTagCategories":
+		w.Write([]byte(`{"data":{"queryTagCategories":{"tag_categories":[{"id":"category-1","name":"Category"}]}}}`))
+	case "SubmitStudioEdit":
+		w.Write([]byte(`{"data":{"studioEdit":{"id":"studio-edit"}}}`))
+	case "SubmitTagEdit":
+		w.Write([]byte(`{"data":{"tagEdit":{"id":"tag-edit"}}}`))
+	default:
+		http.Error(w, "unexpected operation "+req.OperationName, http.StatusBadRequest)
+	}
+}
+
+func newTestClient(t *testing.T, repo Repository) (*Client, *testStashBox) {
+	box := &testStashBox{inputs: make(map[string]json.RawMessage)}
+	srv := httptest.NewServer(box)
+	t.Cleanup(srv.Close)
+
+	return NewClient(models.StashBox{Endpoint: srv.URL}, repo), box
+}
+
+func TestClient_SubmitStudioEdit(t *testing.T) {
+	const (
+		studioID = 1
+		parentID = 2
+	)
+
+	tests := []struct {
+		name       string
+		stashID    string
+		image      []byte
+		imageErr   error
+		wantEdit   string
+		wantErr    bool
+		wantImages int
+	}{
+		{
+			name:     "create",
+			wantEdit: `{"operation":"CREATE"}`,
+		},
+		{
+			name:     "modify linked studio",
+			stashID:  "stash-id",
+			wantEdit: `{"id":"stash-id","operation":"MODIFY"}`,
+		},
+		{
+			name:       "with image",
+			image:      []byte("image"),
+			wantEdit:   `{"operation":"CREATE"}`,
+			wantImages: 1,
+		},
+		{
+			name:     "image error",
+			imageErr: errors.New("image error"),
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := mocks.NewDatabase()
+			c, box := newTestClient(t, NewRepository(db.Repository()))
+
+			var stashIDs []models.StashID
+			if tt.stashID != "" {
+				stashIDs = append(stashIDs, models.StashID{Endpoint: c.box.Endpoint, StashID: tt.stashID})
+			}
+			db.Studio.On("GetStashIDs", mock.Anything, studioID).Return(stashIDs, nil)
+			db.Studio.On("GetStashIDs", mock.Anything, parentID).Return([]models.StashID{{Endpoint: c.box.Endpoint, StashID: "parent-stash-id"}}, nil)
+			db.Studio.On("GetImage", mock.Anything, studioID).Return(tt.image, tt.imageErr)
+
+			parent := parentID
+			studio := &models.Studio{
+				ID:       studioID,
+				Name:     "Studio",
+				URL:      "https://site.com/studio",
+				ParentID: &parent,
+			}
+
+			got, err := c.SubmitStudioEdit(context.Background(), studio)
+			if tt.wantErr {
+				assert.NotNil(t, err)
+				assert.NotContains(t, box.inputs, "SubmitStudioEdit")
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("SubmitStudioEdit() error = %v", err)
+			}
+			assert.Equal(t, "studio-edit", *got)
+			assert.Equal(t, tt.wantImages, box.images)
+
+			var input struct {
+				Edit    json.RawMessage `json:"edit"`
+				Details struct {
+					Name     string   `json:"name"`
+					ParentID *string  `json:"parent_id"`
+					ImageIds []string `json:"image_ids"`
+					Urls     []struct {
+						URL    string `json:"url"`
+						SiteID string `json:"site_id"`
+					} `json:"urls"`
+				} `json:"details"`
+			}
+			if err := json.Unmarshal(box.inputs["SubmitStudioEdit"], &input); err != nil {
+				t.Fatalf("decoding edit input: %v", err)
+			}
+
+			assert.JSONEq(t, tt.wantEdit, string(input.Edit))
+			assert.Equal(t, "Studio", input.Details.Name)
+			if assert.NotNil(t, input.Details.ParentID) {
+				assert.Equal(t, "parent-stash-id", *input.Details.ParentID)
+			}
+			if assert.Len(t, input.Details.Urls, 1) {
+				assert.Equal(t, "site-1", input.Details.Urls[0].SiteID)
+			}
+			if tt.wantImages > 0 {
+				assert.Equal(t, []string{"image-1"}, input.Details.ImageIds)
+			} else {
+				assert.Empty(t, input.Details.ImageIds)
+			}
+		})
+	}
+}
+
+func TestClient_SubmitTagEdit(t *testing.T) {
+	const tagID = 1
+
+	categoryID := "category-1"
+
+	tests := []struct {
+		name         string
+		aliases      []string
+		parents      []string
+		stashID      string
+		wantEdit     string
+		wantCategory *string
+	}{
+		{
+			name:     "create",
+			wantEdit: `{"operation":"CREATE"}`,
+		},
+		{
+			name:     "modify linked tag",
+			aliases:  []string{"alias"},
+			stashID:  "stash-id",
+			wantEdit: `{"id":"stash-id","operation":"MODIFY"}`,
+		},
+		{
+			name:         "parent matches category",
+			parents:      []string{"Other", "category"},
+			wantEdit:     `{"operation":"CREATE"}`,
+			wantCategory: &categoryID,
+		},
+		{
+			name:     "parent does not match category",
+			parents:  []string{"Other"},
+			wantEdit: `{"operation":"CREATE"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := mocks.NewDatabase()
+			c, box := newTestClient(t, NewRepository(db.Repository()))
+
+			var stashIDs []models.StashID
+			if tt.stashID != "" {
+				stashIDs = append(stashIDs, models.StashID{Endpoint: c.box.Endpoint, StashID: tt.stashID})
+			}
+			db.Tag.On("GetAliases", mock.Anything, tagID).Return(tt.aliases, nil)
+			db.Tag.On("GetStashIDs", mock.Anything, tagID).Return(stashIDs, nil)
+
+			var parents []*models.Tag
+			for i, name := range tt.parents {
+				parents = append(parents, &models.Tag{ID: tagID + i + 1, Name: name})
+			}
+			db.Tag.On("FindByChildTagID", mock.Anything, tagID).Return(parents, nil)
+
+			tag := &models.Tag{
+				ID:          tagID,
+				Name:        "Tag",
+				Description: "description",
+			}
+
+			got, err := c.SubmitTagEdit(context.Background(), tag)
+			if err != nil {
+				t.Fatalf("SubmitTagEdit() error = %v", err)
+			}
+			assert.Equal(t, "tag-edit", *got)
+
+			var input struct {
+				Edit    json.RawMessage `json:"edit"`
+				Details struct {
+					Name        string   `json:"name"`
+					Description *string  `json:"description"`
+					Aliases     []string `json:"aliases"`
+					CategoryID  *string  `json:"category_id"`
+				} `json:"details"`
+			}
+			if err := json.Unmarshal(box.inputs["SubmitTagEdit"], &input); err != nil {
+				t.Fatalf("decoding edit input: %v", err)
+			}
+
+			assert.JSONEq(t, tt.wantEdit, string(input.Edit))
+			assert.Equal(t, "Tag", input.Details.Name)
+			if assert.NotNil(t, input.Details.Description) {
+				assert.Equal(t, "description", *input.Details.Description)
+			}
+			assert.Equal(t, tt.aliases, input.Details.Aliases)
+			assert.Equal(t, tt.wantCategory, input.Details.CategoryID)
+
+			// categories are only queried for tags with parents
+			_, queried := box.inputs["QueryTagCategories"]
+			assert.Equal(t, len(tt.parents) > 0, queried)
+		})
+	}
+}
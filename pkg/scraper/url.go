@@ -42,13 +42,34 @@ func loadURL(ctx context.Context, loadURL string, client *http.Client, scraperCo
 		return nil, fmt.Errorf("error creating cookie jar: %w", err)
 	}
 
-	u, err := url.Parse(loadURL)
+	setRequestHeaders(req, jar, scraperConfig, globalConfig)
+
+	resp, err := client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing url %s: %w", loadURL, err)
+		return nil, err
+	}
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("http error %d:%s", resp.StatusCode, http.StatusText(resp.StatusCode))
 	}
 
-	// Fetch relevant cookies from the jar for url u and add them to the request
-	cookies := jar.Cookies(u)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	bodyReader := bytes.NewReader(body)
+	printCookies(jar, scraperConfig, "Jar cookies found for scraper urls")
+	return charset.NewReader(bodyReader, resp.Header.Get("Content-Type"))
+}
+
+// setRequestHeaders adds the cookies from jar that are relevant to the
+// request URL, the configured user agent and any headers from the scraper
+// driver options to req.
+func setRequestHeaders(req *http.Request, jar http.CookieJar, scraperConfig config, globalConfig GlobalConfig) {
+	// Fetch relevant cookies from the jar for the request url and add them to the request
+	cookies := jar.Cookies(req.URL)
 	for _, cookie := range cookies {
 		req.AddCookie(cookie)
 	}
@@ -58,6 +79,7 @@ func loadURL(ctx context.Context, loadURL string, client *http.Client, scraperCo
 		req.Header.Set("User-Agent", userAgent)
 	}
 
+	driverOptions := scraperConfig.DriverOptions
 	if driverOptions != nil { // setting the Headers after the UA allows us to override it from inside the scraper
 		for _, h := range driverOptions.Headers {
 			if h.Key != "" {
@@ -66,25 +88,6 @@ func loadURL(ctx context.Context, loadURL string, client *http.Client, scraperCo
 			}
 		}
 	}
-
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	if resp.StatusCode >= 400 {
-		return nil, fmt.Errorf("http error %d:%s", resp.StatusCode, http.StatusText(resp.StatusCode))
-	}
-
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	bodyReader := bytes.NewReader(body)
-	printCookies(jar, scraperConfig, "Jar cookies found for scraper urls")
-	return charset.NewReader(bodyReader, resp.Header.Get("Content-Type"))
 }
 
 // func urlFromCDP uses chrome cdp and DOM to load and process the url
@@ -169,6 +169,31 @@ func (s *xpathScraper) scrapeSceneByScene(ctx context.Context, scene *models.Sce
 	return scraper.scrapeScene(ctx, q)
 }
 
+func (s *xpathScraper) scrapeScenesByFingerprint(ctx context.Context, scene *models.Scene) ([]*ScrapedScene, error) {
+	// construct the URL
+	queryURL := queryURLParametersFromFingerprints(scene)
+	if s.scraper.QueryURLReplacements != nil {
+		queryURL.applyReplacements(s.scraper.QueryURLReplacements)
+	}
+	url := queryURL.constructURL(s.scraper.QueryURL)
+
+	scraper := s.getXpathScraper()
+
+	if scraper == nil {
+		return nil, errors.New("xpath scraper with name " + s.scraper.Scraper + " not found in config")
+	}
+
+	doc, err := s.loadURL(ctx, url)
+
+	if err != nil {
+		return nil, err
+	}
+
+	q := s.getXPathQuery(doc)
+	q.setType(SearchQuery)
+	return scraper.scrapeScenes(ctx, q)
+}
+
 func (s *xpathScraper) scrapeByFragment(ctx context.Context, input Input) (ScrapedContent, error) {
 	switch {
 	case input.Gallery != nil:
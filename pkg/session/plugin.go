@@ -33,6 +33,9 @@ func (s *Store) VisitedPluginHandler() func(http.Handler) http.Handler {
 				visitedPlugins, _ := val.([]VisitedPluginHook)
 
 				ctx := setVisitedPluginHooks(r.Context(), visitedPlugins)
+				if pluginID, _ := session.Values[pluginIDKey].(string); pluginID != "" {
+					ctx = SetCurrentPluginID(ctx, pluginID)
+				}
 				r = r.WithContext(ctx)
 			}
 
@@ -60,6 +63,18 @@ func setVisitedPluginHooks(ctx context.Context, visitedPlugins []VisitedPluginHo
 	return context.WithValue(ctx, contextVisitedPlugins, visitedPlugins)
 }
 
+// SetCurrentPluginID sets the id of the plugin making the request.
+func SetCurrentPluginID(ctx context.Context, pluginID string) context.Context {
+	return context.WithValue(ctx, contextPluginID, pluginID)
+}
+
+// GetCurrentPluginID returns the id of the plugin making the request, or an
+// empty string if the request was not made by a plugin.
+func GetCurrentPluginID(ctx context.Context) string {
+	v, _ := ctx.Value(contextPluginID).(string)
+	return v
+}
+
 func (s *Store) MakePluginCookie(ctx context.Context) *http.Cookie {
 	currentUser := GetCurrentUserID(ctx)
 	visitedPlugins := GetVisitedPluginHooks(ctx)
@@ -69,6 +84,10 @@ func (s *Store) MakePluginCookie(ctx context.Context) *http.Cookie {
 		session.Values[userIDKey] = *currentUser
 	}
 
+	if pluginID := GetCurrentPluginID(ctx); pluginID != "" {
+		session.Values[pluginIDKey] = pluginID
+	}
+
 	session.Values[visitedPluginHooksKey] = visitedPlugins
 
 	encoded, err := securecookie.EncodeMulti(session.Name(), session.Values,
@@ -15,11 +15,14 @@ type key int
 const (
 	contextUser key = iota
 	contextVisitedPlugins
+	contextAPIKey
+	contextPluginID
 )
 
 const (
 	userIDKey             = "userID"
 	visitedPluginHooksKey = "visitedPluginsHooks"
+	pluginIDKey           = "pluginID"
 )
 
 const (
@@ -146,10 +149,20 @@ func GetCurrentUserID(ctx context.Context) *string {
 	return nil
 }
 
-func (s *Store) Authenticate(w http.ResponseWriter, r *http.Request) (userID string, err error) {
-	c := s.config
+// SetUsingAPIKey sets that the request was authenticated using the API key.
+func SetUsingAPIKey(ctx context.Context) context.Context {
+	return context.WithValue(ctx, contextAPIKey, true)
+}
 
-	// translate api key into current user, if present
+// IsUsingAPIKey returns true if the request was authenticated using the API
+// key.
+func IsUsingAPIKey(ctx context.Context) bool {
+	v, _ := ctx.Value(contextAPIKey).(bool)
+	return v
+}
+
+// GetAPIKey returns the API key provided in the request, if any.
+func GetAPIKey(r *http.Request) string {
 	apiKey := r.Header.Get(ApiKeyHeader)
 
 	// try getting the api key as a query parameter
@@ -157,6 +170,15 @@ func (s *Store) Authenticate(w http.ResponseWriter, r *http.Request) (userID str
 		apiKey = r.URL.Query().Get(ApiKeyParameter)
 	}
 
+	return apiKey
+}
+
+func (s *Store) Authenticate(w http.ResponseWriter, r *http.Request) (userID string, err error) {
+	c := s.config
+
+	// translate api key into current user, if present
+	apiKey := GetAPIKey(r)
+
 	if apiKey != "" {
 		// match against configured API and set userID to the
 		// configured username. In future, we'll want to
@@ -49,6 +49,7 @@ func (db *Anonymiser) Anonymise(ctx context.Context) error {
 			func() error { return db.deleteStashIDs() },
 			func() error { return db.clearOHistory() },
 			func() error { return db.clearWatchHistory() },
+			func() error { return db.clearEditHistory() },
 			func() error { return db.anonymiseFolders(ctx) },
 			func() error { return db.anonymiseFiles(ctx) },
 			func() error { return db.anonymiseCaptions(ctx) },
@@ -62,6 +63,7 @@ func (db *Anonymiser) Anonymise(ctx context.Context) error {
 			func() error { return db.anonymiseTags(ctx) },
 			func() error { return db.anonymiseGroups(ctx) },
 			func() error { return db.anonymiseSavedFilters(ctx) },
+			func() error { return db.rebuildSearchIndexes(ctx) },
 			func() error { return db.Optimise(ctx) },
 		})
 	}(); err != nil {
@@ -105,6 +107,15 @@ func (db *Anonymiser) deleteStashIDs() error {
 	})
 }
 
+// clearEditHistory deletes the edit history and tombstones, since they record
+// the values of fields and the names and paths of deleted objects.
+func (db *Anonymiser) clearEditHistory() error {
+	return utils.Do([]func() error{
+		func() error { return db.truncateTable(editHistoryTable) },
+		func() error { return db.truncateTable(tombstoneTable) },
+	})
+}
+
 func (db *Anonymiser) clearOHistory() error {
 	return utils.Do([]func() error{
 		func() error { return db.truncateTable(scenesODatesTable) },
@@ -6,6 +6,7 @@ import (
 	"embed"
 	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"time"
@@ -34,7 +35,7 @@ const (
 	cacheSizeEnv = "STASH_SQLITE_CACHE_SIZE"
 )
 
-var appSchemaVersion uint = 68
+var appSchemaVersion uint = 75
 
 //go:embed migrations/*.sql
 var migrationsBox embed.FS
@@ -43,6 +44,10 @@ var (
 	// ErrDatabaseNotInitialized indicates that the database is not
 	// initialized, usually due to an incomplete configuration.
 	ErrDatabaseNotInitialized = errors.New("database not initialized")
+
+	// ErrFTS5NotAvailable indicates that stash was built without the
+	// sqlite_fts5 build tag, which is required for full-text search.
+	ErrFTS5NotAvailable = errors.New("the SQLite FTS5 module is not available: stash must be built with the sqlite_fts5 build tag")
 )
 
 // ErrMigrationNeeded indicates that a database migration is needed
@@ -76,6 +81,8 @@ type storeRepository struct {
 	SceneMarker    *SceneMarkerStore
 	Performer      *PerformerStore
 	SavedFilter    *SavedFilterStore
+	EditHistory    *EditHistoryStore
+	Tombstone      *TombstoneStore
 	Studio         *StudioStore
 	Tag            *TagStore
 	Group          *GroupStore
@@ -117,6 +124,8 @@ func NewDatabase() *Database {
 		Tag:            tagStore,
 		Group:          NewGroupStore(blobStore),
 		SavedFilter:    NewSavedFilterStore(),
+		EditHistory:    NewEditHistoryStore(),
+		Tombstone:      NewTombstoneStore(),
 	}
 
 	ret := &Database{
@@ -150,6 +159,11 @@ func (db *Database) Open(dbPath string) error {
 
 	db.dbPath = dbPath
 
+	// fail early, rather than part way through the migrations
+	if err := checkFTS5(); err != nil {
+		return err
+	}
+
 	databaseSchemaVersion, err := db.getDatabaseSchemaVersion()
 	if err != nil {
 		return fmt.Errorf("getting database schema version: %w", err)
@@ -196,6 +210,27 @@ func (db *Database) Open(dbPath string) error {
 	return nil
 }
 
+// checkFTS5 returns ErrFTS5NotAvailable if the sqlite driver was compiled
+// without the FTS5 module.
+func checkFTS5() error {
+	conn, err := sql.Open(sqlite3Driver, "file::memory:")
+	if err != nil {
+		return fmt.Errorf("db.Open(): %w", err)
+	}
+	defer conn.Close()
+
+	var enabled bool
+	if err := conn.QueryRow("SELECT sqlite_compileoption_used('ENABLE_FTS5')").Scan(&enabled); err != nil {
+		return fmt.Errorf("checking for FTS5 module: %w", err)
+	}
+
+	if !enabled {
+		return ErrFTS5NotAvailable
+	}
+
+	return nil
+}
+
 // lock locks the database for writing. This method will block until the lock is acquired.
 func (db *Database) lock() {
 	db.lockChan <- struct{}{}
@@ -374,6 +409,62 @@ func (db *Database) RestoreFromBackup(backupPath string) error {
 	return os.Rename(backupPath, db.dbPath)
 }
 
+// Restore replaces the database with a copy of the database at backupPath.
+// The database connections are closed while the file is replaced, and
+// re-opened afterwards. The backup is kept. Returns a MigrationNeededError if
+// the restored database requires migration.
+func (db *Database) Restore(backupPath string) error {
+	databasePath := db.dbPath
+
+	// copy next to the database first, so that the database is only
+	// replaced if the copy succeeds
+	restorePath := databasePath + ".restore"
+	if err := os.Remove(restorePath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("removing %s: %w", restorePath, err)
+	}
+
+	if err := fsutil.CopyFile(backupPath, restorePath); err != nil {
+		return fmt.Errorf("copying backup %s: %w", backupPath, err)
+	}
+
+	if err := db.Close(); err != nil {
+		return fmt.Errorf("error closing database: %w", err)
+	}
+
+	logger.Infof("Restoring backup database %s into %s", backupPath, databasePath)
+
+	// remove the -shm, -wal files of the replaced database ( if they exist )
+	var err error
+	for _, wf := range []string{databasePath + "-shm", databasePath + "-wal"} {
+		if exists, _ := fsutil.FileExists(wf); exists {
+			if err = os.Remove(wf); err != nil {
+				err = fmt.Errorf("removing %s: %w", wf, err)
+				break
+			}
+		}
+	}
+
+	if err == nil {
+		if renameErr := os.Rename(restorePath, databasePath); renameErr != nil {
+			err = fmt.Errorf("replacing database: %w", renameErr)
+		}
+	}
+
+	if err != nil {
+		_ = os.Remove(restorePath)
+	}
+
+	// re-open the existing database if it could not be replaced
+	if openErr := db.Open(databasePath); openErr != nil {
+		if err != nil {
+			return fmt.Errorf("%w; re-opening database: %v", err, openErr)
+		}
+		return openErr
+	}
+
+	return err
+}
+
 func (db *Database) AppSchemaVersion() uint {
 	return appSchemaVersion
 }
@@ -0,0 +1,142 @@
+package sqlite
+
+import (
+	"context"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/jmoiron/sqlx"
+	"gopkg.in/guregu/null.v4/zero"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const (
+	editHistoryTable = "edit_history"
+)
+
+type editHistoryRow struct {
+	ID         int                      `db:"id" goqu:"skipinsert"`
+	EntityType models.HistoryEntityType `db:"entity_type"`
+	EntityID   int                      `db:"entity_id"`
+	Action     models.HistoryAction     `db:"action"`
+	Field      string                   `db:"field"`
+	OldValue   zero.String              `db:"old_value"`
+	NewValue   zero.String              `db:"new_value"`
+	User       zero.String              `db:"user"`
+	Origin     models.HistoryOrigin     `db:"origin"`
+	PluginID   zero.String              `db:"plugin_id"`
+	CreatedAt  Timestamp                `db:"created_at"`
+}
+
+func (r *editHistoryRow) fromEditHistoryEntry(o models.EditHistoryEntry) {
+	r.ID = o.ID
+	r.EntityType = o.EntityType
+	r.EntityID = o.EntityID
+	r.Action = o.Action
+	r.Field = o.Field
+	r.OldValue = zero.StringFromPtr(o.OldValue)
+	r.NewValue = zero.StringFromPtr(o.NewValue)
+	r.User = zero.StringFromPtr(o.User)
+	r.Origin = o.Origin
+	r.PluginID = zero.StringFromPtr(o.PluginID)
+	r.CreatedAt = Timestamp{Timestamp: o.CreatedAt}
+}
+
+func (r *editHistoryRow) resolve() *models.EditHistoryEntry {
+	return &models.EditHistoryEntry{
+		ID:         r.ID,
+		EntityType: r.EntityType,
+		EntityID:   r.EntityID,
+		Action:     r.Action,
+		Field:      r.Field,
+		OldValue:   r.OldValue.Ptr(),
+		NewValue:   r.NewValue.Ptr(),
+		User:       r.User.Ptr(),
+		Origin:     r.Origin,
+		PluginID:   r.PluginID.Ptr(),
+		CreatedAt:  r.CreatedAt.Timestamp,
+	}
+}
+
+type EditHistoryStore struct {
+	repository
+	tableMgr *table
+}
+
+func NewEditHistoryStore() *EditHistoryStore {
+	return &EditHistoryStore{
+		repository: repository{
+			tableName: editHistoryTable,
+			idColumn:  idColumn,
+		},
+		tableMgr: editHistoryTableMgr,
+	}
+}
+
+func (qb *EditHistoryStore) table() exp.IdentifierExpression {
+	return qb.tableMgr.table
+}
+
+func (qb *EditHistoryStore) selectDataset() *goqu.SelectDataset {
+	return dialect.From(qb.table()).Select(qb.table().All())
+}
+
+func (qb *EditHistoryStore) Create(ctx context.Context, newObject *models.EditHistoryEntry) error {
+	var r editHistoryRow
+	r.fromEditHistoryEntry(*newObject)
+
+	id, err := qb.tableMgr.insertID(ctx, r)
+	if err != nil {
+		return err
+	}
+
+	newObject.ID = id
+
+	return nil
+}
+
+// returns nil, nil if not found
+func (qb *EditHistoryStore) Find(ctx context.Context, id int) (*models.EditHistoryEntry, error) {
+	q := qb.selectDataset().Where(qb.tableMgr.byID(id))
+
+	ret, err := qb.getMany(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ret) == 0 {
+		return nil, nil
+	}
+
+	return ret[0], nil
+}
+
+func (qb *EditHistoryStore) FindByEntity(ctx context.Context, entityType models.HistoryEntityType, id int) ([]*models.EditHistoryEntry, error) {
+	table := qb.table()
+
+	q := qb.selectDataset().Prepared(true).Where(
+		table.Col("entity_type").Eq(entityType),
+		table.Col("entity_id").Eq(id),
+	).Order(table.Col(idColumn).Desc())
+
+	return qb.getMany(ctx, q)
+}
+
+func (qb *EditHistoryStore) getMany(ctx context.Context, q *goqu.SelectDataset) ([]*models.EditHistoryEntry, error) {
+	const single = false
+	var ret []*models.EditHistoryEntry
+	if err := queryFunc(ctx, q, single, func(r *sqlx.Rows) error {
+		var f editHistoryRow
+		if err := r.StructScan(&f); err != nil {
+			return err
+		}
+
+		ret = append(ret, f.resolve())
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
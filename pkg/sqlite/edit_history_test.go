@@ -0,0 +1,94 @@
+//go:build integration
+// +build integration
+
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/history"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEditHistoryRecord(t *testing.T) {
+	const (
+		name        = "historyTag"
+		description = "historyDescription"
+		pluginID    = "historyPlugin"
+	)
+
+	withRollbackTxn(func(ctx context.Context) error {
+		repo := history.WrapRepository(db.Repository())
+
+		// changes without a source are not recorded
+		unrecorded := models.NewTag()
+		unrecorded.Name = "unrecordedTag"
+		if err := repo.Tag.Create(ctx, &unrecorded); err != nil {
+			t.Errorf("Error creating tag: %s", err.Error())
+			return nil
+		}
+
+		ctx = history.WithSource(ctx, history.Source{
+			Origin:   models.HistoryOriginPlugin,
+			PluginID: pluginID,
+		})
+
+		newTag := models.NewTag()
+		newTag.Name = name
+		if err := repo.Tag.Create(ctx, &newTag); err != nil {
+			t.Errorf("Error creating tag: %s", err.Error())
+			return nil
+		}
+
+		if _, err := repo.Tag.UpdatePartial(ctx, newTag.ID, models.TagPartial{
+			Description: models.NewOptionalString(description),
+		}); err != nil {
+			t.Errorf("Error updating tag: %s", err.Error())
+			return nil
+		}
+
+		entries, err := db.EditHistory.FindByEntity(ctx, models.HistoryEntityTypeTag, unrecorded.ID)
+		if err != nil {
+			t.Errorf("Error finding edit history: %s", err.Error())
+			return nil
+		}
+		assert.Len(t, entries, 0)
+
+		entries, err = db.EditHistory.FindByEntity(ctx, models.HistoryEntityTypeTag, newTag.ID)
+		if err != nil {
+			t.Errorf("Error finding edit history: %s", err.Error())
+			return nil
+		}
+
+		// most recent first
+		if !assert.Len(t, entries, 2) {
+			return nil
+		}
+
+		updated := entries[0]
+		assert.Equal(t, models.HistoryActionUpdate, updated.Action)
+		assert.Equal(t, "description", updated.Field)
+		assert.Equal(t, `""`, *updated.OldValue)
+		assert.Equal(t, `"`+description+`"`, *updated.NewValue)
+		assert.Equal(t, models.HistoryOriginPlugin, updated.Origin)
+		assert.Equal(t, pluginID, *updated.PluginID)
+		assert.Nil(t, updated.User)
+
+		created := entries[1]
+		assert.Equal(t, models.HistoryActionCreate, created.Action)
+		assert.Equal(t, "name", created.Field)
+		assert.Nil(t, created.OldValue)
+		assert.Equal(t, `"`+name+`"`, *created.NewValue)
+
+		found, err := db.EditHistory.Find(ctx, updated.ID)
+		if err != nil {
+			t.Errorf("Error finding edit history entry: %s", err.Error())
+			return nil
+		}
+		assert.Equal(t, updated, found)
+
+		return nil
+	})
+}
@@ -0,0 +1,162 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// facetTables are the tables used to compute the facets of an object type.
+type facetTables struct {
+	table string
+	// idColumn is the column referencing the object in the join tables.
+	idColumn        string
+	tagsTable       string
+	performersTable string
+
+	// filesTable and dimensionsTable are used to compute resolutions.
+	// Resolutions are not computed if filesTable is empty.
+	filesTable      string
+	dimensionsTable string
+}
+
+type facetQuery struct {
+	name string
+	sql  string
+	out  *[]*models.FacetValue
+}
+
+type facetRow struct {
+	Value string      `db:"value"`
+	Label null.String `db:"label"`
+	Count int         `db:"count"`
+}
+
+func (r *facetRow) resolve() *models.FacetValue {
+	ret := &models.FacetValue{
+		Value: r.Value,
+		Label: r.Label.String,
+		Count: r.Count,
+	}
+
+	if !r.Label.Valid {
+		ret.Label = r.Value
+	}
+
+	return ret
+}
+
+// resolutionCase returns an expression evaluating to the resolution of the
+// media with the provided dimension, or NULL if it is below the lowest
+// resolution.
+func resolutionCase(widthColumn string, heightColumn string) string {
+	widthHeight := fmt.Sprintf("MIN(%s, %s)", widthColumn, heightColumn)
+
+	var sb strings.Builder
+	sb.WriteString("CASE")
+
+	// highest resolution first. The deprecated VR_HD range overlaps FOUR_K,
+	// so is not used.
+	for i := len(models.AllResolutionEnum) - 1; i >= 0; i-- {
+		r := models.AllResolutionEnum[i]
+		if r == models.ResolutionEnumVrHd {
+			continue
+		}
+
+		fmt.Fprintf(&sb, " WHEN %s >= %d THEN '%s'", widthHeight, r.GetMinResolution(), r)
+	}
+
+	sb.WriteString(" END")
+	return sb.String()
+}
+
+// queryFacets returns the limit most common tags, performers, studios,
+// resolutions and years of the results of query.
+func (r *repository) queryFacets(ctx context.Context, query queryBuilder, t facetTables, limit int) (*models.QueryFacets, error) {
+	const includeSortPagination = false
+	ids := fmt.Sprintf("SELECT temp.id FROM (%s) AS temp", query.toSQL(includeSortPagination))
+
+	ret := &models.QueryFacets{}
+
+	queries := []facetQuery{
+		{
+			name: "tags",
+			sql: fmt.Sprintf(`SELECT j.%[1]s AS value, %[2]s.name AS label, COUNT(*) AS count
+FROM %[3]s AS j INNER JOIN %[2]s ON %[2]s.id = j.%[1]s
+WHERE j.%[4]s IN (%[5]s)
+GROUP BY j.%[1]s`, tagIDColumn, tagTable, t.tagsTable, t.idColumn, ids),
+			out: &ret.Tags,
+		},
+		{
+			name: "performers",
+			sql: fmt.Sprintf(`SELECT j.%[1]s AS value, %[2]s.name AS label, COUNT(*) AS count
+FROM %[3]s AS j INNER JOIN %[2]s ON %[2]s.id = j.%[1]s
+WHERE j.%[4]s IN (%[5]s)
+GROUP BY j.%[1]s`, performerIDColumn, performerTable, t.performersTable, t.idColumn, ids),
+			out: &ret.Performers,
+		},
+		{
+			name: "studios",
+			sql: fmt.Sprintf(`SELECT o.%[1]s AS value, %[2]s.name AS label, COUNT(*) AS count
+FROM %[3]s AS o INNER JOIN %[2]s ON %[2]s.id = o.%[1]s
+WHERE o.id IN (%[4]s)
+GROUP BY o.%[1]s`, studioIDColumn, studioTable, t.table, ids),
+			out: &ret.Studios,
+		},
+		{
+			name: "years",
+			sql: fmt.Sprintf(`SELECT SUBSTR(o.date, 1, 4) AS value, NULL AS label, COUNT(*) AS count
+FROM %[1]s AS o
+WHERE o.date IS NOT NULL AND o.id IN (%[2]s)
+GROUP BY value`, t.table, ids),
+			out: &ret.Years,
+		},
+	}
+
+	if t.filesTable != "" {
+		resolution := resolutionCase("d.width", "d.height")
+		queries = append(queries, facetQuery{
+			name: "resolutions",
+			sql: fmt.Sprintf(`SELECT %[1]s AS value, NULL AS label, COUNT(*) AS count
+FROM %[2]s AS f INNER JOIN %[3]s AS d ON d.%[4]s = f.%[4]s
+WHERE f."primary" = 1 AND f.%[5]s IN (%[6]s)
+GROUP BY value HAVING value IS NOT NULL`, resolution, t.filesTable, t.dimensionsTable, fileIDColumn, t.idColumn, ids),
+			out: &ret.Resolutions,
+		})
+	}
+
+	for _, q := range queries {
+		sql := q.sql + " ORDER BY count DESC, value ASC LIMIT ?"
+
+		args := make([]interface{}, 0, len(query.args)+1)
+		args = append(args, query.args...)
+		args = append(args, limit)
+
+		values := []*models.FacetValue{}
+		if err := r.queryFunc(ctx, sql, args, false, func(rows *sqlx.Rows) error {
+			var f facetRow
+			if err := rows.StructScan(&f); err != nil {
+				return err
+			}
+
+			values = append(values, f.resolve())
+			return nil
+		}); err != nil {
+			return nil, fmt.Errorf("querying %s facet: %w", q.name, err)
+		}
+
+		*q.out = values
+	}
+
+	// resolutions are not computed for all object types
+	if ret.Resolutions == nil {
+		ret.Resolutions = []*models.FacetValue{}
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,185 @@
+//go:build integration
+// +build integration
+
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func assertFacetsOrdered(t *testing.T, facets *models.QueryFacets, limit int) {
+	t.Helper()
+
+	for _, values := range [][]*models.FacetValue{facets.Tags, facets.Performers, facets.Studios, facets.Resolutions, facets.Years} {
+		assert.LessOrEqual(t, len(values), limit)
+		for i := 1; i < len(values); i++ {
+			assert.GreaterOrEqual(t, values[i-1].Count, values[i].Count)
+		}
+	}
+}
+
+func TestSceneQueryFacets(t *testing.T) {
+	const limit = 3
+
+	withTxn(func(ctx context.Context) error {
+		sqb := db.Scene
+
+		facets, err := sqb.QueryFacets(ctx, nil, nil, limit)
+		if err != nil {
+			t.Errorf("SceneStore.QueryFacets() error = %v", err)
+			return nil
+		}
+
+		assertFacetsOrdered(t, facets, limit)
+		assert.NotEmpty(t, facets.Tags)
+		assert.NotEmpty(t, facets.Studios)
+		assert.NotEmpty(t, facets.Resolutions)
+
+		// the counts of each facet match the results filtered by the value
+		for _, v := range facets.Tags {
+			count, err := sqb.QueryCount(ctx, &models.SceneFilterType{
+				Tags: &models.HierarchicalMultiCriterionInput{
+					Value:    []string{v.Value},
+					Modifier: models.CriterionModifierIncludes,
+				},
+			}, nil)
+			if err != nil {
+				t.Errorf("SceneStore.QueryCount() error = %v", err)
+				return nil
+			}
+
+			assert.Equal(t, count, v.Count, "tag %s", v.Label)
+		}
+
+		for _, v := range facets.Studios {
+			count, err := sqb.QueryCount(ctx, &models.SceneFilterType{
+				Studios: &models.HierarchicalMultiCriterionInput{
+					Value:    []string{v.Value},
+					Modifier: models.CriterionModifierIncludes,
+				},
+			}, nil)
+			if err != nil {
+				t.Errorf("SceneStore.QueryCount() error = %v", err)
+				return nil
+			}
+
+			assert.Equal(t, count, v.Count, "studio %s", v.Label)
+		}
+
+		for _, v := range facets.Resolutions {
+			count, err := sqb.QueryCount(ctx, &models.SceneFilterType{
+				Resolution: &models.ResolutionCriterionInput{
+					Value:    models.ResolutionEnum(v.Value),
+					Modifier: models.CriterionModifierEquals,
+				},
+			}, nil)
+			if err != nil {
+				t.Errorf("SceneStore.QueryCount() error = %v", err)
+				return nil
+			}
+
+			assert.Equal(t, count, v.Count, "resolution %s", v.Value)
+		}
+
+		// facets are computed within the filter
+		if len(facets.Tags) > 0 {
+			tag := facets.Tags[0]
+			filtered, err := sqb.QueryFacets(ctx, &models.SceneFilterType{
+				Tags: &models.HierarchicalMultiCriterionInput{
+					Value:    []string{tag.Value},
+					Modifier: models.CriterionModifierIncludes,
+				},
+			}, nil, limit)
+			if err != nil {
+				t.Errorf("SceneStore.QueryFacets() error = %v", err)
+				return nil
+			}
+
+			assert.Contains(t, filtered.Tags, tag)
+			for _, v := range filtered.Tags {
+				assert.LessOrEqual(t, v.Count, tag.Count)
+			}
+		}
+
+		// text searches and pagination are supported
+		q := "scene"
+		perPage := 1
+		if _, err := sqb.QueryFacets(ctx, nil, &models.FindFilterType{
+			Q:       &q,
+			PerPage: &perPage,
+		}, limit); err != nil {
+			t.Errorf("SceneStore.QueryFacets() error = %v", err)
+		}
+
+		return nil
+	})
+}
+
+func TestImageQueryFacets(t *testing.T) {
+	const limit = 3
+
+	withTxn(func(ctx context.Context) error {
+		facets, err := db.Image.QueryFacets(ctx, nil, nil, limit)
+		if err != nil {
+			t.Errorf("ImageStore.QueryFacets() error = %v", err)
+			return nil
+		}
+
+		assertFacetsOrdered(t, facets, limit)
+		assert.NotEmpty(t, facets.Performers)
+
+		for _, v := range facets.Performers {
+			count, err := db.Image.QueryCount(ctx, &models.ImageFilterType{
+				Performers: &models.MultiCriterionInput{
+					Value:    []string{v.Value},
+					Modifier: models.CriterionModifierIncludes,
+				},
+			}, nil)
+			if err != nil {
+				t.Errorf("ImageStore.QueryCount() error = %v", err)
+				return nil
+			}
+
+			assert.Equal(t, count, v.Count, "performer %s", v.Label)
+		}
+
+		return nil
+	})
+}
+
+func TestGalleryQueryFacets(t *testing.T) {
+	const limit = 3
+
+	withTxn(func(ctx context.Context) error {
+		facets, err := db.Gallery.QueryFacets(ctx, nil, nil, limit)
+		if err != nil {
+			t.Errorf("GalleryStore.QueryFacets() error = %v", err)
+			return nil
+		}
+
+		assertFacetsOrdered(t, facets, limit)
+		assert.Len(t, facets.Resolutions, 0)
+		assert.NotEmpty(t, facets.Tags)
+
+		for _, v := range facets.Tags {
+			count, err := db.Gallery.QueryCount(ctx, &models.GalleryFilterType{
+				Tags: &models.HierarchicalMultiCriterionInput{
+					Value:    []string{v.Value},
+					Modifier: models.CriterionModifierIncludes,
+				},
+			}, nil)
+			if err != nil {
+				t.Errorf("GalleryStore.QueryCount() error = %v", err)
+				return nil
+			}
+
+			assert.Equal(t, count, v.Count, "tag %s", v.Label)
+		}
+
+		return nil
+	})
+}
@@ -51,6 +51,18 @@ func (r *basicFileRow) fromBasicFile(o models.BaseFile) {
 	r.UpdatedAt = Timestamp{Timestamp: o.UpdatedAt}
 }
 
+type fileHealthRow struct {
+	Status    null.String   `db:"health_status"`
+	Error     null.String   `db:"health_error"`
+	CheckedAt NullTimestamp `db:"health_checked_at"`
+}
+
+func (r *fileHealthRow) fromFileHealth(o models.FileHealth) {
+	r.Status = null.StringFrom(o.Status.String())
+	r.Error = null.NewString(o.Error, o.Error != "")
+	r.CheckedAt = NullTimestamp{Timestamp: o.CheckedAt, Valid: true}
+}
+
 type videoFileRow struct {
 	FileID           models.FileID `db:"file_id"`
 	Format           string        `db:"format"`
@@ -176,6 +188,10 @@ type fileQueryRow struct {
 	CreatedAt      NullTimestamp `db:"file_created_at"`
 	UpdatedAt      NullTimestamp `db:"file_updated_at"`
 
+	HealthStatus    null.String   `db:"health_status"`
+	HealthError     null.String   `db:"health_error"`
+	HealthCheckedAt NullTimestamp `db:"health_checked_at"`
+
 	ZipBasename   null.String `db:"zip_basename"`
 	ZipFolderPath null.String `db:"zip_folder_path"`
 	ZipSize       null.Int    `db:"zip_size"`
@@ -201,6 +217,14 @@ func (r *fileQueryRow) resolve() models.File {
 		UpdatedAt:      r.UpdatedAt.Timestamp,
 	}
 
+	if r.HealthStatus.Valid {
+		basic.Health = &models.FileHealth{
+			Status:    models.FileHealthStatus(r.HealthStatus.String),
+			Error:     r.HealthError.String,
+			CheckedAt: r.HealthCheckedAt.Timestamp,
+		}
+	}
+
 	if basic.ZipFileID != nil && r.ZipFolderPath.Valid && r.ZipBasename.Valid {
 		basic.ZipFile = &models.BaseFile{
 			ID:       *basic.ZipFileID,
@@ -372,6 +396,17 @@ func (qb *FileStore) DestroyFingerprints(ctx context.Context, fileID models.File
 	return FingerprintReaderWriter.destroyJoins(ctx, fileID, types)
 }
 
+// UpdateHealth sets the health check result of the file. A nil health clears
+// the result.
+func (qb *FileStore) UpdateHealth(ctx context.Context, fileID models.FileID, health *models.FileHealth) error {
+	var r fileHealthRow
+	if health != nil {
+		r.fromFileHealth(*health)
+	}
+
+	return qb.tableMgr.updateByID(ctx, fileID, r)
+}
+
 func (qb *FileStore) Destroy(ctx context.Context, id models.FileID) error {
 	return qb.tableMgr.destroyExisting(ctx, []int{int(id)})
 }
@@ -458,6 +493,9 @@ func (qb *FileStore) selectDataset() *goqu.SelectDataset {
 		table.Col("mod_time"),
 		table.Col("created_at").As("file_created_at"),
 		table.Col("updated_at").As("file_updated_at"),
+		table.Col("health_status"),
+		table.Col("health_error"),
+		table.Col("health_checked_at"),
 		folderTable.Col("path").As("parent_folder_path"),
 		fingerprintTable.Col("type").As("fingerprint_type"),
 		fingerprintTable.Col("fingerprint"),
@@ -835,6 +873,18 @@ func (qb *FileStore) makeFilter(ctx context.Context, fileFilter *models.FileFilt
 	return query
 }
 
+var fileTextSearch = textSearch{
+	idColumn: "files.id",
+	sources: []searchSource{
+		{
+			from:     "files_fts",
+			idColumn: "files_fts.rowid",
+			fts:      "files_fts",
+			columns:  []string{"files_fts.path"},
+		},
+	},
+}
+
 func (qb *FileStore) Query(ctx context.Context, options models.FileQueryOptions) (*models.FileQueryResult, error) {
 	fileFilter := options.FileFilter
 	findFilter := options.FindFilter
@@ -852,9 +902,7 @@ func (qb *FileStore) Query(ctx context.Context, options models.FileQueryOptions)
 	distinctIDs(&query, fileTable)
 
 	if q := findFilter.Q; q != nil && *q != "" {
-		filepathColumn := "folders.path || '" + string(filepath.Separator) + "' || files.basename"
-		searchColumns := []string{filepathColumn}
-		query.parseQueryString(searchColumns, *q)
+		query.parseTextSearch(fileTextSearch, *q)
 	}
 
 	if err := qb.validateFilter(fileFilter); err != nil {
@@ -866,7 +914,9 @@ func (qb *FileStore) Query(ctx context.Context, options models.FileQueryOptions)
 		return nil, err
 	}
 
-	if err := qb.setQuerySort(&query, findFilter); err != nil {
+	if query.useRelevanceSort(findFilter) {
+		query.sortAndPagination += query.getRelevanceSort(findFilter)
+	} else if err := qb.setQuerySort(&query, withoutRelevanceSort(findFilter)); err != nil {
 		return nil, err
 	}
 	query.sortAndPagination += getPagination(findFilter)
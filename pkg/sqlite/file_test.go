@@ -662,3 +662,56 @@ func TestFileStore_IsPrimary(t *testing.T) {
 		})
 	}
 }
+
+func TestFileStore_UpdateHealth(t *testing.T) {
+	checkedAt := time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		fileID models.FileID
+		health *models.FileHealth
+	}{
+		{
+			"corrupt",
+			sceneFileIDs[sceneIdx1WithPerformer],
+			&models.FileHealth{
+				Status:    models.FileHealthStatusCorrupt,
+				Error:     "error",
+				CheckedAt: checkedAt,
+			},
+		},
+		{
+			"ok",
+			imageFileIDs[imageIdx1WithGallery],
+			&models.FileHealth{
+				Status:    models.FileHealthStatusOk,
+				CheckedAt: checkedAt,
+			},
+		},
+		{
+			"clear",
+			sceneFileIDs[sceneIdx1WithPerformer],
+			nil,
+		},
+	}
+
+	qb := db.File
+
+	for _, tt := range tests {
+		runWithRollbackTxn(t, tt.name, func(t *testing.T, ctx context.Context) {
+			assert := assert.New(t)
+			if err := qb.UpdateHealth(ctx, tt.fileID, tt.health); err != nil {
+				t.Errorf("FileStore.UpdateHealth() error = %v", err)
+				return
+			}
+
+			got, err := qb.Find(ctx, tt.fileID)
+			if err != nil {
+				t.Errorf("FileStore.Find() error = %v", err)
+				return
+			}
+
+			assert.Equal(tt.health, got[0].Base().Health)
+		})
+	}
+}
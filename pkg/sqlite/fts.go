@@ -0,0 +1,217 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// minFTSTermLength is the minimum length of a term that can be matched by
+// a trigram full-text index. Shorter terms are matched using LIKE.
+const minFTSTermLength = 3
+
+// relevanceSort is the sort value used to order by search relevance.
+const relevanceSort = "relevance"
+
+// ftsTables are the full-text indexes maintained by triggers.
+var ftsTables = []string{
+	"scenes_fts",
+	"files_fts",
+	"scene_markers_fts",
+	"performers_fts",
+	"studios_fts",
+	"tags_fts",
+}
+
+// searchSource is a table searched by the q value of a find filter.
+type searchSource struct {
+	// from is the table searched, including any joins required to select
+	// idColumn.
+	from string
+	// idColumn is the id of the queried object matched by a row of the table.
+	idColumn string
+	// fts is the full-text index table, if the table is a full-text index.
+	fts string
+	// columns are the searched columns of the table.
+	columns []string
+}
+
+func (s searchSource) isFTS() bool {
+	return s.fts != ""
+}
+
+// ftsQuery returns the full-text query matching term in the searched columns.
+func (s searchSource) ftsQuery(terms ...string) string {
+	var phrases []string
+	for _, t := range terms {
+		phrases = append(phrases, `"`+strings.ReplaceAll(t, `"`, `""`)+`"`)
+	}
+
+	var columns []string
+	for _, c := range s.columns {
+		columns = append(columns, strings.TrimPrefix(c, s.fts+"."))
+	}
+
+	return fmt.Sprintf("{%s} : (%s)", strings.Join(columns, " "), strings.Join(phrases, " OR "))
+}
+
+// matchClause returns the clause matching rows of the table containing term.
+func (s searchSource) matchClause(term string) (string, []interface{}) {
+	if s.isFTS() && utf8.RuneCountInString(term) >= minFTSTermLength {
+		return s.fts + " MATCH ?", []interface{}{s.ftsQuery(term)}
+	}
+
+	var clauses []string
+	var args []interface{}
+	for _, c := range s.columns {
+		clauses = append(clauses, c+" LIKE ?")
+		args = append(args, like(term))
+	}
+
+	return "(" + strings.Join(clauses, " OR ") + ")", args
+}
+
+// textSearch describes the tables searched by the q value of a find filter.
+type textSearch struct {
+	// idColumn is the id column of the queried table.
+	idColumn string
+	sources  []searchSource
+}
+
+// termClause returns the clause matching objects where one of the sources
+// contains term.
+func (s textSearch) termClause(term string) (string, []interface{}) {
+	var clauses []string
+	var args []interface{}
+	for _, src := range s.sources {
+		match, matchArgs := src.matchClause(term)
+		clauses = append(clauses, fmt.Sprintf("%s IN (SELECT %s FROM %s WHERE %s)", s.idColumn, src.idColumn, src.from, match))
+		args = append(args, matchArgs...)
+	}
+
+	return "(" + strings.Join(clauses, " OR ") + ")", args
+}
+
+// sqlString returns s as a quoted SQL string literal.
+func sqlString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// parseTextSearch adds the clauses matching the q value of a find filter.
+// Terms are matched using the full-text indexes of the search sources where
+// possible. The relevance of each result is set in searchRank.
+func (qb *queryBuilder) parseTextSearch(s textSearch, q string) {
+	specs := models.ParseSearchString(q)
+
+	// terms that must or may appear are used to rank the results
+	var rankTerms []string
+	addRankTerm := func(t string) {
+		if utf8.RuneCountInString(t) >= minFTSTermLength {
+			rankTerms = append(rankTerms, t)
+		}
+	}
+
+	for _, t := range specs.MustHave {
+		clause, args := s.termClause(t)
+		qb.addWhere(clause)
+		qb.addArg(args...)
+		addRankTerm(t)
+	}
+
+	for _, t := range specs.MustNot {
+		clause, args := s.termClause(t)
+		qb.addWhere("NOT " + clause)
+		qb.addArg(args...)
+	}
+
+	for _, set := range specs.AnySets {
+		var clauses []string
+
+		for _, t := range set {
+			clause, args := s.termClause(t)
+			clauses = append(clauses, clause)
+			qb.addArg(args...)
+			addRankTerm(t)
+		}
+
+		qb.addWhere("(" + strings.Join(clauses, " OR ") + ")")
+	}
+
+	if len(rankTerms) == 0 {
+		return
+	}
+
+	// The rank column of the index is the bm25 score of the row, which is
+	// lower for better matches. bm25 cannot be called directly since it is
+	// not available within aggregates. The ranks of each index are summed,
+	// so that objects matching in multiple indexes rank higher.
+	// The match query is inlined since joins cannot have arguments.
+	var ranks []string
+	for i, src := range s.sources {
+		if !src.isFTS() {
+			continue
+		}
+
+		alias := fmt.Sprintf("search_rank_%d", i)
+		qb.addJoins(join{
+			table: fmt.Sprintf("(SELECT %s AS id, MIN(%s.rank) AS rank FROM %s WHERE %s MATCH %s GROUP BY %s)",
+				src.idColumn, src.fts, src.from, src.fts, sqlString(src.ftsQuery(rankTerms...)), src.idColumn),
+			as:       alias,
+			onClause: fmt.Sprintf("%s.id = %s", alias, s.idColumn),
+		})
+		ranks = append(ranks, fmt.Sprintf("COALESCE(%s.rank, 0)", alias))
+	}
+
+	qb.searchRank = strings.Join(ranks, " + ")
+}
+
+// useRelevanceSort returns true if the results should be sorted by search
+// relevance. This is the case if relevance sort is requested, or if there is
+// a search and no sort.
+func (qb *queryBuilder) useRelevanceSort(findFilter *models.FindFilterType) bool {
+	if qb.searchRank == "" || findFilter == nil {
+		return false
+	}
+
+	return findFilter.Sort == nil || *findFilter.Sort == "" || *findFilter.Sort == relevanceSort
+}
+
+// getRelevanceSort returns the ORDER BY clause ordering by search relevance.
+// The most relevant results are first unless ascending order is requested
+// explicitly.
+func (qb *queryBuilder) getRelevanceSort(findFilter *models.FindFilterType) string {
+	direction := "DESC"
+	if findFilter.Sort != nil && *findFilter.Sort == relevanceSort && findFilter.Direction != nil {
+		direction = findFilter.GetDirection()
+	}
+
+	// negate the rank since bm25 returns lower values for better matches
+	return fmt.Sprintf(" ORDER BY -(%s) %s, %s ASC", qb.searchRank, getSortDirection(direction), getColumn(qb.from, "id"))
+}
+
+// withoutRelevanceSort returns findFilter without the relevance sort, for
+// queries without a search.
+func withoutRelevanceSort(findFilter *models.FindFilterType) *models.FindFilterType {
+	if findFilter == nil || findFilter.Sort == nil || *findFilter.Sort != relevanceSort {
+		return findFilter
+	}
+
+	ret := *findFilter
+	ret.Sort = nil
+	return &ret
+}
+
+// rebuildSearchIndexes rebuilds the full-text indexes from their stored
+// content, removing any data of deleted rows.
+func (db *Database) rebuildSearchIndexes(ctx context.Context) error {
+	for _, t := range ftsTables {
+		if _, err := db.writeDB.ExecContext(ctx, fmt.Sprintf("INSERT INTO %[1]s(%[1]s) VALUES('rebuild')", t)); err != nil {
+			return fmt.Errorf("rebuilding %s: %w", t, err)
+		}
+	}
+
+	return nil
+}
@@ -366,7 +366,16 @@ func (qb *GalleryStore) UpdatePartial(ctx context.Context, id int, partial model
 }
 
 func (qb *GalleryStore) Destroy(ctx context.Context, id int) error {
-	return qb.tableMgr.destroyExisting(ctx, []int{id})
+	t, err := qb.tombstone(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := qb.tableMgr.destroyExisting(ctx, []int{id}); err != nil {
+		return err
+	}
+
+	return recordTombstone(ctx, t)
 }
 
 func (qb *GalleryStore) GetFiles(ctx context.Context, id int) ([]models.File, error) {
@@ -768,6 +777,22 @@ func (qb *GalleryStore) QueryCount(ctx context.Context, galleryFilter *models.Ga
 	return query.executeCount(ctx)
 }
 
+var galleryFacetTables = facetTables{
+	table:           galleryTable,
+	idColumn:        galleryIDColumn,
+	tagsTable:       galleriesTagsTable,
+	performersTable: performersGalleriesTable,
+}
+
+func (qb *GalleryStore) QueryFacets(ctx context.Context, galleryFilter *models.GalleryFilterType, findFilter *models.FindFilterType, limit int) (*models.QueryFacets, error) {
+	query, err := qb.makeQuery(ctx, galleryFilter, findFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	return galleryRepository.queryFacets(ctx, *query, galleryFacetTables, limit)
+}
+
 var gallerySortOptions = sortOptions{
 	"created_at",
 	"date",
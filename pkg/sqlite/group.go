@@ -266,12 +266,21 @@ func (qb *GroupStore) Update(ctx context.Context, updatedObject *models.Group) e
 }
 
 func (qb *GroupStore) Destroy(ctx context.Context, id int) error {
+	t, err := qb.tombstone(ctx, id)
+	if err != nil {
+		return err
+	}
+
 	// must handle image checksums manually
 	if err := qb.destroyImages(ctx, id); err != nil {
 		return err
 	}
 
-	return groupRepository.destroyExisting(ctx, []int{id})
+	if err := groupRepository.destroyExisting(ctx, []int{id}); err != nil {
+		return err
+	}
+
+	return recordTombstone(ctx, t)
 }
 
 // returns nil, nil if not found
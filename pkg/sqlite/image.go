@@ -6,10 +6,14 @@ import (
 	"errors"
 	"fmt"
 	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/sliceutil"
+	"github.com/stashapp/stash/pkg/utils"
 	"gopkg.in/guregu/null.v4"
 	"gopkg.in/guregu/null.v4/zero"
 
@@ -28,6 +32,33 @@ const (
 	imageURLColumn        = "url"
 )
 
+var findExactImageDuplicateQuery = `
+SELECT GROUP_CONCAT(DISTINCT image_id) as ids
+FROM (
+	SELECT images.id as image_id
+		, files.size as file_size
+		, files_fingerprints.fingerprint as phash
+	FROM images
+	INNER JOIN images_files ON (images.id = images_files.image_id)
+	INNER JOIN files ON (images_files.file_id = files.id)
+	INNER JOIN files_fingerprints ON (images_files.file_id = files_fingerprints.file_id AND files_fingerprints.type = 'phash')
+)
+GROUP BY phash
+HAVING COUNT(phash) > 1
+	AND COUNT(DISTINCT image_id) > 1
+ORDER BY SUM(file_size) DESC;
+`
+
+var findAllImagePhashesQuery = `
+SELECT images.id as id
+    , files_fingerprints.fingerprint as phash
+FROM images
+INNER JOIN images_files ON (images.id = images_files.image_id)
+INNER JOIN files ON (images_files.file_id = files.id)
+INNER JOIN files_fingerprints ON (images_files.file_id = files_fingerprints.file_id AND files_fingerprints.type = 'phash')
+ORDER BY files.size DESC;
+`
+
 type imageRow struct {
 	ID    int         `db:"id" goqu:"skipinsert"`
 	Title zero.String `db:"title"`
@@ -375,7 +406,16 @@ func (qb *ImageStore) Update(ctx context.Context, updatedObject *models.Image) e
 }
 
 func (qb *ImageStore) Destroy(ctx context.Context, id int) error {
-	return qb.tableMgr.destroyExisting(ctx, []int{id})
+	t, err := qb.tombstone(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := qb.tableMgr.destroyExisting(ctx, []int{id}); err != nil {
+		return err
+	}
+
+	return recordTombstone(ctx, t)
 }
 
 // returns nil, nil if not found
@@ -693,6 +733,80 @@ func (qb *ImageStore) OCount(ctx context.Context) (int, error) {
 	return ret, nil
 }
 
+func (qb *ImageStore) FindDuplicates(ctx context.Context, distance int) ([][]*models.Image, error) {
+	var dupeIds [][]int
+	if distance == 0 {
+		var ids []string
+		if err := dbWrapper.Select(ctx, &ids, findExactImageDuplicateQuery); err != nil {
+			return nil, err
+		}
+
+		for _, id := range ids {
+			strIds := strings.Split(id, ",")
+			var imageIds []int
+			for _, strId := range strIds {
+				if intId, err := strconv.Atoi(strId); err == nil {
+					imageIds = sliceutil.AppendUnique(imageIds, intId)
+				}
+			}
+			// filter out
+			if len(imageIds) > 1 {
+				dupeIds = append(dupeIds, imageIds)
+			}
+		}
+	} else {
+		var hashes []*utils.Phash
+
+		if err := imageRepository.queryFunc(ctx, findAllImagePhashesQuery, nil, false, func(rows *sqlx.Rows) error {
+			phash := utils.Phash{
+				Bucket:   -1,
+				Duration: -1,
+			}
+			if err := rows.StructScan(&phash); err != nil {
+				return err
+			}
+
+			hashes = append(hashes, &phash)
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+
+		// images have no duration, so disable the duration check
+		dupeIds = utils.FindDuplicates(hashes, distance, -1)
+	}
+
+	var duplicates [][]*models.Image
+	for _, imageIds := range dupeIds {
+		if images, err := qb.FindMany(ctx, imageIds); err == nil {
+			duplicates = append(duplicates, images)
+		}
+	}
+
+	sortImagesByPath(duplicates)
+
+	return duplicates, nil
+}
+
+func sortImagesByPath(images [][]*models.Image) {
+	lessFunc := func(i int, j int) bool {
+		firstPathI := getFirstImagePath(images[i])
+		firstPathJ := getFirstImagePath(images[j])
+		return firstPathI < firstPathJ
+	}
+	sort.SliceStable(images, lessFunc)
+}
+
+func getFirstImagePath(images []*models.Image) string {
+	var firstPath string
+	for i, image := range images {
+		if i == 0 || image.Path < firstPath {
+			firstPath = image.Path
+		}
+	}
+	return firstPath
+}
+
 func (qb *ImageStore) FindByFolderID(ctx context.Context, folderID models.FolderID) ([]*models.Image, error) {
 	table := qb.table()
 	fileTable := goqu.T(fileTable)
@@ -913,6 +1027,24 @@ func (qb *ImageStore) QueryCount(ctx context.Context, imageFilter *models.ImageF
 	return query.executeCount(ctx)
 }
 
+var imageFacetTables = facetTables{
+	table:           imageTable,
+	idColumn:        imageIDColumn,
+	tagsTable:       imagesTagsTable,
+	performersTable: performersImagesTable,
+	filesTable:      imagesFilesTable,
+	dimensionsTable: imageFileTable,
+}
+
+func (qb *ImageStore) QueryFacets(ctx context.Context, imageFilter *models.ImageFilterType, findFilter *models.FindFilterType, limit int) (*models.QueryFacets, error) {
+	query, err := qb.makeQuery(ctx, imageFilter, findFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	return imageRepository.queryFacets(ctx, *query, imageFacetTables, limit)
+}
+
 var imageSortOptions = sortOptions{
 	"created_at",
 	"date",
@@ -62,6 +62,7 @@ func (qb *imageFilterHandler) criterionHandler() criterionHandler {
 
 			stringCriterionHandler(imageFilter.Checksum, "fingerprints_md5.fingerprint")(ctx, f)
 		}),
+		qb.phashDistanceCriterionHandler(imageFilter.PhashDistance),
 		stringCriterionHandler(imageFilter.Title, "images.title"),
 		stringCriterionHandler(imageFilter.Code, "images.code"),
 		stringCriterionHandler(imageFilter.Details, "images.details"),
@@ -77,6 +78,7 @@ func (qb *imageFilterHandler) criterionHandler() criterionHandler {
 
 		resolutionCriterionHandler(imageFilter.Resolution, "image_files.height", "image_files.width", imageRepository.addImageFilesTable),
 		orientationCriterionHandler(imageFilter.Orientation, "image_files.height", "image_files.width", imageRepository.addImageFilesTable),
+		fileHealthCriterionHandler(imageFilter.FileHealth, imageRepository.addFilesTable),
 		qb.missingCriterionHandler(imageFilter.IsMissing),
 
 		qb.tagsCriterionHandler(imageFilter.Tags),
@@ -136,6 +138,13 @@ func (qb *imageFilterHandler) fileCountCriterionHandler(fileCount *models.IntCri
 	return h.handler(fileCount)
 }
 
+func (qb *imageFilterHandler) phashDistanceCriterionHandler(phashDistance *models.PhashDistanceCriterionInput) criterionHandlerFunc {
+	return phashDistanceCriterionHandler(phashDistance, func(f *filterBuilder) {
+		imageRepository.addImagesFilesTable(f)
+		f.addLeftJoin(fingerprintTable, "fingerprints_phash", "images_files.file_id = fingerprints_phash.file_id AND fingerprints_phash.type = 'phash'")
+	})
+}
+
 func (qb *imageFilterHandler) missingCriterionHandler(isMissing *string) criterionHandlerFunc {
 	return func(ctx context.Context, f *filterBuilder) {
 		if isMissing != nil && *isMissing != "" {
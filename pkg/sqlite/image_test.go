@@ -5,12 +5,14 @@ package sqlite_test
 
 import (
 	"context"
+	"fmt"
 	"reflect"
 	"strconv"
 	"testing"
 	"time"
 
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/utils"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -3018,6 +3020,109 @@ func TestImageQueryPagination(t *testing.T) {
 	})
 }
 
+func createImageWithPhash(ctx context.Context, name string, phash int64) (*models.Image, error) {
+	imageFile := &models.ImageFile{
+		BaseFile: &models.BaseFile{
+			Basename:       name,
+			ParentFolderID: folderIDs[folderIdxWithImageFiles],
+			Fingerprints: []models.Fingerprint{
+				{
+					Type:        models.FingerprintTypePhash,
+					Fingerprint: phash,
+				},
+			},
+		},
+	}
+
+	if err := db.File.Create(ctx, imageFile); err != nil {
+		return nil, err
+	}
+
+	image := &models.Image{}
+
+	if err := db.Image.Create(ctx, image, []models.FileID{imageFile.ID}); err != nil {
+		return nil, err
+	}
+
+	return image, nil
+}
+
+func TestImageStore_FindDuplicates(t *testing.T) {
+	const phash = int64(0x7f00ff00ff00ff00)
+
+	qb := db.Image
+
+	withRollbackTxn(func(ctx context.Context) error {
+		var ids []int
+		// the last image differs from the others by a single bit
+		for i, v := range []int64{phash, phash, phash ^ 1} {
+			image, err := createImageWithPhash(ctx, fmt.Sprintf("TestImageStore_FindDuplicates %d", i), v)
+			if err != nil {
+				t.Errorf("Error creating image: %v", err)
+				return nil
+			}
+			ids = append(ids, image.ID)
+		}
+
+		got, err := qb.FindDuplicates(ctx, 0)
+		if err != nil {
+			t.Errorf("ImageStore.FindDuplicates() error = %v", err)
+			return nil
+		}
+
+		if assert.Len(t, got, 1) {
+			assert.ElementsMatch(t, ids[:2], imagesToIDs(got[0]))
+		}
+
+		got, err = qb.FindDuplicates(ctx, 1)
+		if err != nil {
+			t.Errorf("ImageStore.FindDuplicates() error = %v", err)
+			return nil
+		}
+
+		if assert.Len(t, got, 1) {
+			assert.ElementsMatch(t, ids, imagesToIDs(got[0]))
+		}
+
+		return nil
+	})
+}
+
+func TestImageQueryPhashDistance(t *testing.T) {
+	const phash = int64(0x7f00ff00ff00ff00)
+
+	withRollbackTxn(func(ctx context.Context) error {
+		exact, err := createImageWithPhash(ctx, "TestImageQueryPhashDistance exact", phash)
+		if err != nil {
+			t.Errorf("Error creating image: %v", err)
+			return nil
+		}
+		similar, err := createImageWithPhash(ctx, "TestImageQueryPhashDistance similar", phash^3)
+		if err != nil {
+			t.Errorf("Error creating image: %v", err)
+			return nil
+		}
+
+		distance := 0
+		imageFilter := models.ImageFilterType{
+			PhashDistance: &models.PhashDistanceCriterionInput{
+				Value:    utils.PhashToString(phash),
+				Modifier: models.CriterionModifierEquals,
+				Distance: &distance,
+			},
+		}
+
+		images := queryImages(ctx, t, db.Image, &imageFilter, nil)
+		assert.ElementsMatch(t, []int{exact.ID}, imagesToIDs(images))
+
+		distance = 3
+		images = queryImages(ctx, t, db.Image, &imageFilter, nil)
+		assert.ElementsMatch(t, []int{exact.ID, similar.ID}, imagesToIDs(images))
+
+		return nil
+	})
+}
+
 // TODO Count
 // TODO SizeCount
 // TODO All
@@ -0,0 +1,3 @@
+ALTER TABLE `performer_stash_ids` ADD COLUMN `updated_at` datetime;
+ALTER TABLE `scene_stash_ids` ADD COLUMN `updated_at` datetime;
+ALTER TABLE `studio_stash_ids` ADD COLUMN `updated_at` datetime;
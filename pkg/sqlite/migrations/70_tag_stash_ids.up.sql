@@ -0,0 +1,9 @@
+CREATE TABLE `tag_stash_ids` (
+  `tag_id` integer,
+  `endpoint` varchar(255),
+  `stash_id` varchar(36),
+  `updated_at` datetime,
+  foreign key(`tag_id`) references `tags`(`id`) on delete CASCADE
+);
+
+CREATE INDEX `index_tag_stash_ids_on_tag_id` ON `tag_stash_ids` (`tag_id`);
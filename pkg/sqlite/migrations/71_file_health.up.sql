@@ -0,0 +1,5 @@
+ALTER TABLE `files` ADD COLUMN `health_status` varchar(16);
+ALTER TABLE `files` ADD COLUMN `health_error` text;
+ALTER TABLE `files` ADD COLUMN `health_checked_at` datetime;
+
+CREATE INDEX `index_files_on_health_status` ON `files` (`health_status`) WHERE `health_status` IS NOT NULL;
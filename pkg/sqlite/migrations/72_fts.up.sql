@@ -0,0 +1,149 @@
+-- Full-text indexes used by the q value of find filters.
+-- The trigram tokenizer is used so that terms match substrings, in the same
+-- way as the LIKE '%term%' clauses that these replace.
+-- The rowid of each index is the id of the indexed row.
+
+CREATE VIRTUAL TABLE `scenes_fts` USING fts5(`title`, `details`, `code`, tokenize = 'trigram');
+CREATE VIRTUAL TABLE `files_fts` USING fts5(`path`, tokenize = 'trigram');
+CREATE VIRTUAL TABLE `scene_markers_fts` USING fts5(`title`, tokenize = 'trigram');
+CREATE VIRTUAL TABLE `performers_fts` USING fts5(`name`, `aliases`, tokenize = 'trigram');
+CREATE VIRTUAL TABLE `studios_fts` USING fts5(`name`, `aliases`, tokenize = 'trigram');
+CREATE VIRTUAL TABLE `tags_fts` USING fts5(`name`, `aliases`, tokenize = 'trigram');
+
+-- scenes
+INSERT INTO `scenes_fts` (`rowid`, `title`, `details`, `code`)
+  SELECT `id`, `title`, `details`, `code` FROM `scenes`;
+
+CREATE TRIGGER `scenes_fts_insert` AFTER INSERT ON `scenes` BEGIN
+  INSERT INTO `scenes_fts` (`rowid`, `title`, `details`, `code`) VALUES (new.`id`, new.`title`, new.`details`, new.`code`);
+END;
+
+CREATE TRIGGER `scenes_fts_update` AFTER UPDATE OF `title`, `details`, `code` ON `scenes` BEGIN
+  UPDATE `scenes_fts` SET `title` = new.`title`, `details` = new.`details`, `code` = new.`code` WHERE `rowid` = new.`id`;
+END;
+
+CREATE TRIGGER `scenes_fts_delete` AFTER DELETE ON `scenes` BEGIN
+  DELETE FROM `scenes_fts` WHERE `rowid` = old.`id`;
+END;
+
+-- files
+-- the path separator is not stored, so it is inferred from the folder path
+INSERT INTO `files_fts` (`rowid`, `path`)
+  SELECT `files`.`id`, `folders`.`path` || (CASE WHEN substr(`folders`.`path`, 1, 1) = '/' THEN '/' ELSE '\' END) || `files`.`basename`
+  FROM `files` INNER JOIN `folders` ON `folders`.`id` = `files`.`parent_folder_id`;
+
+CREATE TRIGGER `files_fts_insert` AFTER INSERT ON `files` BEGIN
+  INSERT INTO `files_fts` (`rowid`, `path`)
+    SELECT new.`id`, `path` || (CASE WHEN substr(`path`, 1, 1) = '/' THEN '/' ELSE '\' END) || new.`basename`
+    FROM `folders` WHERE `id` = new.`parent_folder_id`;
+END;
+
+CREATE TRIGGER `files_fts_update` AFTER UPDATE OF `basename`, `parent_folder_id` ON `files` BEGIN
+  UPDATE `files_fts` SET `path` = (
+    SELECT `path` || (CASE WHEN substr(`path`, 1, 1) = '/' THEN '/' ELSE '\' END) || new.`basename`
+    FROM `folders` WHERE `id` = new.`parent_folder_id`
+  ) WHERE `rowid` = new.`id`;
+END;
+
+CREATE TRIGGER `files_fts_delete` AFTER DELETE ON `files` BEGIN
+  DELETE FROM `files_fts` WHERE `rowid` = old.`id`;
+END;
+
+CREATE TRIGGER `files_fts_folder_update` AFTER UPDATE OF `path` ON `folders` BEGIN
+  UPDATE `files_fts` SET `path` = (
+    SELECT new.`path` || (CASE WHEN substr(new.`path`, 1, 1) = '/' THEN '/' ELSE '\' END) || `files`.`basename`
+    FROM `files` WHERE `files`.`id` = `files_fts`.`rowid`
+  ) WHERE `rowid` IN (SELECT `id` FROM `files` WHERE `parent_folder_id` = new.`id`);
+END;
+
+-- scene markers
+INSERT INTO `scene_markers_fts` (`rowid`, `title`)
+  SELECT `id`, `title` FROM `scene_markers`;
+
+CREATE TRIGGER `scene_markers_fts_insert` AFTER INSERT ON `scene_markers` BEGIN
+  INSERT INTO `scene_markers_fts` (`rowid`, `title`) VALUES (new.`id`, new.`title`);
+END;
+
+CREATE TRIGGER `scene_markers_fts_update` AFTER UPDATE OF `title` ON `scene_markers` BEGIN
+  UPDATE `scene_markers_fts` SET `title` = new.`title` WHERE `rowid` = new.`id`;
+END;
+
+CREATE TRIGGER `scene_markers_fts_delete` AFTER DELETE ON `scene_markers` BEGIN
+  DELETE FROM `scene_markers_fts` WHERE `rowid` = old.`id`;
+END;
+
+-- performers
+-- aliases are separated by newlines, so that terms do not match across aliases
+INSERT INTO `performers_fts` (`rowid`, `name`, `aliases`)
+  SELECT `id`, `name`, (SELECT group_concat(`alias`, char(10)) FROM `performer_aliases` WHERE `performer_id` = `performers`.`id`)
+  FROM `performers`;
+
+CREATE TRIGGER `performers_fts_insert` AFTER INSERT ON `performers` BEGIN
+  INSERT INTO `performers_fts` (`rowid`, `name`) VALUES (new.`id`, new.`name`);
+END;
+
+CREATE TRIGGER `performers_fts_update` AFTER UPDATE OF `name` ON `performers` BEGIN
+  UPDATE `performers_fts` SET `name` = new.`name` WHERE `rowid` = new.`id`;
+END;
+
+CREATE TRIGGER `performers_fts_delete` AFTER DELETE ON `performers` BEGIN
+  DELETE FROM `performers_fts` WHERE `rowid` = old.`id`;
+END;
+
+CREATE TRIGGER `performers_fts_alias_insert` AFTER INSERT ON `performer_aliases` BEGIN
+  UPDATE `performers_fts` SET `aliases` = (SELECT group_concat(`alias`, char(10)) FROM `performer_aliases` WHERE `performer_id` = new.`performer_id`) WHERE `rowid` = new.`performer_id`;
+END;
+
+CREATE TRIGGER `performers_fts_alias_delete` AFTER DELETE ON `performer_aliases` BEGIN
+  UPDATE `performers_fts` SET `aliases` = (SELECT group_concat(`alias`, char(10)) FROM `performer_aliases` WHERE `performer_id` = old.`performer_id`) WHERE `rowid` = old.`performer_id`;
+END;
+
+-- studios
+INSERT INTO `studios_fts` (`rowid`, `name`, `aliases`)
+  SELECT `id`, `name`, (SELECT group_concat(`alias`, char(10)) FROM `studio_aliases` WHERE `studio_id` = `studios`.`id`)
+  FROM `studios`;
+
+CREATE TRIGGER `studios_fts_insert` AFTER INSERT ON `studios` BEGIN
+  INSERT INTO `studios_fts` (`rowid`, `name`) VALUES (new.`id`, new.`name`);
+END;
+
+CREATE TRIGGER `studios_fts_update` AFTER UPDATE OF `name` ON `studios` BEGIN
+  UPDATE `studios_fts` SET `name` = new.`name` WHERE `rowid` = new.`id`;
+END;
+
+CREATE TRIGGER `studios_fts_delete` AFTER DELETE ON `studios` BEGIN
+  DELETE FROM `studios_fts` WHERE `rowid` = old.`id`;
+END;
+
+CREATE TRIGGER `studios_fts_alias_insert` AFTER INSERT ON `studio_aliases` BEGIN
+  UPDATE `studios_fts` SET `aliases` = (SELECT group_concat(`alias`, char(10)) FROM `studio_aliases` WHERE `studio_id` = new.`studio_id`) WHERE `rowid` = new.`studio_id`;
+END;
+
+CREATE TRIGGER `studios_fts_alias_delete` AFTER DELETE ON `studio_aliases` BEGIN
+  UPDATE `studios_fts` SET `aliases` = (SELECT group_concat(`alias`, char(10)) FROM `studio_aliases` WHERE `studio_id` = old.`studio_id`) WHERE `rowid` = old.`studio_id`;
+END;
+
+-- tags
+INSERT INTO `tags_fts` (`rowid`, `name`, `aliases`)
+  SELECT `id`, `name`, (SELECT group_concat(`alias`, char(10)) FROM `tag_aliases` WHERE `tag_id` = `tags`.`id`)
+  FROM `tags`;
+
+CREATE TRIGGER `tags_fts_insert` AFTER INSERT ON `tags` BEGIN
+  INSERT INTO `tags_fts` (`rowid`, `name`) VALUES (new.`id`, new.`name`);
+END;
+
+CREATE TRIGGER `tags_fts_update` AFTER UPDATE OF `name` ON `tags` BEGIN
+  UPDATE `tags_fts` SET `name` = new.`name` WHERE `rowid` = new.`id`;
+END;
+
+CREATE TRIGGER `tags_fts_delete` AFTER DELETE ON `tags` BEGIN
+  DELETE FROM `tags_fts` WHERE `rowid` = old.`id`;
+END;
+
+CREATE TRIGGER `tags_fts_alias_insert` AFTER INSERT ON `tag_aliases` BEGIN
+  UPDATE `tags_fts` SET `aliases` = (SELECT group_concat(`alias`, char(10)) FROM `tag_aliases` WHERE `tag_id` = new.`tag_id`) WHERE `rowid` = new.`tag_id`;
+END;
+
+CREATE TRIGGER `tags_fts_alias_delete` AFTER DELETE ON `tag_aliases` BEGIN
+  UPDATE `tags_fts` SET `aliases` = (SELECT group_concat(`alias`, char(10)) FROM `tag_aliases` WHERE `tag_id` = old.`tag_id`) WHERE `rowid` = old.`tag_id`;
+END;
@@ -0,0 +1,18 @@
+-- Field-level history of changes made to objects.
+-- Entries are not deleted with their object, so that destroyed objects
+-- retain their history.
+CREATE TABLE `edit_history` (
+  `id` integer not null primary key autoincrement,
+  `entity_type` varchar(255) not null,
+  `entity_id` integer not null,
+  `action` varchar(255) not null,
+  `field` varchar(255) not null,
+  `old_value` text,
+  `new_value` text,
+  `user` varchar(255),
+  `origin` varchar(255) not null,
+  `plugin_id` varchar(255),
+  `created_at` datetime not null
+);
+
+CREATE INDEX `index_edit_history_on_entity` on `edit_history` (`entity_type`, `entity_id`);
@@ -0,0 +1,13 @@
+-- Records deleted objects, so that deletions can be included in incremental
+-- exports. Objects are identified the same way as in exported JSON.
+CREATE TABLE `tombstones` (
+  `id` integer not null primary key autoincrement,
+  `object_type` varchar(255) not null,
+  `name` varchar(255),
+  `disambiguation` varchar(255),
+  `paths` text,
+  `folder_path` text,
+  `deleted_at` datetime not null
+);
+
+CREATE INDEX `index_tombstones_on_deleted_at` on `tombstones` (`deleted_at`);
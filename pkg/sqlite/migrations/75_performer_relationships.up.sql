@@ -0,0 +1,16 @@
+ALTER TABLE `performers` ADD COLUMN `is_group` boolean not null default '0';
+
+-- HAS_MEMBER relationships are stored as MEMBER_OF relationships of the
+-- member, so that each pair of performers has at most one row.
+CREATE TABLE `performers_relations` (
+  `performer_id` integer not null,
+  `related_id` integer not null,
+  `type` varchar(255) not null,
+  primary key (`performer_id`, `related_id`),
+  foreign key (`performer_id`) references `performers`(`id`) on delete cascade,
+  foreign key (`related_id`) references `performers`(`id`) on delete cascade,
+  check (`performer_id` != `related_id`)
+);
+
+CREATE INDEX `index_performers_relations_related_id` ON `performers_relations` (`related_id`);
+CREATE UNIQUE INDEX `index_performers_relations_pair_unique` ON `performers_relations` (min(`performer_id`, `related_id`), max(`performer_id`, `related_id`));
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/doug-martin/goqu/v9"
 	"github.com/doug-martin/goqu/v9/exp"
@@ -17,11 +18,12 @@ import (
 )
 
 const (
-	performerTable         = "performers"
-	performerIDColumn      = "performer_id"
-	performersAliasesTable = "performer_aliases"
-	performerAliasColumn   = "alias"
-	performersTagsTable    = "performers_tags"
+	performerTable          = "performers"
+	performerIDColumn       = "performer_id"
+	performersAliasesTable  = "performer_aliases"
+	performerAliasColumn    = "alias"
+	performersTagsTable     = "performers_tags"
+	performerRelationsTable = "performers_relations"
 
 	performerURLsTable = "performer_urls"
 	performerURLColumn = "url"
@@ -56,6 +58,7 @@ type performerRow struct {
 	HairColor     zero.String `db:"hair_color"`
 	Weight        null.Int    `db:"weight"`
 	IgnoreAutoTag bool        `db:"ignore_auto_tag"`
+	IsGroup       bool        `db:"is_group"`
 
 	// not used in resolution or updates
 	ImageBlob zero.String `db:"image_blob"`
@@ -91,6 +94,7 @@ func (r *performerRow) fromPerformer(o models.Performer) {
 	r.HairColor = zero.StringFrom(o.HairColor)
 	r.Weight = intFromPtr(o.Weight)
 	r.IgnoreAutoTag = o.IgnoreAutoTag
+	r.IsGroup = o.IsGroup
 }
 
 func (r *performerRow) resolve() *models.Performer {
@@ -119,6 +123,7 @@ func (r *performerRow) resolve() *models.Performer {
 		HairColor:     r.HairColor.String,
 		Weight:        nullIntPtr(r.Weight),
 		IgnoreAutoTag: r.IgnoreAutoTag,
+		IsGroup:       r.IsGroup,
 	}
 
 	if r.Gender.ValueOrZero() != "" {
@@ -163,6 +168,7 @@ func (r *performerRowRecord) fromPartial(o models.PerformerPartial) {
 	r.setNullString("hair_color", o.HairColor)
 	r.setNullInt("weight", o.Weight)
 	r.setBool("ignore_auto_tag", o.IgnoreAutoTag)
+	r.setBool("is_group", o.IsGroup)
 }
 
 type performerRepositoryType struct {
@@ -226,6 +232,7 @@ var (
 
 type PerformerStore struct {
 	blobJoinQueryBuilder
+	performerRelationshipStore
 
 	tableMgr *table
 }
@@ -236,6 +243,9 @@ func NewPerformerStore(blobStore *BlobStore) *PerformerStore {
 			blobStore: blobStore,
 			joinTable: performerTable,
 		},
+		performerRelationshipStore: performerRelationshipStore{
+			table: performerRelationshipTableMgr,
+		},
 		tableMgr: performerTableMgr,
 	}
 }
@@ -282,6 +292,12 @@ func (qb *PerformerStore) Create(ctx context.Context, newObject *models.Performe
 		}
 	}
 
+	if newObject.RelatedPerformers.Loaded() {
+		if err := qb.performerRelationshipStore.replaceRelationships(ctx, id, newObject.RelatedPerformers.List()); err != nil {
+			return err
+		}
+	}
+
 	updated, err := qb.find(ctx, id)
 	if err != nil {
 		return fmt.Errorf("finding after create: %w", err)
@@ -329,6 +345,11 @@ func (qb *PerformerStore) UpdatePartial(ctx context.Context, id int, partial mod
 			return nil, err
 		}
 	}
+	if partial.RelatedPerformers != nil {
+		if err := qb.performerRelationshipStore.modifyRelationships(ctx, id, partial.RelatedPerformers); err != nil {
+			return nil, err
+		}
+	}
 
 	return qb.find(ctx, id)
 }
@@ -365,16 +386,31 @@ func (qb *PerformerStore) Update(ctx context.Context, updatedObject *models.Perf
 		}
 	}
 
+	if updatedObject.RelatedPerformers.Loaded() {
+		if err := qb.performerRelationshipStore.replaceRelationships(ctx, updatedObject.ID, updatedObject.RelatedPerformers.List()); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
 func (qb *PerformerStore) Destroy(ctx context.Context, id int) error {
+	t, err := qb.tombstone(ctx, id)
+	if err != nil {
+		return err
+	}
+
 	// must handle image checksums manually
 	if err := qb.destroyImage(ctx, id); err != nil {
 		return err
 	}
 
-	return performerRepository.destroyExisting(ctx, []int{id})
+	if err := performerRepository.destroyExisting(ctx, []int{id}); err != nil {
+		return err
+	}
+
+	return recordTombstone(ctx, t)
 }
 
 // returns nil, nil if not found
@@ -587,6 +623,18 @@ func (qb *PerformerStore) QueryForAutoTag(ctx context.Context, words []string) (
 	return ret, nil
 }
 
+var performerTextSearch = textSearch{
+	idColumn: "performers.id",
+	sources: []searchSource{
+		{
+			from:     "performers_fts",
+			idColumn: "performers_fts.rowid",
+			fts:      "performers_fts",
+			columns:  []string{"performers_fts.name", "performers_fts.aliases"},
+		},
+	},
+}
+
 func (qb *PerformerStore) makeQuery(ctx context.Context, performerFilter *models.PerformerFilterType, findFilter *models.FindFilterType) (*queryBuilder, error) {
 	if performerFilter == nil {
 		performerFilter = &models.PerformerFilterType{}
@@ -599,9 +647,7 @@ func (qb *PerformerStore) makeQuery(ctx context.Context, performerFilter *models
 	distinctIDs(&query, performerTable)
 
 	if q := findFilter.Q; q != nil && *q != "" {
-		query.join(performersAliasesTable, "", "performer_aliases.performer_id = performers.id")
-		searchColumns := []string{"performers.name", "performer_aliases.alias"}
-		query.parseQueryString(searchColumns, *q)
+		query.parseTextSearch(performerTextSearch, *q)
 	}
 
 	filter := filterBuilderFromHandler(ctx, &performerFilterHandler{
@@ -612,10 +658,14 @@ func (qb *PerformerStore) makeQuery(ctx context.Context, performerFilter *models
 		return nil, err
 	}
 
-	var err error
-	query.sortAndPagination, err = qb.getPerformerSort(findFilter)
-	if err != nil {
-		return nil, err
+	if query.useRelevanceSort(findFilter) {
+		query.sortAndPagination = query.getRelevanceSort(findFilter)
+	} else {
+		var err error
+		query.sortAndPagination, err = qb.getPerformerSort(withoutRelevanceSort(findFilter))
+		if err != nil {
+			return nil, err
+		}
 	}
 	query.sortAndPagination += getPagination(findFilter)
 
@@ -806,6 +856,10 @@ func (qb *PerformerStore) GetStashIDs(ctx context.Context, performerID int) ([]m
 	return performersStashIDsTableMgr.get(ctx, performerID)
 }
 
+func (qb *PerformerStore) UpdateStashIDUpdatedAt(ctx context.Context, performerID int, endpoint string, updatedAt time.Time) error {
+	return performersStashIDsTableMgr.setUpdatedAt(ctx, performerID, endpoint, updatedAt)
+}
+
 func (qb *PerformerStore) FindByStashID(ctx context.Context, stashID models.StashID) ([]*models.Performer, error) {
 	sq := dialect.From(performersStashIDsJoinTable).Select(performersStashIDsJoinTable.Col(performerIDColumn)).Where(
 		performersStashIDsJoinTable.Col("stash_id").Eq(stashID.StashID),
@@ -82,6 +82,7 @@ func (qb *performerFilterHandler) criterionHandler() criterionHandler {
 
 		boolCriterionHandler(filter.FilterFavorites, tableName+".favorite", nil),
 		boolCriterionHandler(filter.IgnoreAutoTag, tableName+".ignore_auto_tag", nil),
+		boolCriterionHandler(filter.IsGroup, tableName+".is_group", nil),
 
 		yearFilterCriterionHandler(filter.BirthYear, tableName+".birthdate"),
 		yearFilterCriterionHandler(filter.DeathYear, tableName+".death_date"),
@@ -156,6 +157,7 @@ func (qb *performerFilterHandler) criterionHandler() criterionHandler {
 		qb.studiosCriterionHandler(filter.Studios),
 
 		qb.appearsWithCriterionHandler(filter.Performers),
+		qb.relatedPerformersCriterionHandler(filter.RelatedPerformers),
 
 		qb.tagCountCriterionHandler(filter.TagCount),
 		qb.sceneCountCriterionHandler(filter.SceneCount),
@@ -533,3 +535,68 @@ func (qb *performerFilterHandler) appearsWithCriterionHandler(performers *models
 		}
 	}
 }
+
+func (qb *performerFilterHandler) relatedPerformersCriterionHandler(related *models.PerformerRelationshipCriterionInput) criterionHandlerFunc {
+	return func(ctx context.Context, f *filterBuilder) {
+		if related == nil {
+			return
+		}
+
+		// relationships from the point of view of each performer
+		relations := fmt.Sprintf(`SELECT performer_id, related_id, type FROM %[1]s
+UNION ALL
+SELECT related_id AS performer_id, performer_id AS related_id, CASE type WHEN '%[2]s' THEN '%[3]s' ELSE type END AS type FROM %[1]s`,
+			performerRelationsTable, models.PerformerRelationshipTypeMemberOf, models.PerformerRelationshipTypeHasMember)
+
+		var where []string
+		var args []interface{}
+		if len(related.Type) > 0 {
+			for _, t := range related.Type {
+				if !t.IsValid() {
+					f.setError(fmt.Errorf("invalid performer relationship type: %s", t))
+					return
+				}
+				args = append(args, t.String())
+			}
+			where = append(where, "type IN "+getInBinding(len(related.Type)))
+		}
+
+		not := ""
+		switch related.Modifier {
+		case models.CriterionModifierIsNull:
+			not = "NOT "
+		case models.CriterionModifierNotNull:
+		case models.CriterionModifierIncludes, models.CriterionModifierIncludesAll, models.CriterionModifierExcludes:
+			if len(related.Value) == 0 {
+				return
+			}
+
+			for _, v := range related.Value {
+				id, err := strconv.Atoi(v)
+				if err != nil {
+					f.setError(fmt.Errorf("invalid performer ID: %s", v))
+					return
+				}
+				args = append(args, id)
+			}
+			where = append(where, "related_id IN "+getInBinding(len(related.Value)))
+
+			if related.Modifier == models.CriterionModifierExcludes {
+				not = "NOT "
+			}
+		default:
+			f.setError(fmt.Errorf("invalid related performers modifier: %s", related.Modifier))
+			return
+		}
+
+		query := fmt.Sprintf("SELECT performer_id FROM (%s)", relations)
+		if len(where) > 0 {
+			query += " WHERE " + strings.Join(where, " AND ")
+		}
+		if related.Modifier == models.CriterionModifierIncludesAll && len(related.Value) > 1 {
+			query += fmt.Sprintf(" GROUP BY performer_id HAVING COUNT(DISTINCT related_id) = %d", len(related.Value))
+		}
+
+		f.addWhere(fmt.Sprintf("%s.id %sIN (%s)", performerTable, not, query), args...)
+	}
+}
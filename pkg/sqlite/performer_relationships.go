@@ -0,0 +1,139 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/jmoiron/sqlx"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// performerRelationshipRow is a relationship between two performers. Each
+// pair of performers has at most one row. HAS_MEMBER relationships are stored
+// as the MEMBER_OF relationship of the member.
+type performerRelationshipRow struct {
+	PerformerID int    `db:"performer_id"`
+	RelatedID   int    `db:"related_id"`
+	Type        string `db:"type"`
+}
+
+// resolve returns the relationship from the point of view of the performer
+// with the given id.
+func (r performerRelationshipRow) resolve(id int) models.PerformerRelationship {
+	t := models.PerformerRelationshipType(r.Type)
+	if r.PerformerID == id {
+		return models.PerformerRelationship{
+			PerformerID: r.RelatedID,
+			Type:        t,
+		}
+	}
+
+	return models.PerformerRelationship{
+		PerformerID: r.PerformerID,
+		Type:        t.Inverse(),
+	}
+}
+
+func newPerformerRelationshipRow(id int, v models.PerformerRelationship) performerRelationshipRow {
+	if v.Type == models.PerformerRelationshipTypeHasMember {
+		return performerRelationshipRow{
+			PerformerID: v.PerformerID,
+			RelatedID:   id,
+			Type:        models.PerformerRelationshipTypeMemberOf.String(),
+		}
+	}
+
+	return performerRelationshipRow{
+		PerformerID: id,
+		RelatedID:   v.PerformerID,
+		Type:        v.Type.String(),
+	}
+}
+
+type performerRelationshipStore struct {
+	table *table
+}
+
+func (s *performerRelationshipStore) GetRelatedPerformers(ctx context.Context, id int) ([]models.PerformerRelationship, error) {
+	table := s.table.table
+	q := dialect.Select(table.All()).
+		From(table).
+		Where(goqu.Or(
+			table.Col(performerIDColumn).Eq(id),
+			table.Col("related_id").Eq(id),
+		))
+
+	const single = false
+	var ret []models.PerformerRelationship
+	if err := queryFunc(ctx, q, single, func(rows *sqlx.Rows) error {
+		var row performerRelationshipRow
+		if err := rows.StructScan(&row); err != nil {
+			return err
+		}
+
+		ret = append(ret, row.resolve(id))
+
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("getting performer relationships from %s: %w", table.GetTable(), err)
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].PerformerID < ret[j].PerformerID
+	})
+
+	return ret, nil
+}
+
+func (s *performerRelationshipStore) destroyAllRelationships(ctx context.Context, id int) error {
+	table := s.table.table
+	q := dialect.Delete(table).Where(goqu.Or(
+		table.Col(performerIDColumn).Eq(id),
+		table.Col("related_id").Eq(id),
+	))
+
+	if _, err := exec(ctx, q); err != nil {
+		return fmt.Errorf("destroying %s: %w", table.GetTable(), err)
+	}
+
+	return nil
+}
+
+// replaceRelationships replaces all relationships of the performer with the
+// given id.
+func (s *performerRelationshipStore) replaceRelationships(ctx context.Context, id int, relationships []models.PerformerRelationship) error {
+	if err := s.destroyAllRelationships(ctx, id); err != nil {
+		return err
+	}
+
+	seen := make(map[int]bool)
+	for _, v := range relationships {
+		if !v.Type.IsValid() {
+			return fmt.Errorf("invalid performer relationship type: %s", v.Type)
+		}
+		if v.PerformerID == id {
+			return fmt.Errorf("performer %d cannot be related to itself", id)
+		}
+		if seen[v.PerformerID] {
+			return fmt.Errorf("performer %d has more than one relationship with performer %d", id, v.PerformerID)
+		}
+		seen[v.PerformerID] = true
+
+		if _, err := s.table.insert(ctx, newPerformerRelationshipRow(id, v)); err != nil {
+			return fmt.Errorf("inserting into %s: %w", s.table.table.GetTable(), err)
+		}
+	}
+
+	return nil
+}
+
+func (s *performerRelationshipStore) modifyRelationships(ctx context.Context, id int, v *models.UpdatePerformerRelationships) error {
+	existing, err := s.GetRelatedPerformers(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return s.replaceRelationships(ctx, id, v.Apply(existing))
+}
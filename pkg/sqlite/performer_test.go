@@ -1703,8 +1703,9 @@ func testPerformerStashIDs(ctx context.Context, t *testing.T, s *models.Performe
 	const stashIDStr = "stashID"
 	const endpoint = "endpoint"
 	stashID := models.StashID{
-		StashID:  stashIDStr,
-		Endpoint: endpoint,
+		StashID:   stashIDStr,
+		Endpoint:  endpoint,
+		UpdatedAt: time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC),
 	}
 
 	qb := db.Performer
@@ -1728,6 +1729,25 @@ func testPerformerStashIDs(ctx context.Context, t *testing.T, s *models.Performe
 
 	assert.Equal(t, []models.StashID{stashID}, s.StashIDs.List())
 
+	// replacing the stash ids without an updated time should retain the existing time
+	s.StashIDs = models.NewRelatedStashIDs([]models.StashID{
+		{
+			StashID:  stashIDStr,
+			Endpoint: endpoint,
+		},
+	})
+	if err := qb.Update(ctx, s); err != nil {
+		t.Error(err.Error())
+	}
+
+	s.StashIDs = models.RelatedStashIDs{}
+	if err := s.LoadStashIDs(ctx, qb); err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	assert.Equal(t, []models.StashID{stashID}, s.StashIDs.List())
+
 	// remove stash ids and ensure was updated
 	s, err = qb.UpdatePartial(ctx, s.ID, models.PerformerPartial{
 		StashIDs: &models.UpdateStashIDs{
@@ -1747,6 +1767,156 @@ func testPerformerStashIDs(ctx context.Context, t *testing.T, s *models.Performe
 	assert.Len(t, s.StashIDs.List(), 0)
 }
 
+func TestPerformerRelatedPerformers(t *testing.T) {
+	if err := withRollbackTxn(func(ctx context.Context) error {
+		qb := db.Performer
+
+		newPerformer := func(name string, isGroup bool) (*models.Performer, error) {
+			p := &models.Performer{
+				Name:    name,
+				IsGroup: isGroup,
+			}
+			if err := qb.Create(ctx, p); err != nil {
+				return nil, fmt.Errorf("Error creating performer: %s", err.Error())
+			}
+			return p, nil
+		}
+
+		group, err := newPerformer("TestPerformerRelatedPerformers group", true)
+		if err != nil {
+			return err
+		}
+		member, err := newPerformer("TestPerformerRelatedPerformers member", false)
+		if err != nil {
+			return err
+		}
+		alterEgo, err := newPerformer("TestPerformerRelatedPerformers alter ego", false)
+		if err != nil {
+			return err
+		}
+
+		assert.True(t, group.IsGroup)
+
+		// set from the group's point of view
+		if _, err := qb.UpdatePartial(ctx, group.ID, models.PerformerPartial{
+			RelatedPerformers: &models.UpdatePerformerRelationships{
+				Relationships: []models.PerformerRelationship{
+					{PerformerID: member.ID, Type: models.PerformerRelationshipTypeHasMember},
+				},
+				Mode: models.RelationshipUpdateModeSet,
+			},
+		}); err != nil {
+			return err
+		}
+
+		// add from the member's point of view
+		if _, err := qb.UpdatePartial(ctx, member.ID, models.PerformerPartial{
+			RelatedPerformers: &models.UpdatePerformerRelationships{
+				Relationships: []models.PerformerRelationship{
+					{PerformerID: alterEgo.ID, Type: models.PerformerRelationshipTypeSamePerson},
+				},
+				Mode: models.RelationshipUpdateModeAdd,
+			},
+		}); err != nil {
+			return err
+		}
+
+		got, err := qb.GetRelatedPerformers(ctx, member.ID)
+		if err != nil {
+			return err
+		}
+		assert.Equal(t, []models.PerformerRelationship{
+			{PerformerID: group.ID, Type: models.PerformerRelationshipTypeMemberOf},
+			{PerformerID: alterEgo.ID, Type: models.PerformerRelationshipTypeSamePerson},
+		}, got)
+
+		got, err = qb.GetRelatedPerformers(ctx, alterEgo.ID)
+		if err != nil {
+			return err
+		}
+		assert.Equal(t, []models.PerformerRelationship{
+			{PerformerID: member.ID, Type: models.PerformerRelationshipTypeSamePerson},
+		}, got)
+
+		// a performer cannot be related to itself
+		_, err = qb.UpdatePartial(ctx, member.ID, models.PerformerPartial{
+			RelatedPerformers: &models.UpdatePerformerRelationships{
+				Relationships: []models.PerformerRelationship{
+					{PerformerID: member.ID, Type: models.PerformerRelationshipTypeSibling},
+				},
+				Mode: models.RelationshipUpdateModeAdd,
+			},
+		})
+		assert.NotNil(t, err)
+
+		queryIDs := func(c models.PerformerRelationshipCriterionInput) []int {
+			performers := queryPerformers(ctx, t, &models.PerformerFilterType{
+				RelatedPerformers: &c,
+			}, nil)
+
+			var ids []int
+			for _, p := range performers {
+				ids = append(ids, p.ID)
+			}
+			return ids
+		}
+
+		ids := queryIDs(models.PerformerRelationshipCriterionInput{
+			Value:    []string{strconv.Itoa(member.ID)},
+			Modifier: models.CriterionModifierIncludes,
+		})
+		assert.ElementsMatch(t, []int{group.ID, alterEgo.ID}, ids)
+
+		ids = queryIDs(models.PerformerRelationshipCriterionInput{
+			Value:    []string{strconv.Itoa(member.ID)},
+			Type:     []models.PerformerRelationshipType{models.PerformerRelationshipTypeHasMember},
+			Modifier: models.CriterionModifierIncludes,
+		})
+		assert.Equal(t, []int{group.ID}, ids)
+
+		ids = queryIDs(models.PerformerRelationshipCriterionInput{
+			Value:    []string{strconv.Itoa(group.ID), strconv.Itoa(alterEgo.ID)},
+			Modifier: models.CriterionModifierIncludesAll,
+		})
+		assert.Equal(t, []int{member.ID}, ids)
+
+		ids = queryIDs(models.PerformerRelationshipCriterionInput{
+			Type:     []models.PerformerRelationshipType{models.PerformerRelationshipTypeMemberOf},
+			Modifier: models.CriterionModifierNotNull,
+		})
+		assert.Equal(t, []int{member.ID}, ids)
+
+		ids = queryIDs(models.PerformerRelationshipCriterionInput{
+			Modifier: models.CriterionModifierIsNull,
+		})
+		assert.NotContains(t, ids, group.ID)
+		assert.Contains(t, ids, performerIDs[performerIdxWithScene])
+
+		isGroup := true
+		performers := queryPerformers(ctx, t, &models.PerformerFilterType{
+			IsGroup: &isGroup,
+		}, nil)
+		if assert.Len(t, performers, 1) {
+			assert.Equal(t, group.ID, performers[0].ID)
+		}
+
+		// relationships are removed with the performer
+		if err := qb.Destroy(ctx, member.ID); err != nil {
+			return err
+		}
+
+		got, err = qb.GetRelatedPerformers(ctx, group.ID)
+		if err != nil {
+			return err
+		}
+		assert.Len(t, got, 0)
+
+		return nil
+	}); err != nil {
+		t.Error(err.Error())
+	}
+}
+
 func TestPerformerQueryRating100(t *testing.T) {
 	const rating = 60
 	ratingCriterion := models.IntCriterionInput{
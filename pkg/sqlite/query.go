@@ -22,6 +22,10 @@ type queryBuilder struct {
 	recursiveWith bool
 
 	sortAndPagination string
+
+	// searchRank is the expression of the relevance of each result to the
+	// q value of the find filter. Lower values are more relevant.
+	searchRank string
 }
 
 func (qb queryBuilder) body() string {
@@ -9,6 +9,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/doug-martin/goqu/v9"
 	"github.com/doug-martin/goqu/v9/exp"
@@ -472,6 +473,11 @@ func (qb *SceneStore) Update(ctx context.Context, updatedObject *models.Scene) e
 }
 
 func (qb *SceneStore) Destroy(ctx context.Context, id int) error {
+	t, err := qb.tombstone(ctx, id)
+	if err != nil {
+		return err
+	}
+
 	// must handle image checksums manually
 	if err := qb.destroyCover(ctx, id); err != nil {
 		return err
@@ -480,7 +486,11 @@ func (qb *SceneStore) Destroy(ctx context.Context, id int) error {
 	// scene markers should be handled prior to calling destroy
 	// galleries should be handled prior to calling destroy
 
-	return qb.tableMgr.destroyExisting(ctx, []int{id})
+	if err := qb.tableMgr.destroyExisting(ctx, []int{id}); err != nil {
+		return err
+	}
+
+	return recordTombstone(ctx, t)
 }
 
 // returns nil, nil if not found
@@ -906,6 +916,35 @@ func (qb *SceneStore) All(ctx context.Context) ([]*models.Scene, error) {
 	))
 }
 
+var sceneTextSearch = textSearch{
+	idColumn: "scenes.id",
+	sources: []searchSource{
+		{
+			from:     "scenes_fts",
+			idColumn: "scenes_fts.rowid",
+			fts:      "scenes_fts",
+			columns:  []string{"scenes_fts.title", "scenes_fts.details", "scenes_fts.code"},
+		},
+		{
+			from:     "files_fts INNER JOIN scenes_files ON scenes_files.file_id = files_fts.rowid",
+			idColumn: "scenes_files.scene_id",
+			fts:      "files_fts",
+			columns:  []string{"files_fts.path"},
+		},
+		{
+			from:     "scene_markers_fts INNER JOIN scene_markers ON scene_markers.id = scene_markers_fts.rowid",
+			idColumn: "scene_markers.scene_id",
+			fts:      "scene_markers_fts",
+			columns:  []string{"scene_markers_fts.title"},
+		},
+		{
+			from:     "files_fingerprints INNER JOIN scenes_files ON scenes_files.file_id = files_fingerprints.file_id",
+			idColumn: "scenes_files.scene_id",
+			columns:  []string{"files_fingerprints.fingerprint"},
+		},
+	},
+}
+
 func (qb *SceneStore) makeQuery(ctx context.Context, sceneFilter *models.SceneFilterType, findFilter *models.FindFilterType) (*queryBuilder, error) {
 	if sceneFilter == nil {
 		sceneFilter = &models.SceneFilterType{}
@@ -918,32 +957,7 @@ func (qb *SceneStore) makeQuery(ctx context.Context, sceneFilter *models.SceneFi
 	distinctIDs(&query, sceneTable)
 
 	if q := findFilter.Q; q != nil && *q != "" {
-		query.addJoins(
-			join{
-				table:    scenesFilesTable,
-				onClause: "scenes_files.scene_id = scenes.id",
-			},
-			join{
-				table:    fileTable,
-				onClause: "scenes_files.file_id = files.id",
-			},
-			join{
-				table:    folderTable,
-				onClause: "files.parent_folder_id = folders.id",
-			},
-			join{
-				table:    fingerprintTable,
-				onClause: "files_fingerprints.file_id = scenes_files.file_id",
-			},
-			join{
-				table:    sceneMarkerTable,
-				onClause: "scene_markers.scene_id = scenes.id",
-			},
-		)
-
-		filepathColumn := "folders.path || '" + string(filepath.Separator) + "' || files.basename"
-		searchColumns := []string{"scenes.title", "scenes.details", filepathColumn, "files_fingerprints.fingerprint", "scene_markers.title"}
-		query.parseQueryString(searchColumns, *q)
+		query.parseTextSearch(sceneTextSearch, *q)
 	}
 
 	filter := filterBuilderFromHandler(ctx, &sceneFilterHandler{
@@ -954,7 +968,9 @@ func (qb *SceneStore) makeQuery(ctx context.Context, sceneFilter *models.SceneFi
 		return nil, err
 	}
 
-	if err := qb.setSceneSort(&query, findFilter); err != nil {
+	if query.useRelevanceSort(findFilter) {
+		query.sortAndPagination += query.getRelevanceSort(findFilter)
+	} else if err := qb.setSceneSort(&query, withoutRelevanceSort(findFilter)); err != nil {
 		return nil, err
 	}
 	query.sortAndPagination += getPagination(findFilter)
@@ -1052,6 +1068,24 @@ func (qb *SceneStore) QueryCount(ctx context.Context, sceneFilter *models.SceneF
 	return query.executeCount(ctx)
 }
 
+var sceneFacetTables = facetTables{
+	table:           sceneTable,
+	idColumn:        sceneIDColumn,
+	tagsTable:       scenesTagsTable,
+	performersTable: performersScenesTable,
+	filesTable:      scenesFilesTable,
+	dimensionsTable: videoFileTable,
+}
+
+func (qb *SceneStore) QueryFacets(ctx context.Context, sceneFilter *models.SceneFilterType, findFilter *models.FindFilterType, limit int) (*models.QueryFacets, error) {
+	query, err := qb.makeQuery(ctx, sceneFilter, findFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	return sceneRepository.queryFacets(ctx, *query, sceneFacetTables, limit)
+}
+
 var sceneSortOptions = sortOptions{
 	"bitrate",
 	"created_at",
@@ -1324,7 +1358,11 @@ func (qb *SceneStore) AddGalleryIDs(ctx context.Context, sceneID int, galleryIDs
 }
 
 func (qb *SceneStore) GetStashIDs(ctx context.Context, sceneID int) ([]models.StashID, error) {
-	return sceneRepository.stashIDs.get(ctx, sceneID)
+	return scenesStashIDsTableMgr.get(ctx, sceneID)
+}
+
+func (qb *SceneStore) UpdateStashIDUpdatedAt(ctx context.Context, sceneID int, endpoint string, updatedAt time.Time) error {
+	return scenesStashIDsTableMgr.setUpdatedAt(ctx, sceneID, endpoint, updatedAt)
 }
 
 func (qb *SceneStore) FindDuplicates(ctx context.Context, distance int, durationDiff float64) ([][]*models.Scene, error) {
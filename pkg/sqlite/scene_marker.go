@@ -289,6 +289,30 @@ func (qb *SceneMarkerStore) Wall(ctx context.Context, q *string) ([]*models.Scen
 	return qb.getMany(ctx, qq)
 }
 
+var sceneMarkerTextSearch = textSearch{
+	idColumn: "scene_markers.id",
+	sources: []searchSource{
+		{
+			from:     "scene_markers_fts",
+			idColumn: "scene_markers_fts.rowid",
+			fts:      "scene_markers_fts",
+			columns:  []string{"scene_markers_fts.title"},
+		},
+		{
+			from:     "scenes_fts INNER JOIN scene_markers ON scene_markers.scene_id = scenes_fts.rowid",
+			idColumn: "scene_markers.id",
+			fts:      "scenes_fts",
+			columns:  []string{"scenes_fts.title"},
+		},
+		{
+			from:     "tags_fts INNER JOIN scene_markers ON scene_markers.primary_tag_id = tags_fts.rowid",
+			idColumn: "scene_markers.id",
+			fts:      "tags_fts",
+			columns:  []string{"tags_fts.name"},
+		},
+	},
+}
+
 func (qb *SceneMarkerStore) makeQuery(ctx context.Context, sceneMarkerFilter *models.SceneMarkerFilterType, findFilter *models.FindFilterType) (*queryBuilder, error) {
 	if sceneMarkerFilter == nil {
 		sceneMarkerFilter = &models.SceneMarkerFilterType{}
@@ -301,10 +325,7 @@ func (qb *SceneMarkerStore) makeQuery(ctx context.Context, sceneMarkerFilter *mo
 	distinctIDs(&query, sceneMarkerTable)
 
 	if q := findFilter.Q; q != nil && *q != "" {
-		query.join(sceneTable, "", "scenes.id = scene_markers.scene_id")
-		query.join(tagTable, "", "scene_markers.primary_tag_id = tags.id")
-		searchColumns := []string{"scene_markers.title", "scenes.title", "tags.name"}
-		query.parseQueryString(searchColumns, *q)
+		query.parseTextSearch(sceneMarkerTextSearch, *q)
 	}
 
 	filter := filterBuilderFromHandler(ctx, &sceneMarkerFilterHandler{
@@ -315,7 +336,9 @@ func (qb *SceneMarkerStore) makeQuery(ctx context.Context, sceneMarkerFilter *mo
 		return nil, err
 	}
 
-	if err := qb.setSceneMarkerSort(&query, findFilter); err != nil {
+	if query.useRelevanceSort(findFilter) {
+		query.sortAndPagination += query.getRelevanceSort(findFilter)
+	} else if err := qb.setSceneMarkerSort(&query, withoutRelevanceSort(findFilter)); err != nil {
 		return nil, err
 	}
 	query.sortAndPagination += getPagination(findFilter)
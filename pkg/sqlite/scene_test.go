@@ -2037,6 +2037,47 @@ func TestSceneQueryQ(t *testing.T) {
 	})
 }
 
+func TestSceneQueryQRelevance(t *testing.T) {
+	const term = "relevance_term"
+
+	runWithRollbackTxn(t, "relevance", func(t *testing.T, ctx context.Context) {
+		sqb := db.Scene
+
+		weak := &models.Scene{
+			Title:   "weak match",
+			Details: "some details containing " + term,
+		}
+		strong := &models.Scene{
+			Title:   term,
+			Details: term + " " + term,
+		}
+		for _, s := range []*models.Scene{weak, strong} {
+			if err := sqb.Create(ctx, s, nil); err != nil {
+				t.Errorf("Error creating scene: %v", err)
+				return
+			}
+		}
+
+		q := term
+		scenes := queryScene(ctx, t, sqb, nil, &models.FindFilterType{Q: &q})
+		assert.Equal(t, []int{strong.ID, weak.ID}, []int{scenes[0].ID, scenes[1].ID})
+
+		// the search index must be updated with the scene
+		title := "updated title"
+		if _, err := sqb.UpdatePartial(ctx, weak.ID, models.ScenePartial{
+			Title: models.NewOptionalString(title),
+		}); err != nil {
+			t.Errorf("Error updating scene: %v", err)
+			return
+		}
+
+		q = title
+		scenes = queryScene(ctx, t, sqb, nil, &models.FindFilterType{Q: &q})
+		assert.Len(t, scenes, 1)
+		assert.Equal(t, weak.ID, scenes[0].ID)
+	})
+}
+
 func queryScene(ctx context.Context, t *testing.T, sqb models.SceneReader, sceneFilter *models.SceneFilterType, findFilter *models.FindFilterType) []*models.Scene {
 	t.Helper()
 	result, err := sqb.Query(ctx, models.SceneQueryOptions{
@@ -4551,6 +4592,70 @@ func TestSceneStore_AssignFiles(t *testing.T) {
 	}
 }
 
+func TestSceneStore_UpdateStashIDUpdatedAt(t *testing.T) {
+	sceneIdx := sceneIdxWithGallery
+	stashID := sceneStashID(sceneIdx)
+	updatedAt := time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		endpoint      string
+		wantUpdatedAt time.Time
+	}{
+		{
+			"matching endpoint",
+			stashID.Endpoint,
+			updatedAt,
+		},
+		{
+			"other endpoint",
+			"other endpoint",
+			time.Time{},
+		},
+	}
+
+	qb := db.Scene
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withRollbackTxn(func(ctx context.Context) error {
+				sceneID := sceneIDs[sceneIdx]
+				before, err := qb.Find(ctx, sceneID)
+				if err != nil {
+					t.Errorf("SceneStore.Find() error = %v", err)
+					return nil
+				}
+
+				if err := qb.UpdateStashIDUpdatedAt(ctx, sceneID, tt.endpoint, updatedAt); err != nil {
+					t.Errorf("SceneStore.UpdateStashIDUpdatedAt() error = %v", err)
+					return nil
+				}
+
+				got, err := qb.GetStashIDs(ctx, sceneID)
+				if err != nil {
+					t.Errorf("SceneStore.GetStashIDs() error = %v", err)
+					return nil
+				}
+
+				if assert.Len(t, got, 1) {
+					assert.Equal(t, stashID.StashID, got[0].StashID)
+					assert.True(t, tt.wantUpdatedAt.Equal(got[0].UpdatedAt), "updated at = %v, want %v", got[0].UpdatedAt, tt.wantUpdatedAt)
+				}
+
+				// the scene itself must not be modified
+				after, err := qb.Find(ctx, sceneID)
+				if err != nil {
+					t.Errorf("SceneStore.Find() error = %v", err)
+					return nil
+				}
+				assert.Equal(t, before.UpdatedAt, after.UpdatedAt)
+
+				return nil
+			})
+		})
+	}
+}
+
 func TestSceneStore_AddView(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -4785,3 +4890,37 @@ func TestSceneStore_CountUniqueViews(t *testing.T) {
 		return nil
 	})
 }
+
+func TestSceneQueryFileHealth(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		sceneID := sceneIDs[sceneIdx1WithPerformer]
+		if err := db.File.UpdateHealth(ctx, sceneFileIDs[sceneIdx1WithPerformer], &models.FileHealth{
+			Status:    models.FileHealthStatusCorrupt,
+			CheckedAt: time.Now(),
+		}); err != nil {
+			t.Errorf("FileStore.UpdateHealth() error = %v", err)
+			return nil
+		}
+
+		sceneFilter := &models.SceneFilterType{
+			FileHealth: &models.FileHealthCriterionInput{
+				Value:    []models.FileHealthStatus{models.FileHealthStatusCorrupt},
+				Modifier: models.CriterionModifierIncludes,
+			},
+		}
+
+		scenes := queryScene(ctx, t, db.Scene, sceneFilter, nil)
+		assert.Len(t, scenes, 1)
+		if len(scenes) == 1 {
+			assert.Equal(t, sceneID, scenes[0].ID)
+		}
+
+		sceneFilter.FileHealth.Modifier = models.CriterionModifierIsNull
+		scenes = queryScene(ctx, t, db.Scene, sceneFilter, nil)
+		for _, s := range scenes {
+			assert.NotEqual(t, sceneID, s.ID)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,250 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const statsDateFormat = "2006-01-02"
+
+// statsEvents returns a query returning the scene_id, date and value of the
+// events counted by a timeline metric.
+func statsEvents(metric models.StatsMetric) (string, error) {
+	switch metric {
+	case models.StatsMetricPlays:
+		return fmt.Sprintf("SELECT scene_id, view_date AS date, 1 AS value FROM %s", scenesViewDatesTable), nil
+	case models.StatsMetricOCount:
+		return fmt.Sprintf("SELECT scene_id, o_date AS date, 1 AS value FROM %s", scenesODatesTable), nil
+	case models.StatsMetricScenesAdded:
+		return fmt.Sprintf("SELECT id AS scene_id, created_at AS date, 1 AS value FROM %s", sceneTable), nil
+	case models.StatsMetricSizeAdded:
+		return fmt.Sprintf(`SELECT s.id AS scene_id, s.created_at AS date, COALESCE(f.size, 0) AS value
+FROM %s AS s
+LEFT JOIN %s AS sf ON sf.scene_id = s.id AND sf."primary" = 1
+LEFT JOIN %s AS f ON f.id = sf.file_id`, sceneTable, scenesFilesTable, fileTable), nil
+	case models.StatsMetricScenesOrganized:
+		// only changes recorded in the edit history are counted
+		return fmt.Sprintf(`SELECT entity_id AS scene_id, created_at AS date, 1 AS value FROM %s
+WHERE entity_type = '%s' AND field = 'organized' AND new_value = 'true'`, editHistoryTable, models.HistoryEntityTypeScene), nil
+	case models.StatsMetricPlayDuration:
+		// play duration is not recorded per play, so the total play duration
+		// of each scene is divided evenly between its plays
+		return fmt.Sprintf(`SELECT v.scene_id, v.view_date AS date, s.play_duration / c.count AS value
+FROM %[1]s AS v
+INNER JOIN %[2]s AS s ON s.id = v.scene_id
+INNER JOIN (SELECT scene_id, COUNT(*) AS count FROM %[1]s GROUP BY scene_id) AS c ON c.scene_id = v.scene_id`, scenesViewDatesTable, sceneTable), nil
+	}
+
+	return "", fmt.Errorf("invalid metric: %s", metric)
+}
+
+// statsBucket returns an expression evaluating to the start date of the
+// bucket of the date column. Weeks start on Monday. Dates with a time zone
+// are converted to UTC by date(), so events are bucketed by their UTC date,
+// not by the date in the local time zone of the server.
+func statsBucket(interval models.StatsInterval, column string) (string, error) {
+	switch interval {
+	case models.StatsIntervalDay:
+		return fmt.Sprintf("date(%s)", column), nil
+	case models.StatsIntervalWeek:
+		return fmt.Sprintf("date(%s, 'weekday 0', '-6 days')", column), nil
+	case models.StatsIntervalMonth:
+		return fmt.Sprintf("date(%s, 'start of month')", column), nil
+	}
+
+	return "", fmt.Errorf("invalid interval: %s", interval)
+}
+
+// statsBreakdownJoin returns the joins and the key and name expressions of
+// a timeline breakdown.
+func statsBreakdownJoin(breakdown *models.StatsBreakdown) (join string, key string, name string, err error) {
+	if breakdown == nil {
+		return "", "NULL", "NULL", nil
+	}
+
+	switch *breakdown {
+	case models.StatsBreakdownStudio:
+		return fmt.Sprintf("INNER JOIN %[1]s AS s ON s.id = e.scene_id INNER JOIN %[2]s AS k ON k.id = s.studio_id", sceneTable, studioTable), "k.id", "k.name", nil
+	case models.StatsBreakdownPerformer:
+		return fmt.Sprintf("INNER JOIN %[1]s AS j ON j.scene_id = e.scene_id INNER JOIN %[2]s AS k ON k.id = j.performer_id", performersScenesTable, performerTable), "k.id", "k.name", nil
+	case models.StatsBreakdownTag:
+		return fmt.Sprintf("INNER JOIN %[1]s AS j ON j.scene_id = e.scene_id INNER JOIN %[2]s AS k ON k.id = j.tag_id", scenesTagsTable, tagTable), "k.id", "k.name", nil
+	}
+
+	return "", "", "", fmt.Errorf("invalid breakdown: %s", *breakdown)
+}
+
+type statsTimelineRow struct {
+	Bucket string      `db:"bucket"`
+	Key    null.Int    `db:"key"`
+	Name   null.String `db:"name"`
+	Value  float64     `db:"value"`
+}
+
+// StatsTimeline returns the values of a scene activity metric over time. Each
+// series has a point for every bucket between the first and last bucket of
+// the timeline, including buckets without events.
+func (qb *SceneStore) StatsTimeline(ctx context.Context, options models.StatsTimelineOptions) ([]*models.StatsTimelineSeries, error) {
+	events, err := statsEvents(options.Metric)
+	if err != nil {
+		return nil, err
+	}
+
+	bucket, err := statsBucket(options.Interval, "e.date")
+	if err != nil {
+		return nil, err
+	}
+
+	join, key, name, err := statsBreakdownJoin(options.Breakdown)
+	if err != nil {
+		return nil, err
+	}
+
+	var where []string
+	var args []interface{}
+	if options.From != nil {
+		where = append(where, "datetime(e.date) >= datetime(?)")
+		args = append(args, options.From.UTC().Format(time.RFC3339))
+	}
+	if options.To != nil {
+		where = append(where, "datetime(e.date) < datetime(?)")
+		args = append(args, options.To.UTC().Format(time.RFC3339))
+	}
+
+	whereClause := ""
+	if len(where) > 0 {
+		whereClause = "WHERE " + strings.Join(where, " AND ")
+	}
+
+	query := fmt.Sprintf(`SELECT %[1]s AS bucket, %[2]s AS key, %[3]s AS name, SUM(e.value) AS value
+FROM (%[4]s) AS e %[5]s
+%[6]s
+GROUP BY bucket, key
+HAVING bucket IS NOT NULL`, bucket, key, name, events, join, whereClause)
+
+	var rows []statsTimelineRow
+	if err := sceneRepository.queryFunc(ctx, query, args, false, func(r *sqlx.Rows) error {
+		var row statsTimelineRow
+		if err := r.StructScan(&row); err != nil {
+			return err
+		}
+
+		rows = append(rows, row)
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("querying %s timeline: %w", options.Metric, err)
+	}
+
+	return buildStatsTimeline(rows, options)
+}
+
+// buildStatsTimeline groups timeline rows into series, filling in the
+// buckets without events. Returns models.ErrStatsTimelineTooLong if there
+// are more than models.MaxStatsTimelineBuckets buckets.
+func buildStatsTimeline(rows []statsTimelineRow, options models.StatsTimelineOptions) ([]*models.StatsTimelineSeries, error) {
+	type seriesValues struct {
+		series *models.StatsTimelineSeries
+		values map[string]float64
+	}
+
+	var first, last time.Time
+	if options.From != nil {
+		first = options.Interval.Start(*options.From)
+	}
+	if options.To != nil {
+		// To is exclusive
+		last = options.Interval.Start(options.To.Add(-time.Nanosecond))
+	}
+
+	byKey := make(map[int64]*seriesValues)
+	var all []*seriesValues
+
+	for _, row := range rows {
+		d, err := time.Parse(statsDateFormat, row.Bucket)
+		if err != nil {
+			return nil, fmt.Errorf("parsing bucket %q: %w", row.Bucket, err)
+		}
+
+		if options.From == nil && (first.IsZero() || d.Before(first)) {
+			first = d
+		}
+		if options.To == nil && d.After(last) {
+			last = d
+		}
+
+		s := byKey[row.Key.Int64]
+		if s == nil {
+			s = &seriesValues{
+				series: &models.StatsTimelineSeries{},
+				values: make(map[string]float64),
+			}
+
+			if row.Key.Valid {
+				id := int(row.Key.Int64)
+				s.series.ID = &id
+				s.series.Name = row.Name.Ptr()
+			}
+
+			byKey[row.Key.Int64] = s
+			all = append(all, s)
+		}
+
+		s.values[row.Bucket] += row.Value
+		s.series.Total += row.Value
+	}
+
+	if !first.IsZero() {
+		n := 0
+		for d := first; !d.After(last); d = options.Interval.Next(d) {
+			n++
+			if n > models.MaxStatsTimelineBuckets {
+				return nil, models.ErrStatsTimelineTooLong
+			}
+		}
+	}
+
+	// a timeline which is not broken down always has a single series
+	if options.Breakdown == nil && len(all) == 0 {
+		all = append(all, &seriesValues{
+			series: &models.StatsTimelineSeries{},
+			values: make(map[string]float64),
+		})
+	}
+
+	sort.SliceStable(all, func(i, j int) bool {
+		if all[i].series.Total != all[j].series.Total {
+			return all[i].series.Total > all[j].series.Total
+		}
+		return *all[i].series.ID < *all[j].series.ID
+	})
+
+	if options.Limit > 0 && len(all) > options.Limit {
+		all = all[:options.Limit]
+	}
+
+	ret := make([]*models.StatsTimelineSeries, len(all))
+	for i, s := range all {
+		s.series.Points = []*models.StatsTimelinePoint{}
+		if !first.IsZero() {
+			for d := first; !d.After(last); d = options.Interval.Next(d) {
+				date := d.Format(statsDateFormat)
+				s.series.Points = append(s.series.Points, &models.StatsTimelinePoint{
+					Date:  date,
+					Value: s.values[date],
+				})
+			}
+		}
+
+		ret[i] = s.series
+	}
+
+	return ret, nil
+}
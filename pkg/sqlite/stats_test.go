@@ -0,0 +1,210 @@
+//go:build integration
+// +build integration
+
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSceneStatsTimeline(t *testing.T) {
+	// dates well before the test data, so that only the views added here
+	// are within the timeline. 2001-01-01 is a Monday.
+	from := time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2001, 2, 1, 0, 0, 0, 0, time.UTC)
+	views := []time.Time{
+		time.Date(2001, 1, 1, 10, 0, 0, 0, time.UTC),
+		time.Date(2001, 1, 2, 23, 59, 0, 0, time.UTC),
+		time.Date(2001, 1, 10, 0, 0, 0, 0, time.UTC),
+	}
+
+	sceneID := sceneIDs[sceneIdxWithStudio]
+	studioID := studioIDs[studioIdxWithScene]
+	studioBreakdown := models.StatsBreakdownStudio
+
+	withRollbackTxn(func(ctx context.Context) error {
+		qb := db.Scene
+
+		if _, err := qb.AddViews(ctx, sceneID, views); err != nil {
+			t.Errorf("SceneStore.AddViews() error = %v", err)
+			return nil
+		}
+
+		t.Run("day", func(t *testing.T) {
+			got, err := qb.StatsTimeline(ctx, models.StatsTimelineOptions{
+				Metric:   models.StatsMetricPlays,
+				Interval: models.StatsIntervalDay,
+				From:     &from,
+				To:       &to,
+			})
+			if err != nil {
+				t.Errorf("SceneStore.StatsTimeline() error = %v", err)
+				return
+			}
+
+			if !assert.Len(t, got, 1) {
+				return
+			}
+
+			series := got[0]
+			assert.Nil(t, series.ID)
+			assert.Equal(t, float64(3), series.Total)
+			if assert.Len(t, series.Points, 31) {
+				assert.Equal(t, &models.StatsTimelinePoint{Date: "2001-01-01", Value: 1}, series.Points[0])
+				assert.Equal(t, &models.StatsTimelinePoint{Date: "2001-01-02", Value: 1}, series.Points[1])
+				assert.Equal(t, &models.StatsTimelinePoint{Date: "2001-01-03", Value: 0}, series.Points[2])
+				assert.Equal(t, &models.StatsTimelinePoint{Date: "2001-01-10", Value: 1}, series.Points[9])
+				assert.Equal(t, "2001-01-31", series.Points[30].Date)
+			}
+		})
+
+		t.Run("too many buckets", func(t *testing.T) {
+			longFrom := time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC)
+			_, err := qb.StatsTimeline(ctx, models.StatsTimelineOptions{
+				Metric:   models.StatsMetricPlays,
+				Interval: models.StatsIntervalDay,
+				From:     &longFrom,
+				To:       &to,
+			})
+			assert.ErrorIs(t, err, models.ErrStatsTimelineTooLong)
+		})
+
+		t.Run("week", func(t *testing.T) {
+			got, err := qb.StatsTimeline(ctx, models.StatsTimelineOptions{
+				Metric:   models.StatsMetricPlays,
+				Interval: models.StatsIntervalWeek,
+				From:     &from,
+				To:       &to,
+			})
+			if err != nil {
+				t.Errorf("SceneStore.StatsTimeline() error = %v", err)
+				return
+			}
+
+			if !assert.Len(t, got, 1) {
+				return
+			}
+
+			assert.Equal(t, []*models.StatsTimelinePoint{
+				{Date: "2001-01-01", Value: 2},
+				{Date: "2001-01-08", Value: 1},
+				{Date: "2001-01-15", Value: 0},
+				{Date: "2001-01-22", Value: 0},
+				{Date: "2001-01-29", Value: 0},
+			}, got[0].Points)
+		})
+
+		t.Run("month by studio", func(t *testing.T) {
+			got, err := qb.StatsTimeline(ctx, models.StatsTimelineOptions{
+				Metric:    models.StatsMetricPlays,
+				Interval:  models.StatsIntervalMonth,
+				From:      &from,
+				To:        &to,
+				Breakdown: &studioBreakdown,
+				Limit:     10,
+			})
+			if err != nil {
+				t.Errorf("SceneStore.StatsTimeline() error = %v", err)
+				return
+			}
+
+			if !assert.Len(t, got, 1) {
+				return
+			}
+
+			series := got[0]
+			if assert.NotNil(t, series.ID) {
+				assert.Equal(t, studioID, *series.ID)
+			}
+			if assert.NotNil(t, series.Name) {
+				assert.Equal(t, getStudioStringValue(studioIdxWithScene, "Name"), *series.Name)
+			}
+			assert.Equal(t, []*models.StatsTimelinePoint{
+				{Date: "2001-01-01", Value: 3},
+			}, series.Points)
+		})
+
+		t.Run("play duration", func(t *testing.T) {
+			duration := 90.0
+			if _, err := qb.SaveActivity(ctx, sceneID, nil, &duration); err != nil {
+				t.Errorf("SceneStore.SaveActivity() error = %v", err)
+				return
+			}
+
+			scene, err := qb.Find(ctx, sceneID)
+			if err != nil {
+				t.Errorf("SceneStore.Find() error = %v", err)
+				return
+			}
+
+			count, err := qb.CountViews(ctx, sceneID)
+			if err != nil {
+				t.Errorf("SceneStore.CountViews() error = %v", err)
+				return
+			}
+
+			got, err := qb.StatsTimeline(ctx, models.StatsTimelineOptions{
+				Metric:   models.StatsMetricPlayDuration,
+				Interval: models.StatsIntervalMonth,
+				From:     &from,
+				To:       &to,
+			})
+			if err != nil {
+				t.Errorf("SceneStore.StatsTimeline() error = %v", err)
+				return
+			}
+
+			// the play duration is divided evenly between the plays
+			if assert.Len(t, got, 1) {
+				assert.InDelta(t, scene.PlayDuration*3/float64(count), got[0].Total, 0.001)
+			}
+		})
+
+		return nil
+	})
+}
+
+func TestSceneStatsTimelineAllMetrics(t *testing.T) {
+	withTxn(func(ctx context.Context) error {
+		for _, metric := range models.AllStatsMetric {
+			for _, interval := range models.AllStatsInterval {
+				got, err := db.Scene.StatsTimeline(ctx, models.StatsTimelineOptions{
+					Metric:   metric,
+					Interval: interval,
+				})
+				if err != nil {
+					t.Errorf("SceneStore.StatsTimeline(%s, %s) error = %v", metric, interval, err)
+					continue
+				}
+
+				assert.Len(t, got, 1, "%s %s", metric, interval)
+			}
+
+			for _, breakdown := range models.AllStatsBreakdown {
+				breakdown := breakdown
+				got, err := db.Scene.StatsTimeline(ctx, models.StatsTimelineOptions{
+					Metric:    metric,
+					Interval:  models.StatsIntervalMonth,
+					Breakdown: &breakdown,
+					Limit:     2,
+				})
+				if err != nil {
+					t.Errorf("SceneStore.StatsTimeline(%s, %s) error = %v", metric, breakdown, err)
+					continue
+				}
+
+				assert.LessOrEqual(t, len(got), 2, "%s %s", metric, breakdown)
+				for i := 1; i < len(got); i++ {
+					assert.GreaterOrEqual(t, got[i-1].Total, got[i].Total)
+				}
+			}
+		}
+
+		return nil
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/doug-martin/goqu/v9"
 	"github.com/doug-martin/goqu/v9/exp"
@@ -276,12 +277,21 @@ func (qb *StudioStore) Update(ctx context.Context, updatedObject *models.Studio)
 }
 
 func (qb *StudioStore) Destroy(ctx context.Context, id int) error {
+	t, err := qb.tombstone(ctx, id)
+	if err != nil {
+		return err
+	}
+
 	// must handle image checksums manually
 	if err := qb.destroyImage(ctx, id); err != nil {
 		return err
 	}
 
-	return studioRepository.destroyExisting(ctx, []int{id})
+	if err := studioRepository.destroyExisting(ctx, []int{id}); err != nil {
+		return err
+	}
+
+	return recordTombstone(ctx, t)
 }
 
 // returns nil, nil if not found
@@ -513,6 +523,18 @@ func (qb *StudioStore) QueryForAutoTag(ctx context.Context, words []string) ([]*
 	return ret, nil
 }
 
+var studioTextSearch = textSearch{
+	idColumn: "studios.id",
+	sources: []searchSource{
+		{
+			from:     "studios_fts",
+			idColumn: "studios_fts.rowid",
+			fts:      "studios_fts",
+			columns:  []string{"studios_fts.name", "studios_fts.aliases"},
+		},
+	},
+}
+
 func (qb *StudioStore) makeQuery(ctx context.Context, studioFilter *models.StudioFilterType, findFilter *models.FindFilterType) (*queryBuilder, error) {
 	if studioFilter == nil {
 		studioFilter = &models.StudioFilterType{}
@@ -525,9 +547,7 @@ func (qb *StudioStore) makeQuery(ctx context.Context, studioFilter *models.Studi
 	distinctIDs(&query, studioTable)
 
 	if q := findFilter.Q; q != nil && *q != "" {
-		query.join(studioAliasesTable, "", "studio_aliases.studio_id = studios.id")
-		searchColumns := []string{"studios.name", "studio_aliases.alias"}
-		query.parseQueryString(searchColumns, *q)
+		query.parseTextSearch(studioTextSearch, *q)
 	}
 
 	filter := filterBuilderFromHandler(ctx, &studioFilterHandler{
@@ -538,10 +558,14 @@ func (qb *StudioStore) makeQuery(ctx context.Context, studioFilter *models.Studi
 		return nil, err
 	}
 
-	var err error
-	query.sortAndPagination, err = qb.getStudioSort(findFilter)
-	if err != nil {
-		return nil, err
+	if query.useRelevanceSort(findFilter) {
+		query.sortAndPagination = query.getRelevanceSort(findFilter)
+	} else {
+		var err error
+		query.sortAndPagination, err = qb.getStudioSort(withoutRelevanceSort(findFilter))
+		if err != nil {
+			return nil, err
+		}
 	}
 	query.sortAndPagination += getPagination(findFilter)
 
@@ -646,6 +670,10 @@ func (qb *StudioStore) GetStashIDs(ctx context.Context, studioID int) ([]models.
 	return studiosStashIDsTableMgr.get(ctx, studioID)
 }
 
+func (qb *StudioStore) UpdateStashIDUpdatedAt(ctx context.Context, studioID int, endpoint string, updatedAt time.Time) error {
+	return studiosStashIDsTableMgr.setUpdatedAt(ctx, studioID, endpoint, updatedAt)
+}
+
 func (qb *StudioStore) GetAliases(ctx context.Context, studioID int) ([]string, error) {
 	return studiosAliasesTableMgr.get(ctx, studioID)
 }
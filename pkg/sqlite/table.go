@@ -275,19 +275,21 @@ type stashIDTable struct {
 }
 
 type stashIDRow struct {
-	StashID  null.String `db:"stash_id"`
-	Endpoint null.String `db:"endpoint"`
+	StashID   null.String   `db:"stash_id"`
+	Endpoint  null.String   `db:"endpoint"`
+	UpdatedAt NullTimestamp `db:"updated_at"`
 }
 
 func (r *stashIDRow) resolve() models.StashID {
 	return models.StashID{
-		StashID:  r.StashID.String,
-		Endpoint: r.Endpoint.String,
+		StashID:   r.StashID.String,
+		Endpoint:  r.Endpoint.String,
+		UpdatedAt: r.UpdatedAt.Timestamp,
 	}
 }
 
 func (t *stashIDTable) get(ctx context.Context, id int) ([]models.StashID, error) {
-	q := dialect.Select("endpoint", "stash_id").From(t.table.table).Where(t.idColumn.Eq(id))
+	q := dialect.Select("endpoint", "stash_id", "updated_at").From(t.table.table).Where(t.idColumn.Eq(id))
 
 	const single = false
 	var ret []models.StashID
@@ -308,8 +310,14 @@ func (t *stashIDTable) get(ctx context.Context, id int) ([]models.StashID, error
 }
 
 func (t *stashIDTable) insertJoin(ctx context.Context, id int, v models.StashID) (sql.Result, error) {
-	q := dialect.Insert(t.table.table).Cols(t.idColumn.GetCol(), "endpoint", "stash_id").Vals(
-		goqu.Vals{id, v.Endpoint, v.StashID},
+	// a zero updated time means the entity has not been synchronised
+	updatedAt := NullTimestamp{
+		Timestamp: v.UpdatedAt.UTC(),
+		Valid:     !v.UpdatedAt.IsZero(),
+	}
+
+	q := dialect.Insert(t.table.table).Cols(t.idColumn.GetCol(), "endpoint", "stash_id", "updated_at").Vals(
+		goqu.Vals{id, v.Endpoint, v.StashID, updatedAt},
 	)
 	ret, err := exec(ctx, q)
 	if err != nil {
@@ -330,6 +338,26 @@ func (t *stashIDTable) insertJoins(ctx context.Context, id int, v []models.Stash
 }
 
 func (t *stashIDTable) replaceJoins(ctx context.Context, id int, v []models.StashID) error {
+	existing, err := t.get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// retain the updated time of unchanged stash ids if not provided
+	v = append([]models.StashID(nil), v...)
+	for i, vv := range v {
+		if !vv.UpdatedAt.IsZero() {
+			continue
+		}
+
+		for _, e := range existing {
+			if e.Endpoint == vv.Endpoint && e.StashID == vv.StashID {
+				v[i].UpdatedAt = e.UpdatedAt
+				break
+			}
+		}
+	}
+
 	if err := t.destroy(ctx, []int{id}); err != nil {
 		return err
 	}
@@ -374,6 +402,23 @@ func (t *stashIDTable) destroyJoins(ctx context.Context, id int, v []models.Stas
 	return nil
 }
 
+// setUpdatedAt sets the updated time of the stash id for the endpoint,
+// without modifying the object that it belongs to.
+func (t *stashIDTable) setUpdatedAt(ctx context.Context, id int, endpoint string, updatedAt time.Time) error {
+	q := dialect.Update(t.table.table).Prepared(true).Set(goqu.Record{
+		"updated_at": NullTimestamp{Timestamp: updatedAt.UTC(), Valid: true},
+	}).Where(
+		t.idColumn.Eq(id),
+		t.table.table.Col("endpoint").Eq(endpoint),
+	)
+
+	if _, err := exec(ctx, q); err != nil {
+		return fmt.Errorf("updating %s: %w", t.table.table.GetTable(), err)
+	}
+
+	return nil
+}
+
 func (t *stashIDTable) modifyJoins(ctx context.Context, id int, v []models.StashID, mode models.RelationshipUpdateMode) error {
 	switch mode {
 	case models.RelationshipUpdateModeSet:
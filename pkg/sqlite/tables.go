@@ -32,6 +32,7 @@ var (
 	performersURLsJoinTable     = goqu.T(performerURLsTable)
 	performersTagsJoinTable     = goqu.T(performersTagsTable)
 	performersStashIDsJoinTable = goqu.T("performer_stash_ids")
+	performerRelationsJoinTable = goqu.T(performerRelationsTable)
 
 	studiosAliasesJoinTable  = goqu.T(studioAliasesTable)
 	studiosTagsJoinTable     = goqu.T(studiosTagsTable)
@@ -43,6 +44,7 @@ var (
 
 	tagsAliasesJoinTable  = goqu.T(tagAliasesTable)
 	tagRelationsJoinTable = goqu.T(tagRelationsTable)
+	tagsStashIDsJoinTable = goqu.T("tag_stash_ids")
 )
 
 var (
@@ -282,6 +284,10 @@ var (
 			idColumn: performersStashIDsJoinTable.Col(performerIDColumn),
 		},
 	}
+
+	performerRelationshipTableMgr = &table{
+		table: performerRelationsJoinTable,
+	}
 )
 
 var (
@@ -339,6 +345,13 @@ var (
 	}
 
 	tagsChildTagsTableMgr = *tagsParentTagsTableMgr.invert()
+
+	tagsStashIDsTableMgr = &stashIDTable{
+		table: table{
+			table:    tagsStashIDsJoinTable,
+			idColumn: tagsStashIDsJoinTable.Col(tagIDColumn),
+		},
+	}
 )
 
 var (
@@ -383,3 +396,17 @@ var (
 		idColumn: goqu.T(savedFilterTable).Col(idColumn),
 	}
 )
+
+var (
+	editHistoryTableMgr = &table{
+		table:    goqu.T(editHistoryTable),
+		idColumn: goqu.T(editHistoryTable).Col(idColumn),
+	}
+)
+
+var (
+	tombstoneTableMgr = &table{
+		table:    goqu.T(tombstoneTable),
+		idColumn: goqu.T(tombstoneTable).Col(idColumn),
+	}
+)
@@ -195,6 +195,12 @@ func (qb *TagStore) Create(ctx context.Context, newObject *models.Tag) error {
 		}
 	}
 
+	if newObject.StashIDs.Loaded() {
+		if err := tagsStashIDsTableMgr.insertJoins(ctx, id, newObject.StashIDs.List()); err != nil {
+			return err
+		}
+	}
+
 	updated, err := qb.find(ctx, id)
 	if err != nil {
 		return fmt.Errorf("finding after create: %w", err)
@@ -238,6 +244,12 @@ func (qb *TagStore) UpdatePartial(ctx context.Context, id int, partial models.Ta
 		}
 	}
 
+	if partial.StashIDs != nil {
+		if err := tagsStashIDsTableMgr.modifyJoins(ctx, id, partial.StashIDs.StashIDs, partial.StashIDs.Mode); err != nil {
+			return nil, err
+		}
+	}
+
 	return qb.find(ctx, id)
 }
 
@@ -267,10 +279,21 @@ func (qb *TagStore) Update(ctx context.Context, updatedObject *models.Tag) error
 		}
 	}
 
+	if updatedObject.StashIDs.Loaded() {
+		if err := tagsStashIDsTableMgr.replaceJoins(ctx, updatedObject.ID, updatedObject.StashIDs.List()); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
 func (qb *TagStore) Destroy(ctx context.Context, id int) error {
+	t, err := qb.tombstone(ctx, id)
+	if err != nil {
+		return err
+	}
+
 	// must handle image checksums manually
 	if err := qb.destroyImage(ctx, id); err != nil {
 		return err
@@ -288,7 +311,11 @@ func (qb *TagStore) Destroy(ctx context.Context, id int) error {
 		return errors.New("cannot delete tag used as a primary tag in scene markers")
 	}
 
-	return tagRepository.destroyExisting(ctx, []int{id})
+	if err := tagRepository.destroyExisting(ctx, []int{id}); err != nil {
+		return err
+	}
+
+	return recordTombstone(ctx, t)
 }
 
 // returns nil, nil if not found
@@ -505,6 +532,63 @@ func (qb *TagStore) FindByNames(ctx context.Context, names []string, nocase bool
 	return ret, nil
 }
 
+func (qb *TagStore) findBySubquery(ctx context.Context, sq *goqu.SelectDataset) ([]*models.Tag, error) {
+	table := qb.table()
+
+	q := qb.selectDataset().Where(
+		table.Col(idColumn).Eq(
+			sq,
+		),
+	)
+
+	return qb.getMany(ctx, q)
+}
+
+func (qb *TagStore) FindByStashID(ctx context.Context, stashID models.StashID) ([]*models.Tag, error) {
+	sq := dialect.From(tagsStashIDsJoinTable).Select(tagsStashIDsJoinTable.Col(tagIDColumn)).Where(
+		tagsStashIDsJoinTable.Col("stash_id").Eq(stashID.StashID),
+		tagsStashIDsJoinTable.Col("endpoint").Eq(stashID.Endpoint),
+	)
+	ret, err := qb.findBySubquery(ctx, sq)
+
+	if err != nil {
+		return nil, fmt.Errorf("getting tags for stash ID %s: %w", stashID.StashID, err)
+	}
+
+	return ret, nil
+}
+
+func (qb *TagStore) FindByStashIDStatus(ctx context.Context, hasStashID bool, stashboxEndpoint string) ([]*models.Tag, error) {
+	table := qb.table()
+	sq := dialect.From(table).LeftJoin(
+		tagsStashIDsJoinTable,
+		goqu.On(table.Col(idColumn).Eq(tagsStashIDsJoinTable.Col(tagIDColumn))),
+	).Select(table.Col(idColumn))
+
+	if hasStashID {
+		sq = sq.Where(
+			tagsStashIDsJoinTable.Col("stash_id").IsNotNull(),
+			tagsStashIDsJoinTable.Col("endpoint").Eq(stashboxEndpoint),
+		)
+	} else {
+		sq = sq.Where(
+			tagsStashIDsJoinTable.Col("stash_id").IsNull(),
+		)
+	}
+
+	ret, err := qb.findBySubquery(ctx, sq)
+
+	if err != nil {
+		return nil, fmt.Errorf("getting tags for stash-box endpoint %s: %w", stashboxEndpoint, err)
+	}
+
+	return ret, nil
+}
+
+func (qb *TagStore) GetStashIDs(ctx context.Context, tagID int) ([]models.StashID, error) {
+	return tagsStashIDsTableMgr.get(ctx, tagID)
+}
+
 func (qb *TagStore) GetParentIDs(ctx context.Context, relatedID int) ([]int, error) {
 	return tagsParentTagsTableMgr.get(ctx, relatedID)
 }
@@ -590,6 +674,18 @@ func (qb *TagStore) QueryForAutoTag(ctx context.Context, words []string) ([]*mod
 	return qb.queryTags(ctx, query+" WHERE "+where, args)
 }
 
+var tagTextSearch = textSearch{
+	idColumn: "tags.id",
+	sources: []searchSource{
+		{
+			from:     "tags_fts",
+			idColumn: "tags_fts.rowid",
+			fts:      "tags_fts",
+			columns:  []string{"tags_fts.name", "tags_fts.aliases"},
+		},
+	},
+}
+
 func (qb *TagStore) Query(ctx context.Context, tagFilter *models.TagFilterType, findFilter *models.FindFilterType) ([]*models.Tag, int, error) {
 	if tagFilter == nil {
 		tagFilter = &models.TagFilterType{}
@@ -602,9 +698,7 @@ func (qb *TagStore) Query(ctx context.Context, tagFilter *models.TagFilterType,
 	distinctIDs(&query, tagTable)
 
 	if q := findFilter.Q; q != nil && *q != "" {
-		query.join(tagAliasesTable, "", "tag_aliases.tag_id = tags.id")
-		searchColumns := []string{"tags.name", "tag_aliases.alias"}
-		query.parseQueryString(searchColumns, *q)
+		query.parseTextSearch(tagTextSearch, *q)
 	}
 
 	filter := filterBuilderFromHandler(ctx, &tagFilterHandler{
@@ -615,10 +709,14 @@ func (qb *TagStore) Query(ctx context.Context, tagFilter *models.TagFilterType,
 		return nil, 0, err
 	}
 
-	var err error
-	query.sortAndPagination, err = qb.getTagSort(&query, findFilter)
-	if err != nil {
-		return nil, 0, err
+	if query.useRelevanceSort(findFilter) {
+		query.sortAndPagination = query.getRelevanceSort(findFilter)
+	} else {
+		var err error
+		query.sortAndPagination, err = qb.getTagSort(&query, withoutRelevanceSort(findFilter))
+		if err != nil {
+			return nil, 0, err
+		}
 	}
 	query.sortAndPagination += getPagination(findFilter)
 	idsResult, countResult, err := query.executeFind(ctx)
@@ -819,6 +917,12 @@ AND NOT EXISTS(SELECT 1 FROM `+table+` o WHERE o.`+idColumn+` = `+table+`.`+idCo
 		return err
 	}
 
+	// keep source stash ids for endpoints the destination is not linked to
+	_, err = dbWrapper.Exec(ctx, "UPDATE tag_stash_ids SET tag_id = ? WHERE tag_id IN "+inBinding+" AND endpoint NOT IN (SELECT endpoint FROM tag_stash_ids WHERE tag_id = ?)", args...)
+	if err != nil {
+		return err
+	}
+
 	for _, id := range source {
 		err = qb.Destroy(ctx, id)
 		if err != nil {
@@ -900,6 +900,84 @@ func TestTagUpdateAlias(t *testing.T) {
 	}
 }
 
+func TestTagStashIDs(t *testing.T) {
+	if err := withRollbackTxn(func(ctx context.Context) error {
+		qb := db.Tag
+
+		// create tag to test against
+		const name = "TestTagStashIDs"
+		tag := models.Tag{
+			Name: name,
+		}
+		err := qb.Create(ctx, &tag)
+		if err != nil {
+			return fmt.Errorf("Error creating tag: %s", err.Error())
+		}
+
+		if err := tag.LoadStashIDs(ctx, qb); err != nil {
+			return err
+		}
+
+		// ensure no stash IDs to begin with
+		assert.Len(t, tag.StashIDs.List(), 0)
+
+		stashID := models.StashID{
+			StashID:  "stashID",
+			Endpoint: "endpoint",
+		}
+
+		// update stash ids and ensure was updated
+		if _, err := qb.UpdatePartial(ctx, tag.ID, models.TagPartial{
+			StashIDs: &models.UpdateStashIDs{
+				StashIDs: []models.StashID{stashID},
+				Mode:     models.RelationshipUpdateModeSet,
+			},
+		}); err != nil {
+			return fmt.Errorf("Error updating tag: %s", err.Error())
+		}
+
+		stashIDs, err := qb.GetStashIDs(ctx, tag.ID)
+		if err != nil {
+			return fmt.Errorf("Error getting stash ids: %s", err.Error())
+		}
+		assert.Equal(t, []models.StashID{stashID}, stashIDs)
+
+		// ensure tag can be found by stash id
+		found, err := qb.FindByStashID(ctx, stashID)
+		if err != nil {
+			return fmt.Errorf("Error finding tag by stash id: %s", err.Error())
+		}
+		assert.Len(t, found, 1)
+		assert.Equal(t, tag.ID, found[0].ID)
+
+		found, err = qb.FindByStashIDStatus(ctx, true, stashID.Endpoint)
+		if err != nil {
+			return fmt.Errorf("Error finding tag by stash id status: %s", err.Error())
+		}
+		assert.Len(t, found, 1)
+
+		// remove stash ids and ensure was updated
+		if _, err := qb.UpdatePartial(ctx, tag.ID, models.TagPartial{
+			StashIDs: &models.UpdateStashIDs{
+				StashIDs: []models.StashID{stashID},
+				Mode:     models.RelationshipUpdateModeRemove,
+			},
+		}); err != nil {
+			return fmt.Errorf("Error updating tag: %s", err.Error())
+		}
+
+		stashIDs, err = qb.GetStashIDs(ctx, tag.ID)
+		if err != nil {
+			return fmt.Errorf("Error getting stash ids: %s", err.Error())
+		}
+		assert.Len(t, stashIDs, 0)
+
+		return nil
+	}); err != nil {
+		t.Error(err.Error())
+	}
+}
+
 func TestTagMerge(t *testing.T) {
 	assert := assert.New(t)
 
@@ -0,0 +1,290 @@
+package sqlite
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/jmoiron/sqlx"
+	"gopkg.in/guregu/null.v4/zero"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const (
+	tombstoneTable = "tombstones"
+)
+
+type tombstoneRow struct {
+	ID             int                      `db:"id" goqu:"skipinsert"`
+	ObjectType     models.HistoryEntityType `db:"object_type"`
+	Name           zero.String              `db:"name"`
+	Disambiguation zero.String              `db:"disambiguation"`
+	// JSON encoded list of paths
+	Paths      zero.String  `db:"paths"`
+	FolderPath zero.String  `db:"folder_path"`
+	DeletedAt  UTCTimestamp `db:"deleted_at"`
+}
+
+func (r *tombstoneRow) fromTombstone(o models.Tombstone) error {
+	r.ID = o.ID
+	r.ObjectType = o.ObjectType
+	r.Name = zero.StringFrom(o.Name)
+	r.Disambiguation = zero.StringFrom(o.Disambiguation)
+	r.FolderPath = zero.StringFrom(o.FolderPath)
+	r.DeletedAt = UTCTimestamp{Timestamp{Timestamp: o.DeletedAt}}
+
+	if len(o.Paths) > 0 {
+		paths, err := json.Marshal(o.Paths)
+		if err != nil {
+			return err
+		}
+		r.Paths = zero.StringFrom(string(paths))
+	}
+
+	return nil
+}
+
+func (r *tombstoneRow) resolve() (*models.Tombstone, error) {
+	ret := &models.Tombstone{
+		ID:             r.ID,
+		ObjectType:     r.ObjectType,
+		Name:           r.Name.String,
+		Disambiguation: r.Disambiguation.String,
+		FolderPath:     r.FolderPath.String,
+		DeletedAt:      r.DeletedAt.Timestamp.Timestamp,
+	}
+
+	if r.Paths.String != "" {
+		if err := json.Unmarshal([]byte(r.Paths.String), &ret.Paths); err != nil {
+			return nil, fmt.Errorf("decoding paths of tombstone %d: %w", r.ID, err)
+		}
+	}
+
+	return ret, nil
+}
+
+type TombstoneStore struct {
+	repository
+	tableMgr *table
+}
+
+func NewTombstoneStore() *TombstoneStore {
+	return &TombstoneStore{
+		repository: repository{
+			tableName: tombstoneTable,
+			idColumn:  idColumn,
+		},
+		tableMgr: tombstoneTableMgr,
+	}
+}
+
+func (qb *TombstoneStore) table() exp.IdentifierExpression {
+	return qb.tableMgr.table
+}
+
+func (qb *TombstoneStore) selectDataset() *goqu.SelectDataset {
+	return dialect.From(qb.table()).Select(qb.table().All())
+}
+
+func (qb *TombstoneStore) Create(ctx context.Context, newObject *models.Tombstone) error {
+	var r tombstoneRow
+	if err := r.fromTombstone(*newObject); err != nil {
+		return err
+	}
+
+	id, err := qb.tableMgr.insertID(ctx, r)
+	if err != nil {
+		return err
+	}
+
+	newObject.ID = id
+
+	return nil
+}
+
+func (qb *TombstoneStore) FindSince(ctx context.Context, t time.Time) ([]*models.Tombstone, error) {
+	table := qb.table()
+
+	// deleted_at has a precision of one second, so include tombstones from
+	// the same second
+	since := UTCTimestamp{Timestamp{Timestamp: t.Truncate(time.Second)}}
+
+	q := qb.selectDataset().Prepared(true).Where(
+		table.Col("deleted_at").Gte(since),
+	).Order(table.Col(idColumn).Asc())
+
+	const single = false
+	var ret []*models.Tombstone
+	if err := queryFunc(ctx, q, single, func(r *sqlx.Rows) error {
+		var f tombstoneRow
+		if err := r.StructScan(&f); err != nil {
+			return err
+		}
+
+		t, err := f.resolve()
+		if err != nil {
+			return err
+		}
+
+		ret = append(ret, t)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// recordTombstone records the deletion of an object. t is nil if the object
+// does not exist.
+func recordTombstone(ctx context.Context, t *models.Tombstone) error {
+	if t == nil {
+		return nil
+	}
+
+	t.DeletedAt = time.Now()
+
+	var r tombstoneRow
+	if err := r.fromTombstone(*t); err != nil {
+		return err
+	}
+
+	if _, err := tombstoneTableMgr.insertID(ctx, r); err != nil {
+		return fmt.Errorf("recording deletion: %w", err)
+	}
+
+	return nil
+}
+
+func (qb *SceneStore) tombstone(ctx context.Context, id int) (*models.Tombstone, error) {
+	files, err := qb.GetFiles(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// scenes are identified by their files, so scenes without files cannot
+	// be identified
+	if len(files) == 0 {
+		return nil, nil
+	}
+
+	ret := &models.Tombstone{
+		ObjectType: models.HistoryEntityTypeScene,
+	}
+	for _, f := range files {
+		ret.Paths = append(ret.Paths, f.Path)
+	}
+
+	return ret, nil
+}
+
+func (qb *ImageStore) tombstone(ctx context.Context, id int) (*models.Tombstone, error) {
+	files, err := qb.GetFiles(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// images are identified by their files, so images without files cannot
+	// be identified
+	if len(files) == 0 {
+		return nil, nil
+	}
+
+	ret := &models.Tombstone{
+		ObjectType: models.HistoryEntityTypeImage,
+	}
+	for _, f := range files {
+		ret.Paths = append(ret.Paths, f.Base().Path)
+	}
+
+	return ret, nil
+}
+
+func (qb *GalleryStore) tombstone(ctx context.Context, id int) (*models.Tombstone, error) {
+	g, err := qb.Find(ctx, id)
+	if err != nil || g == nil {
+		return nil, err
+	}
+
+	ret := &models.Tombstone{
+		ObjectType: models.HistoryEntityTypeGallery,
+	}
+
+	// match the gallery reference of the exported JSON
+	files, err := qb.GetFiles(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case len(files) > 0:
+		for _, f := range files {
+			ret.Paths = append(ret.Paths, f.Base().Path)
+		}
+	case g.FolderID != nil:
+		folder, err := qb.folderStore.Find(ctx, *g.FolderID)
+		if err != nil {
+			return nil, err
+		}
+		if folder != nil {
+			ret.FolderPath = folder.Path
+		}
+	default:
+		ret.Name = g.Title
+	}
+
+	return ret, nil
+}
+
+func (qb *PerformerStore) tombstone(ctx context.Context, id int) (*models.Tombstone, error) {
+	p, err := qb.Find(ctx, id)
+	if err != nil || p == nil {
+		return nil, err
+	}
+
+	return &models.Tombstone{
+		ObjectType:     models.HistoryEntityTypePerformer,
+		Name:           p.Name,
+		Disambiguation: p.Disambiguation,
+	}, nil
+}
+
+func (qb *StudioStore) tombstone(ctx context.Context, id int) (*models.Tombstone, error) {
+	s, err := qb.Find(ctx, id)
+	if err != nil || s == nil {
+		return nil, err
+	}
+
+	return &models.Tombstone{
+		ObjectType: models.HistoryEntityTypeStudio,
+		Name:       s.Name,
+	}, nil
+}
+
+func (qb *TagStore) tombstone(ctx context.Context, id int) (*models.Tombstone, error) {
+	t, err := qb.Find(ctx, id)
+	if err != nil || t == nil {
+		return nil, err
+	}
+
+	return &models.Tombstone{
+		ObjectType: models.HistoryEntityTypeTag,
+		Name:       t.Name,
+	}, nil
+}
+
+func (qb *GroupStore) tombstone(ctx context.Context, id int) (*models.Tombstone, error) {
+	g, err := qb.Find(ctx, id)
+	if err != nil || g == nil {
+		return nil, err
+	}
+
+	return &models.Tombstone{
+		ObjectType: models.HistoryEntityTypeGroup,
+		Name:       g.Name,
+	}, nil
+}
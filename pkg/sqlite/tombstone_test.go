@@ -0,0 +1,66 @@
+//go:build integration
+// +build integration
+
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTombstoneDestroy(t *testing.T) {
+	const (
+		name           = "tombstonePerformer"
+		disambiguation = "tombstoneDisambiguation"
+	)
+
+	withRollbackTxn(func(ctx context.Context) error {
+		since := time.Now()
+
+		newPerformer := models.NewPerformer()
+		newPerformer.Name = name
+		newPerformer.Disambiguation = disambiguation
+		if err := db.Performer.Create(ctx, &newPerformer); err != nil {
+			t.Errorf("Error creating performer: %s", err.Error())
+			return nil
+		}
+
+		if err := db.Performer.Destroy(ctx, newPerformer.ID); err != nil {
+			t.Errorf("Error destroying performer: %s", err.Error())
+			return nil
+		}
+
+		tombstones, err := db.Tombstone.FindSince(ctx, since)
+		if err != nil {
+			t.Errorf("Error finding tombstones: %s", err.Error())
+			return nil
+		}
+
+		// other tests may destroy objects at the same time
+		var found *models.Tombstone
+		for _, ts := range tombstones {
+			if ts.ObjectType == models.HistoryEntityTypePerformer && ts.Name == name {
+				found = ts
+			}
+		}
+
+		if !assert.NotNil(t, found) {
+			return nil
+		}
+
+		assert.Equal(t, disambiguation, found.Disambiguation)
+
+		tombstones, err = db.Tombstone.FindSince(ctx, since.Add(time.Hour))
+		if err != nil {
+			t.Errorf("Error finding tombstones: %s", err.Error())
+			return nil
+		}
+		assert.Len(t, tombstones, 0)
+
+		return nil
+	})
+}
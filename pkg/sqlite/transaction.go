@@ -42,6 +42,11 @@ func (db *Database) Begin(ctx context.Context, writable bool) (context.Context,
 		dbtx = db.writeDB
 	}
 
+	// the database is closed while it is being restored
+	if dbtx == nil {
+		return nil, ErrDatabaseNotInitialized
+	}
+
 	tx, err := dbtx.BeginTxx(ctx, nil)
 	if err != nil {
 		return nil, fmt.Errorf("beginning transaction: %w", err)
@@ -131,5 +136,7 @@ func (db *Database) Repository() models.Repository {
 		Studio:         db.Studio,
 		Tag:            db.Tag,
 		SavedFilter:    db.SavedFilter,
+		EditHistory:    db.EditHistory,
+		Tombstone:      db.Tombstone,
 	}
 }
@@ -15,6 +15,7 @@ type FinderAliasImageGetter interface {
 	GetAliases(ctx context.Context, studioID int) ([]string, error)
 	GetImage(ctx context.Context, tagID int) ([]byte, error)
 	FindByChildTagID(ctx context.Context, childID int) ([]*models.Tag, error)
+	models.StashIDLoader
 }
 
 // ToJSON converts a Tag object into its JSON equivalent.
@@ -51,6 +52,11 @@ func ToJSON(ctx context.Context, reader FinderAliasImageGetter, tag *models.Tag)
 
 	newTagJSON.Parents = GetNames(parents)
 
+	if err := tag.LoadStashIDs(ctx, reader); err != nil {
+		return nil, fmt.Errorf("loading tag stash ids: %w", err)
+	}
+	newTagJSON.StashIDs = tag.StashIDs.List()
+
 	return &newTagJSON, nil
 }
 
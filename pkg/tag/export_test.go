@@ -31,6 +31,14 @@ var (
 	autoTagIgnored = true
 	createTime     = time.Date(2001, 01, 01, 0, 0, 0, 0, time.UTC)
 	updateTime     = time.Date(2002, 01, 01, 0, 0, 0, 0, time.UTC)
+
+	stashID = models.StashID{
+		StashID:  "StashID",
+		Endpoint: "Endpoint",
+	}
+	stashIDs = []models.StashID{
+		stashID,
+	}
 )
 
 func createTag(id int) models.Tag {
@@ -42,6 +50,7 @@ func createTag(id int) models.Tag {
 		IgnoreAutoTag: autoTagIgnored,
 		CreatedAt:     createTime,
 		UpdatedAt:     updateTime,
+		StashIDs:      models.NewRelatedStashIDs(stashIDs),
 	}
 }
 
@@ -58,8 +67,9 @@ func createJSONTag(aliases []string, image string, parents []string) *jsonschema
 		UpdatedAt: json.JSONTime{
 			Time: updateTime,
 		},
-		Image:   image,
-		Parents: parents,
+		Image:    image,
+		Parents:  parents,
+		StashIDs: stashIDs,
 	}
 }
 
@@ -111,8 +111,10 @@ export const IdentifyDialog: React.FC<IIdentifyDialogProps> = ({
 
     const scrapers = scraperData.listScrapers;
 
-    const fragmentScrapers = scrapers.filter((s) =>
-      s.scene?.supported_scrapes.includes(GQL.ScrapeType.Fragment)
+    const fragmentScrapers = scrapers.filter(
+      (s) =>
+        s.scene?.supported_scrapes.includes(GQL.ScrapeType.Fragment) ||
+        s.scene?.supported_scrapes.includes(GQL.ScrapeType.Fingerprint)
     );
 
     ret.push(
@@ -4,6 +4,8 @@ import * as GQL from "src/core/generated-graphql";
 import {
   mutateSubmitStashBoxPerformerDraft,
   mutateSubmitStashBoxSceneDraft,
+  mutateSubmitStashBoxStudioEdit,
+  mutateSubmitStashBoxTagEdit,
 } from "src/core/StashService";
 import { ModalComponent } from "src/components/Shared/Modal";
 import { getStashboxBase } from "src/utils/stashbox";
@@ -12,9 +14,12 @@ import { faPaperPlane } from "@fortawesome/free-solid-svg-icons";
 import { ExternalLink } from "../Shared/ExternalLink";
 
 interface IProps {
-  type: "scene" | "performer";
+  type: "scene" | "performer" | "studio" | "tag";
   entity: Pick<
-    GQL.SceneDataFragment | GQL.PerformerDataFragment,
+    | GQL.SceneDataFragment
+    | GQL.PerformerDataFragment
+    | GQL.StudioDataFragment
+    | GQL.TagDataFragment,
     "id" | "stash_ids"
   >;
   boxes: Pick<GQL.StashBox, "name" | "endpoint">[];
@@ -41,6 +46,9 @@ export const SubmitStashBoxDraft: React.FC<IProps> = ({
   const selectedBox: (typeof boxes)[number] | undefined =
     boxes[selectedBoxIndex];
 
+  // stash-box has no studio or tag drafts, so these are submitted as edits
+  const isEdit = type === "studio" || type === "tag";
+
   // #4354: reset state when shown, or if any props change
   useEffect(() => {
     if (show) {
@@ -65,6 +73,12 @@ export const SubmitStashBoxDraft: React.FC<IProps> = ({
     } else if (type === "performer") {
       const r = await mutateSubmitStashBoxPerformerDraft(input);
       return r.data?.submitStashBoxPerformerDraft;
+    } else if (type === "studio") {
+      const r = await mutateSubmitStashBoxStudioEdit(input);
+      return r.data?.submitStashBoxStudioEdit;
+    } else if (type === "tag") {
+      const r = await mutateSubmitStashBoxTagEdit(input);
+      return r.data?.submitStashBoxTagEdit;
     }
   }
 
@@ -76,11 +90,12 @@ export const SubmitStashBoxDraft: React.FC<IProps> = ({
       const responseId = await doSubmit();
 
       const stashboxBase = getStashboxBase(selectedBox.endpoint);
+      const reviewPath = isEdit ? "edits" : "drafts";
       if (responseId) {
-        setReviewUrl(`${stashboxBase}drafts/${responseId}`);
+        setReviewUrl(`${stashboxBase}${reviewPath}/${responseId}`);
       } else {
-        // if the mutation returned a null id but didn't error, then just link to the drafts page
-        setReviewUrl(`${stashboxBase}drafts`);
+        // if the mutation returned a null id but didn't error, then just link to the drafts or edits page
+        setReviewUrl(`${stashboxBase}${reviewPath}`);
       }
     } catch (e) {
       if (e instanceof Error && e.message) {
@@ -112,7 +127,11 @@ export const SubmitStashBoxDraft: React.FC<IProps> = ({
           <div>
             <ExternalLink href={reviewUrl}>
               <FormattedMessage
-                id="stashbox.go_review_draft"
+                id={
+                  isEdit
+                    ? "stashbox.go_review_edit"
+                    : "stashbox.go_review_draft"
+                }
                 values={{ endpoint_name: selectedBox?.name }}
               />
             </ExternalLink>
@@ -121,23 +140,38 @@ export const SubmitStashBoxDraft: React.FC<IProps> = ({
       );
     } else {
       return (
-        <Form.Group className="form-row align-items-end">
-          <Form.Label className="col-6">
-            <FormattedMessage id="stashbox.selected_stash_box" />:
-          </Form.Label>
-          <Form.Control
-            as="select"
-            onChange={(e) => setSelectedBoxIndex(Number(e.currentTarget.value))}
-            value={selectedBoxIndex}
-            className="col-6 input-control"
-          >
-            {boxes.map((box, i) => (
-              <option value={i} key={`${box.endpoint}-${i}`}>
-                {box.name}
-              </option>
-            ))}
-          </Form.Control>
-        </Form.Group>
+        <>
+          <Form.Group className="form-row align-items-end">
+            <Form.Label className="col-6">
+              <FormattedMessage id="stashbox.selected_stash_box" />:
+            </Form.Label>
+            <Form.Control
+              as="select"
+              onChange={(e) =>
+                setSelectedBoxIndex(Number(e.currentTarget.value))
+              }
+              value={selectedBoxIndex}
+              className="col-6 input-control"
+            >
+              {boxes.map((box, i) => (
+                <option value={i} key={`${box.endpoint}-${i}`}>
+                  {box.name}
+                </option>
+              ))}
+            </Form.Control>
+          </Form.Group>
+          {isEdit && (
+            <p className="text-warning">
+              <FormattedMessage
+                id="stashbox.edit_warning"
+                values={{
+                  entity_type: intl.formatMessage({ id: type }),
+                  endpoint_name: selectedBox?.name,
+                }}
+              />
+            </p>
+          )}
+        </>
       );
     }
   }
@@ -3,7 +3,12 @@ import { Icon } from "../Shared/Icon";
 import { LoadingIndicator } from "../Shared/LoadingIndicator";
 import { StashSetting } from "./StashConfiguration";
 import { SettingSection } from "./SettingSection";
-import { BooleanSetting, StringListSetting, StringSetting } from "./Inputs";
+import {
+  BooleanSetting,
+  NumberSetting,
+  StringListSetting,
+  StringSetting,
+} from "./Inputs";
 import { useSettings } from "./context";
 import { useIntl } from "react-intl";
 import { faQuestionCircle } from "@fortawesome/free-solid-svg-icons";
@@ -36,6 +41,40 @@ export const SettingsLibraryPanel: React.FC = () => {
         onChange={(v) => saveGeneral({ stashes: v })}
       />
 
+      <SettingSection>
+        <BooleanSetting
+          id="watch-library"
+          headingID="config.library.watch_library_label"
+          subHeadingID="config.library.watch_library_desc"
+          checked={general.watchLibrary ?? false}
+          onChange={(v) => saveGeneral({ watchLibrary: v })}
+        />
+        <BooleanSetting
+          id="read-nfo-files"
+          headingID="config.library.read_nfo_files_label"
+          subHeadingID="config.library.read_nfo_files_desc"
+          checked={general.readNfoFiles ?? false}
+          onChange={(v) => saveGeneral({ readNfoFiles: v })}
+        />
+      </SettingSection>
+
+      <SettingSection headingID="config.library.trash.heading">
+        <BooleanSetting
+          id="use-trash"
+          headingID="config.library.trash.use_trash_label"
+          subHeadingID="config.library.trash.use_trash_desc"
+          checked={general.useTrash ?? false}
+          onChange={(v) => saveGeneral({ useTrash: v })}
+        />
+        <NumberSetting
+          id="trash-retention-days"
+          headingID="config.library.trash.retention_days_label"
+          subHeadingID="config.library.trash.retention_days_desc"
+          value={general.trashRetentionDays ?? undefined}
+          onChange={(v) => saveGeneral({ trashRetentionDays: v })}
+        />
+      </SettingSection>
+
       <SettingSection headingID="config.library.media_content_extensions">
         <StringSetting
           id="video-extensions"
@@ -47,6 +86,16 @@ export const SettingsLibraryPanel: React.FC = () => {
           }
         />
 
+        <StringSetting
+          id="audio-extensions"
+          headingID="config.general.audio_ext_head"
+          subHeadingID="config.general.audio_ext_desc"
+          value={listToCommaDelimited(general.audioExtensions ?? undefined)}
+          onChange={(v) =>
+            saveGeneral({ audioExtensions: commaDelimitedToList(v) })
+          }
+        />
+
         <StringSetting
           id="image-extensions"
           headingID="config.general.image_ext_head"
@@ -222,6 +222,50 @@ export const SettingsConfigurationPanel: React.FC = () => {
           value={general.backupDirectoryPath ?? undefined}
           onChange={(v) => saveGeneral({ backupDirectoryPath: v })}
         />
+
+        <BooleanSetting
+          id="auto-backup"
+          headingID="config.general.auto_backup.heading"
+          subHeadingID="config.general.auto_backup.description"
+          checked={general.autoBackup ?? false}
+          onChange={(v) => saveGeneral({ autoBackup: v })}
+        />
+
+        <NumberSetting
+          id="auto-backup-keep-daily"
+          headingID="config.general.auto_backup.keep_daily.heading"
+          subHeadingID="config.general.auto_backup.keep_daily.description"
+          value={general.autoBackupKeepDaily ?? 7}
+          onChange={(v) => saveGeneral({ autoBackupKeepDaily: v })}
+          disabled={!general.autoBackup}
+        />
+
+        <NumberSetting
+          id="auto-backup-keep-weekly"
+          headingID="config.general.auto_backup.keep_weekly.heading"
+          subHeadingID="config.general.auto_backup.keep_weekly.description"
+          value={general.autoBackupKeepWeekly ?? 4}
+          onChange={(v) => saveGeneral({ autoBackupKeepWeekly: v })}
+          disabled={!general.autoBackup}
+        />
+
+        <BooleanSetting
+          id="auto-backup-include-blobs"
+          headingID="config.general.auto_backup.include_blobs.heading"
+          subHeadingID="config.general.auto_backup.include_blobs.description"
+          checked={general.autoBackupIncludeBlobs ?? false}
+          onChange={(v) => saveGeneral({ autoBackupIncludeBlobs: v })}
+          disabled={!general.autoBackup}
+        />
+
+        <BooleanSetting
+          id="auto-backup-include-config"
+          headingID="config.general.auto_backup.include_config.heading"
+          subHeadingID="config.general.auto_backup.include_config.description"
+          checked={general.autoBackupIncludeConfig ?? false}
+          onChange={(v) => saveGeneral({ autoBackupIncludeConfig: v })}
+          disabled={!general.autoBackup}
+        />
       </SettingSection>
 
       <SettingSection headingID="config.general.database">
@@ -265,6 +309,14 @@ export const SettingsConfigurationPanel: React.FC = () => {
           onChange={(v) => saveGeneral({ calculateMD5: v })}
         />
 
+        <BooleanSetting
+          id="use-fingerprint-xattrs"
+          headingID="config.general.use_fingerprint_xattrs_label"
+          subHeadingID="config.general.use_fingerprint_xattrs_desc"
+          checked={general.useFingerprintXattrs ?? false}
+          onChange={(v) => saveGeneral({ useFingerprintXattrs: v })}
+        />
+
         <SelectSetting
           id="generated_file_naming_hash"
           headingID="config.general.generated_file_naming_hash_head"
@@ -38,6 +38,9 @@ const Stash: React.FC<IStashProps> = ({
     <Row className={`stash-row align-items-center ${classAdd}`}>
       <Form.Label column md={7}>
         {stash.path}
+        {stash.remote && (
+          <div className="text-muted">{stash.remote.url}</div>
+        )}
       </Form.Label>
       <Col md={2} xs={4} className="col form-label">
         {/* NOTE - language is opposite to meaning:
@@ -24,6 +24,20 @@ import { Icon } from "../Shared/Icon";
 
 type PluginConfigs = Record<string, Record<string, unknown>>;
 
+// the password of a remote library is not returned by the server. It is
+// omitted from the input so that the existing password is kept.
+function stashConfigToInput(s: GQL.StashConfigInput): GQL.StashConfigInput {
+  if (!s.remote) {
+    return s;
+  }
+
+  const { url, username, privateKeyPath, hostKey } = s.remote;
+  return {
+    ...s,
+    remote: { url, username, privateKeyPath, hostKey },
+  };
+}
+
 export interface ISettingsContextState {
   loading: boolean;
   error: ApolloError | undefined;
@@ -188,7 +202,10 @@ export const SettingsContext: React.FC = ({ children }) => {
         setUpdateSuccess(undefined);
         await updateGeneralConfig({
           variables: {
-            input,
+            input: {
+              ...input,
+              stashes: input.stashes?.map(stashConfigToInput),
+            },
           },
         });
 
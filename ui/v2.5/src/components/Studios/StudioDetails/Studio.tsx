@@ -25,6 +25,7 @@ import { StudioImagesPanel } from "./StudioImagesPanel";
 import { StudioChildrenPanel } from "./StudioChildrenPanel";
 import { StudioPerformersPanel } from "./StudioPerformersPanel";
 import { StudioEditPanel } from "./StudioEditPanel";
+import { StudioSubmitButton } from "./StudioSubmitButton";
 import {
   CompressedStudioDetailsPanel,
   StudioDetailsPanel,
@@ -511,6 +512,11 @@ const StudioPage: React.FC<IProps> = ({ studio, tabKey }) => {
                   onAutoTag={onAutoTag}
                   autoTagDisabled={studio.ignore_auto_tag}
                   onDelete={onDelete}
+                  customButtons={
+                    <div>
+                      <StudioSubmitButton studio={studio} />
+                    </div>
+                  }
                 />
               )}
             </div>
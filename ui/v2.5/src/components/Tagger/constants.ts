@@ -79,6 +79,7 @@ export const PERFORMER_FIELDS = [
   "career_length",
   "urls",
   "details",
+  "related_performers",
 ];
 
 export const STUDIO_FIELDS = ["name", "image", "urls", "parent_studio"];
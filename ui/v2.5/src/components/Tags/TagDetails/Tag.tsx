@@ -30,6 +30,7 @@ import { TagGalleriesPanel } from "./TagGalleriesPanel";
 import { CompressedTagDetailsPanel, TagDetailsPanel } from "./TagDetailsPanel";
 import { TagEditPanel } from "./TagEditPanel";
 import { TagMergeModal } from "./TagMergeDialog";
+import { TagSubmitButton } from "./TagSubmitButton";
 import {
   faSignInAlt,
   faSignOutAlt,
@@ -568,7 +569,14 @@ const TagPage: React.FC<IProps> = ({ tag, tabKey }) => {
                   autoTagDisabled={tag.ignore_auto_tag}
                   onDelete={onDelete}
                   classNames="mb-2"
-                  customButtons={renderMergeButton()}
+                  customButtons={
+                    <>
+                      {renderMergeButton()}
+                      <div>
+                        <TagSubmitButton tag={tag} />
+                      </div>
+                    </>
+                  }
                 />
               )}
             </div>
@@ -2310,6 +2310,22 @@ export const mutateSubmitStashBoxPerformerDraft = (
     variables: { input },
   });
 
+export const mutateSubmitStashBoxStudioEdit = (
+  input: GQL.StashBoxDraftSubmissionInput
+) =>
+  client.mutate<GQL.SubmitStashBoxStudioEditMutation>({
+    mutation: GQL.SubmitStashBoxStudioEditDocument,
+    variables: { input },
+  });
+
+export const mutateSubmitStashBoxTagEdit = (
+  input: GQL.StashBoxDraftSubmissionInput
+) =>
+  client.mutate<GQL.SubmitStashBoxTagEditMutation>({
+    mutation: GQL.SubmitStashBoxTagEditDocument,
+    variables: { input },
+  });
+
 /// Configuration
 
 export const useConfiguration = () => GQL.useConfigurationQuery();
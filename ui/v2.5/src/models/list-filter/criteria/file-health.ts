@@ -0,0 +1,42 @@
+import {
+  CriterionModifier,
+  FileHealthCriterionInput,
+  FileHealthStatus,
+} from "src/core/generated-graphql";
+import { CriterionOption, MultiStringCriterion } from "./criterion";
+
+const stringFileHealthMap = new Map<string, FileHealthStatus>([
+  ["OK", FileHealthStatus.Ok],
+  ["Corrupt", FileHealthStatus.Corrupt],
+]);
+
+export const FileHealthCriterionOption = new CriterionOption({
+  messageID: "file_health",
+  type: "file_health",
+  modifierOptions: [
+    CriterionModifier.Includes,
+    CriterionModifier.Excludes,
+    CriterionModifier.IsNull,
+    CriterionModifier.NotNull,
+  ],
+  defaultModifier: CriterionModifier.Includes,
+  options: Array.from(stringFileHealthMap.keys()),
+  makeCriterion: () => new FileHealthCriterion(),
+});
+
+export class FileHealthCriterion extends MultiStringCriterion {
+  constructor() {
+    super(FileHealthCriterionOption);
+  }
+
+  public toCriterionInput(): FileHealthCriterionInput {
+    const value = this.value
+      .map((v) => stringFileHealthMap.get(v))
+      .filter((v) => v) as FileHealthStatus[];
+
+    return {
+      value,
+      modifier: this.modifier,
+    };
+  }
+}
@@ -13,6 +13,7 @@ import { PerformersCriterionOption } from "./criteria/performers";
 import { RatingCriterionOption } from "./criteria/rating";
 import { ResolutionCriterionOption } from "./criteria/resolution";
 import { OrientationCriterionOption } from "./criteria/orientation";
+import { FileHealthCriterionOption } from "./criteria/file-health";
 import { StudiosCriterionOption } from "./criteria/studios";
 import {
   PerformerTagsCriterionOption,
@@ -46,6 +47,7 @@ const criterionOptions = [
   createMandatoryNumberCriterionOption("o_counter", "o_count"),
   ResolutionCriterionOption,
   OrientationCriterionOption,
+  FileHealthCriterionOption,
   ImageIsMissingCriterionOption,
   TagsCriterionOption,
   RatingCriterionOption,
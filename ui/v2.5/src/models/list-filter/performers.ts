@@ -33,6 +33,7 @@ const sortByOptions = [
   "career_length",
   "weight",
   "measurements",
+  "relevance",
 ]
   .map(ListFilterOptions.createSortBy)
   .concat([
@@ -15,6 +15,7 @@ const sortByOptions = [
   "scene_id",
   "random",
   "scenes_updated_at",
+  "relevance",
 ].map(ListFilterOptions.createSortBy);
 const displayModeOptions = [DisplayMode.Wall];
 const criterionOptions = [
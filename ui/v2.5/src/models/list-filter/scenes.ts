@@ -32,6 +32,7 @@ import { StashIDCriterionOption } from "./criteria/stash-ids";
 import { RatingCriterionOption } from "./criteria/rating";
 import { PathCriterionOption } from "./criteria/path";
 import { OrientationCriterionOption } from "./criteria/orientation";
+import { FileHealthCriterionOption } from "./criteria/file-health";
 
 const defaultSortBy = "date";
 const sortByOptions = [
@@ -50,6 +51,7 @@ const sortByOptions = [
   "interactive",
   "interactive_speed",
   "perceptual_similarity",
+  "relevance",
   ...MediaSortByOptions,
 ]
   .map(ListFilterOptions.createSortBy)
@@ -85,6 +87,7 @@ const criterionOptions = [
   createMandatoryNumberCriterionOption("o_counter", "o_count"),
   ResolutionCriterionOption,
   OrientationCriterionOption,
+  FileHealthCriterionOption,
   createMandatoryNumberCriterionOption("framerate"),
   createMandatoryNumberCriterionOption("bitrate"),
   createStringCriterionOption("video_codec"),
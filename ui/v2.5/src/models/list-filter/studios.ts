@@ -15,7 +15,7 @@ import { ListFilterOptions } from "./filter-options";
 import { DisplayMode } from "./types";
 
 const defaultSortBy = "name";
-const sortByOptions = ["name", "tag_count", "random", "rating"]
+const sortByOptions = ["name", "tag_count", "random", "rating", "relevance"]
   .map(ListFilterOptions.createSortBy)
   .concat([
     {
@@ -193,6 +193,7 @@ export type CriterionType =
   | "title"
   | "oshash"
   | "orientation"
+  | "file_health"
   | "checksum"
   | "phash_distance"
   | "director"
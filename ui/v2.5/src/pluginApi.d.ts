@@ -231,6 +231,8 @@ declare namespace PluginApi {
     const SubmitStashBoxFingerprintsDocument: { [key: string]: any };
     const SubmitStashBoxPerformerDraftDocument: { [key: string]: any };
     const SubmitStashBoxSceneDraftDocument: { [key: string]: any };
+    const SubmitStashBoxStudioEditDocument: { [key: string]: any };
+    const SubmitStashBoxTagEditDocument: { [key: string]: any };
     const SystemStatusDocument: { [key: string]: any };
     const SystemStatusEnum: { [key: string]: any };
     const TagCreateDocument: { [key: string]: any };
@@ -759,6 +761,8 @@ declare namespace PluginApi {
       function mutateStopJob(...args: any[]): any;
       function mutateSubmitStashBoxPerformerDraft(...args: any[]): any;
       function mutateSubmitStashBoxSceneDraft(...args: any[]): any;
+      function mutateSubmitStashBoxStudioEdit(...args: any[]): any;
+      function mutateSubmitStashBoxTagEdit(...args: any[]): any;
       function mutateUninstallPluginPackages(...args: any[]): any;
       function mutateUninstallScraperPackages(...args: any[]): any;
       function mutateUpdatePluginPackages(...args: any[]): any;